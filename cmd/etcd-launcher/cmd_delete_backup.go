@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type deleteBackupOptions struct {
+	options
+
+	caBundleFile string
+	object       string
+}
+
+func DeleteBackupCommand(log *zap.SugaredLogger) *cobra.Command {
+	opt := deleteBackupOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "delete-backup",
+		Short:        "Delete an etcd snapshot from the backup destination",
+		RunE:         DeleteBackupFunc(log, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			return nil
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "", "file containing the PEM-encoded CA bundle for the backup destination")
+	cmd.PersistentFlags().StringVar(&opt.object, "object", "", "name of the backup object to delete")
+
+	return cmd
+}
+
+func DeleteBackupFunc(log *zap.SugaredLogger, opt *deleteBackupOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		log := log.With("object", opt.object)
+
+		if opt.object == "" {
+			return errors.New("no --object given")
+		}
+
+		b, err := getBackupBackend(ctx, opt.caBundleFile)
+		if err != nil {
+			return err
+		}
+
+		// a backup that no longer exists is fine
+		if err := b.Delete(ctx, opt.object); err != nil {
+			return fmt.Errorf("failed to delete backup: %w", err)
+		}
+
+		log.Info("deleted snapshot")
+
+		return nil
+	})
+}
@@ -30,7 +30,8 @@ import (
 type snapshotOptions struct {
 	options
 
-	file string
+	file              string
+	encryptionKeyFile string
 }
 
 func SnapshotCommand(log *zap.SugaredLogger) *cobra.Command {
@@ -58,6 +59,7 @@ func SnapshotCommand(log *zap.SugaredLogger) *cobra.Command {
 	})
 
 	cmd.PersistentFlags().StringVar(&opt.file, "file", "/backup/snapshot.db", "file to save database snapshot to")
+	cmd.PersistentFlags().StringVar(&opt.encryptionKeyFile, "encryption-key-file", "", "file containing the data key to encrypt the snapshot with")
 
 	return cmd
 }
@@ -67,6 +69,11 @@ func SnapshotFunc(log *zap.SugaredLogger, opt *snapshotOptions) cobraFuncE {
 		ctx := cmd.Context()
 		log := log.With("cluster", opt.cluster)
 
+		dataKey, err := etcd.ReadEncryptionKey(opt.encryptionKeyFile)
+		if err != nil {
+			return err
+		}
+
 		e := &etcd.Cluster{
 			Cluster:           opt.cluster,
 			EtcdctlAPIVersion: opt.etcdctlAPIVersion,
@@ -107,6 +114,15 @@ func SnapshotFunc(log *zap.SugaredLogger, opt *snapshotOptions) cobraFuncE {
 			// successfully then.
 			if err == nil {
 				log.Infow("saved snapshot from endpoint", "endpoint", strings.Join(config.Endpoints, ","), "file", opt.file)
+
+				if dataKey != nil {
+					if err := etcd.EncryptFile(opt.file, dataKey); err != nil {
+						return fmt.Errorf("failed to encrypt snapshot: %w", err)
+					}
+
+					log.Info("encrypted snapshot")
+				}
+
 				return nil
 			}
 
@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/cmd/etcd-launcher/pkg/etcd"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+type streamRevisionsOptions struct {
+	options
+
+	caBundleFile      string
+	encryptionKeyFile string
+	segmentInterval   time.Duration
+	retention         time.Duration
+}
+
+func StreamRevisionsCommand(log *zap.SugaredLogger) *cobra.Command {
+	opt := streamRevisionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "stream-revisions",
+		Short:        "Continuously upload all etcd revisions to the backup destination",
+		RunE:         StreamRevisionsFunc(log, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			return nil
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "", "file containing the PEM-encoded CA bundle for the backup destination")
+	cmd.PersistentFlags().StringVar(&opt.encryptionKeyFile, "encryption-key-file", "", "file containing the data key to encrypt the recorded revisions with")
+	cmd.PersistentFlags().DurationVar(&opt.segmentInterval, "segment-interval", kubermaticv1.DefaultContinuousBackupSegmentInterval, "interval in which recorded revisions are uploaded")
+	cmd.PersistentFlags().DurationVar(&opt.retention, "retention", kubermaticv1.DefaultContinuousBackupRetention, "duration after which uploaded revisions are deleted")
+
+	return cmd
+}
+
+func StreamRevisionsFunc(log *zap.SugaredLogger, opt *streamRevisionsOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		log := log.With("cluster", opt.cluster)
+
+		if opt.segmentInterval <= 0 {
+			return errors.New("--segment-interval must be positive")
+		}
+
+		b, err := getBackupBackend(ctx, opt.caBundleFile)
+		if err != nil {
+			return err
+		}
+
+		dataKey, err := etcd.ReadEncryptionKey(opt.encryptionKeyFile)
+		if err != nil {
+			return err
+		}
+
+		e := &etcd.Cluster{
+			Cluster:           opt.cluster,
+			EtcdctlAPIVersion: opt.etcdctlAPIVersion,
+
+			CaCertFile:     opt.etcdCAFile,
+			ClientCertFile: opt.etcdCertFile,
+			ClientKeyFile:  opt.etcdKeyFile,
+		}
+
+		if _, err := e.Init(ctx); err != nil {
+			return fmt.Errorf("failed to initialize etcd cluster configuration: %w", err)
+		}
+
+		if err := e.SetClusterSize(ctx); err != nil {
+			return fmt.Errorf("failed to set expected cluster size: %w", err)
+		}
+
+		client, err := e.GetEtcdClient(ctx, log)
+		if err != nil {
+			return fmt.Errorf("failed to get etcd cluster client: %w", err)
+		}
+		defer client.Close()
+
+		streamer := &etcd.RevisionStreamer{
+			Cluster:         opt.cluster,
+			Client:          client,
+			Backend:         b,
+			DataKey:         dataKey,
+			SegmentInterval: opt.segmentInterval,
+			Retention:       opt.retention,
+		}
+
+		return streamer.Run(ctx, log)
+	})
+}
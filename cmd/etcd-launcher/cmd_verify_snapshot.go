@@ -0,0 +1,118 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/cmd/etcd-launcher/pkg/etcd"
+)
+
+type verifySnapshotOptions struct {
+	options
+
+	caBundleFile      string
+	encryptionKeyFile string
+	object            string
+	workDir           string
+	resultFile        string
+	restoreDrill      bool
+}
+
+func VerifySnapshotCommand(log *zap.SugaredLogger) *cobra.Command {
+	opt := verifySnapshotOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "verify-snapshot",
+		Short:        "Download an uploaded etcd snapshot and verify that it is valid",
+		RunE:         VerifySnapshotFunc(log, &opt),
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.CopyInto(&opt.options)
+
+			return nil
+		},
+	}
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err := c.Usage(); err != nil {
+			return err
+		}
+
+		// ensure we exit with code 1 later on
+		return err
+	})
+
+	cmd.PersistentFlags().StringVar(&opt.caBundleFile, "ca-bundle", "", "file containing the PEM-encoded CA bundle for the backup destination")
+	cmd.PersistentFlags().StringVar(&opt.encryptionKeyFile, "encryption-key-file", "", "file containing the data key to decrypt the snapshot with")
+	cmd.PersistentFlags().StringVar(&opt.object, "object", "", "name of the backup object to verify")
+	cmd.PersistentFlags().StringVar(&opt.workDir, "work-dir", "/backup", "directory to download (and restore) the snapshot to")
+	cmd.PersistentFlags().StringVar(&opt.resultFile, "result-file", "/dev/termination-log", "file to write the verification result to")
+	cmd.PersistentFlags().BoolVar(&opt.restoreDrill, "restore-drill", false, "restore the snapshot into a temporary etcd and count its keys")
+
+	return cmd
+}
+
+func VerifySnapshotFunc(log *zap.SugaredLogger, opt *verifySnapshotOptions) cobraFuncE {
+	return handleErrors(log, func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		log := log.With("cluster", opt.cluster, "object", opt.object)
+
+		if opt.object == "" {
+			return errors.New("no --object given")
+		}
+
+		b, err := getBackupBackend(ctx, opt.caBundleFile)
+		if err != nil {
+			return err
+		}
+
+		dataKey, err := etcd.ReadEncryptionKey(opt.encryptionKeyFile)
+		if err != nil {
+			return err
+		}
+
+		result, err := etcd.VerifySnapshot(ctx, log, b, opt.object, opt.workDir, dataKey, opt.restoreDrill)
+		if err != nil {
+			// make the reason visible in the job's status as well
+			if writeErr := os.WriteFile(opt.resultFile, []byte(err.Error()), 0644); writeErr != nil {
+				log.Warnw("failed to write result file", zap.Error(writeErr))
+			}
+
+			return err
+		}
+
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+
+		if err := os.WriteFile(opt.resultFile, encoded, 0644); err != nil {
+			return fmt.Errorf("failed to write result file: %w", err)
+		}
+
+		log.Infow("snapshot is valid", "checksum", result.Checksum, "revision", result.Revision)
+
+		return nil
+	})
+}
@@ -17,13 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"crypto/x509"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -106,6 +112,10 @@ func addCommands(cmd *cobra.Command, logger *zap.SugaredLogger, versions kuberma
 		IsRunningCommand(logger),
 		DefragCommand(logger),
 		SnapshotCommand(logger),
+		StreamRevisionsCommand(logger),
+		VerifySnapshotCommand(logger),
+		StoreBackupCommand(logger),
+		DeleteBackupCommand(logger),
 	)
 }
 
@@ -119,3 +129,29 @@ func handleErrors(logger *zap.SugaredLogger, action cobraFuncE) cobraFuncE {
 		return err
 	}
 }
+
+// getBackupBackend returns the storage backend for the backup destination, which is configured
+// via the same environment variables that are used for the backup store container.
+func getBackupBackend(ctx context.Context, caBundleFile string) (backend.Backend, error) {
+	var certPool *x509.CertPool
+	if caBundleFile != "" {
+		bundle, err := certificates.NewCABundleFromFile(caBundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA bundle: %w", err)
+		}
+
+		certPool = bundle.CertPool()
+	}
+
+	config := backend.ConfigFromEnv(certPool)
+	if config.Type != kubermaticv1.BackupDestinationTypeFilesystem && config.BucketName == "" {
+		return nil, fmt.Errorf("no bucket name given via %s", backend.BucketNameEnvVarKey)
+	}
+
+	b, err := backend.New(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s backend: %w", config.Type, err)
+	}
+
+	return b, nil
+}
@@ -28,7 +28,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
@@ -38,6 +37,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/kubermatic/v2/pkg/util/wait"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -645,16 +645,34 @@ func (e *Cluster) restoreDatadirFromBackupIfNeeded(ctx context.Context, log *zap
 
 	log.Infow("restoring datadir from backup", "backup-name", activeRestore.Spec.BackupName)
 
-	s3Client, bucketName, err := resources.GetEtcdRestoreS3Client(ctx, activeRestore, false, seedClient, cluster, nil)
+	b, err := resources.GetEtcdRestoreBackend(ctx, activeRestore, false, seedClient, cluster, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get s3 client: %w", err)
+		return fmt.Errorf("failed to get backup storage backend: %w", err)
 	}
 
 	objectName := fmt.Sprintf("%s-%s", cluster.GetName(), activeRestore.Spec.BackupName)
 	downloadedSnapshotFile := fmt.Sprintf("/tmp/%s", objectName)
 
-	if err := s3Client.FGetObject(ctx, bucketName, objectName, downloadedSnapshotFile, minio.GetObjectOptions{}); err != nil {
-		return fmt.Errorf("failed to download backup (%s/%s): %w", bucketName, objectName, err)
+	if err := backend.DownloadFile(ctx, b, objectName, downloadedSnapshotFile); err != nil {
+		return fmt.Errorf("failed to download backup %s: %w", objectName, err)
+	}
+
+	dataKey, err := resources.GetEtcdRestoreDataKey(ctx, activeRestore, seedClient, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get backup data key: %w", err)
+	}
+
+	if err := DecryptFileIfNeeded(downloadedSnapshotFile, dataKey); err != nil {
+		return fmt.Errorf("failed to decrypt backup: %w", err)
+	}
+
+	if activeRestore.Spec.IsPointInTime() {
+		log.Infow("replaying revisions on top of backup", "target-revision", activeRestore.Spec.TargetRevision, "target-time", activeRestore.Spec.TargetTime)
+
+		downloadedSnapshotFile, err = e.replayRevisions(ctx, log, b, cluster, activeRestore, downloadedSnapshotFile, dataKey)
+		if err != nil {
+			return fmt.Errorf("failed to replay revisions: %w", err)
+		}
 	}
 
 	if err := os.RemoveAll(e.DataDir); err != nil {
@@ -48,6 +48,51 @@ func (e *Cluster) StartEtcdCmd(log *zap.SugaredLogger) (*exec.Cmd, error) {
 	return cmd, nil
 }
 
+// startReplayEtcdCmd starts a single-member etcd on localhost, which is only used to replay
+// recorded revisions during a point-in-time restore.
+func startReplayEtcdCmd(log *zap.SugaredLogger, dataDir string) (*exec.Cmd, error) {
+	if _, err := os.Stat(etcdCommandPath); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to find etcd executable: %w", err)
+	}
+
+	args := []string{
+		fmt.Sprintf("--name=%s", replayMemberName),
+		fmt.Sprintf("--data-dir=%s", dataDir),
+		fmt.Sprintf("--initial-cluster=%s=%s", replayMemberName, replayPeerURL),
+		fmt.Sprintf("--initial-cluster-token=%s", replayMemberName),
+		fmt.Sprintf("--listen-peer-urls=%s", replayPeerURL),
+		fmt.Sprintf("--initial-advertise-peer-urls=%s", replayPeerURL),
+		fmt.Sprintf("--listen-client-urls=%s", replayClientURL),
+		fmt.Sprintf("--advertise-client-urls=%s", replayClientURL),
+		// a single revision can contain arbitrarily many changes, and each
+		// revision is replayed as one transaction
+		"--max-txn-ops=65536",
+		"--max-request-bytes=67108864",
+	}
+
+	cmd := exec.Command(etcdCommandPath, args...)
+	cmd.Env = os.Environ()
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+
+	log.Infof("starting replay etcd command: %s %s", etcdCommandPath, strings.Join(args, " "))
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start replay etcd: %w", err)
+	}
+
+	return cmd, nil
+}
+
+func stopReplayEtcdCmd(log *zap.SugaredLogger, cmd *exec.Cmd) {
+	if err := cmd.Process.Kill(); err != nil {
+		log.Warnw("failed to stop replay etcd", zap.Error(err))
+	}
+
+	// the exit error is expected after killing the process
+	_ = cmd.Wait()
+}
+
 func etcdCmd(config *Cluster) []string {
 	cmd := []string{
 		fmt.Sprintf("--name=%s", config.PodName),
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+)
+
+// ReadEncryptionKey reads the backup data key from the given file. If no file is given,
+// backups are not encrypted and nil is returned.
+func ReadEncryptionKey(filename string) ([]byte, error) {
+	if filename == "" {
+		return nil, nil
+	}
+
+	key, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key: %w", err)
+	}
+
+	if len(key) != etcdbackup.EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes long, but is %d bytes", etcdbackup.EncryptionKeySize, len(key))
+	}
+
+	return key, nil
+}
+
+// EncryptFile replaces the file with its encrypted version.
+func EncryptFile(filename string, dataKey []byte) error {
+	return transformFile(filename, func(w io.Writer, r *bufio.Reader) error {
+		return etcdbackup.Encrypt(w, r, dataKey)
+	})
+}
+
+// DecryptFileIfNeeded replaces the file with its decrypted version, if it is encrypted. Plain
+// snapshots are left untouched, so that backups taken before encryption was enabled can still
+// be restored.
+func DecryptFileIfNeeded(filename string, dataKey []byte) error {
+	return transformFile(filename, func(w io.Writer, r *bufio.Reader) error {
+		encrypted, err := etcdbackup.IsEncrypted(r)
+		if err != nil {
+			return err
+		}
+
+		if !encrypted {
+			return errNotEncrypted
+		}
+
+		if dataKey == nil {
+			return errors.New("backup is encrypted, but no data key is available")
+		}
+
+		return etcdbackup.Decrypt(w, r, dataKey)
+	})
+}
+
+var errNotEncrypted = errors.New("not encrypted")
+
+func transformFile(filename string, transform func(w io.Writer, r *bufio.Reader) error) error {
+	in, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmpFile := filename + ".tmp"
+
+	out, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+	defer out.Close()
+
+	if err := transform(out, bufio.NewReader(in)); err != nil {
+		if errors.Is(err, errNotEncrypted) {
+			return nil
+		}
+		return fmt.Errorf("failed to process %s: %w", filename, err)
+	}
+
+	if err := out.Sync(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, filename)
+}
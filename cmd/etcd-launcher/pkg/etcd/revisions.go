@@ -0,0 +1,493 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	client "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
+	"k8c.io/kubermatic/v2/pkg/util/wait"
+)
+
+const (
+	replayMemberName = "replay"
+	replayPeerURL    = "http://127.0.0.1:12380"
+	replayClientURL  = "http://127.0.0.1:12379"
+
+	timeoutReplayServerStart = 2 * time.Minute
+
+	// pruning is not time critical, so do not list the bucket on every segment upload.
+	segmentPruneInterval = 1 * time.Hour
+)
+
+// RevisionStreamer watches all keys of an etcd cluster and uploads every observed revision
+// in segments into the backup destination. Together with a snapshot, these segments allow to restore the
+// cluster to any revision after the snapshot. If a DataKey is set, segments are encrypted
+// before uploading them.
+type RevisionStreamer struct {
+	Cluster         string
+	Client          *client.Client
+	Backend         backend.Backend
+	DataKey         []byte
+	SegmentInterval time.Duration
+	Retention       time.Duration
+
+	buffer    []etcdbackup.RevisionEvent
+	lastPrune time.Time
+}
+
+// Run streams revisions until the context is cancelled or the watch fails.
+func (s *RevisionStreamer) Run(ctx context.Context, log *zap.SugaredLogger) error {
+	startRevision, err := s.resumeRevision(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to determine revision to resume from: %w", err)
+	}
+
+	log.Infow("streaming revisions", "revision", startRevision)
+
+	cancelWatch := func() {}
+	defer func() { cancelWatch() }()
+
+	watch := func(revision int64) client.WatchChan {
+		cancelWatch()
+
+		var watchCtx context.Context
+		watchCtx, cancelWatch = context.WithCancel(client.WithRequireLeader(ctx))
+
+		return s.Client.Watch(watchCtx, "", client.WithPrefix(), client.WithRev(revision))
+	}
+
+	watchChan := watch(startRevision)
+
+	ticker := time.NewTicker(s.SegmentInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// use a fresh context, so the remaining events still make it into the bucket
+			flushCtx, flushCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer flushCancel()
+
+			return s.flush(flushCtx, log)
+
+		case <-ticker.C:
+			if err := s.flush(ctx, log); err != nil {
+				// keep the buffer and try again on the next tick
+				log.Warnw("failed to upload segment", zap.Error(err))
+			}
+
+			if time.Since(s.lastPrune) > segmentPruneInterval {
+				if err := s.prune(ctx, log); err != nil {
+					log.Warnw("failed to prune old segments", zap.Error(err))
+				}
+			}
+
+		case resp, ok := <-watchChan:
+			if !ok {
+				return errors.New("watch channel was closed")
+			}
+
+			// the revisions we wanted to resume from are gone, so there is nothing we can do but
+			// to continue with the oldest available one; the gap will be detected on restore and
+			// the next snapshot is the earliest point to restore to again
+			if resp.CompactRevision != 0 {
+				log.Errorw("revisions have been compacted before they could be recorded, history has a gap", "compact-revision", resp.CompactRevision)
+
+				watchChan = watch(resp.CompactRevision)
+				continue
+			}
+
+			if err := resp.Err(); err != nil {
+				return fmt.Errorf("watch failed: %w", err)
+			}
+
+			observed := time.Now().UnixNano()
+			for _, event := range resp.Events {
+				s.buffer = append(s.buffer, toRevisionEvent(event, observed))
+			}
+		}
+	}
+}
+
+func toRevisionEvent(event *client.Event, observed int64) etcdbackup.RevisionEvent {
+	revisionEvent := etcdbackup.RevisionEvent{
+		Revision: event.Kv.ModRevision,
+		Time:     observed,
+		Key:      event.Kv.Key,
+	}
+
+	if event.Type == mvccpb.DELETE {
+		revisionEvent.Type = etcdbackup.RevisionEventDelete
+	} else {
+		revisionEvent.Type = etcdbackup.RevisionEventPut
+		revisionEvent.Value = event.Kv.Value
+	}
+
+	return revisionEvent
+}
+
+// resumeRevision returns the revision after the last uploaded segment or, if no segment
+// exists yet, the next revision the cluster will create.
+func (s *RevisionStreamer) resumeRevision(ctx context.Context) (int64, error) {
+	segments, err := listSegments(ctx, s.Backend, s.Cluster)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(segments) > 0 {
+		return segments[len(segments)-1].LastRevision + 1, nil
+	}
+
+	resp, err := s.Client.Get(ctx, "health")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current revision: %w", err)
+	}
+
+	return resp.Header.Revision + 1, nil
+}
+
+func (s *RevisionStreamer) flush(ctx context.Context, log *zap.SugaredLogger) error {
+	if len(s.buffer) == 0 {
+		return nil
+	}
+
+	data := &bytes.Buffer{}
+	if err := etcdbackup.EncodeRevisionEvents(data, s.buffer); err != nil {
+		return fmt.Errorf("failed to encode revisions: %w", err)
+	}
+
+	if s.DataKey != nil {
+		encrypted := &bytes.Buffer{}
+		if err := etcdbackup.Encrypt(encrypted, data, s.DataKey); err != nil {
+			return fmt.Errorf("failed to encrypt revisions: %w", err)
+		}
+		data = encrypted
+	}
+
+	first := s.buffer[0].Revision
+	last := s.buffer[len(s.buffer)-1].Revision
+	objectName := etcdbackup.RevisionSegmentObjectName(s.Cluster, first, last)
+
+	if err := s.Backend.Upload(ctx, objectName, data, int64(data.Len())); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectName, err)
+	}
+
+	log.Debugw("uploaded segment", "object", objectName, "events", len(s.buffer))
+	s.buffer = nil
+
+	return nil
+}
+
+func (s *RevisionStreamer) prune(ctx context.Context, log *zap.SugaredLogger) error {
+	s.lastPrune = time.Now()
+
+	objects, err := s.Backend.List(ctx, etcdbackup.RevisionSegmentPrefix(s.Cluster))
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if time.Since(object.LastModified) < s.Retention {
+			continue
+		}
+
+		if err := s.Backend.Delete(ctx, object.Name); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", object.Name, err)
+		}
+
+		log.Debugw("deleted expired segment", "object", object.Name)
+	}
+
+	return nil
+}
+
+func listSegments(ctx context.Context, b backend.Backend, clusterName string) ([]etcdbackup.Segment, error) {
+	objects, err := b.List(ctx, etcdbackup.RevisionSegmentPrefix(clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+
+	var segments []etcdbackup.Segment
+
+	for _, object := range objects {
+		segment, err := etcdbackup.ParseRevisionSegment(clusterName, object.Name)
+		if err != nil {
+			// not something we uploaded; ignore it
+			continue
+		}
+
+		segments = append(segments, *segment)
+	}
+
+	etcdbackup.SortSegments(segments)
+
+	return segments, nil
+}
+
+func downloadSegment(ctx context.Context, b backend.Backend, segment etcdbackup.Segment, dataKey []byte) ([]etcdbackup.RevisionEvent, error) {
+	object, err := b.Download(ctx, segment.ObjectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", segment.ObjectName, err)
+	}
+	defer object.Close()
+
+	reader := bufio.NewReader(object)
+
+	encrypted, err := etcdbackup.IsEncrypted(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", segment.ObjectName, err)
+	}
+
+	var data io.Reader = reader
+	if encrypted {
+		if dataKey == nil {
+			return nil, fmt.Errorf("segment %s is encrypted, but no data key is available", segment.ObjectName)
+		}
+
+		decrypted := &bytes.Buffer{}
+		if err := etcdbackup.Decrypt(decrypted, reader, dataKey); err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", segment.ObjectName, err)
+		}
+		data = decrypted
+	}
+
+	events, err := etcdbackup.DecodeRevisionEvents(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", segment.ObjectName, err)
+	}
+
+	return events, nil
+}
+
+// replayRevisions restores the snapshot into a temporary single-member etcd, replays the
+// recorded revisions up to the restore's target on top of it and saves the result as a new
+// snapshot, whose path is returned. Replaying happens on a separate member because the actual
+// data dir is restored for the full cluster and could not start without its peers.
+func (e *Cluster) replayRevisions(ctx context.Context, log *zap.SugaredLogger, b backend.Backend, cluster *kubermaticv1.Cluster, restore *kubermaticv1.EtcdRestore, snapshotFile string, dataKey []byte) (string, error) {
+	target := etcdbackup.RevisionTarget{Revision: restore.Spec.TargetRevision}
+	if restore.Spec.TargetTime != nil {
+		target.Time = &restore.Spec.TargetTime.Time
+	}
+
+	tmpDir, err := os.MkdirTemp("", "etcd-replay")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	replayClient, stop, err := startReplayMember(ctx, log, snapshotFile, tmpDir)
+	if err != nil {
+		return "", err
+	}
+	defer stop()
+
+	resp, err := replayClient.Get(ctx, "health")
+	if err != nil {
+		return "", fmt.Errorf("failed to get snapshot revision: %w", err)
+	}
+	snapshotRevision := resp.Header.Revision
+
+	events, err := collectRevisionEvents(ctx, b, cluster.Name, snapshotRevision, target, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	selected, err := etcdbackup.SelectRevisionEvents(events, snapshotRevision, target)
+	if err != nil {
+		return "", err
+	}
+
+	log.Infow("replaying revisions", "snapshot-revision", snapshotRevision, "events", len(selected))
+
+	if err := applyRevisionEvents(ctx, replayClient, selected); err != nil {
+		return "", err
+	}
+
+	replayedSnapshotFile := fmt.Sprintf("%s-replayed", snapshotFile)
+	if err := saveSnapshot(ctx, replayClient, replayedSnapshotFile); err != nil {
+		return "", err
+	}
+
+	return replayedSnapshotFile, nil
+}
+
+// startReplayMember restores the snapshot into a data dir below tmpDir and starts a single-member
+// etcd on it. The returned function closes the client and stops etcd again.
+func startReplayMember(ctx context.Context, log *zap.SugaredLogger, snapshotFile string, tmpDir string) (*client.Client, func(), error) {
+	dataDir := filepath.Join(tmpDir, "data")
+
+	sp := snapshot.NewV3(log.Desugar())
+	if err := sp.Restore(snapshot.RestoreConfig{
+		SnapshotPath:        snapshotFile,
+		Name:                replayMemberName,
+		OutputDataDir:       dataDir,
+		OutputWALDir:        filepath.Join(dataDir, "member", "wal"),
+		PeerURLs:            []string{replayPeerURL},
+		InitialCluster:      fmt.Sprintf("%s=%s", replayMemberName, replayPeerURL),
+		InitialClusterToken: replayMemberName,
+		SkipHashCheck:       false,
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	replayCmd, err := startReplayEtcdCmd(log, dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replayClient, err := newReplayClient(ctx, log)
+	if err != nil {
+		stopReplayEtcdCmd(log, replayCmd)
+		return nil, nil, err
+	}
+
+	return replayClient, func() {
+		closeClient(replayClient, log)
+		stopReplayEtcdCmd(log, replayCmd)
+	}, nil
+}
+
+func newReplayClient(ctx context.Context, log *zap.SugaredLogger) (*client.Client, error) {
+	replayClient, err := client.New(client.Config{
+		Endpoints:   []string{replayClientURL},
+		DialTimeout: 2 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay client: %w", err)
+	}
+
+	if err := wait.PollImmediateLog(ctx, log, 1*time.Second, timeoutReplayServerStart, func(ctx context.Context) (error, error) {
+		ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		_, err := replayClient.Get(ctx, "health")
+		return err, nil
+	}); err != nil {
+		closeClient(replayClient, log)
+		return nil, fmt.Errorf("replay etcd did not become ready: %w", err)
+	}
+
+	return replayClient, nil
+}
+
+// collectRevisionEvents downloads all segments that are required to reach the target.
+func collectRevisionEvents(ctx context.Context, b backend.Backend, clusterName string, snapshotRevision int64, target etcdbackup.RevisionTarget, dataKey []byte) ([]etcdbackup.RevisionEvent, error) {
+	segments, err := listSegments(ctx, b, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []etcdbackup.RevisionEvent
+
+	for _, segment := range segments {
+		if segment.LastRevision <= snapshotRevision {
+			continue
+		}
+
+		if target.Revision != nil && segment.FirstRevision > *target.Revision {
+			break
+		}
+
+		segmentEvents, err := downloadSegment(ctx, b, segment, dataKey)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, segmentEvents...)
+
+		// one event after the target time is enough to know that nothing is missing
+		if target.Time != nil && len(events) > 0 && time.Unix(0, events[len(events)-1].Time).After(*target.Time) {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// applyRevisionEvents writes all events of a revision in a single transaction, so the replayed
+// revisions are identical to the original ones.
+func applyRevisionEvents(ctx context.Context, c *client.Client, events []etcdbackup.RevisionEvent) error {
+	for start := 0; start < len(events); {
+		revision := events[start].Revision
+
+		var ops []client.Op
+		end := start
+		for ; end < len(events) && events[end].Revision == revision; end++ {
+			event := events[end]
+
+			// keys attached to leases are replayed without them, as the leases are
+			// not part of the recorded history
+			switch event.Type {
+			case etcdbackup.RevisionEventPut:
+				ops = append(ops, client.OpPut(string(event.Key), string(event.Value)))
+			case etcdbackup.RevisionEventDelete:
+				ops = append(ops, client.OpDelete(string(event.Key)))
+			default:
+				return fmt.Errorf("unknown event type %q in revision %d", event.Type, revision)
+			}
+		}
+
+		resp, err := c.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return fmt.Errorf("failed to replay revision %d: %w", revision, err)
+		}
+
+		if resp.Header.Revision != revision {
+			return fmt.Errorf("replaying revision %d resulted in revision %d", revision, resp.Header.Revision)
+		}
+
+		start = end
+	}
+
+	return nil
+}
+
+func saveSnapshot(ctx context.Context, c *client.Client, filename string) error {
+	reader, err := c.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot of replayed data: %w", err)
+	}
+	defer reader.Close()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, reader); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return f.Sync()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	client "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.uber.org/zap"
+
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
+)
+
+// VerifySnapshot downloads a backup into workDir and checks that it is a valid etcd snapshot.
+// Encrypted backups are decrypted with the data key first; the checksum is always calculated
+// over the object as it was uploaded. If restoreDrill is set, the snapshot is also restored into
+// a throwaway etcd to count its keys.
+func VerifySnapshot(ctx context.Context, log *zap.SugaredLogger, b backend.Backend, objectName, workDir string, dataKey []byte, restoreDrill bool) (*etcdbackup.VerificationResult, error) {
+	snapshotFile := filepath.Join(workDir, "snapshot.db")
+
+	checksum, err := downloadWithChecksum(ctx, b, objectName, snapshotFile)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infow("downloaded backup", "object", objectName, "checksum", checksum)
+
+	if err := DecryptFileIfNeeded(snapshotFile, dataKey); err != nil {
+		return nil, fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+
+	sp := snapshot.NewV3(log.Desugar())
+	status, err := sp.Status(snapshotFile)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot is invalid: %w", err)
+	}
+
+	result := &etcdbackup.VerificationResult{
+		Checksum: checksum,
+		Revision: status.Revision,
+	}
+
+	if restoreDrill {
+		keyCount, err := countRestoredKeys(ctx, log, snapshotFile, workDir)
+		if err != nil {
+			return nil, fmt.Errorf("restore drill failed: %w", err)
+		}
+
+		result.KeyCount = &keyCount
+	}
+
+	return result, nil
+}
+
+func downloadWithChecksum(ctx context.Context, b backend.Backend, objectName, filename string) (string, error) {
+	object, err := b.Download(ctx, objectName)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", objectName, err)
+	}
+	defer object.Close()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hash), object); err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", objectName, err)
+	}
+
+	if err := f.Sync(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func countRestoredKeys(ctx context.Context, log *zap.SugaredLogger, snapshotFile, workDir string) (int64, error) {
+	replayClient, stop, err := startReplayMember(ctx, log, snapshotFile, filepath.Join(workDir, "drill"))
+	if err != nil {
+		return 0, err
+	}
+	defer stop()
+
+	resp, err := replayClient.Get(ctx, "", client.WithPrefix(), client.WithCountOnly())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count keys: %w", err)
+	}
+
+	return resp.Count, nil
+}
@@ -64,6 +64,7 @@ func main() {
 	reconciling.Configure(log)
 
 	cli.Hello(log, "Kubelet DNAT-Controller", logOpts.Debug, nil)
+	log.Warn("The Kubelet DNAT-Controller is deprecated together with OpenVPN, clusters should be migrated to Konnectivity")
 
 	_, network, err := net.ParseCIDR(*networkFlag)
 	if err != nil {
@@ -24,7 +24,9 @@ import (
 	"go.uber.org/zap"
 
 	applicationdefinitionsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-definition-synchronizer"
+	applicationrollout "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-rollout"
 	applicationsecretsynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/application-secret-synchronizer"
+	clustermigration "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/cluster-migration"
 	clustertemplatesynchronizer "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/cluster-template-synchronizer"
 	externalcluster "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/external-cluster"
 	kcstatuscontroller "k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/kc-status-controller"
@@ -122,12 +124,21 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	if err := externalcluster.Add(ctrlCtx.ctx, ctrlCtx.mgr, ctrlCtx.log); err != nil {
 		return fmt.Errorf("failed to create external cluster controller: %w", err)
 	}
+	if err := externalcluster.AddApplicationController(ctrlCtx.mgr, ctrlCtx.workerCount, ctrlCtx.log, ctrlCtx.namespace, ctrlCtx.applicationCache); err != nil {
+		return fmt.Errorf("failed to create external cluster application controller: %w", err)
+	}
 	if err := kubeone.Add(ctrlCtx.ctx, ctrlCtx.mgr, ctrlCtx.log); err != nil {
 		return fmt.Errorf("failed to create kubeone controller: %w", err)
 	}
 	if err := kcstatuscontroller.Add(ctrlCtx.ctx, ctrlCtx.mgr, 1, ctrlCtx.log, ctrlCtx.namespace, ctrlCtx.versions); err != nil {
 		return fmt.Errorf("failed to create kubermatic configuration controller: %w", err)
 	}
+	if err := clustermigration.Add(ctrlCtx.mgr, ctrlCtx.workerCount, ctrlCtx.log, ctrlCtx.seedsGetter, ctrlCtx.seedKubeconfigGetter); err != nil {
+		return fmt.Errorf("failed to create cluster migration controller: %w", err)
+	}
+	if err := applicationrollout.Add(ctrlCtx.mgr, ctrlCtx.workerCount, ctrlCtx.log, ctrlCtx.seedsGetter, ctrlCtx.seedKubeconfigGetter); err != nil {
+		return fmt.Errorf("failed to create application rollout controller: %w", err)
+	}
 
 	// init CE/EE-only controllers
 	if err := setupControllers(ctrlCtx); err != nil {
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/go-logr/zapr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -64,8 +65,9 @@ type controllerRunOptions struct {
 	featureGates            features.FeatureGate
 	configFile              string
 
-	workerName string
-	namespace  string
+	workerName       string
+	namespace        string
+	applicationCache string
 }
 
 type controllerContext struct {
@@ -80,6 +82,7 @@ type controllerContext struct {
 	seedKubeconfigGetter    provider.SeedKubeconfigGetter
 	labelSelectorFunc       func(*metav1.ListOptions)
 	namespace               string
+	applicationCache        string
 	versions                kubermatic.Versions
 
 	configGetter provider.KubermaticConfigurationGetter
@@ -99,6 +102,7 @@ func main() {
 	flag.IntVar(&ctrlCtx.workerCount, "worker-count", 4, "Number of workers which process the clusters in parallel.")
 	flag.StringVar(&runOpts.internalAddr, "internal-address", "127.0.0.1:8085", "The address on which the /metrics endpoint will be served.")
 	flag.StringVar(&runOpts.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for datacenter custom resources.")
+	flag.StringVar(&runOpts.applicationCache, "application-cache", filepath.Join(os.TempDir(), "applications"), "Path to the directory in which applications for imported clusters are downloaded.")
 	flag.BoolVar(&runOpts.enableLeaderElection, "enable-leader-election", true, "Enable leader election for controller manager. "+
 		"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&runOpts.leaderElectionNamespace, "leader-election-namespace", "", "Leader election namespace. In-cluster discovery will be attempted in such case.")
@@ -114,6 +118,7 @@ func main() {
 	ctrlCtx.log = log
 	ctrlCtx.workerName = runOpts.workerName
 	ctrlCtx.namespace = runOpts.namespace
+	ctrlCtx.applicationCache = runOpts.applicationCache
 
 	// Set the logger used by sigs.k8s.io/controller-runtime
 	ctrlruntimelog.SetLogger(zapr.NewLogger(rawLog.WithOptions(zap.AddCallerSkip(1))))
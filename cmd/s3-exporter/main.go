@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/x509"
 	"flag"
 	"net/http"
@@ -26,10 +27,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/collectors"
 	"k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
-	"k8c.io/kubermatic/v2/pkg/util/s3"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 
 	"k8s.io/client-go/tools/clientcmd"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,10 +42,12 @@ func main() {
 	logOpts := log.NewDefaultOptions()
 	logOpts.AddFlags(flag.CommandLine)
 
-	endpoint := flag.String("endpoint", "", "The s3 endpoint, e.G. https://my-s3.com:9000")
+	destinationType := flag.String("type", string(kubermaticv1.BackupDestinationTypeS3), "The type of the backup destination, one of s3, filesystem, azure or gcs. Azure and GCS credentials are read from the ACCOUNT_NAME and ACCOUNT_KEY or the SERVICE_ACCOUNT environment variables")
+	endpoint := flag.String("endpoint", "", "The s3 endpoint, e.G. https://my-s3.com:9000; optional for Azure and GCS")
 	accessKeyID := flag.String("access-key-id", "", "S3 Access key, defaults to the ACCESS_KEY_ID environment variable")
 	secretAccessKey := flag.String("secret-access-key", "", "S3 Secret Access Key, defaults to the SECRET_ACCESS_KEY evnironment variable")
 	bucket := flag.String("bucket", "kubermatic-etcd-backups", "The bucket to monitor")
+	path := flag.String("path", backend.FilesystemMountPath, "The directory to monitor for filesystem destinations")
 	kubeconfig := flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	listenAddress := flag.String("address", ":9340", "The port to listen on")
 	caBundleFile := flag.String("ca-bundle", "", "Filename of the CA bundle to use (if not given, default system certificates are used)")
@@ -56,15 +60,22 @@ func main() {
 	// set the logger used by sigs.k8s.io/controller-runtime
 	ctrlruntimelog.SetLogger(zapr.NewLogger(rawLog.WithOptions(zap.AddCallerSkip(1))))
 
-	if *accessKeyID == "" {
-		*accessKeyID = os.Getenv("ACCESS_KEY_ID")
-	}
-	if *secretAccessKey == "" {
-		*secretAccessKey = os.Getenv("SECRET_ACCESS_KEY")
+	credentials := map[string][]byte{}
+	for _, key := range backend.CredentialKeys(kubermaticv1.BackupDestinationType(*destinationType)) {
+		credentials[key] = []byte(os.Getenv(key))
 	}
 
-	if *endpoint == "" || *accessKeyID == "" || *secretAccessKey == "" {
-		logger.Fatal("All of 'endpoint', 'access-key-id' and 'secret-access-key' must be set!")
+	if *destinationType == string(kubermaticv1.BackupDestinationTypeS3) {
+		if *accessKeyID != "" {
+			credentials[backend.S3AccessKeyIDKey] = []byte(*accessKeyID)
+		}
+		if *secretAccessKey != "" {
+			credentials[backend.S3SecretAccessKeyKey] = []byte(*secretAccessKey)
+		}
+
+		if *endpoint == "" || len(credentials[backend.S3AccessKeyIDKey]) == 0 || len(credentials[backend.S3SecretAccessKeyKey]) == 0 {
+			logger.Fatal("All of 'endpoint', 'access-key-id' and 'secret-access-key' must be set!")
+		}
 	}
 
 	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
@@ -88,13 +99,20 @@ func main() {
 	}
 
 	stopChannel := make(chan struct{})
-	minioClient, err := s3.NewClient(*endpoint, *accessKeyID, *secretAccessKey, certPool)
+	b, err := backend.New(context.Background(), backend.Config{
+		Type:        kubermaticv1.BackupDestinationType(*destinationType),
+		Endpoint:    *endpoint,
+		BucketName:  *bucket,
+		Path:        *path,
+		Credentials: credentials,
+		RootCAs:     certPool,
+		AppName:     "kubermatic-exporter",
+	})
 	if err != nil {
-		logger.Fatalw("Failed to get S3 client", zap.Error(err))
+		logger.Fatalw("Failed to create storage backend", zap.Error(err))
 	}
-	minioClient.SetAppInfo("kubermatic-exporter", "v0.2")
 
-	collectors.MustRegisterS3Collector(minioClient, client, *bucket, logger)
+	collectors.MustRegisterS3Collector(b, client, *bucket, logger)
 
 	http.Handle("/", promhttp.Handler())
 	go func() {
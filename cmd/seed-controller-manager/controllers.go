@@ -26,8 +26,10 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addoninstaller"
 	applicationsecretclustercontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/application-secret-cluster-controller"
 	autoupdatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/auto-update-controller"
+	carotationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/ca-rotation-controller"
 	cloudcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cloud"
 	clustercredentialscontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-credentials-controller"
+	clusterhibernationcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-hibernation-controller"
 	clusterphasecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-phase-controller"
 	clusterstuckcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-stuck-controller"
 	clustertemplatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/cluster-template-controller"
@@ -76,6 +78,8 @@ var AllControllers = map[string]controllerCreator{
 	clustertemplatecontroller.ControllerName:                createClusterTemplateController,
 	projectcontroller.ControllerName:                        createProjectController,
 	clusterphasecontroller.ControllerName:                   createClusterPhaseController,
+	clusterhibernationcontroller.ControllerName:             createClusterHibernationController,
+	carotationcontroller.ControllerName:                     createCARotationController,
 	presetcontroller.ControllerName:                         createPresetController,
 	encryptionatrestcontroller.ControllerName:               createEncryptionAtRestController,
 	ipam.ControllerName:                                     createIPAMController,
@@ -258,6 +262,28 @@ func createClusterPhaseController(ctrlCtx *controllerContext) error {
 	)
 }
 
+func createClusterHibernationController(ctrlCtx *controllerContext) error {
+	return clusterhibernationcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.log,
+		ctrlCtx.versions,
+	)
+}
+
+func createCARotationController(ctrlCtx *controllerContext) error {
+	return carotationcontroller.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.log,
+		ctrlCtx.versions,
+	)
+}
+
 func createAddonController(ctrlCtx *controllerContext) error {
 	return addon.Add(
 		ctrlCtx.mgr,
@@ -128,7 +128,7 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.StringVar(&c.oidcIssuerClientSecret, "oidc-issuer-client-secret", "", "OpenID client secret")
 	flag.StringVar(&c.kubermaticImage, "kubermatic-image", defaulting.DefaultKubermaticImage, "The location from which to pull the Kubermatic image")
 	flag.StringVar(&c.etcdLauncherImage, "etcd-launcher-image", defaulting.DefaultEtcdLauncherImage, "The location from which to pull the etcd launcher image")
-	flag.StringVar(&c.dnatControllerImage, "dnatcontroller-image", defaulting.DefaultDNATControllerImage, "The location of the dnatcontroller-image")
+	flag.StringVar(&c.dnatControllerImage, "dnatcontroller-image", defaulting.DefaultDNATControllerImage, "The location of the dnatcontroller-image. Deprecated: only used by clusters that still run OpenVPN.")
 	flag.StringVar(&c.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for Seed resources")
 	flag.IntVar(&c.concurrentClusterUpdate, "max-parallel-reconcile", 10, "The default number of resources updates per cluster")
 	flag.IntVar(&c.addonEnforceInterval, "addon-enforce-interval", 5, "Check and ensure default usercluster addons are deployed every interval in minutes. Set to 0 to disable.")
@@ -26,6 +26,7 @@ import (
 	eeseedctrlmgr "k8c.io/kubermatic/v2/pkg/ee/cmd/seed-controller-manager"
 	groupprojectbindingcontroller "k8c.io/kubermatic/v2/pkg/ee/group-project-binding/controller"
 	kubelbcontroller "k8c.io/kubermatic/v2/pkg/ee/kubelb"
+	meteringreportcontroller "k8c.io/kubermatic/v2/pkg/ee/metering/report-controller"
 	resourcequotaseedcontroller "k8c.io/kubermatic/v2/pkg/ee/resource-quota/seed-controller"
 	"k8c.io/kubermatic/v2/pkg/provider"
 
@@ -53,5 +54,9 @@ func setupControllers(ctrlCtx *controllerContext) error {
 		return fmt.Errorf("failed to create KubeLB controller: %w", err)
 	}
 
+	if err := meteringreportcontroller.Add(ctrlCtx.mgr, ctrlCtx.log, ctrlCtx.runOptions.namespace, ctrlCtx.runOptions.workerCount, ctrlCtx.seedGetter, ctrlCtx.runOptions.overwriteRegistry); err != nil {
+		return fmt.Errorf("failed to create metering report controller: %w", err)
+	}
+
 	return nil
 }
@@ -103,6 +103,7 @@ type controllerRunOptions struct {
 	ccmMigrationCompleted             bool
 	nutanixCSIEnabled                 bool
 	isKonnectivityEnabled             bool
+	isKonnectivityMigrating           bool
 	konnectivityServerHost            string
 	konnectivityServerPort            int
 	konnectivityKeepaliveTime         string
@@ -152,6 +153,7 @@ func main() {
 	flag.BoolVar(&runOp.ccmMigrationCompleted, "ccm-migration-completed", false, "cluster has been successfully migrated.")
 	flag.BoolVar(&runOp.nutanixCSIEnabled, "nutanix-csi-enabled", false, "enable Nutanix CSI")
 	flag.BoolVar(&runOp.isKonnectivityEnabled, "konnectivity-enabled", false, "Enable Konnectivity.")
+	flag.BoolVar(&runOp.isKonnectivityMigrating, "konnectivity-migration", false, "Deploy the Konnectivity agent and keep the OpenVPN client while the cluster is migrated from OpenVPN to Konnectivity.")
 	flag.StringVar(&runOp.konnectivityServerHost, "konnectivity-server-host", "", "Konnectivity Server host.")
 	flag.IntVar(&runOp.konnectivityServerPort, "konnectivity-server-port", 6443, "Konnectivity Server port.")
 	flag.StringVar(&runOp.konnectivityKeepaliveTime, "konnectivity-keepalive-time", "1m", "Konnectivity keepalive time.")
@@ -195,10 +197,10 @@ func main() {
 	if err != nil {
 		log.Fatalw("Failed parsing clusterURL", zap.Error(err))
 	}
-	if !runOp.isKonnectivityEnabled && runOp.openvpnServerPort == 0 {
+	if (!runOp.isKonnectivityEnabled || runOp.isKonnectivityMigrating) && runOp.openvpnServerPort == 0 {
 		log.Fatal("-openvpn-server-port must be set")
 	}
-	if runOp.isKonnectivityEnabled && runOp.konnectivityServerHost == "" {
+	if (runOp.isKonnectivityEnabled || runOp.isKonnectivityMigrating) && runOp.konnectivityServerHost == "" {
 		log.Fatal("-konnectivity-server-host must be set when Konnectivity is enabled")
 	}
 	if len(runOp.caBundleFile) == 0 {
@@ -323,6 +325,7 @@ func main() {
 		runOp.clusterName,
 		runOp.nutanixCSIEnabled,
 		runOp.isKonnectivityEnabled,
+		runOp.isKonnectivityMigrating,
 		runOp.konnectivityServerHost,
 		runOp.konnectivityServerPort,
 		runOp.konnectivityKeepaliveTime,
@@ -418,6 +421,7 @@ func main() {
 		ApplicationCache: runOp.applicationCache,
 		Kubeconfig:       kubeconfigFlag.Value.String(),
 		SecretNamespace:  runOp.namespace,
+		ClusterName:      runOp.clusterName,
 		CABundleFile:     runOp.caBundleFile,
 	}
 
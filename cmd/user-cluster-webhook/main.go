@@ -34,7 +34,9 @@ import (
 	applicationinstallationmutation "k8c.io/kubermatic/v2/pkg/webhook/application/applicationinstallation/mutation"
 	applicationinstallationvalidation "k8c.io/kubermatic/v2/pkg/webhook/application/applicationinstallation/validation"
 	machinevalidation "k8c.io/kubermatic/v2/pkg/webhook/machine/validation"
+	servicevalidation "k8c.io/kubermatic/v2/pkg/webhook/service/validation"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
@@ -129,7 +131,7 @@ func main() {
 	applicationinstallationmutation.NewAdmissionHandler(log, seedMgr.GetScheme()).SetupWebhookWithManager(seedMgr)
 
 	// Setup the validation admission handler for ApplicationInstallation CRDs in seed manager.
-	applicationinstallationvalidation.NewAdmissionHandler(log, seedMgr.GetScheme(), seedMgr.GetClient()).SetupWebhookWithManager(seedMgr)
+	applicationinstallationvalidation.NewAdmissionHandler(log, seedMgr.GetScheme(), seedMgr.GetClient(), userMgr.GetClient()).SetupWebhookWithManager(seedMgr)
 
 	// Setup Machine Webhook in user manager.
 	machineValidator, err := machinevalidation.NewValidator(seedMgr.GetClient(), userMgr.GetClient(), log, options.caBundle, options.projectID)
@@ -140,6 +142,12 @@ func main() {
 		log.Fatalw("Failed to setup Machine validation webhook", zap.Error(err))
 	}
 
+	// Setup Service Webhook in user manager.
+	serviceValidator := servicevalidation.NewValidator(seedMgr.GetClient(), log, options.projectID)
+	if err := builder.WebhookManagedBy(userMgr).For(&corev1.Service{}).WithValidator(serviceValidator).Complete(); err != nil {
+		log.Fatalw("Failed to setup Service validation webhook", zap.Error(err))
+	}
+
 	// /////////////////////////////////////////
 	// Start managers
 
@@ -45,11 +45,13 @@ annotation="kubermatic.k8c.io/location"
 locationMap='{
   "applicationdefinitions.apps.kubermatic.k8c.io": "master,seed",
   "applicationinstallations.apps.kubermatic.k8c.io": "usercluster",
+  "applicationrollouts.apps.kubermatic.k8c.io": "master",
   "addonconfigs.kubermatic.k8c.io": "master",
   "addons.kubermatic.k8c.io": "master,seed",
   "admissionplugins.kubermatic.k8c.io": "master",
   "alertmanagers.kubermatic.k8c.io": "master,seed",
   "allowedregistries.kubermatic.k8c.io": "master",
+  "clustermigrations.kubermatic.k8c.io": "master",
   "clusters.kubermatic.k8c.io": "master,seed",
   "clustertemplateinstances.kubermatic.k8c.io": "master,seed",
   "clustertemplates.kubermatic.k8c.io": "master,seed",
@@ -64,6 +66,7 @@ locationMap='{
   "ipampools.kubermatic.k8c.io": "master,seed",
   "kubermaticconfigurations.kubermatic.k8c.io": "master,seed",
   "kubermaticsettings.kubermatic.k8c.io": "master",
+  "meteringreports.kubermatic.k8c.io": "master,seed",
   "mlaadminsettings.kubermatic.k8c.io": "master,seed",
   "presets.kubermatic.k8c.io": "master,seed",
   "projects.kubermatic.k8c.io": "master,seed",
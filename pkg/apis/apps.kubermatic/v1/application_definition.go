@@ -150,9 +150,15 @@ type ApplicationSource struct {
 
 const (
 	HelmTemplateMethod TemplateMethod = "helm"
+
+	// KustomizeTemplateMethod renders the source with Kustomize and applies the result using server-side apply.
+	KustomizeTemplateMethod TemplateMethod = "kustomize"
+
+	// ManifestsTemplateMethod applies the plain YAML or JSON manifests found in the source using server-side apply.
+	ManifestsTemplateMethod TemplateMethod = "manifests"
 )
 
-// +kubebuilder:validation:Enum=helm
+// +kubebuilder:validation:Enum=helm;kustomize;manifests
 type TemplateMethod string
 
 type ApplicationTemplate struct {
@@ -66,10 +66,19 @@ type ApplicationInstallationSpec struct {
 	ApplicationRef ApplicationRef `json:"applicationRef"`
 
 	// Values describe overrides for manifest-rendering. It's a free yaml field.
+	// String values can use Go templates to refer to the cluster the application is installed in, e.g.
+	// "{{ .Cluster.Name }}" or "{{ first .Cluster.Network.PodCIDRBlocks }}". The available fields are defined by
+	// ValuesTemplateData in k8c.io/kubermatic/v2/pkg/applications/providers/template.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Values runtime.RawExtension `json:"values,omitempty"`
 	// As kubebuilder does not support interface{} as a type, deferring json decoding, seems to be our best option (see https://github.com/kubernetes-sigs/controller-tools/issues/294#issuecomment-518379253)
 
+	// ValuesFrom lists Secrets and ConfigMaps whose content is merged into the values, in order. Later references
+	// override earlier ones, and Values override all of them. Changes to the referenced objects are applied on the
+	// next reconciliation of the application.
+	// +optional
+	ValuesFrom []ValuesReference `json:"valuesFrom,omitempty"`
+
 	// ReconciliationInterval is the interval at which to force the reconciliation of the application. By default, Applications are only reconciled
 	// on changes on spec, annotations, or the parent application definition. Meaning that if the user manually deletes the workload
 	// deployed by the application, nothing will happen until the application CR change.
@@ -81,6 +90,128 @@ type ApplicationInstallationSpec struct {
 
 	// DeployOptions holds the settings specific to the templating method used to deploy the application.
 	DeployOptions *DeployOptions `json:"deployOptions,omitempty"`
+
+	// DependsOn lists the ApplicationInstallations that must be Ready before this application is installed or upgraded.
+	// When applications are deleted together, this application is uninstalled before its dependencies.
+	// +optional
+	DependsOn []ApplicationDependency `json:"dependsOn,omitempty"`
+
+	// DriftDetection enables the periodic comparison of the objects installed by the application with their desired state.
+	// When enabled, reconciliations that are not caused by a change of the application only detect drift instead of
+	// re-running the installation, unless the remediation policy allows it. Only supported by the 'helm' template method.
+	// +optional
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Secret;ConfigMap
+
+// ValuesReferenceKind is the kind of the object referenced by a ValuesReference.
+type ValuesReferenceKind string
+
+const (
+	ValuesReferenceKindSecret    ValuesReferenceKind = "Secret"
+	ValuesReferenceKindConfigMap ValuesReferenceKind = "ConfigMap"
+)
+
+// +kubebuilder:validation:Enum="";UserCluster;Seed
+
+// ValuesReferenceSource is the cluster in which the object referenced by a ValuesReference is located.
+type ValuesReferenceSource string
+
+const (
+	// ValuesReferenceSourceUserCluster references an object in the user cluster.
+	ValuesReferenceSourceUserCluster ValuesReferenceSource = "UserCluster"
+
+	// ValuesReferenceSourceSeed references an object in the namespace of the cluster on the seed. Only objects
+	// annotated with `apps.kubermatic.k8c.io/secret-type`, like the application secrets synchronized from the
+	// master, can be referenced.
+	ValuesReferenceSourceSeed ValuesReferenceSource = "Seed"
+)
+
+// DefaultValuesKey is the key of a ValuesReference if none is set.
+const DefaultValuesKey = "values.yaml"
+
+// ValuesReference references a key of a Secret or ConfigMap that contains Helm values in YAML format.
+type ValuesReference struct {
+	// Kind of the referenced object.
+	Kind ValuesReferenceKind `json:"kind"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+
+	// Namespace of the referenced object in the user cluster. Defaults to the namespace of the ApplicationInstallation.
+	// Must not be set if the source is "Seed".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Source is the cluster in which the referenced object is located. Defaults to "UserCluster".
+	// +optional
+	Source ValuesReferenceSource `json:"source,omitempty"`
+
+	// Key in the data of the referenced object. Defaults to "values.yaml".
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Optional marks the reference as optional: a missing object or key is ignored instead of failing the installation.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Report;Upgrade
+
+// DriftRemediationPolicy defines what happens when a drift is detected.
+type DriftRemediationPolicy string
+
+const (
+	// DriftRemediationPolicyReport only records the drifted objects in the status.
+	DriftRemediationPolicyReport DriftRemediationPolicy = "Report"
+
+	// DriftRemediationPolicyUpgrade records the drifted objects and upgrades the application to restore their desired state.
+	DriftRemediationPolicyUpgrade DriftRemediationPolicy = "Upgrade"
+)
+
+// DriftDetection configures the drift detection of an application.
+type DriftDetection struct {
+	// Interval at which drift is detected. Defaults to 10 minutes.
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// RemediationPolicy defines what happens when a drift is detected. Defaults to "Report", which only records the
+	// drifted objects in the status. "Upgrade" additionally re-runs the installation to restore the desired state.
+	// +optional
+	RemediationPolicy DriftRemediationPolicy `json:"remediationPolicy,omitempty"`
+}
+
+// ApplicationDependency references the ApplicationInstallation(s) an application depends on. Exactly one of Name and
+// ApplicationRef must be set.
+type ApplicationDependency struct {
+	// Name of the ApplicationInstallation.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the ApplicationInstallation. Defaults to the namespace of the depending ApplicationInstallation.
+	// Can only be used together with Name.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ApplicationRef matches every ApplicationInstallation of the cluster that installs this application and version.
+	// The dependency is satisfied as soon as one of them is Ready.
+	// +optional
+	ApplicationRef *ApplicationRef `json:"applicationRef,omitempty"`
+}
+
+// Matches returns true if candidate is referenced by this dependency of the owner ApplicationInstallation.
+func (d ApplicationDependency) Matches(owner, candidate *ApplicationInstallation) bool {
+	if d.ApplicationRef != nil {
+		return candidate.Spec.ApplicationRef == *d.ApplicationRef
+	}
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = owner.Namespace
+	}
+
+	return candidate.Name == d.Name && candidate.Namespace == namespace
 }
 
 // DeployOptions holds the settings specific to the templating method used to deploy the application.
@@ -168,10 +299,59 @@ type ApplicationInstallationStatus struct {
 	// HelmRelease holds the information about the helm release installed by this application. This field is only filled if template method is 'helm'.
 	HelmRelease *HelmRelease `json:"helmRelease,omitempty"`
 
+	// ManagedResources lists the objects applied into the user cluster by this application. This field is only filled if template method is 'kustomize' or 'manifests'.
+	// Objects that are no longer rendered are pruned on upgrade, and all listed objects are deleted on uninstall.
+	ManagedResources []ManagedResource `json:"managedResources,omitempty"`
+
+	// Drift holds the result of the last drift detection. This field is only filled if drift detection is enabled.
+	Drift *ApplicationDrift `json:"drift,omitempty"`
+
 	// Failures counts the number of failed installation or updagrade. it is reset on successful reconciliation.
 	Failures int `json:"failures,omitempty"`
 }
 
+// ManagedResource identifies an object applied into the user cluster by an application.
+type ManagedResource struct {
+	// APIVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object. Empty for cluster-scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
+}
+
+// ApplicationDrift is the result of a drift detection.
+type ApplicationDrift struct {
+	// LastCheckTime is the last time drift was detected.
+	LastCheckTime metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// LastRemediationTime is the last time a drift was remediated by upgrading the application.
+	LastRemediationTime metav1.Time `json:"lastRemediationTime,omitempty"`
+
+	// Objects lists the objects whose live state differs from their desired state.
+	Objects []DriftedObject `json:"objects,omitempty"`
+}
+
+// DriftedObject describes an object whose live state differs from its desired state.
+type DriftedObject struct {
+	ManagedResource `json:",inline"`
+
+	// Missing is true if the object does not exist in the cluster.
+	Missing bool `json:"missing,omitempty"`
+
+	// Fields lists the paths of the fields (e.g. spec.replicas) whose live value differs from the desired one.
+	Fields []string `json:"fields,omitempty"`
+
+	// Managers lists the field managers, other than the installer, that have modified the object. This usually
+	// identifies who changed the object by hand (e.g. kubectl-edit).
+	Managers []string `json:"managers,omitempty"`
+}
+
 type HelmRelease struct {
 	// Name is the name of the release.
 	Name string `json:"name,omitempty"`
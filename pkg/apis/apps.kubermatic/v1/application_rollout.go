@@ -0,0 +1,203 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ApplicationRolloutResourceName represents "Resource" defined in Kubernetes.
+	ApplicationRolloutResourceName = "applicationrollouts"
+
+	// ApplicationRolloutKindName represents "Kind" defined in Kubernetes.
+	ApplicationRolloutKindName = "ApplicationRollout"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.applicationRef.name",name="Application",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.applicationRef.version",name="Version",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.currentWave",name="Wave",type="integer"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name="Phase",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ApplicationRollout upgrades the ApplicationInstallations of an application to a new version
+// across many user clusters. The installations are upgraded in waves, and the next wave only
+// starts once all installations of the current wave are healthy.
+type ApplicationRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationRolloutSpec   `json:"spec,omitempty"`
+	Status ApplicationRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationRolloutList is a list of ApplicationRollouts.
+type ApplicationRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ApplicationRollout `json:"items"`
+}
+
+// ApplicationRolloutSpec specifies which ApplicationInstallations are upgraded and how.
+type ApplicationRolloutSpec struct {
+	// ApplicationRef is the application whose installations are upgraded and the version they
+	// are upgraded to. The version must be defined in the ApplicationDefinition.
+	ApplicationRef ApplicationRef `json:"applicationRef"`
+
+	// ClusterSelector selects the user clusters by their labels. If empty, all clusters are selected.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Projects restricts the rollout to the clusters of the given project IDs. If empty, the
+	// clusters of all projects are selected.
+	// +optional
+	Projects []string `json:"projects,omitempty"`
+
+	// Strategy configures how the selected installations are upgraded.
+	// +optional
+	Strategy ApplicationRolloutStrategy `json:"strategy,omitempty"`
+}
+
+// ApplicationRolloutStrategy configures the waves of a rollout.
+type ApplicationRolloutStrategy struct {
+	// MaxUnavailable is the number or percentage of the selected installations that are upgraded
+	// at the same time, i.e. the size of a wave. Percentages are rounded down, but a wave always
+	// contains at least one installation. Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// HealthTimeout is how long an upgraded installation may take to become ready before it is
+	// considered failed. Defaults to 10m.
+	// +optional
+	HealthTimeout metav1.Duration `json:"healthTimeout,omitempty"`
+
+	// FailureThreshold is the number of failed upgrades that are tolerated. The rollout is halted
+	// as soon as more upgrades have failed. Defaults to 0, i.e. the first failure halts the rollout.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+
+	// AutoRollback reverts all installations upgraded by this rollout to their previous version
+	// when the rollout is halted.
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
+}
+
+// +kubebuilder:validation:Enum="";Progressing;Completed;Halted;RolledBack
+
+// ApplicationRolloutPhase represents the lifecycle phase of an ApplicationRollout.
+type ApplicationRolloutPhase string
+
+const (
+	// ApplicationRolloutPhaseProgressing means the installations are being upgraded.
+	ApplicationRolloutPhaseProgressing ApplicationRolloutPhase = "Progressing"
+	// ApplicationRolloutPhaseCompleted means all selected installations have been upgraded successfully.
+	ApplicationRolloutPhaseCompleted ApplicationRolloutPhase = "Completed"
+	// ApplicationRolloutPhaseHalted means the rollout has been stopped because too many upgrades failed.
+	ApplicationRolloutPhaseHalted ApplicationRolloutPhase = "Halted"
+	// ApplicationRolloutPhaseRolledBack means the rollout has been halted and the upgraded
+	// installations have been reverted to their previous version.
+	ApplicationRolloutPhaseRolledBack ApplicationRolloutPhase = "RolledBack"
+)
+
+// +kubebuilder:validation:Enum=Pending;Upgrading;Healthy;Failed;RolledBack
+
+// ApplicationRolloutTargetPhase represents the state of a single installation in a rollout.
+type ApplicationRolloutTargetPhase string
+
+const (
+	// ApplicationRolloutTargetPhasePending means the installation has not been upgraded yet.
+	ApplicationRolloutTargetPhasePending ApplicationRolloutTargetPhase = "Pending"
+	// ApplicationRolloutTargetPhaseUpgrading means the installation has been upgraded and is not ready yet.
+	ApplicationRolloutTargetPhaseUpgrading ApplicationRolloutTargetPhase = "Upgrading"
+	// ApplicationRolloutTargetPhaseHealthy means the installation is ready at the new version.
+	ApplicationRolloutTargetPhaseHealthy ApplicationRolloutTargetPhase = "Healthy"
+	// ApplicationRolloutTargetPhaseFailed means the installation did not become ready in time.
+	ApplicationRolloutTargetPhaseFailed ApplicationRolloutTargetPhase = "Failed"
+	// ApplicationRolloutTargetPhaseRolledBack means the installation has been reverted to its previous version.
+	ApplicationRolloutTargetPhaseRolledBack ApplicationRolloutTargetPhase = "RolledBack"
+)
+
+// ApplicationRolloutStatus contains the progress of an ApplicationRollout.
+type ApplicationRolloutStatus struct {
+	// Phase is the current phase of the rollout.
+	// +optional
+	Phase ApplicationRolloutPhase `json:"phase,omitempty"`
+
+	// Message describes why the rollout has been halted.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CurrentWave is the number of the wave currently being upgraded, starting at 1.
+	// +optional
+	CurrentWave int `json:"currentWave,omitempty"`
+
+	// Targets are the installations selected when the rollout started. Clusters created
+	// afterwards are not part of the rollout.
+	// +optional
+	Targets []ApplicationRolloutTarget `json:"targets,omitempty"`
+
+	// StartTime is when the installations were selected.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the rollout has been completed, halted or rolled back.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ApplicationRolloutTarget is an ApplicationInstallation upgraded by a rollout.
+type ApplicationRolloutTarget struct {
+	// Seed is the name of the Seed hosting the cluster.
+	Seed string `json:"seed"`
+
+	// Cluster is the name of the user cluster.
+	Cluster string `json:"cluster"`
+
+	// Namespace of the ApplicationInstallation in the user cluster.
+	Namespace string `json:"namespace"`
+
+	// Name of the ApplicationInstallation.
+	Name string `json:"name"`
+
+	// PreviousVersion is the version the installation referenced before the rollout, it is
+	// restored on rollback.
+	PreviousVersion string `json:"previousVersion"`
+
+	// Wave is the number of the wave the installation has been upgraded in.
+	// +optional
+	Wave int `json:"wave,omitempty"`
+
+	// Phase is the state of the installation in the rollout.
+	Phase ApplicationRolloutTargetPhase `json:"phase"`
+
+	// LastTransitionTime is the last time the phase changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message describes why the upgrade failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
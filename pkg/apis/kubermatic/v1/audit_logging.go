@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 )
 
 // +kubebuilder:validation:Enum="";metadata;recommended;minimal
@@ -26,6 +27,13 @@ import (
 // are `metadata`, `recommended` and `minimal`. See KKP documentation for what each policy preset includes.
 type AuditPolicyPreset string
 
+const (
+	// AuditWebhookConfigLabelKey must be set to "true" on Secrets in the KKP namespace that
+	// clusters are allowed to reference as their audit webhook config. Secrets referenced by
+	// the audit logging defaults of a seed do not need it.
+	AuditWebhookConfigLabelKey = "kubermatic.k8c.io/audit-webhook-config"
+)
+
 const (
 	AuditPolicyMetadata    AuditPolicyPreset = "metadata"
 	AuditPolicyRecommended AuditPolicyPreset = "recommended"
@@ -51,6 +59,38 @@ type AuditLoggingSettings struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// Optional: PolicyPreset can be set to utilize a pre-defined set of audit policy rules.
 	PolicyPreset AuditPolicyPreset `json:"policyPreset,omitempty"`
+	// Optional: PolicyConfigMapRef references a ConfigMap key holding a custom audit policy
+	// (an audit.k8s.io/v1 Policy). The ConfigMap must be in the KKP namespace on the seed.
+	// It cannot be combined with PolicyPreset or Rules.
+	PolicyConfigMapRef *corev1.ConfigMapKeySelector `json:"policyConfigMapRef,omitempty"`
+	// Optional: Rules is an inline list of audit policy rules that is used instead of a preset.
+	// It cannot be combined with PolicyPreset or PolicyConfigMapRef.
+	Rules []auditv1.PolicyRule `json:"rules,omitempty"`
 	// Optional: Configures the fluent-bit sidecar deployed alongside kube-apiserver.
 	SidecarSettings *AuditSidecarSettings `json:"sidecar,omitempty"`
+	// Optional: WebhookBackend makes kube-apiserver send audit events to a webhook instead of
+	// shipping them with the fluent-bit sidecar. It cannot be combined with a sidecar config.
+	WebhookBackend *AuditWebhookBackendSettings `json:"webhookBackend,omitempty"`
+}
+
+// AuditWebhookBackendSettings configures the audit webhook backend of kube-apiserver.
+type AuditWebhookBackendSettings struct {
+	// AuditWebhookConfig references a Secret key holding the kubeconfig that describes
+	// the remote webhook endpoint. The Secret must be in the KKP namespace on the seed. If the
+	// backend is configured on a cluster, the Secret must also have the
+	// `kubermatic.k8c.io/audit-webhook-config: "true"` label.
+	AuditWebhookConfig *corev1.SecretKeySelector `json:"auditWebhookConfig"`
+	// Optional: AuditWebhookInitialBackoff is the amount of time to wait before retrying the
+	// first failed request, e.g. "10s". Defaults to the kube-apiserver default.
+	AuditWebhookInitialBackoff string `json:"auditWebhookInitialBackoff,omitempty"`
+}
+
+// SeedAuditLoggingSettings configures the default audit backend for the user clusters of a seed.
+// It is used by every cluster that has audit logging enabled but configures neither a sidecar
+// config nor a webhook backend itself.
+type SeedAuditLoggingSettings struct {
+	// Optional: SidecarConfig is the default configuration of the fluent-bit sidecar.
+	SidecarConfig *AuditSidecarConfiguration `json:"sidecarConfig,omitempty"`
+	// Optional: WebhookBackend is the default webhook backend. It cannot be combined with SidecarConfig.
+	WebhookBackend *AuditWebhookBackendSettings `json:"webhookBackend,omitempty"`
 }
@@ -50,6 +50,19 @@ const (
 
 	// PresetInvalidatedAnnotation is key of the annotation used to indicate why the preset was invalidated.
 	PresetInvalidatedAnnotation = "presetInvalidated"
+
+	// RotateEncryptionKeyAnnotation is key of the annotation used to manually trigger the rotation of the
+	// secretbox encryption key of a cluster that has a key rotation policy configured.
+	RotateEncryptionKeyAnnotation = "kubermatic.k8c.io/rotate-encryption-key"
+
+	// RotateCAAnnotation is key of the annotation used to trigger the rotation of the root CA of a cluster.
+	// It is removed once the rotation has started; the progress is tracked in the CARotation condition.
+	RotateCAAnnotation = "kubermatic.k8c.io/rotate-ca"
+
+	// RenewCertificatesAnnotation is key of the annotation used to force the renewal of all leaf
+	// certificates of a cluster. It is removed once the renewal is finished; the progress is tracked
+	// in the CertificateRenewal condition.
+	RenewCertificatesAnnotation = "kubermatic.k8c.io/renew-certificates"
 )
 
 const (
@@ -186,6 +199,14 @@ type ClusterSpec struct {
 	// applying OS updates to nodes. This is only respected on Flatcar nodes currently.
 	UpdateWindow *UpdateWindow `json:"updateWindow,omitempty"`
 
+	// Optional: Hibernated scales the cluster to zero. All MachineDeployments are scaled down first,
+	// then the control plane is stopped while the etcd data is kept. Unsetting the field resumes
+	// the cluster and restores the previous number of replicas.
+	Hibernated bool `json:"hibernated,omitempty"`
+	// Optional: HibernationSchedule hibernates the cluster automatically during the given window,
+	// for example starting at `Sat 00:00` for `48h`. The reference time for this is the seed's system time.
+	HibernationSchedule *UpdateWindow `json:"hibernationSchedule,omitempty"`
+
 	// Enables the admission plugin `PodSecurityPolicy`. This plugin is deprecated by Kubernetes.
 	UsePodSecurityPolicyAdmissionPlugin bool `json:"usePodSecurityPolicyAdmissionPlugin,omitempty"`
 	// Enables the admission plugin `PodNodeSelector`. Needs additional configuration via the `podNodeSelectorAdmissionPluginConfig` field.
@@ -372,7 +393,7 @@ const (
 // This ENUM contains the misspelling CloudControllerReconcilledSuccessfully (double L);
 // this is so that KKP can slowly migrate and in KKP 2.22 we will remove the misspelling.
 
-// +kubebuilder:validation:Enum="";SeedResourcesUpToDate;ClusterControllerReconciledSuccessfully;AddonControllerReconciledSuccessfully;AddonInstallerControllerReconciledSuccessfully;BackupControllerReconciledSuccessfully;CloudControllerReconciledSuccessfully;CloudControllerReconcilledSuccessfully;UpdateControllerReconciledSuccessfully;MonitoringControllerReconciledSuccessfully;MachineDeploymentReconciledSuccessfully;MLAControllerReconciledSuccessfully;ClusterInitialized;EtcdClusterInitialized;CSIKubeletMigrationCompleted;ClusterUpdateSuccessful;ClusterUpdateInProgress;CSIKubeletMigrationSuccess;CSIKubeletMigrationInProgress;EncryptionControllerReconciledSuccessfully;IPAMControllerReconciledSuccessfully;
+// +kubebuilder:validation:Enum="";SeedResourcesUpToDate;ClusterControllerReconciledSuccessfully;AddonControllerReconciledSuccessfully;AddonInstallerControllerReconciledSuccessfully;BackupControllerReconciledSuccessfully;CloudControllerReconciledSuccessfully;CloudControllerReconcilledSuccessfully;UpdateControllerReconciledSuccessfully;MonitoringControllerReconciledSuccessfully;MachineDeploymentReconciledSuccessfully;MLAControllerReconciledSuccessfully;ClusterInitialized;EtcdClusterInitialized;CSIKubeletMigrationCompleted;ClusterUpdateSuccessful;ClusterUpdateInProgress;CSIKubeletMigrationSuccess;CSIKubeletMigrationInProgress;EncryptionControllerReconciledSuccessfully;IPAMControllerReconciledSuccessfully;CARotation;CertificateRenewal;KonnectivityMigration;
 
 // ClusterConditionType is used to indicate the type of a cluster condition. For all condition
 // types, the `true` value must indicate success. All condition types must be registered within
@@ -406,6 +427,47 @@ type EncryptionConfiguration struct {
 	// Configuration for the `secretbox` static key encryption scheme as supported by Kubernetes.
 	// More info: https://kubernetes.io/docs/tasks/administer-cluster/encrypt-data/#providers
 	Secretbox *SecretboxEncryptionConfiguration `json:"secretbox,omitempty"`
+	// Configuration for envelope encryption via external key management services, using
+	// KMS v2 plugins that run as sidecars next to kube-apiserver.
+	// More info: https://kubernetes.io/docs/tasks/administer-cluster/kms-provider/
+	KMS *KMSEncryptionConfiguration `json:"kms,omitempty"`
+}
+
+// KMSEncryptionConfiguration defines envelope encryption based on KMS v2 plugins.
+type KMSEncryptionConfiguration struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// List of KMS providers. The first element of this list is considered the "primary"
+	// provider which will be used for encrypting data while writing it. Additional providers
+	// will be used for decrypting data while reading it, which allows to rotate to a new
+	// provider by adding it at the top of the list and removing the old one once the
+	// re-encryption of data has finished.
+	Providers []KMSProvider `json:"providers"`
+}
+
+// KMSProvider configures a single KMS v2 plugin that is deployed as a sidecar to kube-apiserver.
+type KMSProvider struct {
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+
+	// Name of the provider, used in various places to refer to it. kube-apiserver stores the name
+	// alongside all data encrypted with the provider, so it must not be changed in place.
+	Name string `json:"name"`
+	// Image of the KMS v2 plugin. The plugin needs to serve the KMS v2 gRPC API on the unix socket
+	// passed in the `KMS_PLUGIN_SOCKET` environment variable, which can be referenced in the
+	// arguments via `$(KMS_PLUGIN_SOCKET)`.
+	Image string `json:"image"`
+	// Command overrides the entrypoint of the plugin image.
+	Command []string `json:"command,omitempty"`
+	// Args are passed to the plugin.
+	Args []string `json:"args,omitempty"`
+	// Env allows to set additional environment variables for the plugin, e.g. to pass credentials
+	// for the external key management service from secrets in the cluster namespace.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Resources configures the resource requirements of the plugin container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Timeout for requests from kube-apiserver to the plugin. Defaults to 3s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // SecretboxEncryptionConfiguration defines static key encryption based on the 'secretbox' solution for Kubernetes.
@@ -417,6 +479,19 @@ type SecretboxEncryptionConfiguration struct {
 	// keys will be used for decrypting data while reading it, if keys higher in the list
 	// did not succeed in decrypting it.
 	Keys []SecretboxKey `json:"keys"`
+
+	// Rotation configures the automated rotation of the primary key. When set, KKP generates new keys
+	// into Secrets in the cluster namespace, prepends them to `keys`, re-encrypts all data and removes
+	// the retired key afterwards.
+	Rotation *SecretboxKeyRotationPolicy `json:"rotation,omitempty"`
+}
+
+// SecretboxKeyRotationPolicy defines when the primary secretbox key of a cluster is rotated.
+type SecretboxKeyRotationPolicy struct {
+	// Interval after which the primary key is rotated, e.g. `720h`. If not set, keys are only rotated
+	// when the cluster is annotated with `kubermatic.k8c.io/rotate-encryption-key`, which is possible
+	// regardless of the interval.
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // SecretboxKey stores a key or key reference for encrypting Kubernetes API data at rest with a static key.
@@ -457,6 +532,19 @@ const (
 
 	ClusterConditionUpdateProgress ClusterConditionType = "UpdateProgress"
 
+	// ClusterConditionCARotation tracks the rotation of the cluster root CA. While a rotation is in
+	// progress, the condition is false and its reason names the current phase.
+	ClusterConditionCARotation ClusterConditionType = "CARotation"
+
+	// ClusterConditionCertificateRenewal tracks a forced renewal of the cluster leaf certificates.
+	// While a renewal is in progress, the condition is false and its reason names the current stage.
+	ClusterConditionCertificateRenewal ClusterConditionType = "CertificateRenewal"
+
+	// ClusterConditionKonnectivityMigration tracks the switchover of a running cluster from OpenVPN
+	// to Konnectivity. While the switchover is in progress, the condition is false and its reason
+	// names the current phase.
+	ClusterConditionKonnectivityMigration ClusterConditionType = "KonnectivityMigration"
+
 	// ClusterConditionNone is a special value indicating that no cluster condition should be set.
 	ClusterConditionNone ClusterConditionType = ""
 	// This condition is met when a CSI migration is ongoing and the CSI
@@ -473,6 +561,37 @@ const (
 	ReasonClusterUpdateInProgress             = "ClusterUpdateInProgress"
 	ReasonClusterCSIKubeletMigrationCompleted = "CSIKubeletMigrationSuccess"
 	ReasonClusterCCMMigrationInProgress       = "CSIKubeletMigrationInProgress"
+
+	// ReasonCARotationTrustingNewCA means that the new root CA is added to all trust bundles and
+	// the control plane and nodes are rolled to pick it up.
+	ReasonCARotationTrustingNewCA = "TrustingNewCA"
+	// ReasonCARotationReissuingCertificates means that the new root CA is used for signing and all
+	// certificates are re-issued, while the old root CA is still trusted.
+	ReasonCARotationReissuingCertificates = "ReissuingCertificates"
+	// ReasonCARotationDroppingOldCA means that the old root CA is removed from all trust bundles.
+	ReasonCARotationDroppingOldCA = "DroppingOldCA"
+	// ReasonCARotationCompleted means that the last CA rotation has finished.
+	ReasonCARotationCompleted = "CARotationCompleted"
+
+	// ReasonCertificateRenewalEtcd means that the etcd serving and client certificates are renewed.
+	ReasonCertificateRenewalEtcd = "RenewingEtcdCertificates"
+	// ReasonCertificateRenewalApiserver means that the apiserver serving and client certificates are renewed.
+	ReasonCertificateRenewalApiserver = "RenewingApiserverCertificates"
+	// ReasonCertificateRenewalClients means that the kubeconfigs and certificates of the remaining
+	// control plane components are renewed.
+	ReasonCertificateRenewalClients = "RenewingClientCertificates"
+	// ReasonCertificateRenewalCompleted means that the last certificate renewal has finished.
+	ReasonCertificateRenewalCompleted = "CertificateRenewalCompleted"
+
+	// ReasonKonnectivityMigrationDeployingAgents means that the Konnectivity server and agents are
+	// deployed next to OpenVPN, while the control plane still reaches the nodes via OpenVPN.
+	ReasonKonnectivityMigrationDeployingAgents = "DeployingKonnectivityAgents"
+	// ReasonKonnectivityMigrationSwitchingControlPlane means that the control plane is rolled out
+	// to use Konnectivity, while OpenVPN is kept for the control plane pods that were not replaced yet.
+	ReasonKonnectivityMigrationSwitchingControlPlane = "SwitchingControlPlane"
+	// ReasonKonnectivityMigrationCompleted means that the cluster has been migrated to Konnectivity
+	// and OpenVPN has been removed.
+	ReasonKonnectivityMigrationCompleted = "KonnectivityMigrationCompleted"
 )
 
 var AllClusterConditionTypes = []ClusterConditionType{
@@ -502,7 +621,7 @@ type ClusterCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Creating;Updating;Running;Terminating
+// +kubebuilder:validation:Enum=Creating;Updating;Running;Terminating;Hibernated
 
 type ClusterPhase string
 
@@ -512,6 +631,7 @@ const (
 	ClusterUpdating    ClusterPhase = "Updating"
 	ClusterRunning     ClusterPhase = "Running"
 	ClusterTerminating ClusterPhase = "Terminating"
+	ClusterHibernated  ClusterPhase = "Hibernated"
 )
 
 // ClusterStatus stores status information about a cluster.
@@ -578,6 +698,40 @@ type ClusterStatus struct {
 
 	// ResourceUsage shows the current usage of resources for the cluster.
 	ResourceUsage *ResourceDetails `json:"resourceUsage,omitempty"`
+
+	// Hibernation describes the progress of hibernating or resuming the cluster.
+	// It is unset for running clusters.
+	// +optional
+	Hibernation *ClusterHibernationStatus `json:"hibernation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ScalingDown;Hibernated;Resuming
+
+type ClusterHibernationPhase string
+
+const (
+	// ClusterHibernationScalingDown means the MachineDeployments are being scaled to zero.
+	ClusterHibernationScalingDown ClusterHibernationPhase = "ScalingDown"
+	// ClusterHibernationHibernated means all workers are gone and the control plane is scaled to zero.
+	ClusterHibernationHibernated ClusterHibernationPhase = "Hibernated"
+	// ClusterHibernationResuming means the control plane is starting and the MachineDeployments
+	// are restored once the apiserver is available.
+	ClusterHibernationResuming ClusterHibernationPhase = "Resuming"
+)
+
+// ClusterHibernationStatus holds status information about a hibernated cluster.
+type ClusterHibernationStatus struct {
+	Phase ClusterHibernationPhase `json:"phase"`
+	// MachineDeploymentReplicas records the replicas of all MachineDeployments, by name,
+	// before they were scaled to zero.
+	MachineDeploymentReplicas map[string]int32 `json:"machineDeploymentReplicas,omitempty"`
+	// LastTransitionTime is the time the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IsHibernated returns true if the control plane of the cluster is scaled to zero.
+func (cs *ClusterStatus) IsHibernated() bool {
+	return cs.Hibernation != nil && cs.Hibernation.Phase == ClusterHibernationHibernated
 }
 
 // ClusterVersionsStatus contains information regarding the current and desired versions
@@ -639,8 +793,34 @@ type ClusterEncryptionStatus struct {
 	// The `encryption_controller` logic will process the cluster based on the current phase and issue necessary changes
 	// to make sure encryption on the cluster is active and updated with what the ClusterSpec defines.
 	Phase ClusterEncryptionPhase `json:"phase"`
+
+	// History of automated key rotations, the most recent one last. Only the last
+	// 10 rotations are kept.
+	KeyRotations []EncryptionKeyRotation `json:"keyRotations,omitempty"`
 }
 
+// EncryptionKeyRotation records a single automated rotation of the primary encryption key.
+type EncryptionKeyRotation struct {
+	// Name of the newly generated primary key.
+	Key string `json:"key"`
+	// Name of the key that was primary before and is removed once all data was re-encrypted.
+	RetiredKey string `json:"retiredKey,omitempty"`
+	// Trigger of the rotation, either `Interval` or `Manual`.
+	Trigger EncryptionKeyRotationTrigger `json:"trigger"`
+	// StartTime is the time the new key was added to the cluster.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time all data was re-encrypted and the retired key was removed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Interval;Manual
+type EncryptionKeyRotationTrigger string
+
+const (
+	EncryptionKeyRotationTriggerInterval EncryptionKeyRotationTrigger = "Interval"
+	EncryptionKeyRotationTriggerManual   EncryptionKeyRotationTrigger = "Manual"
+)
+
 // +kubebuilder:validation:Enum=Pending;Failed;Active;EncryptionNeeded
 type ClusterEncryptionPhase string
 
@@ -897,12 +1077,42 @@ type ClusterNetworkingConfig struct {
 	// Deprecated: KonnectivityEnabled enables konnectivity for controlplane to node network communication.
 	// As OpenVPN will be removed in the future KKP versions, clusters with konnectivity disabled will not be supported.
 	// All existing clusters with OpenVPN should migrate to the Konnectivity.
+	// Use TunnelingMechanism instead; this field is kept in sync with it by the cluster webhook.
 	KonnectivityEnabled *bool `json:"konnectivityEnabled,omitempty"`
 
+	// TunnelingMechanism defines how the control plane reaches the user cluster nodes
+	// ("Konnectivity" / "OpenVPN"). New clusters default to Konnectivity for every expose strategy.
+	// Switching a running OpenVPN cluster to Konnectivity starts a migration that is tracked by the
+	// KonnectivityMigration condition; OpenVPN is only removed once the control plane has switched over.
+	// +optional
+	TunnelingMechanism TunnelingMechanism `json:"tunnelingMechanism,omitempty"`
+
 	// TunnelingAgentIP is the address used by the tunneling agents
 	TunnelingAgentIP string `json:"tunnelingAgentIP,omitempty"`
 }
 
+// GetTunnelingMechanism returns the configured tunneling mechanism. Clusters that have not been
+// defaulted since TunnelingMechanism was introduced fall back to the deprecated KonnectivityEnabled flag.
+func (c ClusterNetworkingConfig) GetTunnelingMechanism() TunnelingMechanism {
+	if c.TunnelingMechanism != "" {
+		return c.TunnelingMechanism
+	}
+
+	if c.KonnectivityEnabled != nil && *c.KonnectivityEnabled { //nolint:staticcheck
+		return TunnelingMechanismKonnectivity
+	}
+
+	return TunnelingMechanismOpenVPN
+}
+
+// +kubebuilder:validation:Enum=Konnectivity;OpenVPN
+type TunnelingMechanism string
+
+const (
+	TunnelingMechanismKonnectivity TunnelingMechanism = "Konnectivity"
+	TunnelingMechanismOpenVPN      TunnelingMechanism = "OpenVPN"
+)
+
 // MachineNetworkingConfig specifies the networking parameters used for IPAM.
 type MachineNetworkingConfig struct {
 	CIDR       string   `json:"cidr"`
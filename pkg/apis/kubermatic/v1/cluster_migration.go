@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterMigrationResourceName represents "Resource" defined in Kubernetes.
+	ClusterMigrationResourceName = "clustermigrations"
+
+	// ClusterMigrationKindName represents "Kind" defined in Kubernetes.
+	ClusterMigrationKindName = "ClusterMigration"
+
+	// ClusterMigrationAnnotation is set on the source and target Cluster objects while a
+	// ClusterMigration is moving them and contains the name of the migration.
+	ClusterMigrationAnnotation = "kubermatic.k8c.io/cluster-migration"
+
+	// ClusterMigrationEndpointsUpdatedAnnotation is set on a ClusterMigration by an administrator
+	// to confirm that DNS records and kubeconfigs referring to the SourceAddress have been updated
+	// to the TargetAddress. It is only required if the API server address changes.
+	ClusterMigrationEndpointsUpdatedAnnotation = "kubermatic.k8c.io/cluster-migration-endpoints-updated"
+)
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.clusterName",name="Cluster",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.sourceSeed",name="SourceSeed",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.targetSeed",name="TargetSeed",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name="Phase",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// ClusterMigration moves a user cluster from one Seed to another by taking a final etcd
+// snapshot on the source seed and restoring it into a new cluster on the target seed.
+type ClusterMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterMigrationSpec   `json:"spec,omitempty"`
+	Status ClusterMigrationStatus `json:"status,omitempty"`
+}
+
+// ClusterMigrationSpec specifies which cluster to move where.
+type ClusterMigrationSpec struct {
+	// ClusterName is the name of the user cluster to migrate. The seed currently hosting the
+	// cluster is determined automatically.
+	ClusterName string `json:"clusterName"`
+	// TargetSeed is the name of the Seed the cluster is moved to.
+	TargetSeed string `json:"targetSeed"`
+	// TargetDatacenter is the datacenter on the target seed the cluster is moved to. It must
+	// use the same cloud provider as the cluster's current datacenter.
+	TargetDatacenter string `json:"targetDatacenter"`
+	// Destination is the name of the backup destination used to hand the etcd snapshot over to
+	// the target seed. A destination with this name must be configured on both seeds and point
+	// to the same storage. If empty, the source seed's default destination is used.
+	// +optional
+	Destination string `json:"destination,omitempty"`
+}
+
+// +kubebuilder:validation:Enum="";Pending;Running;Completed;Failed
+
+// ClusterMigrationPhase represents the lifecycle phase of a ClusterMigration.
+type ClusterMigrationPhase string
+
+const (
+	// ClusterMigrationPhasePending means the migration has not started yet.
+	ClusterMigrationPhasePending ClusterMigrationPhase = "Pending"
+	// ClusterMigrationPhaseRunning means the migration is in progress.
+	ClusterMigrationPhaseRunning ClusterMigrationPhase = "Running"
+	// ClusterMigrationPhaseCompleted means the cluster has been moved to the target seed.
+	ClusterMigrationPhaseCompleted ClusterMigrationPhase = "Completed"
+	// ClusterMigrationPhaseFailed means the migration cannot be completed without manual
+	// intervention; the reason is given in the conditions.
+	ClusterMigrationPhaseFailed ClusterMigrationPhase = "Failed"
+)
+
+// +kubebuilder:validation:Enum=SourceClusterPaused;SnapshotCompleted;TargetClusterCreated;EtcdRestored;EndpointsUpdated;SourceCleanedUp
+
+// ClusterMigrationConditionType is the type of a step in a ClusterMigration.
+type ClusterMigrationConditionType string
+
+const (
+	// ClusterMigrationConditionSourceClusterPaused indicates that the source cluster is paused
+	// and its API server has been stopped, so that etcd no longer changes.
+	ClusterMigrationConditionSourceClusterPaused ClusterMigrationConditionType = "SourceClusterPaused"
+	// ClusterMigrationConditionSnapshotCompleted indicates that the final etcd snapshot has
+	// been uploaded to the backup destination.
+	ClusterMigrationConditionSnapshotCompleted ClusterMigrationConditionType = "SnapshotCompleted"
+	// ClusterMigrationConditionTargetClusterCreated indicates that the cluster and its
+	// certificates have been created on the target seed.
+	ClusterMigrationConditionTargetClusterCreated ClusterMigrationConditionType = "TargetClusterCreated"
+	// ClusterMigrationConditionEtcdRestored indicates that the snapshot has been restored into
+	// the etcd ring of the target cluster.
+	ClusterMigrationConditionEtcdRestored ClusterMigrationConditionType = "EtcdRestored"
+	// ClusterMigrationConditionEndpointsUpdated indicates that the target cluster is exposed,
+	// its API server is healthy and its kubeconfigs point to the target address. If the address
+	// changed, the condition stays false with reason ManualActionRequired until the change has
+	// been confirmed using the ClusterMigrationEndpointsUpdatedAnnotation.
+	ClusterMigrationConditionEndpointsUpdated ClusterMigrationConditionType = "EndpointsUpdated"
+	// ClusterMigrationConditionSourceCleanedUp indicates that the cluster has been removed from
+	// the source seed.
+	ClusterMigrationConditionSourceCleanedUp ClusterMigrationConditionType = "SourceCleanedUp"
+)
+
+// ClusterMigrationConditionTypes lists the steps of a migration in the order they are performed.
+var ClusterMigrationConditionTypes = []ClusterMigrationConditionType{
+	ClusterMigrationConditionSourceClusterPaused,
+	ClusterMigrationConditionSnapshotCompleted,
+	ClusterMigrationConditionTargetClusterCreated,
+	ClusterMigrationConditionEtcdRestored,
+	ClusterMigrationConditionEndpointsUpdated,
+	ClusterMigrationConditionSourceCleanedUp,
+}
+
+// ClusterMigrationStatus contains the progress of a ClusterMigration.
+type ClusterMigrationStatus struct {
+	// Phase is the current lifecycle phase of the migration.
+	// +optional
+	Phase ClusterMigrationPhase `json:"phase,omitempty"`
+	// SourceSeed is the seed that hosted the cluster when the migration started.
+	// +optional
+	SourceSeed string `json:"sourceSeed,omitempty"`
+	// SourceDatacenter is the datacenter the cluster used before the migration.
+	// +optional
+	SourceDatacenter string `json:"sourceDatacenter,omitempty"`
+	// SourceAddress is the API server URL of the cluster on the source seed. DNS records or
+	// kubeconfigs still pointing to this address need to be updated to the TargetAddress
+	// before the migration can complete.
+	// +optional
+	SourceAddress string `json:"sourceAddress,omitempty"`
+	// TargetAddress is the API server URL of the cluster on the target seed.
+	// +optional
+	TargetAddress string `json:"targetAddress,omitempty"`
+	// BackupName is the name of the final etcd snapshot in the backup destination.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+	// Conditions contains the state of every step of the migration.
+	// +optional
+	Conditions map[ClusterMigrationConditionType]ClusterMigrationCondition `json:"conditions,omitempty"`
+}
+
+// HasConditionValue returns true if the migration has the given condition with the given status.
+func (s *ClusterMigrationStatus) HasConditionValue(conditionType ClusterMigrationConditionType, conditionStatus corev1.ConditionStatus) bool {
+	condition, exists := s.Conditions[conditionType]
+	if !exists {
+		return false
+	}
+
+	return condition.Status == conditionStatus
+}
+
+type ClusterMigrationCondition struct {
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time we got an update on a given condition.
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime"`
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// ClusterMigrationList is a list of cluster migrations.
+type ClusterMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of the cluster migrations.
+	Items []ClusterMigration `json:"items"`
+}
@@ -16,10 +16,10 @@ limitations under the License.
 
 package v1
 
-// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Tunneling
+// +kubebuilder:validation:Enum=NodePort;LoadBalancer;Tunneling;Gateway
 
 // ExposeStrategy is the strategy used to expose a cluster control plane.
-// Possible values are `NodePort`, `LoadBalancer` or `Tunneling` (requires a feature gate).
+// Possible values are `NodePort`, `LoadBalancer`, `Tunneling` (requires a feature gate) or `Gateway`.
 type ExposeStrategy string
 
 const (
@@ -43,6 +43,18 @@ const (
 	// (e.g. Service of type LoadBalancer) without consuming one or more ports
 	// for each user cluster.
 	ExposeStrategyTunneling ExposeStrategy = "Tunneling"
+	// ExposeStrategyGateway exposes all control planes of a seed through a single
+	// Gateway API listener, which routes TLS traffic without termination to the
+	// cluster's apiserver based on the SNI.
+	// The Gateway is configured via the Seed's `gateway` settings and requires
+	// the Gateway API CRDs and a suitable GatewayClass on the seed cluster.
+	//
+	// Like Tunneling, this strategy requires one single entry point for all user
+	// clusters, but it does not require an agent on the worker nodes and can be
+	// implemented by any Gateway controller supporting TLSRoutes.
+	// As traffic is routed based on the SNI, clients must connect using the
+	// cluster's external name; Konnectivity is required for the same reason.
+	ExposeStrategyGateway ExposeStrategy = "Gateway"
 )
 
 // Finalizers should be kept to their controllers. Only if a finalizer is
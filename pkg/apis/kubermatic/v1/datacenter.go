@@ -281,6 +281,10 @@ type SeedSpec struct {
 	// NodeportProxy can be used to configure the NodePort proxy service that is
 	// responsible for making user-cluster control planes accessible from the outside.
 	NodeportProxy NodeportProxyConfig `json:"nodeportProxy,omitempty"`
+	// Optional: Gateway configures the Gateway API Gateway that exposes the control
+	// planes of all user clusters using the `Gateway` expose strategy. Clusters
+	// can only use this expose strategy if this is configured.
+	Gateway *SeedGatewaySettings `json:"gateway,omitempty"`
 	// Optional: ProxySettings can be used to configure HTTP proxy settings on the
 	// worker nodes in user clusters. However, proxy settings on nodes take precedence.
 	ProxySettings *ProxySettings `json:"proxySettings,omitempty"`
@@ -288,6 +292,8 @@ type SeedSpec struct {
 	ExposeStrategy ExposeStrategy `json:"exposeStrategy,omitempty"`
 	// Optional: MLA allows configuring seed level MLA (Monitoring, Logging & Alerting) stack settings.
 	MLA *SeedMLASettings `json:"mla,omitempty"`
+	// Optional: AuditLogging configures the default audit backend for user clusters with audit logging enabled.
+	AuditLogging *SeedAuditLoggingSettings `json:"auditLogging,omitempty"`
 	// DefaultComponentSettings are default values to set for newly created clusters.
 	// Deprecated: Use DefaultClusterTemplate instead.
 	DefaultComponentSettings ComponentSettings `json:"defaultComponentSettings,omitempty"`
@@ -327,12 +333,74 @@ type EtcdBackupRestore struct {
 
 // BackupDestination defines the bucket name and endpoint as a backup destination, and holds reference to the credentials secret.
 type BackupDestination struct {
-	// Endpoint is the API endpoint to use for backup and restore.
-	Endpoint string `json:"endpoint"`
-	// BucketName is the bucket name to use for backup and restore.
-	BucketName string `json:"bucketName"`
-	// Credentials hold the ref to the secret with backup credentials
+	// Type is the kind of storage the backups are stored in. Defaults to "s3".
+	// +optional
+	Type BackupDestinationType `json:"type,omitempty"`
+	// Endpoint is the API endpoint to use for backup and restore. Required for S3, optional for
+	// Azure (defaults to the public endpoint of the storage account) and GCS.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// BucketName is the bucket name to use for backup and restore. For Azure, this is the name
+	// of the blob container.
+	// +optional
+	BucketName string `json:"bucketName,omitempty"`
+	// Credentials hold the ref to the secret with backup credentials. For S3, the Secret must contain
+	// ACCESS_KEY_ID and SECRET_ACCESS_KEY, for Azure ACCOUNT_NAME and ACCOUNT_KEY and for GCS a
+	// SERVICE_ACCOUNT key with a JSON service account key. Filesystem destinations need no credentials.
 	Credentials *corev1.SecretReference `json:"credentials,omitempty"`
+	// Filesystem configures the volume backups are stored in if the type is "filesystem".
+	// +optional
+	Filesystem *BackupDestinationFilesystem `json:"filesystem,omitempty"`
+	// Encryption enables client-side encryption of all backups uploaded to this destination.
+	// Each cluster gets its own data key, which is wrapped with a key from the seed and stored
+	// next to the backups. Snapshots that were uploaded before encryption was enabled can
+	// still be restored.
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+}
+
+// +kubebuilder:validation:Enum="";s3;filesystem;azure;gcs
+
+// BackupDestinationType is the kind of storage a BackupDestination refers to.
+type BackupDestinationType string
+
+const (
+	// BackupDestinationTypeS3 stores backups in an S3-compatible bucket.
+	BackupDestinationTypeS3 BackupDestinationType = "s3"
+	// BackupDestinationTypeFilesystem stores backups on a (usually NFS-backed) volume.
+	BackupDestinationTypeFilesystem BackupDestinationType = "filesystem"
+	// BackupDestinationTypeAzure stores backups in an Azure Blob Storage container.
+	BackupDestinationTypeAzure BackupDestinationType = "azure"
+	// BackupDestinationTypeGCS stores backups in a Google Cloud Storage bucket.
+	BackupDestinationTypeGCS BackupDestinationType = "gcs"
+)
+
+// GetType returns the type of the destination, defaulting to S3.
+func (d *BackupDestination) GetType() BackupDestinationType {
+	if d.Type == "" {
+		return BackupDestinationTypeS3
+	}
+
+	return d.Type
+}
+
+// BackupDestinationFilesystem configures a volume as a backup destination.
+type BackupDestinationFilesystem struct {
+	// PersistentVolumeClaim is the name of a ReadWriteMany PersistentVolumeClaim in the kube-system
+	// namespace, in which the backups are stored. For restores, the volume bound to the claim is
+	// additionally bound to a claim in the cluster namespace, so it must be mountable from multiple
+	// namespaces (e.g. NFS).
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+}
+
+// BackupEncryption configures the envelope encryption of etcd backups.
+type BackupEncryption struct {
+	// KeySecret references the Secret containing the seed keys. Each key in the Secret's data
+	// is a key ID, each value must be a 32 byte AES key. Keys that were used before must be kept
+	// in the Secret until all clusters have been re-wrapped with the active key.
+	KeySecret corev1.SecretReference `json:"keySecret"`
+	// ActiveKey is the ID of the key in the KeySecret that is used to wrap the cluster data keys.
+	// Changing it re-wraps all data keys for this destination, without re-uploading backups.
+	ActiveKey string `json:"activeKey"`
 }
 
 type NodeportProxyConfig struct {
@@ -357,6 +425,19 @@ type NodeportProxyConfig struct {
 	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
+// SeedGatewaySettings configures the Gateway that is shared by all user clusters
+// on a seed using the `Gateway` expose strategy.
+type SeedGatewaySettings struct {
+	// GatewayClassName is the name of the GatewayClass used for the Gateway. The
+	// referenced controller must support TLS listeners in Passthrough mode and
+	// TLSRoutes.
+	// +kubebuilder:validation:MinLength=1
+	GatewayClassName string `json:"gatewayClassName"`
+	// Optional: Annotations are added to the Gateway and can be used to further
+	// tweak the LoadBalancer integration of the Gateway controller.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 type EnvoyLoadBalancerService struct {
 	// Annotations are used to further tweak the LoadBalancer integration with the
 	// cloud provider.
@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,6 +33,13 @@ const (
 	DefaultKeptBackupsCount = 20
 	MaxKeptBackupsCount     = 50
 
+	// DefaultContinuousBackupSegmentInterval is the default interval in which the revision
+	// streamer uploads new segments.
+	DefaultContinuousBackupSegmentInterval = 1 * time.Minute
+	// DefaultContinuousBackupRetention is the default duration for which uploaded revision
+	// segments are kept.
+	DefaultContinuousBackupRetention = 7 * 24 * time.Hour
+
 	// BackupStatusPhase value indicating that the corresponding job has started.
 	BackupStatusPhaseRunning = "Running"
 
@@ -75,6 +84,38 @@ type EtcdBackupConfigSpec struct {
 	// Destination indicates where the backup will be stored. The destination name must correspond to a destination in
 	// the cluster's Seed.Spec.EtcdBackupRestore.
 	Destination string `json:"destination"`
+	// Continuous enables streaming of all etcd revisions into the destination in between the
+	// scheduled snapshots. This allows an EtcdRestore to restore the cluster to any point in
+	// time after a snapshot instead of only the snapshot itself. Only one EtcdBackupConfig per
+	// cluster and destination should enable this.
+	// +optional
+	Continuous *EtcdContinuousBackup `json:"continuous,omitempty"`
+	// Verification enables checking every completed backup after it has been uploaded. The
+	// results are recorded in the backup's status.
+	// +optional
+	Verification *EtcdBackupVerification `json:"verification,omitempty"`
+}
+
+// EtcdBackupVerification configures how completed backups are verified. By default, the
+// uploaded snapshot is downloaded again and its integrity, checksum and revision are checked.
+type EtcdBackupVerification struct {
+	// RestoreDrill additionally restores every backup into a throwaway etcd and counts its keys.
+	// This is more expensive, but also ensures that the backup can actually be restored.
+	// +optional
+	RestoreDrill bool `json:"restoreDrill,omitempty"`
+}
+
+// EtcdContinuousBackup configures the revision streamer for an EtcdBackupConfig.
+type EtcdContinuousBackup struct {
+	// SegmentInterval is how often the recorded revisions are uploaded as a new segment to the
+	// backup destination. This defines the maximum amount of changes that can be lost. Defaults
+	// to 1 minute.
+	// +optional
+	SegmentInterval *metav1.Duration `json:"segmentInterval,omitempty"`
+	// Retention is how long uploaded segments are kept before they are deleted. This should be at
+	// least as long as the oldest kept snapshot. Defaults to 7 days.
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -119,6 +160,18 @@ type BackupStatus struct {
 	DeleteFinishedTime metav1.Time       `json:"deleteFinishedTime,omitempty"`
 	DeletePhase        BackupStatusPhase `json:"deletePhase,omitempty"`
 	DeleteMessage      string            `json:"deleteMessage,omitempty"`
+	VerifyJobName      string            `json:"verifyJobName,omitempty"`
+	// +optional
+	VerifyFinishedTime metav1.Time       `json:"verifyFinishedTime,omitempty"`
+	VerifyPhase        BackupStatusPhase `json:"verifyPhase,omitempty"`
+	VerifyMessage      string            `json:"verifyMessage,omitempty"`
+	// Checksum is the SHA-256 checksum of the uploaded backup, as determined by the verification.
+	Checksum string `json:"checksum,omitempty"`
+	// Revision is the etcd revision contained in the backup, as determined by the verification.
+	Revision int64 `json:"revision,omitempty"`
+	// KeyCount is the number of keys found when restoring the backup. Only set if the
+	// verification performed a restore drill.
+	KeyCount int64 `json:"keyCount,omitempty"`
 }
 
 type EtcdBackupConfigCondition struct {
@@ -137,7 +190,7 @@ type EtcdBackupConfigCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=SchedulingActive
+// +kubebuilder:validation:Enum=SchedulingActive;ContinuousBackupActive
 
 // EtcdBackupConfigConditionType is used to indicate the type of a EtcdBackupConfig condition. For all condition
 // types, the `true` value must indicate success. All condition types must be registered within
@@ -148,6 +201,10 @@ const (
 	// EtcdBackupConfigConditionSchedulingActive indicates that the EtcdBackupConfig is active, i.e.
 	// new backups are being scheduled according to the config's schedule.
 	EtcdBackupConfigConditionSchedulingActive EtcdBackupConfigConditionType = "SchedulingActive"
+
+	// EtcdBackupConfigConditionContinuousBackupActive indicates that the revision streamer for a
+	// continuous EtcdBackupConfig is running and uploading segments.
+	EtcdBackupConfigConditionContinuousBackupActive EtcdBackupConfigConditionType = "ContinuousBackupActive"
 )
 
 func (bc *EtcdBackupConfig) GetKeptBackupsCount() int {
@@ -162,3 +219,24 @@ func (bc *EtcdBackupConfig) GetKeptBackupsCount() int {
 	}
 	return *bc.Spec.Keep
 }
+
+// IsContinuous returns true if the revision streamer should run for this EtcdBackupConfig.
+func (bc *EtcdBackupConfig) IsContinuous() bool {
+	return bc.Spec.Continuous != nil && bc.Spec.Schedule != ""
+}
+
+// GetSegmentInterval returns the configured segment interval or the default.
+func (c *EtcdContinuousBackup) GetSegmentInterval() time.Duration {
+	if c == nil || c.SegmentInterval == nil || c.SegmentInterval.Duration <= 0 {
+		return DefaultContinuousBackupSegmentInterval
+	}
+	return c.SegmentInterval.Duration
+}
+
+// GetRetention returns the configured segment retention or the default.
+func (c *EtcdContinuousBackup) GetRetention() time.Duration {
+	if c == nil || c.Retention == nil || c.Retention.Duration <= 0 {
+		return DefaultContinuousBackupRetention
+	}
+	return c.Retention.Duration
+}
@@ -78,6 +78,21 @@ type EtcdRestoreSpec struct {
 	// Destination indicates where the backup was stored. The destination name should correspond to a destination in
 	// the cluster's Seed.Spec.EtcdBackupRestore. If empty, it will use the legacy destination configured in Seed.Spec.BackupRestore
 	Destination string `json:"destination,omitempty"`
+	// TargetRevision restores the cluster to exactly this etcd revision. The revisions after the
+	// backup are replayed from the segments recorded by a continuous EtcdBackupConfig in the same
+	// destination. Mutually exclusive with TargetTime.
+	// +optional
+	TargetRevision *int64 `json:"targetRevision,omitempty"`
+	// TargetTime restores the cluster to the last etcd revision recorded at or before this time.
+	// The revisions after the backup are replayed from the segments recorded by a continuous
+	// EtcdBackupConfig in the same destination. Mutually exclusive with TargetRevision.
+	// +optional
+	TargetTime *metav1.Time `json:"targetTime,omitempty"`
+}
+
+// IsPointInTime returns true if the restore should replay recorded revisions on top of the backup.
+func (s *EtcdRestoreSpec) IsPointInTime() bool {
+	return s.TargetRevision != nil || s.TargetTime != nil
 }
 
 // +kubebuilder:object:generate=true
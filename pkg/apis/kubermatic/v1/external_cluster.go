@@ -22,6 +22,7 @@ import (
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	"k8c.io/kubermatic/v2/pkg/semver"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -46,7 +47,7 @@ const (
 	KubeOneManifestSecretPrefix = "manifest-kubeone-external-cluster"
 )
 
-// +kubebuilder:validation:Enum=aks;bringyourown;eks;gke;kubeone
+// +kubebuilder:validation:Enum=aks;bringyourown;capi;eks;gke;kubeone
 
 // ExternalClusterProvider is the identifier for the cloud provider that hosts
 // the external cluster control plane.
@@ -55,6 +56,7 @@ type ExternalClusterProvider string
 const (
 	ExternalClusterAKSProvider          ExternalClusterProvider = "aks"
 	ExternalClusterBringYourOwnProvider ExternalClusterProvider = "bringyourown"
+	ExternalClusterCAPIProvider         ExternalClusterProvider = "capi"
 	ExternalClusterEKSProvider          ExternalClusterProvider = "eks"
 	ExternalClusterGKEProvider          ExternalClusterProvider = "gke"
 	ExternalClusterKubeOneProvider      ExternalClusterProvider = "kubeone"
@@ -85,6 +87,55 @@ type ExternalCluster struct {
 type ExternalClusterStatus struct {
 	// Conditions contains conditions an externalcluster is in, its primary use case is status signaling for controller
 	Condition ExternalClusterCondition `json:"condition,omitempty"`
+
+	// KubeOne contains status information specific to KubeOne-managed clusters.
+	KubeOne *ExternalClusterKubeOneStatus `json:"kubeone,omitempty"`
+
+	// Health contains the result of the last health probe of imported clusters.
+	Health *ExternalClusterHealth `json:"health,omitempty"`
+
+	// CAPI contains status information specific to Cluster API clusters.
+	CAPI *ExternalClusterCAPIStatus `json:"capi,omitempty"`
+}
+
+// ExternalClusterCAPIStatus mirrors the state of a Cluster API cluster in its management cluster.
+type ExternalClusterCAPIStatus struct {
+	// Phase is the phase reported by the Cluster object, e.g. `Provisioned`.
+	Phase string `json:"phase,omitempty"`
+	// MachineDeployments lists the MachineDeployments belonging to the cluster.
+	MachineDeployments []CAPIMachineDeployment `json:"machineDeployments,omitempty"`
+}
+
+// CAPIMachineDeployment summarizes a Cluster API MachineDeployment.
+type CAPIMachineDeployment struct {
+	Name string `json:"name"`
+	// Version is the Kubernetes version of the machines.
+	Version string `json:"version,omitempty"`
+	// Phase is the phase reported by the MachineDeployment, e.g. `Running` or `ScalingUp`.
+	Phase         string `json:"phase,omitempty"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+}
+
+// ExternalClusterHealth summarizes the state of an imported cluster as observed by KKP.
+type ExternalClusterHealth struct {
+	// LastProbeTime is the time when the cluster was last probed.
+	LastProbeTime metav1.Time `json:"lastProbeTime"`
+	// APIServer is the health of the cluster's API server.
+	APIServer HealthStatus `json:"apiserver"`
+	// Version is the Kubernetes version reported by the API server.
+	Version *semver.Semver `json:"version,omitempty"`
+	// Nodes is the number of nodes in the cluster.
+	Nodes int `json:"nodes"`
+	// ReadyNodes is the number of nodes in the cluster that are ready.
+	ReadyNodes int `json:"readyNodes"`
+	// Capacity is the total capacity of all nodes.
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+	// Allocatable is the total amount of resources of all nodes that is available for pods.
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+	// KubeconfigExpiry is the time when the client certificate of the kubeconfig expires.
+	// It is not set for kubeconfigs that do not authenticate with a client certificate.
+	KubeconfigExpiry *metav1.Time `json:"kubeconfigExpiry,omitempty"`
 }
 
 type ExternalClusterCondition struct {
@@ -106,6 +157,123 @@ type ExternalClusterKubeOneCloudSpec struct {
 	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference,omitempty"`
 	SSHReference         *providerconfig.GlobalSecretKeySelector `json:"sshReference,omitempty"`
 	ManifestReference    *providerconfig.GlobalSecretKeySelector `json:"manifestReference,omitempty"`
+
+	// ControlPlane describes the control plane hosts of the cluster. If set, the hosts
+	// in the manifest referenced by ManifestReference are replaced with these.
+	ControlPlane *KubeOneHostPool `json:"controlPlane,omitempty"`
+
+	// WorkerPools describes the static worker hosts of the cluster, which are provisioned by
+	// KubeOne. If set, the static workers in the manifest referenced by ManifestReference are
+	// replaced with the hosts of all pools.
+	WorkerPools []KubeOneHostPool `json:"workerPools,omitempty"`
+
+	// Addons configures the KubeOne addons to deploy into the cluster. If set, the addons in
+	// the manifest referenced by ManifestReference are replaced with these.
+	Addons *KubeOneAddons `json:"addons,omitempty"`
+}
+
+// KubeOneHostPool is a group of hosts that share the same SSH access and node configuration.
+type KubeOneHostPool struct {
+	// Name identifies the pool and is added as the `kubeone.k8c.io/pool` label to its nodes.
+	Name string `json:"name"`
+
+	// SSHUsername is the user to log in as. Defaults to "root".
+	SSHUsername string `json:"sshUsername,omitempty"`
+	// SSHPort is the port to connect to. Defaults to 22.
+	SSHPort int `json:"sshPort,omitempty"`
+	// Bastion is the IP or hostname of a jump host used to reach the hosts.
+	Bastion string `json:"bastion,omitempty"`
+	// BastionUser is the user to log in to the bastion host as. Defaults to "root".
+	BastionUser string `json:"bastionUser,omitempty"`
+	// BastionPort is the SSH port of the bastion host. Defaults to 22.
+	BastionPort int `json:"bastionPort,omitempty"`
+
+	// Labels are applied to all nodes of the pool.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are applied to all nodes of the pool when they are provisioned.
+	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// Hosts are the machines belonging to this pool.
+	Hosts []KubeOneHost `json:"hosts"`
+}
+
+// KubeOneHost describes a single machine provisioned by KubeOne.
+type KubeOneHost struct {
+	// PublicAddress is the externally accessible IP address of the host.
+	PublicAddress string `json:"publicAddress"`
+	// PrivateAddress is the internal IP address of the host.
+	PrivateAddress string `json:"privateAddress,omitempty"`
+	// Hostname overrides the hostname detected by KubeOne.
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// KubeOneAddons configures the addons KubeOne deploys into the cluster.
+type KubeOneAddons struct {
+	// GlobalParams are passed to all addons when rendering them.
+	GlobalParams map[string]string `json:"globalParams,omitempty"`
+	// Addons is the list of addons to deploy.
+	Addons []KubeOneAddon `json:"addons,omitempty"`
+}
+
+// KubeOneAddon configures a single KubeOne addon.
+type KubeOneAddon struct {
+	// Name of the embedded KubeOne addon.
+	Name string `json:"name"`
+	// Params are used to render the addon and override GlobalParams.
+	Params map[string]string `json:"params,omitempty"`
+	// Delete removes the addon and all its resources from the cluster.
+	Delete bool `json:"delete,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=import;upgrade;migrate;apply
+
+// KubeOneAction is the kind of operation a KubeOne job performs on a cluster.
+type KubeOneAction string
+
+const (
+	KubeOneImportAction  KubeOneAction = "import"
+	KubeOneUpgradeAction KubeOneAction = "upgrade"
+	KubeOneMigrateAction KubeOneAction = "migrate"
+	KubeOneApplyAction   KubeOneAction = "apply"
+)
+
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+
+// KubeOneJobPhase is the phase of a KubeOne job.
+type KubeOneJobPhase string
+
+const (
+	KubeOneJobPhaseRunning   KubeOneJobPhase = "Running"
+	KubeOneJobPhaseSucceeded KubeOneJobPhase = "Succeeded"
+	KubeOneJobPhaseFailed    KubeOneJobPhase = "Failed"
+)
+
+// ExternalClusterKubeOneStatus contains status information for KubeOne-managed clusters.
+type ExternalClusterKubeOneStatus struct {
+	// ManifestHash is the hash of the last KubeOne manifest that was successfully applied
+	// to the cluster.
+	ManifestHash string `json:"manifestHash,omitempty"`
+
+	// Jobs is the history of the KubeOne jobs run for this cluster, newest first.
+	Jobs []KubeOneJobStatus `json:"jobs,omitempty"`
+}
+
+// KubeOneJobStatus describes a single KubeOne job.
+type KubeOneJobStatus struct {
+	// Action is the operation performed by the job.
+	Action KubeOneAction `json:"action"`
+	// JobName is the name of the Job in the cluster's KubeOne namespace.
+	JobName string `json:"jobName"`
+	// Phase is the current phase of the job.
+	Phase KubeOneJobPhase `json:"phase"`
+	// StartTime is the time when the job was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when the job succeeded or failed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message contains details about failed jobs.
+	Message string `json:"message,omitempty"`
+	// ManifestHash is the hash of the manifest applied by upgrade and apply jobs.
+	ManifestHash string `json:"manifestHash,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -176,6 +344,7 @@ type ExternalClusterCloudSpec struct {
 	AKS          *ExternalClusterAKSCloudSpec          `json:"aks,omitempty"`
 	KubeOne      *ExternalClusterKubeOneCloudSpec      `json:"kubeone,omitempty"`
 	BringYourOwn *ExternalClusterBringYourOwnCloudSpec `json:"bringyourown,omitempty"`
+	CAPI         *ExternalClusterCAPICloudSpec         `json:"capi,omitempty"`
 }
 
 type ExternalClusterPhase string
@@ -203,6 +372,8 @@ const (
 
 	KubeOnePhaseReconcilingMigrate ExternalClusterPhase = "ReconcilingMigrate"
 
+	KubeOnePhaseReconcilingApply ExternalClusterPhase = "ReconcilingApply"
+
 	// ExternalClusterPhaseDeleting status indicates the cluster is being deleted.
 	ExternalClusterPhaseDeleting ExternalClusterPhase = "Deleting"
 
@@ -238,7 +409,29 @@ const (
 	ExternalClusterPhaseConfigError ExternalClusterPhase = "ConfigError"
 )
 
-type ExternalClusterBringYourOwnCloudSpec struct{}
+type ExternalClusterBringYourOwnCloudSpec struct {
+	// ApplicationsEnabled installs the ApplicationInstallation CRD into the cluster and reconciles
+	// the ApplicationInstallations created in it, like in clusters managed by KKP.
+	ApplicationsEnabled bool `json:"applicationsEnabled,omitempty"`
+}
+
+// ExternalClusterCAPICloudSpec points to a Cluster API `Cluster` object in a management cluster.
+type ExternalClusterCAPICloudSpec struct {
+	// CredentialsReference references a Secret containing the kubeconfig of the management
+	// cluster under the `kubeconfig` key.
+	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference"`
+
+	// Namespace is the namespace of the Cluster object in the management cluster.
+	Namespace string `json:"namespace"`
+	// Name is the name of the Cluster object in the management cluster.
+	Name string `json:"name"`
+
+	// MachineDeploymentReplicas maps the names of MachineDeployments belonging to the cluster
+	// to their desired number of replicas. KKP scales the MachineDeployments in the management
+	// cluster accordingly; MachineDeployments not listed here are left untouched.
+	// +optional
+	MachineDeploymentReplicas map[string]int32 `json:"machineDeploymentReplicas,omitempty"`
+}
 
 type ExternalClusterGKECloudSpec struct {
 	CredentialsReference *providerconfig.GlobalSecretKeySelector `json:"credentialsReference"`
@@ -340,6 +533,9 @@ func (i *ExternalCluster) GetCredentialsSecretName() string {
 	if cloud.AKS != nil {
 		cluster.Spec.Cloud.Azure = &AzureCloudSpec{}
 	}
+	if cloud.CAPI != nil {
+		return fmt.Sprintf("%s-capi-%s", CredentialPrefix, i.Name)
+	}
 	return cluster.GetSecretName()
 }
 
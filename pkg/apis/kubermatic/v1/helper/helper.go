@@ -240,6 +240,38 @@ func CCMMigrationCompleted(cluster *kubermaticv1.Cluster) bool {
 	return cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionCSIKubeletMigrationCompleted, corev1.ConditionTrue)
 }
 
+// KonnectivityMigrationPhase returns the current phase (the condition reason) of the migration
+// from OpenVPN to Konnectivity, or an empty string if no migration is in progress.
+func KonnectivityMigrationPhase(cluster *kubermaticv1.Cluster) string {
+	condition, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionKonnectivityMigration]
+	if !ok || condition.Status != corev1.ConditionFalse {
+		return ""
+	}
+
+	return condition.Reason
+}
+
+// IsKonnectivityEnabled returns true if the control plane reaches the user cluster nodes via
+// Konnectivity. While a cluster is migrated from OpenVPN, this only becomes true once the
+// Konnectivity agents are running in the user cluster.
+func IsKonnectivityEnabled(cluster *kubermaticv1.Cluster) bool {
+	enabled := cluster.Spec.ClusterNetwork.GetTunnelingMechanism() == kubermaticv1.TunnelingMechanismKonnectivity
+
+	return enabled && KonnectivityMigrationPhase(cluster) != kubermaticv1.ReasonKonnectivityMigrationDeployingAgents
+}
+
+// IsOpenVPNDeployed returns true if the OpenVPN setup has to be kept for the cluster, which is
+// also the case while the cluster is migrated to Konnectivity.
+func IsOpenVPNDeployed(cluster *kubermaticv1.Cluster) bool {
+	return !IsKonnectivityEnabled(cluster) || KonnectivityMigrationPhase(cluster) != ""
+}
+
+// IsKonnectivityDeployed returns true if the Konnectivity setup has to be deployed for the cluster,
+// which is also the case while the control plane still uses OpenVPN during a migration.
+func IsKonnectivityDeployed(cluster *kubermaticv1.Cluster) bool {
+	return IsKonnectivityEnabled(cluster) || KonnectivityMigrationPhase(cluster) != ""
+}
+
 type SeedPatchFunc func(seed *kubermaticv1.Seed)
 
 func UpdateSeedStatus(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed, patch SeedPatchFunc) error {
@@ -328,6 +360,67 @@ func SetSeedCondition(seed *kubermaticv1.Seed, conditionType kubermaticv1.SeedCo
 	seed.Status.Conditions[conditionType] = newCondition
 }
 
+type ClusterMigrationPatchFunc func(migration *kubermaticv1.ClusterMigration)
+
+// UpdateClusterMigrationStatus will attempt to patch the status of the given migration.
+func UpdateClusterMigrationStatus(ctx context.Context, client ctrlruntimeclient.Client, migration *kubermaticv1.ClusterMigration, patch ClusterMigrationPatchFunc) error {
+	key := ctrlruntimeclient.ObjectKeyFromObject(migration)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// fetch the current state of the migration
+		if err := client.Get(ctx, key, migration); err != nil {
+			return err
+		}
+
+		// modify it
+		original := migration.DeepCopy()
+		patch(migration)
+
+		// save some work
+		if reflect.DeepEqual(original.Status, migration.Status) {
+			return nil
+		}
+
+		// update the status
+		return client.Status().Patch(ctx, migration, ctrlruntimeclient.MergeFrom(original))
+	})
+}
+
+// SetClusterMigrationCondition sets a condition on the given migration using the provided type,
+// status, reason and message.
+func SetClusterMigrationCondition(migration *kubermaticv1.ClusterMigration, conditionType kubermaticv1.ClusterMigrationConditionType, status corev1.ConditionStatus, reason string, message string) {
+	newCondition := kubermaticv1.ClusterMigrationCondition{
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+
+	oldCondition, hadCondition := migration.Status.Conditions[conditionType]
+	if hadCondition {
+		conditionCopy := oldCondition.DeepCopy()
+
+		// Reset the times before comparing
+		conditionCopy.LastHeartbeatTime.Reset()
+		conditionCopy.LastTransitionTime.Reset()
+
+		if apiequality.Semantic.DeepEqual(*conditionCopy, newCondition) {
+			return
+		}
+	}
+
+	now := metav1.Now()
+	newCondition.LastHeartbeatTime = now
+	newCondition.LastTransitionTime = oldCondition.LastTransitionTime
+	if hadCondition && oldCondition.Status != status {
+		newCondition.LastTransitionTime = now
+	}
+
+	if migration.Status.Conditions == nil {
+		migration.Status.Conditions = map[kubermaticv1.ClusterMigrationConditionType]kubermaticv1.ClusterMigrationCondition{}
+	}
+	migration.Status.Conditions[conditionType] = newCondition
+}
+
 type ResourceQuotaPatchFunc func(resourceQuota *kubermaticv1.ResourceQuota)
 
 // UpdateResourceQuotaStatus will attempt to patch the resource quota status
@@ -40,6 +40,9 @@ func ExternalClusterCloudProviderName(spec kubermaticv1.ExternalClusterCloudSpec
 	if spec.BringYourOwn != nil {
 		clouds = append(clouds, kubermaticv1.ExternalClusterBringYourOwnProvider)
 	}
+	if spec.CAPI != nil {
+		clouds = append(clouds, kubermaticv1.ExternalClusterCAPIProvider)
+	}
 	if len(clouds) == 0 {
 		return "", nil
 	}
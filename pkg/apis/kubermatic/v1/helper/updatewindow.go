@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+const week = 7 * 24 * time.Hour
+
+// UpdateWindowSchedule is a parsed UpdateWindow.
+type UpdateWindowSchedule struct {
+	// Weekday is nil for windows that repeat every day.
+	Weekday *time.Weekday
+	// Offset is the start of the window relative to the beginning of the day.
+	Offset time.Duration
+	Length time.Duration
+}
+
+// ParseUpdateWindow parses the start and length of an UpdateWindow. The start can either be a
+// time of day (`22:30`) or a short weekday plus a time of day (`Mon 21:00`).
+func ParseUpdateWindow(updateWindow *kubermaticv1.UpdateWindow) (*UpdateWindowSchedule, error) {
+	length, err := time.ParseDuration(updateWindow.Length)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing update Length: %w", err)
+	}
+
+	var layout string
+	if strings.Contains(updateWindow.Start, " ") {
+		layout = "Mon 15:04"
+	} else {
+		layout = "15:04"
+	}
+
+	start, err := time.Parse(layout, updateWindow.Start)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing start day: %w", err)
+	}
+
+	schedule := &UpdateWindowSchedule{
+		Offset: time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		Length: length,
+	}
+
+	// time.Parse validates the weekday, but does not reflect it in the result
+	if day, _, found := strings.Cut(updateWindow.Start, " "); found {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.EqualFold(weekday.String()[:3], day) {
+				schedule.Weekday = &weekday
+				break
+			}
+		}
+	}
+
+	return schedule, nil
+}
+
+// Active returns whether the given time is within the window. The window is
+// interpreted in the location of the given time.
+func (s *UpdateWindowSchedule) Active(now time.Time) bool {
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	period := 24 * time.Hour
+
+	if s.Weekday != nil {
+		start = start.AddDate(0, 0, int(*s.Weekday)-int(now.Weekday()))
+		period = week
+	}
+
+	start = start.Add(s.Offset)
+	if start.After(now) {
+		start = start.Add(-period)
+	}
+
+	return now.Sub(start) < s.Length
+}
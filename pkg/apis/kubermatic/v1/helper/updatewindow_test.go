@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+func TestUpdateWindowScheduleActive(t *testing.T) {
+	// 2023-07-12 is a Wednesday
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2023, time.July, 12, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		window   kubermaticv1.UpdateWindow
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "daily window, inside",
+			window:   kubermaticv1.UpdateWindow{Start: "22:30", Length: "8h"},
+			now:      wednesday(23, 0),
+			expected: true,
+		},
+		{
+			name:     "daily window, inside after midnight",
+			window:   kubermaticv1.UpdateWindow{Start: "22:30", Length: "8h"},
+			now:      wednesday(6, 29),
+			expected: true,
+		},
+		{
+			name:     "daily window, outside",
+			window:   kubermaticv1.UpdateWindow{Start: "22:30", Length: "8h"},
+			now:      wednesday(6, 30),
+			expected: false,
+		},
+		{
+			name:     "weekly window, inside",
+			window:   kubermaticv1.UpdateWindow{Start: "Sat 00:00", Length: "48h"},
+			now:      time.Date(2023, time.July, 16, 23, 59, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "weekly window, outside",
+			window:   kubermaticv1.UpdateWindow{Start: "Sat 00:00", Length: "48h"},
+			now:      wednesday(12, 0),
+			expected: false,
+		},
+		{
+			name:     "weekly window spanning the week boundary, inside",
+			window:   kubermaticv1.UpdateWindow{Start: "Fri 18:00", Length: "110h"},
+			now:      wednesday(7, 0),
+			expected: true,
+		},
+		{
+			name:     "weekly window on the same day, before the start",
+			window:   kubermaticv1.UpdateWindow{Start: "Wed 13:00", Length: "1h"},
+			now:      wednesday(12, 59),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseUpdateWindow(&tc.window)
+			if err != nil {
+				t.Fatalf("failed to parse window: %v", err)
+			}
+
+			if active := schedule.Active(tc.now); active != tc.expected {
+				t.Errorf("expected window to be active: %v, got %v", tc.expected, active)
+			}
+		})
+	}
+}
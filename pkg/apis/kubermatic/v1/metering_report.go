@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MeteringReportResourceName represents "Resource" defined in Kubernetes.
+	MeteringReportResourceName = "meteringreports"
+
+	// MeteringReportKindName represents "Kind" defined in Kubernetes.
+	MeteringReportKindName = "MeteringReport"
+
+	// MeteringReportPhasePending indicates that the report Job has not started yet.
+	MeteringReportPhasePending MeteringReportPhase = "Pending"
+
+	// MeteringReportPhaseRunning indicates that the report is being generated.
+	MeteringReportPhaseRunning MeteringReportPhase = "Running"
+
+	// MeteringReportPhaseSucceeded indicates that the report has been written to the bucket.
+	MeteringReportPhaseSucceeded MeteringReportPhase = "Succeeded"
+
+	// MeteringReportPhaseFailed indicates that the report could not be generated.
+	MeteringReportPhaseFailed MeteringReportPhase = "Failed"
+)
+
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+
+// MeteringReportPhase represents the lifecycle phase of a MeteringReport.
+type MeteringReportPhase string
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.from",name="From",type="date"
+// +kubebuilder:printcolumn:JSONPath=".spec.to",name="To",type="date"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name="Phase",type="string"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+
+// MeteringReport is a single metering report over a time range. MeteringReports can be created in the
+// KKP namespace of a seed to request an ad-hoc report, and are created for every scheduled report run.
+type MeteringReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the requested report.
+	Spec MeteringReportSpec `json:"spec,omitempty"`
+	// Status holds the current state of the report generation.
+	Status MeteringReportStatus `json:"status,omitempty"`
+}
+
+// MeteringReportSpec describes a metering report. The metering tool only reports relative to the time
+// it runs, so the time range of an ad-hoc report must either be the previous calendar month or end at
+// the time the report is created.
+type MeteringReportSpec struct {
+	// From is the start of the reported time range.
+	From metav1.Time `json:"from"`
+	// To is the end of the reported time range.
+	To metav1.Time `json:"to"`
+
+	// +optional
+
+	// ReportConfiguration is the name of the report configuration in the seed's metering configuration
+	// that created this report, or whose report types are used for an ad-hoc report.
+	ReportConfiguration string `json:"reportConfiguration,omitempty"`
+
+	// +optional
+
+	// Types of reports to generate. Defaults to the types of the report configuration, or to cluster and namespace.
+	Types []string `json:"types,omitempty"`
+}
+
+// MeteringReportStatus describes the current state of a metering report.
+type MeteringReportStatus struct {
+	// +optional
+	Phase MeteringReportPhase `json:"phase,omitempty"`
+
+	// +optional
+
+	// JobName is the name of the Job generating the report.
+	JobName string `json:"jobName,omitempty"`
+
+	// +optional
+
+	// StartTime is the time the report generation started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime is the time the report generation finished.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Location is the URL of the prefix in the S3 bucket the report files were written to, e.g. `s3://bucket/weekly`.
+	Location string `json:"location,omitempty"`
+
+	// +optional
+
+	// Files are the keys of the report objects in the bucket.
+	Files []string `json:"files,omitempty"`
+
+	// +optional
+
+	// Checksum is the SHA-256 checksum over the keys and contents of all report files, in the form `sha256:<hex>`.
+	Checksum string `json:"checksum,omitempty"`
+
+	// +optional
+
+	// Message describes why the report generation failed.
+	Message string `json:"message,omitempty"`
+}
+
+// IsFinished returns true if the report generation has succeeded or failed.
+func (s *MeteringReportStatus) IsFinished() bool {
+	return s.Phase == MeteringReportPhaseSucceeded || s.Phase == MeteringReportPhaseFailed
+}
+
+// +kubebuilder:object:generate=true
+// +kubebuilder:object:root=true
+
+// MeteringReportList is a list of metering reports.
+type MeteringReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of the metering reports.
+	Items []MeteringReport `json:"items"`
+}
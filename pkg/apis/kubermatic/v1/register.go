@@ -55,10 +55,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&UserSSHKeyList{},
 		&Cluster{},
 		&ClusterList{},
+		&ClusterMigration{},
+		&ClusterMigrationList{},
 		&EtcdBackupConfig{},
 		&EtcdBackupConfigList{},
 		&EtcdRestore{},
 		&EtcdRestoreList{},
+		&MeteringReport{},
+		&MeteringReportList{},
 		&User{},
 		&UserList{},
 		&Project{},
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +30,8 @@ const (
 	ResourceQuotaSubjectKindLabelKey = "subject-kind"
 
 	ProjectSubjectKind = "project"
+	UserSubjectKind    = "user"
+	GroupSubjectKind   = "group"
 )
 
 // +kubebuilder:resource:scope=Cluster
@@ -39,7 +42,7 @@ const (
 // +kubebuilder:printcolumn:JSONPath=".spec.subject.name",name="Subject Name",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.subject.kind",name="Subject Kind",type="string"
 
-// ResourceQuota specifies the amount of cluster resources a project can use.
+// ResourceQuota specifies the amount of cluster resources a project, user or group can use.
 type ResourceQuota struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -64,21 +67,43 @@ type ResourceQuotaStatus struct {
 	GlobalUsage ResourceDetails `json:"globalUsage,omitempty"`
 	// LocalUsage is holds the current usage of resources for the local seed.
 	LocalUsage ResourceDetails `json:"localUsage,omitempty"`
+	// Projects holds the IDs of the projects whose resources count towards a user or group quota.
+	// It is calculated in the master cluster from the user and group project bindings.
+	Projects []string `json:"projects,omitempty"`
+}
+
+// AppliesToProject returns true if the resources of the given project count towards the quota.
+func (q *ResourceQuota) AppliesToProject(projectID string) bool {
+	switch q.Spec.Subject.Kind {
+	case ProjectSubjectKind:
+		return q.Spec.Subject.Name == projectID
+	case UserSubjectKind, GroupSubjectKind:
+		for _, project := range q.Status.Projects {
+			if project == projectID {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // Subject describes the entity to which the quota applies to.
 type Subject struct {
-	// Name of the quota subject.
+	// Name of the quota subject. This is the project ID for projects, the name of the User
+	// object for users and the group name for groups.
 	Name string `json:"name"`
 
-	// +kubebuilder:validation:Enum=project
+	// +kubebuilder:validation:Enum=project;user;group
 	// +kubebuilder:default=project
 
-	// Kind of the quota subject. For now the only possible kind is project.
+	// Kind of the quota subject. A project quota limits the resources of a single project, while
+	// a user or group quota limits the combined resources of all projects the user or group is bound to.
 	Kind string `json:"kind"`
 }
 
-// ResourceDetails holds the CPU, Memory and Storage quantities.
+// ResourceDetails holds the CPU, Memory and Storage quantities as well as the number of nodes, GPUs,
+// load balancers and the quantities of extended resources.
 type ResourceDetails struct {
 	// CPU holds the quantity of CPU. For the format, please check k8s.io/apimachinery/pkg/api/resource.Quantity.
 	CPU *resource.Quantity `json:"cpu,omitempty"`
@@ -86,10 +111,65 @@ type ResourceDetails struct {
 	Memory *resource.Quantity `json:"memory,omitempty"`
 	// Storage represents the disk size. For the format, please check k8s.io/apimachinery/pkg/api/resource.Quantity.
 	Storage *resource.Quantity `json:"storage,omitempty"`
+	// Nodes represents the number of nodes, i.e. Machines.
+	Nodes *resource.Quantity `json:"nodes,omitempty"`
+	// GPUs represents the number of GPUs of the nodes, as given by their instance types or flavors.
+	GPUs *resource.Quantity `json:"gpus,omitempty"`
+	// LoadBalancers represents the number of Services of type LoadBalancer in the user clusters.
+	LoadBalancers *resource.Quantity `json:"loadBalancers,omitempty"`
+	// ExtendedResources holds the quantities of extended resources advertised by the nodes,
+	// for example `example.com/fpga`.
+	ExtendedResources corev1.ResourceList `json:"extendedResources,omitempty"`
 }
 
 func (r ResourceDetails) IsEmpty() bool {
-	return (r.CPU == nil || r.CPU.IsZero()) && (r.Memory == nil || r.Memory.IsZero()) && (r.Storage == nil || r.Storage.IsZero())
+	for _, quantity := range r.ExtendedResources {
+		if !quantity.IsZero() {
+			return false
+		}
+	}
+
+	return isZeroQuantity(r.CPU) && isZeroQuantity(r.Memory) && isZeroQuantity(r.Storage) &&
+		isZeroQuantity(r.Nodes) && isZeroQuantity(r.GPUs) && isZeroQuantity(r.LoadBalancers)
+}
+
+// Add adds the quantities of other to r. Quantities that are not set in r are initialized
+// if they are set in other.
+func (r *ResourceDetails) Add(other ResourceDetails) {
+	r.CPU = addQuantity(r.CPU, other.CPU)
+	r.Memory = addQuantity(r.Memory, other.Memory)
+	r.Storage = addQuantity(r.Storage, other.Storage)
+	r.Nodes = addQuantity(r.Nodes, other.Nodes)
+	r.GPUs = addQuantity(r.GPUs, other.GPUs)
+	r.LoadBalancers = addQuantity(r.LoadBalancers, other.LoadBalancers)
+
+	for name, quantity := range other.ExtendedResources {
+		if r.ExtendedResources == nil {
+			r.ExtendedResources = corev1.ResourceList{}
+		}
+
+		sum := r.ExtendedResources[name]
+		sum.Add(quantity)
+		r.ExtendedResources[name] = sum
+	}
+}
+
+func isZeroQuantity(q *resource.Quantity) bool {
+	return q == nil || q.IsZero()
+}
+
+func addQuantity(sum, q *resource.Quantity) *resource.Quantity {
+	if q == nil {
+		return sum
+	}
+
+	if sum == nil {
+		sum = &resource.Quantity{}
+	}
+
+	sum.Add(*q)
+
+	return sum
 }
 
 // +kubebuilder:object:generate=true
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -678,11 +679,28 @@ func (in *ApplicationSettings) DeepCopy() *ApplicationSettings {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditLoggingSettings) DeepCopyInto(out *AuditLoggingSettings) {
 	*out = *in
+	if in.PolicyConfigMapRef != nil {
+		in, out := &in.PolicyConfigMapRef, &out.PolicyConfigMapRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]auditv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SidecarSettings != nil {
 		in, out := &in.SidecarSettings, &out.SidecarSettings
 		*out = new(AuditSidecarSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WebhookBackend != nil {
+		in, out := &in.WebhookBackend, &out.WebhookBackend
+		*out = new(AuditWebhookBackendSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLoggingSettings.
@@ -768,6 +786,26 @@ func (in *AuditSidecarSettings) DeepCopy() *AuditSidecarSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditWebhookBackendSettings) DeepCopyInto(out *AuditWebhookBackendSettings) {
+	*out = *in
+	if in.AuditWebhookConfig != nil {
+		in, out := &in.AuditWebhookConfig, &out.AuditWebhookConfig
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhookBackendSettings.
+func (in *AuditWebhookBackendSettings) DeepCopy() *AuditWebhookBackendSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditWebhookBackendSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Azure) DeepCopyInto(out *Azure) {
 	*out = *in
@@ -822,6 +860,16 @@ func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
 		*out = new(corev1.SecretReference)
 		**out = **in
 	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(BackupDestinationFilesystem)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
@@ -834,6 +882,37 @@ func (in *BackupDestination) DeepCopy() *BackupDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupDestinationFilesystem) DeepCopyInto(out *BackupDestinationFilesystem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestinationFilesystem.
+func (in *BackupDestinationFilesystem) DeepCopy() *BackupDestinationFilesystem {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupDestinationFilesystem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryption) DeepCopyInto(out *BackupEncryption) {
+	*out = *in
+	out.KeySecret = in.KeySecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryption.
+func (in *BackupEncryption) DeepCopy() *BackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	*out = *in
@@ -842,6 +921,7 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	in.BackupFinishedTime.DeepCopyInto(&out.BackupFinishedTime)
 	in.DeleteStartTime.DeepCopyInto(&out.DeleteStartTime)
 	in.DeleteFinishedTime.DeepCopyInto(&out.DeleteFinishedTime)
+	in.VerifyFinishedTime.DeepCopyInto(&out.VerifyFinishedTime)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -888,6 +968,21 @@ func (in Bytes) DeepCopy() Bytes {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAPIMachineDeployment) DeepCopyInto(out *CAPIMachineDeployment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAPIMachineDeployment.
+func (in *CAPIMachineDeployment) DeepCopy() *CAPIMachineDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(CAPIMachineDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNIPluginSettings) DeepCopyInto(out *CNIPluginSettings) {
 	*out = *in
@@ -1075,6 +1170,13 @@ func (in *ClusterEncryptionStatus) DeepCopyInto(out *ClusterEncryptionStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.KeyRotations != nil {
+		in, out := &in.KeyRotations, &out.KeyRotations
+		*out = make([]EncryptionKeyRotation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEncryptionStatus.
@@ -1087,6 +1189,29 @@ func (in *ClusterEncryptionStatus) DeepCopy() *ClusterEncryptionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHibernationStatus) DeepCopyInto(out *ClusterHibernationStatus) {
+	*out = *in
+	if in.MachineDeploymentReplicas != nil {
+		in, out := &in.MachineDeploymentReplicas, &out.MachineDeploymentReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHibernationStatus.
+func (in *ClusterHibernationStatus) DeepCopy() *ClusterHibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -1119,6 +1244,119 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigration) DeepCopyInto(out *ClusterMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigration.
+func (in *ClusterMigration) DeepCopy() *ClusterMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationCondition) DeepCopyInto(out *ClusterMigrationCondition) {
+	*out = *in
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationCondition.
+func (in *ClusterMigrationCondition) DeepCopy() *ClusterMigrationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationList) DeepCopyInto(out *ClusterMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationList.
+func (in *ClusterMigrationList) DeepCopy() *ClusterMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationSpec) DeepCopyInto(out *ClusterMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationSpec.
+func (in *ClusterMigrationSpec) DeepCopy() *ClusterMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationStatus) DeepCopyInto(out *ClusterMigrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(map[ClusterMigrationConditionType]ClusterMigrationCondition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationStatus.
+func (in *ClusterMigrationStatus) DeepCopy() *ClusterMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetworkingConfig) DeepCopyInto(out *ClusterNetworkingConfig) {
 	*out = *in
@@ -1208,6 +1446,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(UpdateWindow)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(UpdateWindow)
+		**out = **in
+	}
 	if in.AdmissionPlugins != nil {
 		in, out := &in.AdmissionPlugins, &out.AdmissionPlugins
 		*out = make([]string, len(*in))
@@ -1329,6 +1572,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(ResourceDetails)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(ClusterHibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -2481,6 +2729,11 @@ func (in *EncryptionConfiguration) DeepCopyInto(out *EncryptionConfiguration) {
 		*out = new(SecretboxEncryptionConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSEncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionConfiguration.
@@ -2493,6 +2746,26 @@ func (in *EncryptionConfiguration) DeepCopy() *EncryptionConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotation) DeepCopyInto(out *EncryptionKeyRotation) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotation.
+func (in *EncryptionKeyRotation) DeepCopy() *EncryptionKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyLoadBalancerService) DeepCopyInto(out *EnvoyLoadBalancerService) {
 	*out = *in
@@ -2605,6 +2878,16 @@ func (in *EtcdBackupConfigSpec) DeepCopyInto(out *EtcdBackupConfigSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Continuous != nil {
+		in, out := &in.Continuous, &out.Continuous
+		*out = new(EtcdContinuousBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(EtcdBackupVerification)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupConfigSpec.
@@ -2677,12 +2960,52 @@ func (in *EtcdBackupRestore) DeepCopy() *EtcdBackupRestore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupVerification) DeepCopyInto(out *EtcdBackupVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupVerification.
+func (in *EtcdBackupVerification) DeepCopy() *EtcdBackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdContinuousBackup) DeepCopyInto(out *EtcdContinuousBackup) {
+	*out = *in
+	if in.SegmentInterval != nil {
+		in, out := &in.SegmentInterval, &out.SegmentInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdContinuousBackup.
+func (in *EtcdContinuousBackup) DeepCopy() *EtcdContinuousBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdContinuousBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdRestore) DeepCopyInto(out *EtcdRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -2740,6 +3063,15 @@ func (in *EtcdRestoreList) DeepCopyObject() runtime.Object {
 func (in *EtcdRestoreSpec) DeepCopyInto(out *EtcdRestoreSpec) {
 	*out = *in
 	out.Cluster = in.Cluster
+	if in.TargetRevision != nil {
+		in, out := &in.TargetRevision, &out.TargetRevision
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetTime != nil {
+		in, out := &in.TargetTime, &out.TargetTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreSpec.
@@ -2942,7 +3274,7 @@ func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCluster.
@@ -2998,6 +3330,53 @@ func (in *ExternalClusterBringYourOwnCloudSpec) DeepCopy() *ExternalClusterBring
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterCAPICloudSpec) DeepCopyInto(out *ExternalClusterCAPICloudSpec) {
+	*out = *in
+	if in.CredentialsReference != nil {
+		in, out := &in.CredentialsReference, &out.CredentialsReference
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
+	if in.MachineDeploymentReplicas != nil {
+		in, out := &in.MachineDeploymentReplicas, &out.MachineDeploymentReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterCAPICloudSpec.
+func (in *ExternalClusterCAPICloudSpec) DeepCopy() *ExternalClusterCAPICloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterCAPICloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterCAPIStatus) DeepCopyInto(out *ExternalClusterCAPIStatus) {
+	*out = *in
+	if in.MachineDeployments != nil {
+		in, out := &in.MachineDeployments, &out.MachineDeployments
+		*out = make([]CAPIMachineDeployment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterCAPIStatus.
+func (in *ExternalClusterCAPIStatus) DeepCopy() *ExternalClusterCAPIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterCAPIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalClusterCloudSpec) DeepCopyInto(out *ExternalClusterCloudSpec) {
 	*out = *in
@@ -3026,6 +3405,11 @@ func (in *ExternalClusterCloudSpec) DeepCopyInto(out *ExternalClusterCloudSpec)
 		*out = new(ExternalClusterBringYourOwnCloudSpec)
 		**out = **in
 	}
+	if in.CAPI != nil {
+		in, out := &in.CAPI, &out.CAPI
+		*out = new(ExternalClusterCAPICloudSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterCloudSpec.
@@ -3103,6 +3487,45 @@ func (in *ExternalClusterGKECloudSpec) DeepCopy() *ExternalClusterGKECloudSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterHealth) DeepCopyInto(out *ExternalClusterHealth) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.KubeconfigExpiry != nil {
+		in, out := &in.KubeconfigExpiry, &out.KubeconfigExpiry
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterHealth.
+func (in *ExternalClusterHealth) DeepCopy() *ExternalClusterHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalClusterKubeOneCloudSpec) DeepCopyInto(out *ExternalClusterKubeOneCloudSpec) {
 	*out = *in
@@ -3121,6 +3544,23 @@ func (in *ExternalClusterKubeOneCloudSpec) DeepCopyInto(out *ExternalClusterKube
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(KubeOneHostPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]KubeOneHostPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(KubeOneAddons)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterKubeOneCloudSpec.
@@ -3133,6 +3573,28 @@ func (in *ExternalClusterKubeOneCloudSpec) DeepCopy() *ExternalClusterKubeOneClo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalClusterKubeOneStatus) DeepCopyInto(out *ExternalClusterKubeOneStatus) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]KubeOneJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterKubeOneStatus.
+func (in *ExternalClusterKubeOneStatus) DeepCopy() *ExternalClusterKubeOneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalClusterKubeOneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalClusterList) DeepCopyInto(out *ExternalClusterList) {
 	*out = *in
@@ -3263,6 +3725,21 @@ func (in *ExternalClusterSpec) DeepCopy() *ExternalClusterSpec {
 func (in *ExternalClusterStatus) DeepCopyInto(out *ExternalClusterStatus) {
 	*out = *in
 	out.Condition = in.Condition
+	if in.KubeOne != nil {
+		in, out := &in.KubeOne, &out.KubeOne
+		*out = new(ExternalClusterKubeOneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(ExternalClusterHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CAPI != nil {
+		in, out := &in.CAPI, &out.CAPI
+		*out = new(ExternalClusterCAPIStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalClusterStatus.
@@ -3731,29 +4208,93 @@ func (in ImageListWithVersions) DeepCopyInto(out *ImageListWithVersions) {
 			(*out)[key] = outVal
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageListWithVersions.
-func (in ImageListWithVersions) DeepCopy() ImageListWithVersions {
-	if in == nil {
-		return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageListWithVersions.
+func (in ImageListWithVersions) DeepCopy() ImageListWithVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageListWithVersions)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Incompatibility) DeepCopyInto(out *Incompatibility) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Incompatibility.
+func (in *Incompatibility) DeepCopy() *Incompatibility {
+	if in == nil {
+		return nil
+	}
+	out := new(Incompatibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSEncryptionConfiguration) DeepCopyInto(out *KMSEncryptionConfiguration) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]KMSProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSEncryptionConfiguration.
+func (in *KMSEncryptionConfiguration) DeepCopy() *KMSEncryptionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSEncryptionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSProvider) DeepCopyInto(out *KMSProvider) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	out := new(ImageListWithVersions)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Incompatibility) DeepCopyInto(out *Incompatibility) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Incompatibility.
-func (in *Incompatibility) DeepCopy() *Incompatibility {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSProvider.
+func (in *KMSProvider) DeepCopy() *KMSProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(Incompatibility)
+	out := new(KMSProvider)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3850,6 +4391,129 @@ func (in *KubeLBSettings) DeepCopy() *KubeLBSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeOneAddon) DeepCopyInto(out *KubeOneAddon) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneAddon.
+func (in *KubeOneAddon) DeepCopy() *KubeOneAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeOneAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeOneAddons) DeepCopyInto(out *KubeOneAddons) {
+	*out = *in
+	if in.GlobalParams != nil {
+		in, out := &in.GlobalParams, &out.GlobalParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]KubeOneAddon, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneAddons.
+func (in *KubeOneAddons) DeepCopy() *KubeOneAddons {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeOneAddons)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeOneHost) DeepCopyInto(out *KubeOneHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneHost.
+func (in *KubeOneHost) DeepCopy() *KubeOneHost {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeOneHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeOneHostPool) DeepCopyInto(out *KubeOneHostPool) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]KubeOneHost, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneHostPool.
+func (in *KubeOneHostPool) DeepCopy() *KubeOneHostPool {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeOneHostPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeOneJobStatus) DeepCopyInto(out *KubeOneJobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneJobStatus.
+func (in *KubeOneJobStatus) DeepCopy() *KubeOneJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeOneJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtHTTPSource) DeepCopyInto(out *KubeVirtHTTPSource) {
 	*out = *in
@@ -4793,6 +5457,33 @@ func (in *MeteringConfiguration) DeepCopy() *MeteringConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringReport) DeepCopyInto(out *MeteringReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringReport.
+func (in *MeteringReport) DeepCopy() *MeteringReport {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeteringReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MeteringReportConfiguration) DeepCopyInto(out *MeteringReportConfiguration) {
 	*out = *in
@@ -4818,6 +5509,88 @@ func (in *MeteringReportConfiguration) DeepCopy() *MeteringReportConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringReportList) DeepCopyInto(out *MeteringReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MeteringReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringReportList.
+func (in *MeteringReportList) DeepCopy() *MeteringReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeteringReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringReportSpec) DeepCopyInto(out *MeteringReportSpec) {
+	*out = *in
+	in.From.DeepCopyInto(&out.From)
+	in.To.DeepCopyInto(&out.To)
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringReportSpec.
+func (in *MeteringReportSpec) DeepCopy() *MeteringReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringReportStatus) DeepCopyInto(out *MeteringReportStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringReportStatus.
+func (in *MeteringReportStatus) DeepCopy() *MeteringReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MlaOptions) DeepCopyInto(out *MlaOptions) {
 	*out = *in
@@ -5735,6 +6508,28 @@ func (in *ResourceDetails) DeepCopyInto(out *ResourceDetails) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GPUs != nil {
+		in, out := &in.GPUs, &out.GPUs
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ExtendedResources != nil {
+		in, out := &in.ExtendedResources, &out.ExtendedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDetails.
@@ -5828,6 +6623,11 @@ func (in *ResourceQuotaStatus) DeepCopyInto(out *ResourceQuotaStatus) {
 	*out = *in
 	in.GlobalUsage.DeepCopyInto(&out.GlobalUsage)
 	in.LocalUsage.DeepCopyInto(&out.LocalUsage)
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaStatus.
@@ -5949,6 +6749,11 @@ func (in *SecretboxEncryptionConfiguration) DeepCopyInto(out *SecretboxEncryptio
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(SecretboxKeyRotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretboxEncryptionConfiguration.
@@ -5981,6 +6786,26 @@ func (in *SecretboxKey) DeepCopy() *SecretboxKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretboxKeyRotationPolicy) DeepCopyInto(out *SecretboxKeyRotationPolicy) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretboxKeyRotationPolicy.
+func (in *SecretboxKeyRotationPolicy) DeepCopy() *SecretboxKeyRotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretboxKeyRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Seed) DeepCopyInto(out *Seed) {
 	*out = *in
@@ -6008,6 +6833,31 @@ func (in *Seed) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedAuditLoggingSettings) DeepCopyInto(out *SeedAuditLoggingSettings) {
+	*out = *in
+	if in.SidecarConfig != nil {
+		in, out := &in.SidecarConfig, &out.SidecarConfig
+		*out = new(AuditSidecarConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookBackend != nil {
+		in, out := &in.WebhookBackend, &out.WebhookBackend
+		*out = new(AuditWebhookBackendSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedAuditLoggingSettings.
+func (in *SeedAuditLoggingSettings) DeepCopy() *SeedAuditLoggingSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedAuditLoggingSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedCondition) DeepCopyInto(out *SeedCondition) {
 	*out = *in
@@ -6025,6 +6875,28 @@ func (in *SeedCondition) DeepCopy() *SeedCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedGatewaySettings) DeepCopyInto(out *SeedGatewaySettings) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedGatewaySettings.
+func (in *SeedGatewaySettings) DeepCopy() *SeedGatewaySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedGatewaySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedList) DeepCopyInto(out *SeedList) {
 	*out = *in
@@ -6084,6 +6956,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		}
 	}
 	in.NodeportProxy.DeepCopyInto(&out.NodeportProxy)
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(SeedGatewaySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProxySettings != nil {
 		in, out := &in.ProxySettings, &out.ProxySettings
 		*out = new(ProxySettings)
@@ -6094,6 +6971,11 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = new(SeedMLASettings)
 		**out = **in
 	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(SeedAuditLoggingSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	in.DefaultComponentSettings.DeepCopyInto(&out.DefaultComponentSettings)
 	if in.Metering != nil {
 		in, out := &in.Metering, &out.Metering
@@ -38,6 +38,7 @@ import (
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/kube"
 	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
@@ -362,6 +363,27 @@ func (h HelmClient) Uninstall(releaseName string) (*release.UninstallReleaseResp
 	return uninstallReleaseResponse, err
 }
 
+// DeployedManifest returns the rendered manifest of the currently deployed revision of the release.
+func (h HelmClient) DeployedManifest(releaseName string) (string, error) {
+	rel, err := h.actionConfig.Releases.Deployed(releaseName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployed revision of release %s: %w", releaseName, err)
+	}
+	return rel.Manifest, nil
+}
+
+// FieldManager returns the name of the field manager Helm uses when it creates or updates objects.
+func FieldManager() string {
+	// same logic as helm.sh/helm/v3/pkg/kube.getManagedFieldsManager
+	if kube.ManagedFieldsManager != "" {
+		return kube.ManagedFieldsManager
+	}
+	if len(os.Args[0]) == 0 {
+		return "unknown"
+	}
+	return filepath.Base(os.Args[0])
+}
+
 // buildDependencies adds missing repositories and then does a Helm dependency build (i.e. download the chart dependencies
 // from repositories into "charts" folder).
 func (h HelmClient) buildDependencies(chartLoc string, auth AuthSettings) (*chart.Chart, error) {
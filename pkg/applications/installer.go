@@ -44,6 +44,9 @@ type ApplicationInstaller interface {
 
 	// Delete function uninstalls the application on the user-cluster and returns an error if the uninstallation has failed. StatusUpdater is guaranteed to be non nil. This is idempotent.
 	Delete(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error)
+
+	// DetectDrift function returns the objects of the installed application whose live state in the user-cluster differs from the desired one.
+	DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]appskubermaticv1.DriftedObject, error)
 }
 
 // ApplicationManager handles the installation / uninstallation of an Application on the user-cluster.
@@ -57,6 +60,9 @@ type ApplicationManager struct {
 	// Namespace where credentials secrets are stored.
 	SecretNamespace string
 
+	// ClusterName is the name of the user-cluster.
+	ClusterName string
+
 	// CABundleFile is an optional path to a PEM-encoded CA bundle file.
 	CABundleFile string
 }
@@ -82,7 +88,7 @@ func (a *ApplicationManager) DonwloadSource(ctx context.Context, log *zap.Sugare
 
 // Apply creates the namespace where the application will be installed (if necessary) and installs the application.
 func (a *ApplicationManager) Apply(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, appDefinition *appskubermaticv1.ApplicationDefinition, applicationInstallation *appskubermaticv1.ApplicationInstallation, appSourcePath string) (util.StatusUpdater, error) {
-	templateProvider, err := providers.NewTemplateProvider(ctx, log, seedClient, a.Kubeconfig, a.ApplicationCache, applicationInstallation, a.SecretNamespace, a.CABundleFile)
+	templateProvider, err := providers.NewTemplateProvider(ctx, log, seedClient, userClient, a.Kubeconfig, a.ApplicationCache, applicationInstallation, a.SecretNamespace, a.ClusterName, a.CABundleFile)
 	if err != nil {
 		return util.NoStatusUpdate, fmt.Errorf("failed to initialize template provider: %w", err)
 	}
@@ -96,7 +102,7 @@ func (a *ApplicationManager) Apply(ctx context.Context, log *zap.SugaredLogger,
 
 // Delete uninstalls the application where the application was installed if necessary.
 func (a *ApplicationManager) Delete(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
-	templateProvider, err := providers.NewTemplateProvider(ctx, log, seedClient, a.Kubeconfig, a.ApplicationCache, applicationInstallation, a.SecretNamespace, a.CABundleFile)
+	templateProvider, err := providers.NewTemplateProvider(ctx, log, seedClient, userClient, a.Kubeconfig, a.ApplicationCache, applicationInstallation, a.SecretNamespace, a.ClusterName, a.CABundleFile)
 	if err != nil {
 		return util.NoStatusUpdate, fmt.Errorf("failed to initialize template provider: %w", err)
 	}
@@ -104,6 +110,21 @@ func (a *ApplicationManager) Delete(ctx context.Context, log *zap.SugaredLogger,
 	return templateProvider.Uninstall(applicationInstallation)
 }
 
+// DetectDrift compares the installed application with its desired state, if supported by the template method.
+func (a *ApplicationManager) DetectDrift(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]appskubermaticv1.DriftedObject, error) {
+	templateProvider, err := providers.NewTemplateProvider(ctx, log, seedClient, userClient, a.Kubeconfig, a.ApplicationCache, applicationInstallation, a.SecretNamespace, a.ClusterName, a.CABundleFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize template provider: %w", err)
+	}
+
+	driftDetector, ok := templateProvider.(providers.DriftDetector)
+	if !ok {
+		return nil, fmt.Errorf("template method '%v' does not support drift detection", applicationInstallation.Status.Method)
+	}
+
+	return driftDetector.DetectDrift(applicationInstallation)
+}
+
 // reconcileNamespace ensures namespace is created and has desired labels and annotations if applicationInstallation.Spec.Namespace.Create flag is set.
 func (a *ApplicationManager) reconcileNamespace(ctx context.Context, log *zap.SugaredLogger, applicationInstallation *appskubermaticv1.ApplicationInstallation, userClient ctrlruntimeclient.Client) error {
 	desiredNs := applicationInstallation.Spec.Namespace
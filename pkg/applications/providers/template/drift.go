@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// detectDrift compares the desired objects with their live state in the cluster and returns the drifted ones.
+// Only the fields set in the desired objects are compared, so fields defaulted by the API server or set by other
+// controllers are not reported. installerManager is the field manager used to install the objects, it is not
+// reported as a manager of drifted objects.
+func detectDrift(ctx context.Context, client ctrlruntimeclient.Client, namespace string, installerManager string, objects []*unstructured.Unstructured) ([]appskubermaticv1.DriftedObject, error) {
+	var drifted []appskubermaticv1.DriftedObject
+
+	for _, desired := range objects {
+		if err := defaultNamespace(client, desired, namespace); err != nil {
+			return nil, err
+		}
+
+		ref := managedResourceFor(desired)
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(desired), live); err != nil {
+			if apierrors.IsNotFound(err) {
+				drifted = append(drifted, appskubermaticv1.DriftedObject{ManagedResource: ref, Missing: true})
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %w", describeManagedResource(ref), err)
+		}
+
+		fields := diffObject(desired.Object, live.Object)
+		if len(fields) == 0 {
+			continue
+		}
+
+		drifted = append(drifted, appskubermaticv1.DriftedObject{
+			ManagedResource: ref,
+			Fields:          fields,
+			Managers:        foreignManagers(live, installerManager),
+		})
+	}
+
+	return drifted, nil
+}
+
+// diffObject returns the sorted paths of the fields of desired that have a different value in live. Only the labels
+// and annotations of the metadata are compared, and the status is ignored.
+func diffObject(desired, live map[string]interface{}) []string {
+	var fields []string
+
+	for key, desiredValue := range desired {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			desiredMeta, _ := desiredValue.(map[string]interface{})
+			liveMeta, _ := live[key].(map[string]interface{})
+			for _, metaKey := range []string{"labels", "annotations"} {
+				if value, ok := desiredMeta[metaKey]; ok {
+					fields = append(fields, diffValue("metadata."+metaKey, value, liveMeta[metaKey])...)
+				}
+			}
+		default:
+			fields = append(fields, diffValue(key, desiredValue, live[key])...)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+func diffValue(path string, desired, live interface{}) []string {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			if len(desiredValue) == 0 && live == nil {
+				return nil
+			}
+			return []string{path}
+		}
+
+		var fields []string
+		for key, value := range desiredValue {
+			fields = append(fields, diffValue(path+"."+key, value, liveValue[key])...)
+		}
+		return fields
+
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			if len(desiredValue) == 0 && live == nil {
+				return nil
+			}
+			return []string{path}
+		}
+
+		if len(desiredValue) != len(liveValue) {
+			return []string{path}
+		}
+
+		var fields []string
+		for i := range desiredValue {
+			fields = append(fields, diffValue(path+"["+strconv.Itoa(i)+"]", desiredValue[i], liveValue[i])...)
+		}
+		return fields
+
+	case nil:
+		return nil
+
+	default:
+		if !reflect.DeepEqual(normalizeNumber(desired), normalizeNumber(live)) {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+// normalizeNumber converts numbers to float64, as manifests decoded from YAML and objects read from the API server
+// do not use the same types.
+func normalizeNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return value
+	}
+}
+
+// foreignManagers returns the sorted managers of the object's fields, except the installer and the managers of subresources
+// (e.g. controllers updating the status).
+func foreignManagers(obj *unstructured.Unstructured, installerManager string) []string {
+	managers := sets.New[string]()
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == installerManager || entry.Subresource != "" {
+			continue
+		}
+		managers.Insert(entry.Manager)
+	}
+
+	if managers.Len() == 0 {
+		return nil
+	}
+
+	return sets.List(managers)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestDiffObject(t *testing.T) {
+	desired := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":   "app",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": float64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "nginx:1.25"},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		live           map[string]interface{}
+		expectedFields []string
+	}{
+		{
+			name: "defaulted fields, other metadata and status are ignored",
+			live: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":            "app",
+					"resourceVersion": "42",
+					"labels":          map[string]interface{}{"app": "web", "extra": "label"},
+				},
+				"spec": map[string]interface{}{
+					"replicas":             int64(2),
+					"revisionHistoryLimit": int64(10),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "web", "image": "nginx:1.25", "imagePullPolicy": "IfNotPresent"},
+							},
+						},
+					},
+				},
+				"status": map[string]interface{}{"replicas": int64(2)},
+			},
+		},
+		{
+			name: "changed, removed and added fields are reported",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "app",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(5),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "web", "image": "nginx:latest"},
+								map[string]interface{}{"name": "sidecar", "image": "busybox"},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: []string{
+				"metadata.labels",
+				"spec.replicas",
+				"spec.template.spec.containers",
+			},
+		},
+		{
+			name: "nested fields are reported with their path",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app": "other"},
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(2),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "web", "image": "nginx:latest"},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: []string{
+				"metadata.labels.app",
+				"spec.template.spec.containers[0].image",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := diffObject(desired, tc.live)
+			if diff := deep.Equal(tc.expectedFields, fields); diff != nil {
+				t.Fatalf("Got unexpected fields: %v", diff)
+			}
+		})
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	ctx := context.Background()
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "app-namespace",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "user-cluster-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate},
+				{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate},
+				{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status"},
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](5),
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "app-namespace"},
+		Data:       map[string]string{"key": "value"},
+	}
+
+	client := newApplyClient()
+	if err := client.Create(ctx, deployment); err != nil {
+		t.Fatalf("Failed to create deployment: %v", err)
+	}
+	if err := client.Create(ctx, configMap); err != nil {
+		t.Fatalf("Failed to create configmap: %v", err)
+	}
+
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+---
+` + configMapManifest("settings") + `---
+` + configMapManifest("removed")
+
+	objects, err := decodeObjects(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+
+	drifted, err := detectDrift(ctx, client, "app-namespace", "user-cluster-controller-manager", objects)
+	if err != nil {
+		t.Fatalf("Failed to detect drift: %v", err)
+	}
+
+	expected := []appskubermaticv1.DriftedObject{
+		{
+			ManagedResource: appskubermaticv1.ManagedResource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "app-namespace", Name: "web"},
+			Fields:          []string{"spec.replicas"},
+			Managers:        []string{"kubectl-edit"},
+		},
+		{
+			ManagedResource: appskubermaticv1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "app-namespace", Name: "removed"},
+			Missing:         true,
+		},
+	}
+	if diff := deep.Equal(expected, drifted); diff != nil {
+		t.Fatalf("Got unexpected drift: %v", diff)
+	}
+}
@@ -20,9 +20,9 @@ import (
 	"context"
 	"crypto/sha1"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"path"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -55,6 +55,12 @@ type HelmTemplate struct {
 
 	// CABundleFile is an optional file path to a PEM-encoded CA bundle.
 	CABundleFile string
+
+	// UserClient to user cluster.
+	UserClient ctrlruntimeclient.Client
+
+	// ClusterName is the name of the user cluster. It is used to expose the cluster to the templates in the values.
+	ClusterName string
 }
 
 // InstallOrUpgrade the chart located at chartLoc with parameters (releaseName, values) defined applicationInstallation into cluster.
@@ -96,11 +102,10 @@ func (h HelmTemplate) InstallOrUpgrade(chartLoc string, appDefinition *appskuber
 		return util.NoStatusUpdate, err
 	}
 
-	values := make(map[string]interface{})
-	if len(applicationInstallation.Spec.Values.Raw) > 0 {
-		if err := json.Unmarshal(applicationInstallation.Spec.Values.Raw, &values); err != nil {
-			return util.NoStatusUpdate, fmt.Errorf("failed to unmarshall values: %w", err)
-		}
+	resolver := valuesResolver{seedClient: h.SeedClient, userClient: h.UserClient, seedNamespace: h.SecretNamespace, clusterName: h.ClusterName}
+	values, err := resolver.resolve(h.Ctx, applicationInstallation)
+	if err != nil {
+		return util.NoStatusUpdate, err
 	}
 
 	helmRelease, err := helmClient.InstallOrUpgrade(chartLoc, getReleaseName(applicationInstallation), values, *deployOpts, auth)
@@ -176,6 +181,45 @@ func (h HelmTemplate) Uninstall(applicationInstallation *appskubermaticv1.Applic
 	return statusUpdater, err
 }
 
+// DetectDrift compares the manifest of the deployed release with the live objects in the user cluster.
+func (h HelmTemplate) DetectDrift(applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]appskubermaticv1.DriftedObject, error) {
+	helmCacheDir, err := util.CreateHelmTempDir(h.CacheDir)
+	if err != nil {
+		return nil, err
+	}
+	defer util.CleanUpHelmTempDir(helmCacheDir, h.Log)
+
+	restClientGetter := &genericclioptions.ConfigFlags{
+		KubeConfig: &h.Kubeconfig,
+		Namespace:  &applicationInstallation.Spec.Namespace.Name,
+	}
+
+	helmClient, err := helmclient.NewClient(
+		h.Ctx,
+		restClientGetter,
+		helmclient.NewSettings(helmCacheDir),
+		applicationInstallation.Spec.Namespace.Name,
+		h.Log,
+		h.CABundleFile,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := helmClient.DeployedManifest(getReleaseName(applicationInstallation))
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := decodeObjects(strings.NewReader(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode release manifest: %w", err)
+	}
+
+	return detectDrift(h.Ctx, h.UserClient, applicationInstallation.Spec.Namespace.Name, helmclient.FieldManager(), objects)
+}
+
 // getReleaseName computes the release name from the applicationInstallation.
 // The releaseName length must be less or equal to 53. So we first start to compute this release Name:
 //
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// KustomizeTemplate installs, upgrades or uninstalls a Kustomize overlay into the cluster.
+type KustomizeTemplate struct {
+	Ctx context.Context
+
+	Log *zap.SugaredLogger
+
+	// UserClient to user cluster.
+	UserClient ctrlruntimeclient.Client
+}
+
+// InstallOrUpgrade builds the kustomization located at source and applies the result into the cluster. Objects
+// that are no longer part of the build output are pruned.
+func (k KustomizeTemplate) InstallOrUpgrade(source string, appDefinition *appskubermaticv1.ApplicationDefinition, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	objects, err := buildKustomization(source)
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	return applyObjects(k.Ctx, k.Log, k.UserClient, applicationInstallation, objects)
+}
+
+// Uninstall deletes all objects applied by the application from the cluster.
+func (k KustomizeTemplate) Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	return deleteObjects(k.Ctx, k.Log, k.UserClient, applicationInstallation)
+}
+
+// buildKustomization runs the equivalent of "kustomize build" on dir.
+func buildKustomization(dir string) ([]*unstructured.Unstructured, error) {
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	rendered, err := resMap.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode kustomization: %w", err)
+	}
+
+	objects, err := decodeObjects(bytes.NewReader(rendered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode kustomization: %w", err)
+	}
+
+	return objects, nil
+}
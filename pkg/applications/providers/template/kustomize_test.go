@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestBuildKustomization(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "base", "kustomization.yaml"), `resources:
+- configmap.yaml
+`)
+	writeFile(t, filepath.Join(dir, "base", "configmap.yaml"), configMapManifest("settings"))
+	writeFile(t, filepath.Join(dir, "overlay", "kustomization.yaml"), `namePrefix: prod-
+namespace: production
+resources:
+- ../base
+- clusterrole.yaml
+`)
+	writeFile(t, filepath.Join(dir, "overlay", "clusterrole.yaml"), clusterRoleManifest("reader"))
+
+	objects, err := buildKustomization(filepath.Join(dir, "overlay"))
+	if err != nil {
+		t.Fatalf("Failed to build kustomization: %v", err)
+	}
+
+	var refs []string
+	for _, obj := range objects {
+		refs = append(refs, obj.GetKind()+" "+obj.GetNamespace()+"/"+obj.GetName())
+	}
+
+	expected := []string{
+		"ConfigMap production/prod-settings",
+		"ClusterRole /prod-reader",
+	}
+	if diff := deep.Equal(expected, refs); diff != nil {
+		t.Fatalf("Got unexpected objects: %v", diff)
+	}
+}
+
+func TestBuildKustomizationWithoutKustomizationFile(t *testing.T) {
+	if _, err := buildKustomization(t.TempDir()); err == nil {
+		t.Fatal("Expected building a directory without kustomization file to fail")
+	}
+}
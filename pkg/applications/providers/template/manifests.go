@@ -0,0 +1,332 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/providers/util"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldOwner is the field manager used to server-side apply the objects of an application.
+const fieldOwner = "kubermatic-application-installer"
+
+// ManifestsTemplate installs, upgrades or uninstalls plain Kubernetes manifests into the cluster.
+type ManifestsTemplate struct {
+	Ctx context.Context
+
+	Log *zap.SugaredLogger
+
+	// UserClient to user cluster.
+	UserClient ctrlruntimeclient.Client
+}
+
+// InstallOrUpgrade applies all YAML and JSON manifests located in source into the cluster and prunes the objects
+// that are no longer part of the manifests.
+func (m ManifestsTemplate) InstallOrUpgrade(source string, appDefinition *appskubermaticv1.ApplicationDefinition, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	objects, err := readManifests(source)
+	if err != nil {
+		return util.NoStatusUpdate, err
+	}
+
+	return applyObjects(m.Ctx, m.Log, m.UserClient, applicationInstallation, objects)
+}
+
+// Uninstall deletes all objects applied by the application from the cluster.
+func (m ManifestsTemplate) Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	return deleteObjects(m.Ctx, m.Log, m.UserClient, applicationInstallation)
+}
+
+// readManifests decodes all objects from the .yaml, .yml and .json files found in dir and its subdirectories.
+// Hidden files and directories (e.g. .git) are skipped. Files are read in lexical order.
+func readManifests(dir string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		fileObjects, err := decodeObjects(f)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		objects = append(objects, fileObjects...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests: %w", err)
+	}
+
+	return objects, nil
+}
+
+// decodeObjects decodes a stream of YAML documents or JSON objects. Empty documents are skipped and lists are
+// flattened into their items.
+func decodeObjects(r io.Reader) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		content := map[string]interface{}{}
+		if err := decoder.Decode(&content); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if len(content) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: content}
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				objects = append(objects, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	for _, obj := range objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("object %q of kind %q must have an apiVersion, a kind and a name", obj.GetName(), obj.GetKind())
+		}
+	}
+
+	return objects, nil
+}
+
+// applyObjects server-side applies the objects into the cluster, then deletes the objects that were previously applied
+// by the applicationInstallation but are no longer part of objects. The returned StatusUpdater always records every
+// object that may still exist in the cluster, so a failed apply or prune is picked up by the next reconciliation.
+func applyObjects(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation, objects []*unstructured.Unstructured) (util.StatusUpdater, error) {
+	previous := applicationInstallation.Status.ManagedResources
+	applied := make([]appskubermaticv1.ManagedResource, 0, len(objects))
+
+	for _, obj := range sortForApply(objects) {
+		if err := defaultNamespace(client, obj, applicationInstallation.Spec.Namespace.Name); err != nil {
+			return managedResourcesUpdater(mergeManagedResources(previous, applied)), err
+		}
+
+		ref := managedResourceFor(obj)
+		log.Debugw("applying object", "object", ref)
+
+		if err := client.Patch(ctx, obj, ctrlruntimeclient.Apply, ctrlruntimeclient.FieldOwner(fieldOwner), ctrlruntimeclient.ForceOwnership); err != nil {
+			return managedResourcesUpdater(mergeManagedResources(previous, applied)), fmt.Errorf("failed to apply %s: %w", describeManagedResource(ref), err)
+		}
+
+		applied = append(applied, ref)
+	}
+
+	stale := subtractManagedResources(previous, applied)
+	remaining, err := deleteManagedResources(ctx, log, client, stale)
+
+	return managedResourcesUpdater(append(applied, remaining...)), err
+}
+
+// deleteObjects deletes all objects tracked in the status of the applicationInstallation from the cluster.
+func deleteObjects(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error) {
+	remaining, err := deleteManagedResources(ctx, log, client, applicationInstallation.Status.ManagedResources)
+
+	return managedResourcesUpdater(remaining), err
+}
+
+// deleteManagedResources deletes the resources in reverse order and returns the ones that could not be deleted.
+// Resources that are already gone, including those whose API is no longer served, are considered deleted.
+func deleteManagedResources(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, resources []appskubermaticv1.ManagedResource) ([]appskubermaticv1.ManagedResource, error) {
+	var (
+		remaining []appskubermaticv1.ManagedResource
+		errs      []error
+	)
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		ref := resources[i]
+
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+
+		log.Debugw("deleting object", "object", ref)
+
+		err := client.Delete(ctx, obj, ctrlruntimeclient.PropagationPolicy("Background"))
+		if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", describeManagedResource(ref), err))
+			// prepend to keep the original order
+			remaining = append([]appskubermaticv1.ManagedResource{ref}, remaining...)
+		}
+	}
+
+	return remaining, kerrors.NewAggregate(errs)
+}
+
+// sortForApply returns the objects with Namespaces and CustomResourceDefinitions first, so that the objects depending
+// on them can be applied in the same run.
+func sortForApply(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	sorted := make([]*unstructured.Unstructured, len(objects))
+	copy(sorted, objects)
+
+	priority := func(obj *unstructured.Unstructured) int {
+		switch obj.GetKind() {
+		case "Namespace", "CustomResourceDefinition":
+			return 0
+		default:
+			return 1
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priority(sorted[i]) < priority(sorted[j])
+	})
+
+	return sorted
+}
+
+// defaultNamespace sets the namespace of namespaced objects that do not define one.
+func defaultNamespace(client ctrlruntimeclient.Client, obj *unstructured.Unstructured, namespace string) error {
+	namespaced, err := client.IsObjectNamespaced(obj)
+	if err != nil {
+		return fmt.Errorf("failed to determine scope of %s %q: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	switch {
+	case !namespaced:
+		obj.SetNamespace("")
+	case obj.GetNamespace() == "":
+		obj.SetNamespace(namespace)
+	}
+
+	return nil
+}
+
+func managedResourceFor(obj *unstructured.Unstructured) appskubermaticv1.ManagedResource {
+	return appskubermaticv1.ManagedResource{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+func describeManagedResource(ref appskubermaticv1.ManagedResource) string {
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s %q", ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s %q", ref.Kind, ref.Namespace+"/"+ref.Name)
+}
+
+// sameObject compares two resources by identity. The API version is ignored, as the same object can be served in
+// multiple versions of its API group.
+func sameObject(a, b appskubermaticv1.ManagedResource) bool {
+	return a.Kind == b.Kind && a.Namespace == b.Namespace && a.Name == b.Name && apiGroup(a.APIVersion) == apiGroup(b.APIVersion)
+}
+
+func apiGroup(apiVersion string) string {
+	if group, _, found := strings.Cut(apiVersion, "/"); found {
+		return group
+	}
+	return ""
+}
+
+func containsManagedResource(resources []appskubermaticv1.ManagedResource, ref appskubermaticv1.ManagedResource) bool {
+	for _, r := range resources {
+		if sameObject(r, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// subtractManagedResources returns the resources of a that are not in b.
+func subtractManagedResources(a, b []appskubermaticv1.ManagedResource) []appskubermaticv1.ManagedResource {
+	var result []appskubermaticv1.ManagedResource
+	for _, ref := range a {
+		if !containsManagedResource(b, ref) {
+			result = append(result, ref)
+		}
+	}
+	return result
+}
+
+// mergeManagedResources returns b followed by the resources of a that are not in b.
+func mergeManagedResources(a, b []appskubermaticv1.ManagedResource) []appskubermaticv1.ManagedResource {
+	result := make([]appskubermaticv1.ManagedResource, 0, len(a)+len(b))
+	result = append(result, b...)
+	return append(result, subtractManagedResources(a, b)...)
+}
+
+func managedResourcesUpdater(resources []appskubermaticv1.ManagedResource) util.StatusUpdater {
+	return func(status *appskubermaticv1.ApplicationInstallationStatus) {
+		if len(resources) == 0 {
+			status.ManagedResources = nil
+			return
+		}
+		status.ManagedResources = resources
+	}
+}
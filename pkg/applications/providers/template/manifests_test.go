@@ -0,0 +1,311 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDecodeObjects(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedNames []string
+		expectedErr   bool
+	}{
+		{
+			name: "multiple documents with an empty one",
+			input: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+`,
+			expectedNames: []string{"first", "second"},
+		},
+		{
+			name: "list is flattened",
+			input: `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: second
+`,
+			expectedNames: []string{"first", "second"},
+		},
+		{
+			name:          "json object",
+			input:         `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "first"}}`,
+			expectedNames: []string{"first"},
+		},
+		{
+			name: "object without kind",
+			input: `apiVersion: v1
+metadata:
+  name: first
+`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects, err := decodeObjects(strings.NewReader(tc.input))
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Expected error = %v, got %v", tc.expectedErr, err)
+			}
+
+			var names []string
+			for _, obj := range objects {
+				names = append(names, obj.GetName())
+			}
+
+			if diff := deep.Equal(tc.expectedNames, names); diff != nil {
+				t.Fatalf("Got unexpected objects: %v", diff)
+			}
+		})
+	}
+}
+
+func TestReadManifests(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "b.yaml"), configMapManifest("from-b"))
+	writeFile(t, filepath.Join(dir, "a", "a.yml"), configMapManifest("from-a"))
+	writeFile(t, filepath.Join(dir, ".git", "config.yaml"), configMapManifest("from-git"))
+	writeFile(t, filepath.Join(dir, "README.md"), "# not a manifest")
+
+	objects, err := readManifests(dir)
+	if err != nil {
+		t.Fatalf("Failed to read manifests: %v", err)
+	}
+
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+
+	if diff := deep.Equal([]string{"from-a", "from-b"}, names); diff != nil {
+		t.Fatalf("Got unexpected objects: %v", diff)
+	}
+}
+
+func TestManifestsTemplate(t *testing.T) {
+	ctx := context.Background()
+	client := newApplyClient()
+
+	appInstallation := &appskubermaticv1.ApplicationInstallation{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "kube-system"},
+		Spec: appskubermaticv1.ApplicationInstallationSpec{
+			Namespace: appskubermaticv1.AppNamespaceSpec{Name: "app-namespace"},
+		},
+	}
+
+	template := ManifestsTemplate{Ctx: ctx, Log: kubermaticlog.Logger, UserClient: client}
+	dir := t.TempDir()
+
+	// install
+	writeFile(t, filepath.Join(dir, "manifests.yaml"), configMapManifest("first")+"---\n"+configMapManifest("second")+"---\n"+clusterRoleManifest("role"))
+
+	statusUpdater, err := template.InstallOrUpgrade(dir, &appskubermaticv1.ApplicationDefinition{}, appInstallation)
+	if err != nil {
+		t.Fatalf("Failed to install: %v", err)
+	}
+	statusUpdater(&appInstallation.Status)
+
+	expected := []appskubermaticv1.ManagedResource{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "app-namespace", Name: "first"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "app-namespace", Name: "second"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "role"},
+	}
+	if diff := deep.Equal(expected, appInstallation.Status.ManagedResources); diff != nil {
+		t.Fatalf("Got unexpected managed resources after install: %v", diff)
+	}
+	expectExists(t, client, &corev1.ConfigMap{}, "app-namespace", "second", true)
+
+	// upgrade prunes the objects that are no longer rendered
+	writeFile(t, filepath.Join(dir, "manifests.yaml"), configMapManifest("first")+"---\n"+clusterRoleManifest("role"))
+
+	statusUpdater, err = template.InstallOrUpgrade(dir, &appskubermaticv1.ApplicationDefinition{}, appInstallation)
+	if err != nil {
+		t.Fatalf("Failed to upgrade: %v", err)
+	}
+	statusUpdater(&appInstallation.Status)
+
+	expected = []appskubermaticv1.ManagedResource{expected[0], expected[2]}
+	if diff := deep.Equal(expected, appInstallation.Status.ManagedResources); diff != nil {
+		t.Fatalf("Got unexpected managed resources after upgrade: %v", diff)
+	}
+	expectExists(t, client, &corev1.ConfigMap{}, "app-namespace", "first", true)
+	expectExists(t, client, &corev1.ConfigMap{}, "app-namespace", "second", false)
+
+	// uninstall deletes everything
+	statusUpdater, err = template.Uninstall(appInstallation)
+	if err != nil {
+		t.Fatalf("Failed to uninstall: %v", err)
+	}
+	statusUpdater(&appInstallation.Status)
+
+	if appInstallation.Status.ManagedResources != nil {
+		t.Fatalf("Expected no managed resources after uninstall, got %v", appInstallation.Status.ManagedResources)
+	}
+	expectExists(t, client, &corev1.ConfigMap{}, "app-namespace", "first", false)
+	expectExists(t, client, &rbacv1.ClusterRole{}, "", "role", false)
+}
+
+func TestApplyObjectsKeepsTrackOfPreviousResourcesOnFailure(t *testing.T) {
+	ctx := context.Background()
+	client := newApplyClient()
+
+	previous := appskubermaticv1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "app-namespace", Name: "previous"}
+	appInstallation := &appskubermaticv1.ApplicationInstallation{
+		Spec: appskubermaticv1.ApplicationInstallationSpec{
+			Namespace: appskubermaticv1.AppNamespaceSpec{Name: "app-namespace"},
+		},
+		Status: appskubermaticv1.ApplicationInstallationStatus{
+			ManagedResources: []appskubermaticv1.ManagedResource{previous},
+		},
+	}
+
+	objects, err := decodeObjects(strings.NewReader(configMapManifest("first") + "---\napiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: broken\n"))
+	if err != nil {
+		t.Fatalf("Failed to decode objects: %v", err)
+	}
+
+	statusUpdater, err := applyObjects(ctx, kubermaticlog.Logger, client, appInstallation, objects)
+	if err == nil {
+		t.Fatal("Expected applying an unknown kind to fail")
+	}
+	statusUpdater(&appInstallation.Status)
+
+	expected := []appskubermaticv1.ManagedResource{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "app-namespace", Name: "first"},
+		previous,
+	}
+	if diff := deep.Equal(expected, appInstallation.Status.ManagedResources); diff != nil {
+		t.Fatalf("Got unexpected managed resources: %v", diff)
+	}
+}
+
+// newApplyClient returns a fake client that emulates server-side apply, which is not supported by the fake client.
+func newApplyClient() ctrlruntimeclient.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion, rbacv1.SchemeGroupVersion, appsv1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), meta.RESTScopeRoot)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+
+	return fake.NewClientBuilder().
+		WithRESTMapper(mapper).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, client ctrlruntimeclient.WithWatch, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return client.Patch(ctx, obj, patch, opts...)
+				}
+
+				existing := &unstructured.Unstructured{}
+				existing.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+				if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(obj), existing); err != nil {
+					if apierrors.IsNotFound(err) {
+						return client.Create(ctx, obj)
+					}
+					return err
+				}
+
+				obj.SetResourceVersion(existing.GetResourceVersion())
+				return client.Update(ctx, obj)
+			},
+		}).
+		Build()
+}
+
+func expectExists(t *testing.T, client ctrlruntimeclient.Client, obj ctrlruntimeclient.Object, namespace, name string, exists bool) {
+	t.Helper()
+
+	err := client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, obj)
+	switch {
+	case err == nil && !exists:
+		t.Fatalf("Expected %s/%s to be deleted", namespace, name)
+	case apierrors.IsNotFound(err) && exists:
+		t.Fatalf("Expected %s/%s to exist", namespace, name)
+	case err != nil && !apierrors.IsNotFound(err):
+		t.Fatalf("Failed to get %s/%s: %v", namespace, name, err)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func configMapManifest(name string) string {
+	return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ` + name + `
+data:
+  key: value
+`
+}
+
+func clusterRoleManifest(name string) string {
+	return `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: ` + name + `
+rules: []
+`
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// appSecretTypeAnnotation marks the application secrets that are synchronized from the master into the
+// cluster namespaces. Only such objects can be referenced on the seed, so that the other secrets of the
+// cluster namespace, like the cluster CAs, can not be leaked into the values of an application.
+const appSecretTypeAnnotation = "apps.kubermatic.k8c.io/secret-type"
+
+// ValuesTemplateData is the data available to the templates in the values of an ApplicationInstallation.
+type ValuesTemplateData struct {
+	Cluster ValuesClusterData
+}
+
+// ValuesClusterData contains the facts about the user cluster the application is installed in.
+type ValuesClusterData struct {
+	// Name is the auto-generated, internal cluster name, e.g. "bbc8sc24wb".
+	Name string
+	// HumanReadableName is the user-specified cluster name.
+	HumanReadableName string
+	// ProjectID is the ID of the project the cluster belongs to.
+	ProjectID string
+	// Labels are the labels users have configured for their cluster, including
+	// system-defined labels like the project ID.
+	Labels map[string]string
+	// CloudProviderName is the name of the cloud provider used, e.g. "aws" or "openstack".
+	CloudProviderName string
+	// DatacenterName is the name of the datacenter the cluster is running in.
+	DatacenterName string
+	// Version is the exact current cluster version.
+	Version string
+	// MajorMinorVersion is the "Major.Minor" part of the current cluster version.
+	MajorMinorVersion string
+	// DNSName is the external DNS name of the cluster's API server.
+	DNSName string
+	// APIServerURL is the URL under which the cluster's API server is available.
+	APIServerURL string
+	// Network contains DNS and CIDR settings for the cluster.
+	Network ValuesClusterNetwork
+	// CNIPlugin contains the CNI plugin settings.
+	CNIPlugin ValuesCNIPlugin
+}
+
+type ValuesClusterNetwork struct {
+	DNSDomain         string
+	PodCIDRBlocks     []string
+	ServiceCIDRBlocks []string
+	PodCIDRIPv4       string
+	PodCIDRIPv6       string
+	ProxyMode         string
+	DualStack         bool
+}
+
+type ValuesCNIPlugin struct {
+	Type    string
+	Version string
+}
+
+// NewValuesTemplateData returns the template data for the given cluster.
+func NewValuesTemplateData(cluster *kubermaticv1.Cluster) (*ValuesTemplateData, error) {
+	providerName, err := kubermaticv1helper.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine cloud provider name: %w", err)
+	}
+
+	var cniPlugin ValuesCNIPlugin
+	if cluster.Spec.CNIPlugin != nil {
+		cniPlugin = ValuesCNIPlugin{
+			Type:    cluster.Spec.CNIPlugin.Type.String(),
+			Version: cluster.Spec.CNIPlugin.Version,
+		}
+	}
+
+	return &ValuesTemplateData{
+		Cluster: ValuesClusterData{
+			Name:              cluster.Name,
+			HumanReadableName: cluster.Spec.HumanReadableName,
+			ProjectID:         cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+			Labels:            cluster.Labels,
+			CloudProviderName: providerName,
+			DatacenterName:    cluster.Spec.Cloud.DatacenterName,
+			Version:           cluster.Status.Versions.ControlPlane.String(),
+			MajorMinorVersion: cluster.Status.Versions.ControlPlane.MajorMinor(),
+			DNSName:           cluster.Status.Address.ExternalName,
+			APIServerURL:      cluster.Status.Address.URL,
+			Network: ValuesClusterNetwork{
+				DNSDomain:         cluster.Spec.ClusterNetwork.DNSDomain,
+				PodCIDRBlocks:     cluster.Spec.ClusterNetwork.Pods.CIDRBlocks,
+				ServiceCIDRBlocks: cluster.Spec.ClusterNetwork.Services.CIDRBlocks,
+				PodCIDRIPv4:       cluster.Spec.ClusterNetwork.Pods.GetIPv4CIDR(),
+				PodCIDRIPv6:       cluster.Spec.ClusterNetwork.Pods.GetIPv6CIDR(),
+				ProxyMode:         cluster.Spec.ClusterNetwork.ProxyMode,
+				DualStack:         cluster.IsDualStack(),
+			},
+			CNIPlugin: cniPlugin,
+		},
+	}, nil
+}
+
+// valuesResolver computes the values of an ApplicationInstallation.
+type valuesResolver struct {
+	seedClient ctrlruntimeclient.Client
+	userClient ctrlruntimeclient.Client
+	// seedNamespace is the namespace of the cluster on the seed.
+	seedNamespace string
+	// clusterName is the name of the cluster the application is installed in.
+	clusterName string
+}
+
+// resolve merges the values referenced by ValuesFrom in order, and then the rendered Values on top of them.
+func (r valuesResolver) resolve(ctx context.Context, applicationInstallation *appskubermaticv1.ApplicationInstallation) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	for i, ref := range applicationInstallation.Spec.ValuesFrom {
+		refValues, err := r.loadReference(ctx, applicationInstallation, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load valuesFrom[%d]: %w", i, err)
+		}
+		mergeValues(values, refValues)
+	}
+
+	raw := applicationInstallation.Spec.Values.Raw
+	if len(raw) == 0 {
+		return values, nil
+	}
+
+	inlineValues := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &inlineValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshall values: %w", err)
+	}
+
+	// avoid fetching the cluster if the values do not use any template
+	if bytes.Contains(raw, []byte("{{")) {
+		data, err := r.templateData(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered, err := renderValues(inlineValues, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render values: %w", err)
+		}
+		inlineValues = rendered.(map[string]interface{})
+	}
+
+	mergeValues(values, inlineValues)
+
+	return values, nil
+}
+
+func (r valuesResolver) templateData(ctx context.Context) (*ValuesTemplateData, error) {
+	if r.clusterName == "" {
+		return nil, fmt.Errorf("values use templates, but the cluster is unknown")
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.seedClient.Get(ctx, types.NamespacedName{Name: r.clusterName}, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	return NewValuesTemplateData(cluster)
+}
+
+// loadReference returns the values stored in the object referenced by ref. It returns no values if the
+// reference is optional and the object or key does not exist.
+func (r valuesResolver) loadReference(ctx context.Context, applicationInstallation *appskubermaticv1.ApplicationInstallation, ref appskubermaticv1.ValuesReference) (map[string]interface{}, error) {
+	client := r.userClient
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = applicationInstallation.Namespace
+	}
+
+	if ref.Source == appskubermaticv1.ValuesReferenceSourceSeed {
+		client = r.seedClient
+		namespace = r.seedNamespace
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = appskubermaticv1.DefaultValuesKey
+	}
+
+	var (
+		obj  ctrlruntimeclient.Object
+		data func() (string, bool)
+	)
+
+	switch ref.Kind {
+	case appskubermaticv1.ValuesReferenceKindSecret:
+		secret := &corev1.Secret{}
+		obj = secret
+		data = func() (string, bool) {
+			value, ok := secret.Data[key]
+			return string(value), ok
+		}
+	case appskubermaticv1.ValuesReferenceKindConfigMap:
+		configMap := &corev1.ConfigMap{}
+		obj = configMap
+		data = func() (string, bool) {
+			value, ok := configMap.Data[key]
+			return value, ok
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", ref.Kind)
+	}
+
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) && ref.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+
+	if ref.Source == appskubermaticv1.ValuesReferenceSourceSeed {
+		if _, ok := obj.GetAnnotations()[appSecretTypeAnnotation]; !ok {
+			return nil, fmt.Errorf("%s %s/%s on the seed is not annotated with %s", ref.Kind, namespace, ref.Name, appSecretTypeAnnotation)
+		}
+	}
+
+	value, ok := data()
+	if !ok {
+		if ref.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s %s/%s has no key %q", ref.Kind, namespace, ref.Name, key)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("failed to parse key %q of %s %s/%s: %w", key, ref.Kind, namespace, ref.Name, err)
+	}
+
+	return values, nil
+}
+
+// renderValues renders all strings of the values that contain a template.
+func renderValues(value interface{}, data *ValuesTemplateData) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			rendered, err := renderValues(item, data)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+
+	case []interface{}:
+		for i, item := range v {
+			rendered, err := renderValues(item, data)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+
+		tpl, err := template.New("values").Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %q: %w", v, err)
+		}
+
+		var buf strings.Builder
+		if err := tpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render template %q: %w", v, err)
+		}
+		return buf.String(), nil
+
+	default:
+		return v, nil
+	}
+}
+
+// mergeValues deeply merges src into dst. Maps are merged recursively, all other values in src replace
+// the ones in dst.
+func mergeValues(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+
+		dst[key] = srcValue
+	}
+}
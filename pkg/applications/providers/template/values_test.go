@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestResolveValues(t *testing.T) {
+	const (
+		appNamespace  = "apps"
+		seedNamespace = "cluster-abc123"
+	)
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "abc123",
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			HumanReadableName: "production",
+			Cloud: kubermaticv1.CloudSpec{
+				DatacenterName: "hetzner-fsn1",
+				Hetzner:        &kubermaticv1.HetznerCloudSpec{},
+			},
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Pods: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			Address: kubermaticv1.ClusterAddress{ExternalName: "abc123.example.com"},
+			Versions: kubermaticv1.ClusterVersionsStatus{
+				ControlPlane: *semver.NewSemverOrDie("1.27.3"),
+			},
+		},
+	}
+
+	seedClient := kubermaticfake.NewClientBuilder().WithObjects(
+		cluster,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "registry-credentials",
+				Namespace:   seedNamespace,
+				Annotations: map[string]string{appSecretTypeAnnotation: "helm"},
+			},
+			Data: map[string][]byte{"values.yaml": []byte("image:\n  pullSecret: from-seed\n")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: seedNamespace},
+			Data:       map[string][]byte{"values.yaml": []byte("ca: secret\n")},
+		},
+	).Build()
+
+	userClient := kubermaticfake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: appNamespace},
+			Data:       map[string]string{"defaults.yaml": "replicas: 1\nimage:\n  tag: v1\n  repository: example/app\n"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "other"},
+			Data:       map[string][]byte{"values.yaml": []byte("password: hunter2\nimage:\n  tag: v2\n")},
+		},
+	).Build()
+
+	testCases := []struct {
+		name           string
+		values         string
+		valuesFrom     []appskubermaticv1.ValuesReference
+		expectedValues map[string]interface{}
+		expectedError  string
+	}{
+		{
+			name:           "no values",
+			expectedValues: map[string]interface{}{},
+		},
+		{
+			name: "references are merged in order and inline values take precedence",
+			valuesFrom: []appskubermaticv1.ValuesReference{
+				{Kind: appskubermaticv1.ValuesReferenceKindConfigMap, Name: "defaults", Key: "defaults.yaml"},
+				{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "credentials", Namespace: "other"},
+				{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "registry-credentials", Source: appskubermaticv1.ValuesReferenceSourceSeed},
+			},
+			values: `{"replicas": 3}`,
+			expectedValues: map[string]interface{}{
+				"replicas": float64(3),
+				"password": "hunter2",
+				"image": map[string]interface{}{
+					"repository": "example/app",
+					"tag":        "v2",
+					"pullSecret": "from-seed",
+				},
+			},
+		},
+		{
+			name:   "templates are rendered with the cluster data",
+			values: `{"clusterName": "{{ .Cluster.Name }}", "dns": ["{{ .Cluster.DNSName }}"], "pods": "{{ first .Cluster.Network.PodCIDRBlocks }}", "provider": "{{ .Cluster.CloudProviderName }}-{{ .Cluster.MajorMinorVersion }}", "project": "{{ .Cluster.ProjectID }}", "replicas": 2}`,
+			expectedValues: map[string]interface{}{
+				"clusterName": "abc123",
+				"dns":         []interface{}{"abc123.example.com"},
+				"pods":        "172.25.0.0/16",
+				"provider":    "hetzner-1.27",
+				"project":     "my-project",
+				"replicas":    float64(2),
+			},
+		},
+		{
+			name: "missing optional reference is ignored",
+			valuesFrom: []appskubermaticv1.ValuesReference{
+				{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "does-not-exist", Optional: true},
+				{Kind: appskubermaticv1.ValuesReferenceKindConfigMap, Name: "defaults", Optional: true},
+			},
+			expectedValues: map[string]interface{}{},
+		},
+		{
+			name: "missing reference fails",
+			valuesFrom: []appskubermaticv1.ValuesReference{
+				{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "does-not-exist"},
+			},
+			expectedError: "not found",
+		},
+		{
+			name: "missing key fails",
+			valuesFrom: []appskubermaticv1.ValuesReference{
+				{Kind: appskubermaticv1.ValuesReferenceKindConfigMap, Name: "defaults"},
+			},
+			expectedError: `has no key "values.yaml"`,
+		},
+		{
+			name: "seed objects that are not application secrets can not be referenced",
+			valuesFrom: []appskubermaticv1.ValuesReference{
+				{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "ca", Source: appskubermaticv1.ValuesReferenceSourceSeed},
+			},
+			expectedError: "is not annotated with " + appSecretTypeAnnotation,
+		},
+		{
+			name:          "unknown template field fails",
+			values:        `{"name": "{{ .Cluster.DoesNotExist }}"}`,
+			expectedError: "failed to render values",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appInstallation := &appskubermaticv1.ApplicationInstallation{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: appNamespace},
+				Spec: appskubermaticv1.ApplicationInstallationSpec{
+					Values:     runtime.RawExtension{Raw: []byte(tc.values)},
+					ValuesFrom: tc.valuesFrom,
+				},
+			}
+
+			resolver := valuesResolver{seedClient: seedClient, userClient: userClient, seedNamespace: seedNamespace, clusterName: cluster.Name}
+			values, err := resolver.resolve(context.Background(), appInstallation)
+
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("Expected error containing %q, but got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if diff := deep.Equal(tc.expectedValues, values); diff != nil {
+				t.Fatalf("Got unexpected values: %v", diff)
+			}
+		})
+	}
+}
@@ -70,20 +70,33 @@ type TemplateProvider interface {
 	Uninstall(applicationInstallation *appskubermaticv1.ApplicationInstallation) (util.StatusUpdater, error)
 }
 
+// DriftDetector is implemented by the TemplateProviders that can compare the installed objects with their desired state.
+type DriftDetector interface {
+
+	// DetectDrift returns the objects of the application whose live state differs from the desired one.
+	DetectDrift(applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]appskubermaticv1.DriftedObject, error)
+}
+
 // NewTemplateProvider return the concrete implementation of TemplateProvider according to the templateMethod.
 func NewTemplateProvider(
 	ctx context.Context,
 	log *zap.SugaredLogger,
 	seedClient ctrlruntimeclient.Client,
+	userClient ctrlruntimeclient.Client,
 	kubeconfig string,
 	cacheDir string,
 	appInstallation *appskubermaticv1.ApplicationInstallation,
 	secretNamespace string,
+	clusterName string,
 	caBundleFile string,
 ) (TemplateProvider, error) {
 	switch appInstallation.Status.Method {
 	case appskubermaticv1.HelmTemplateMethod:
-		return template.HelmTemplate{Ctx: ctx, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, SecretNamespace: secretNamespace, SeedClient: seedClient, CABundleFile: caBundleFile}, nil
+		return template.HelmTemplate{Ctx: ctx, Kubeconfig: kubeconfig, CacheDir: cacheDir, Log: log, SecretNamespace: secretNamespace, SeedClient: seedClient, CABundleFile: caBundleFile, UserClient: userClient, ClusterName: clusterName}, nil
+	case appskubermaticv1.KustomizeTemplateMethod:
+		return template.KustomizeTemplate{Ctx: ctx, Log: log, UserClient: userClient}, nil
+	case appskubermaticv1.ManifestsTemplateMethod:
+		return template.ManifestsTemplate{Ctx: ctx, Log: log, UserClient: userClient}, nil
 	default:
 		return nil, fmt.Errorf("template method '%v' not implemented", appInstallation.Status.Method)
 	}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const certificatePrefix = "kubermatic_cluster_certificate_"
+
+// CertificateCollector exports the expiry of the labelled certificate Secrets in the cluster namespaces.
+type CertificateCollector struct {
+	client ctrlruntimeclient.Reader
+
+	certificateExpiry *prometheus.Desc
+}
+
+func newCertificateCollector(client ctrlruntimeclient.Reader) *CertificateCollector {
+	return &CertificateCollector{
+		client: client,
+		certificateExpiry: prometheus.NewDesc(
+			certificatePrefix+"expiration_timestamp_seconds",
+			"Unix timestamp at which the certificate expires (the earliest one for bundles)",
+			[]string{"cluster", "component", "secret", "key"},
+			nil,
+		),
+	}
+}
+
+// MustRegisterCertificateCollector registers the certificate collector at the given prometheus registry.
+func MustRegisterCertificateCollector(registry prometheus.Registerer, client ctrlruntimeclient.Reader) {
+	registry.MustRegister(newCertificateCollector(client))
+}
+
+// Describe returns the metrics descriptors.
+func (cc CertificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cc.certificateExpiry
+}
+
+// Collect gets called by prometheus to collect the metrics.
+func (cc CertificateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := cc.client.List(ctx, clusters); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list clusters in CertificateCollector: %w", err))
+		return
+	}
+
+	clusterNames := map[string]string{}
+	for _, cluster := range clusters.Items {
+		if cluster.Status.NamespaceName != "" {
+			clusterNames[cluster.Status.NamespaceName] = cluster.Name
+		}
+	}
+
+	// only the labelled certificate Secrets are listed, as reading all Secrets of a seed on
+	// every scrape would put needless load on the API server
+	secrets := &corev1.SecretList{}
+	if err := cc.client.List(ctx, secrets, ctrlruntimeclient.HasLabels{resources.CertificateComponentLabelKey}); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list secrets in CertificateCollector: %w", err))
+		return
+	}
+
+	for _, secret := range secrets.Items {
+		clusterName, ok := clusterNames[secret.Namespace]
+		if !ok {
+			continue
+		}
+
+		cc.collectSecret(ch, clusterName, &secret)
+	}
+}
+
+func (cc *CertificateCollector) collectSecret(ch chan<- prometheus.Metric, clusterName string, secret *corev1.Secret) {
+	component := secret.Labels[resources.CertificateComponentLabelKey]
+
+	for key, data := range secret.Data {
+		certs := parseSecretCertificates(key, data)
+		if len(certs) == 0 {
+			continue
+		}
+
+		notAfter := certs[0].NotAfter
+		for _, cert := range certs[1:] {
+			if cert.NotAfter.Before(notAfter) {
+				notAfter = cert.NotAfter
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			cc.certificateExpiry,
+			prometheus.GaugeValue,
+			float64(notAfter.Unix()),
+			clusterName,
+			component,
+			secret.Name,
+			key,
+		)
+	}
+}
+
+// parseSecretCertificates returns the certificates in a Secret value, which can either be
+// PEM-encoded certificates or a kubeconfig with client certificates. Values that contain
+// neither are ignored.
+func parseSecretCertificates(key string, data []byte) []*x509.Certificate {
+	switch {
+	case strings.HasSuffix(key, ".crt"):
+		certs, err := certutil.ParseCertsPEM(data)
+		if err != nil {
+			return nil
+		}
+		return certs
+
+	case key == resources.KubeconfigSecretKey:
+		kubeconfig, err := clientcmd.Load(data)
+		if err != nil {
+			return nil
+		}
+
+		var certs []*x509.Certificate
+		for _, authInfo := range kubeconfig.AuthInfos {
+			if len(authInfo.ClientCertificateData) == 0 {
+				continue
+			}
+			if parsed, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData); err == nil {
+				certs = append(certs, parsed...)
+			}
+		}
+		return certs
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCertificateExpiryMetric(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+
+	etcdKP, err := triple.NewClientKeyPair(ca, "etcd", nil)
+	if err != nil {
+		t.Fatalf("failed to create key pair: %v", err)
+	}
+
+	kubeconfig, err := resources.BuildNewKubeconfigAsByte(ca, triple.EncodeCertPEM(ca.Cert), "https://apiserver", "scheduler", nil, "cluster1")
+	if err != nil {
+		t.Fatalf("failed to create kubeconfig: %v", err)
+	}
+
+	kubeconfigCerts := parseSecretCertificates(resources.KubeconfigSecretKey, kubeconfig)
+	if len(kubeconfigCerts) != 1 {
+		t.Fatalf("expected one client certificate in kubeconfig, got %d", len(kubeconfigCerts))
+	}
+
+	kubermaticFakeClient := fake.
+		NewClientBuilder().
+		WithObjects(
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+				Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-cluster1"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "cluster-cluster1",
+					Name:      resources.EtcdTLSCertificateSecretName,
+					Labels:    map[string]string{resources.CertificateComponentLabelKey: "etcd"},
+				},
+				Data: map[string][]byte{
+					"etcd-tls.crt": triple.EncodeCertPEM(etcdKP.Cert),
+					"etcd-tls.key": triple.EncodePrivateKeyPEM(etcdKP.Key),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "cluster-cluster1",
+					Name:      resources.SchedulerKubeconfigSecretName,
+					Labels:    map[string]string{resources.CertificateComponentLabelKey: "scheduler"},
+				},
+				Data: map[string][]byte{resources.KubeconfigSecretKey: kubeconfig},
+			},
+			// Secrets without the certificate component label are not collected
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-cluster1", Name: "custom"},
+				Data: map[string][]byte{
+					resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+					"password":                []byte("not a certificate"),
+				},
+			},
+		).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(newCertificateCollector(kubermaticFakeClient)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf(`
+# HELP kubermatic_cluster_certificate_expiration_timestamp_seconds Unix timestamp at which the certificate expires (the earliest one for bundles)
+# TYPE kubermatic_cluster_certificate_expiration_timestamp_seconds gauge
+kubermatic_cluster_certificate_expiration_timestamp_seconds{cluster="cluster1",component="etcd",key="etcd-tls.crt",secret="etcd-tls-certificate"} %[1]g
+kubermatic_cluster_certificate_expiration_timestamp_seconds{cluster="cluster1",component="scheduler",key="kubeconfig",secret="scheduler-kubeconfig"} %[2]g
+`, float64(etcdKP.Cert.NotAfter.Unix()), float64(kubeconfigCerts[0].NotAfter.Unix()))
+
+	if err := testutil.CollectAndCompare(registry, strings.NewReader(expected), "kubermatic_cluster_certificate_expiration_timestamp_seconds"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -18,19 +18,20 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
-	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
-	"k8c.io/kubermatic/v2/pkg/util/s3"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,10 +43,19 @@ type clusterBackupCollector struct {
 	ObjectLastModifiedDate *prometheus.Desc
 	EmptyObjectCount       *prometheus.Desc
 	QuerySuccess           *prometheus.Desc
+	VerificationSuccess    *prometheus.Desc
+	VerificationTime       *prometheus.Desc
+	VerifiedRevision       *prometheus.Desc
+	VerifiedKeyCount       *prometheus.Desc
+	VerificationInfo       *prometheus.Desc
+	VerificationFailures   *prometheus.Desc
 	client                 ctrlruntimeclient.Reader
 	logger                 *zap.SugaredLogger
 	caBundle               *certificates.CABundle
 	seedGetter             provider.SeedGetter
+	// filesystemRoot is the directory in which the filesystem destinations are mounted,
+	// one subdirectory per destination.
+	filesystemRoot string
 }
 
 // MustRegisterClusterBackupCollector registers the cluster backup collector.
@@ -56,11 +66,21 @@ func MustRegisterClusterBackupCollector(
 	caBundle *certificates.CABundle,
 	seedGetter provider.SeedGetter,
 ) {
-	collector := clusterBackupCollector{}
+	registry.MustRegister(newClusterBackupCollector(client, logger, caBundle, seedGetter))
+}
+
+func newClusterBackupCollector(
+	client ctrlruntimeclient.Reader,
+	logger *zap.SugaredLogger,
+	caBundle *certificates.CABundle,
+	seedGetter provider.SeedGetter,
+) *clusterBackupCollector {
+	collector := &clusterBackupCollector{}
 	collector.client = client
 	collector.logger = logger
 	collector.caBundle = caBundle
 	collector.seedGetter = seedGetter
+	collector.filesystemRoot = backend.FilesystemMountPath
 
 	collector.ObjectCount = prometheus.NewDesc(
 		"kubermatic_etcdbackup_object_count",
@@ -76,10 +96,34 @@ func MustRegisterClusterBackupCollector(
 		[]string{"destination", "cluster"}, nil)
 	collector.QuerySuccess = prometheus.NewDesc(
 		"kubermatic_etcdbackup_query_success",
-		"Whether querying the S3 was successful",
+		"Whether querying the backup destination was successful",
 		[]string{"destination"}, nil)
-
-	registry.MustRegister(&collector)
+	collector.VerificationSuccess = prometheus.NewDesc(
+		"kubermatic_etcdbackup_last_verification_success",
+		"Whether the most recently verified backup of a backup config is valid",
+		[]string{"destination", "cluster", "backup_config"}, nil)
+	collector.VerificationTime = prometheus.NewDesc(
+		"kubermatic_etcdbackup_last_verification_time_seconds",
+		"Time at which the most recently verified backup of a backup config was verified",
+		[]string{"destination", "cluster", "backup_config"}, nil)
+	collector.VerifiedRevision = prometheus.NewDesc(
+		"kubermatic_etcdbackup_last_verified_revision",
+		"The etcd revision contained in the most recently verified backup of a backup config",
+		[]string{"destination", "cluster", "backup_config"}, nil)
+	collector.VerifiedKeyCount = prometheus.NewDesc(
+		"kubermatic_etcdbackup_last_verified_key_count",
+		"The number of keys found by the restore drill of the most recently verified backup of a backup config",
+		[]string{"destination", "cluster", "backup_config"}, nil)
+	collector.VerificationInfo = prometheus.NewDesc(
+		"kubermatic_etcdbackup_last_verification_info",
+		"Additional information about the most recently verified backup of a backup config",
+		[]string{"destination", "cluster", "backup_config", "backup", "checksum"}, nil)
+	collector.VerificationFailures = prometheus.NewDesc(
+		"kubermatic_etcdbackup_verification_failed_count",
+		"The amount of currently tracked backups of a backup config that failed verification",
+		[]string{"destination", "cluster", "backup_config"}, nil)
+
+	return collector
 }
 
 func (c *clusterBackupCollector) Describe(ch chan<- *prometheus.Desc) {
@@ -87,6 +131,12 @@ func (c *clusterBackupCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.ObjectLastModifiedDate
 	ch <- c.EmptyObjectCount
 	ch <- c.QuerySuccess
+	ch <- c.VerificationSuccess
+	ch <- c.VerificationTime
+	ch <- c.VerifiedRevision
+	ch <- c.VerifiedKeyCount
+	ch <- c.VerificationInfo
+	ch <- c.VerificationFailures
 }
 
 func (c *clusterBackupCollector) Collect(ch chan<- prometheus.Metric) {
@@ -130,26 +180,27 @@ func (c *clusterBackupCollector) collect(ctx context.Context, ch chan<- promethe
 		ch <- prometheus.MustNewConstMetric(c.QuerySuccess, prometheus.GaugeValue, success, destName)
 	}
 
+	backupConfigList := &kubermaticv1.EtcdBackupConfigList{}
+	if err := c.client.List(ctx, backupConfigList); err != nil {
+		return fmt.Errorf("failed to list backup configs: %w", err)
+	}
+
+	for _, backupConfig := range backupConfigList.Items {
+		c.setVerificationMetrics(ch, backupConfig)
+	}
+
 	return nil
 }
 
 func (c *clusterBackupCollector) collectDestination(ctx context.Context, ch chan<- prometheus.Metric, clusters []kubermaticv1.Cluster, destName string, destination *kubermaticv1.BackupDestination) error {
-	listOpts := minio.ListObjectsOptions{
-		Recursive: true,
-	}
-
-	s3Client, err := c.getS3Client(ctx, destination)
+	b, err := c.getBackend(ctx, destName, destination)
 	if err != nil {
-		return fmt.Errorf("failed to create S3 client: %w", err)
+		return fmt.Errorf("failed to create %s backend: %w", destination.GetType(), err)
 	}
 
-	var objects []minio.ObjectInfo
-	for listerObject := range s3Client.ListObjects(ctx, destination.BucketName, listOpts) {
-		if listerObject.Err != nil {
-			return fmt.Errorf("failed to list objects in bucket: %w", listerObject.Err)
-		}
-
-		objects = append(objects, listerObject)
+	objects, err := b.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list objects in bucket: %w", err)
 	}
 
 	for _, cluster := range clusters {
@@ -159,10 +210,10 @@ func (c *clusterBackupCollector) collectDestination(ctx context.Context, ch chan
 	return nil
 }
 
-func (c *clusterBackupCollector) setMetricsForCluster(ch chan<- prometheus.Metric, destination *kubermaticv1.BackupDestination, allObjects []minio.ObjectInfo, destName string, clusterName string) {
-	var clusterObjects []minio.ObjectInfo
+func (c *clusterBackupCollector) setMetricsForCluster(ch chan<- prometheus.Metric, destination *kubermaticv1.BackupDestination, allObjects []backend.Object, destName string, clusterName string) {
+	var clusterObjects []backend.Object
 	for _, object := range allObjects {
-		if strings.HasPrefix(object.Key, fmt.Sprintf("%s-", clusterName)) {
+		if strings.HasPrefix(object.Name, fmt.Sprintf("%s-", clusterName)) {
 			clusterObjects = append(clusterObjects, object)
 		}
 	}
@@ -179,31 +230,84 @@ func (c *clusterBackupCollector) setMetricsForCluster(ch chan<- prometheus.Metri
 	ch <- prometheus.MustNewConstMetric(c.EmptyObjectCount, prometheus.GaugeValue, float64(getEmptyObjectCount(clusterObjects)), labelValues...)
 }
 
-func (c *clusterBackupCollector) getS3Client(ctx context.Context, destination *kubermaticv1.BackupDestination) (*minio.Client, error) {
-	if destination.Credentials == nil {
-		return nil, fmt.Errorf("credentials not set for backup destination %q", destination)
+func (c *clusterBackupCollector) setVerificationMetrics(ch chan<- prometheus.Metric, backupConfig kubermaticv1.EtcdBackupConfig) {
+	var (
+		lastVerified *kubermaticv1.BackupStatus
+		failures     int
+	)
+
+	for i, backup := range backupConfig.Status.CurrentBackups {
+		switch backup.VerifyPhase {
+		case kubermaticv1.BackupStatusPhaseFailed:
+			failures++
+		case kubermaticv1.BackupStatusPhaseCompleted:
+		default:
+			continue
+		}
+
+		if lastVerified == nil || backup.VerifyFinishedTime.After(lastVerified.VerifyFinishedTime.Time) {
+			lastVerified = &backupConfig.Status.CurrentBackups[i]
+		}
+	}
+
+	// verification is not enabled or no backup has been verified yet
+	if lastVerified == nil {
+		return
 	}
 
-	key := types.NamespacedName{
-		Name:      destination.Credentials.Name,
-		Namespace: destination.Credentials.Namespace,
+	labelValues := []string{backupConfig.Spec.Destination, backupConfig.Spec.Cluster.Name, backupConfig.Name}
+
+	success := float64(0)
+	if lastVerified.VerifyPhase == kubermaticv1.BackupStatusPhaseCompleted {
+		success = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.VerificationSuccess, prometheus.GaugeValue, success, labelValues...)
+	ch <- prometheus.MustNewConstMetric(c.VerificationTime, prometheus.GaugeValue, float64(lastVerified.VerifyFinishedTime.Unix()), labelValues...)
+	ch <- prometheus.MustNewConstMetric(c.VerificationFailures, prometheus.GaugeValue, float64(failures), labelValues...)
+
+	if success == 1 {
+		ch <- prometheus.MustNewConstMetric(c.VerifiedRevision, prometheus.GaugeValue, float64(lastVerified.Revision), labelValues...)
+		if backupConfig.Spec.Verification != nil && backupConfig.Spec.Verification.RestoreDrill {
+			ch <- prometheus.MustNewConstMetric(c.VerifiedKeyCount, prometheus.GaugeValue, float64(lastVerified.KeyCount), labelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.VerificationInfo, prometheus.GaugeValue, 1, append(labelValues, lastVerified.BackupName, lastVerified.Checksum)...)
 	}
+}
+
+func (c *clusterBackupCollector) getBackend(ctx context.Context, destName string, destination *kubermaticv1.BackupDestination) (backend.Backend, error) {
+	var credentials map[string][]byte
+
+	if destination.Credentials != nil {
+		key := types.NamespacedName{
+			Name:      destination.Credentials.Name,
+			Namespace: destination.Credentials.Namespace,
+		}
 
-	creds := &corev1.Secret{}
-	if err := c.client.Get(ctx, key, creds); err != nil {
-		return nil, fmt.Errorf("failed to retrieve credentials secret: %w", err)
+		creds := &corev1.Secret{}
+		if err := c.client.Get(ctx, key, creds); err != nil {
+			return nil, fmt.Errorf("failed to retrieve credentials secret: %w", err)
+		}
+		credentials = creds.Data
+	} else if destination.GetType() != kubermaticv1.BackupDestinationTypeFilesystem {
+		return nil, errors.New("credentials not set for backup destination")
 	}
 
-	accessKey := string(creds.Data[etcdbackup.AccessKeyIdEnvVarKey])
-	secretKey := string(creds.Data[etcdbackup.SecretAccessKeyEnvVarKey])
-	if accessKey == "" || secretKey == "" {
-		return nil, fmt.Errorf("backup credentials do not contain %q or %q keys", etcdbackup.AccessKeyIdEnvVarKey, etcdbackup.SecretAccessKeyEnvVarKey)
+	config := backend.NewConfig(destination, credentials, c.caBundle.CertPool())
+
+	if destination.GetType() == kubermaticv1.BackupDestinationTypeFilesystem {
+		// the operator mounts every filesystem destination into its own subdirectory
+		// once its volume is available; a missing mount would look like an empty destination
+		config.Path = filepath.Join(c.filesystemRoot, destName)
+		if _, err := os.Stat(config.Path); err != nil {
+			return nil, fmt.Errorf("filesystem destination is not mounted: %w", err)
+		}
 	}
 
-	return s3.NewClient(destination.Endpoint, accessKey, secretKey, c.caBundle.CertPool())
+	return backend.New(ctx, config)
 }
 
-func getLastModifiedTimestamp(objects []minio.ObjectInfo) (lastmodifiedTimestamp time.Time) {
+func getLastModifiedTimestamp(objects []backend.Object) (lastmodifiedTimestamp time.Time) {
 	for _, object := range objects {
 		if object.LastModified.After(lastmodifiedTimestamp) {
 			lastmodifiedTimestamp = object.LastModified
@@ -213,7 +317,7 @@ func getLastModifiedTimestamp(objects []minio.ObjectInfo) (lastmodifiedTimestamp
 	return lastmodifiedTimestamp
 }
 
-func getEmptyObjectCount(objects []minio.ObjectInfo) (emptyObjects int) {
+func getEmptyObjectCount(objects []backend.Object) (emptyObjects int) {
 	for _, object := range objects {
 		if object.Size == 0 {
 			emptyObjects++
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterBackupFilesystemDestinationMetrics(t *testing.T) {
+	root := t.TempDir()
+	lastModified := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// only the "local" destination is mounted
+	files := map[string]string{
+		"local/cluster1-2023-06-01T10:00:00": "backup",
+		"local/cluster1-2023-06-01T12:00:00": "",
+		"local/cluster2-2023-06-01T10:00:00": "backup",
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(filename, lastModified, lastModified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seed := &kubermaticv1.Seed{
+		Spec: kubermaticv1.SeedSpec{
+			EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+				Destinations: map[string]*kubermaticv1.BackupDestination{
+					"local": {
+						Type:       kubermaticv1.BackupDestinationTypeFilesystem,
+						Filesystem: &kubermaticv1.BackupDestinationFilesystem{PersistentVolumeClaim: "local-backups"},
+					},
+					"unmounted": {
+						Type:       kubermaticv1.BackupDestinationTypeFilesystem,
+						Filesystem: &kubermaticv1.BackupDestinationFilesystem{PersistentVolumeClaim: "other-backups"},
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.
+		NewClientBuilder().
+		WithObjects(&kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster1",
+			},
+		}).
+		Build()
+
+	collector := newClusterBackupCollector(client, kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(), certificates.NewFakeCABundle(), func() (*kubermaticv1.Seed, error) {
+		return seed, nil
+	})
+	collector.filesystemRoot = root
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP kubermatic_etcdbackup_empty_object_count The amount of empty objects (size=0) partitioned by backup destination and cluster
+# TYPE kubermatic_etcdbackup_empty_object_count gauge
+kubermatic_etcdbackup_empty_object_count{cluster="cluster1",destination="local"} 1
+# HELP kubermatic_etcdbackup_object_count The amount of objects partitioned by backup destination and cluster
+# TYPE kubermatic_etcdbackup_object_count gauge
+kubermatic_etcdbackup_object_count{cluster="cluster1",destination="local"} 2
+# HELP kubermatic_etcdbackup_object_last_modified_time_seconds Modification time of the last modified object
+# TYPE kubermatic_etcdbackup_object_last_modified_time_seconds gauge
+kubermatic_etcdbackup_object_last_modified_time_seconds{cluster="cluster1",destination="local"} 1.6856208e+09
+# HELP kubermatic_etcdbackup_query_success Whether querying the backup destination was successful
+# TYPE kubermatic_etcdbackup_query_success gauge
+kubermatic_etcdbackup_query_success{destination="local"} 1
+kubermatic_etcdbackup_query_success{destination="unmounted"} 0
+`
+
+	metrics := []string{
+		"kubermatic_etcdbackup_empty_object_count",
+		"kubermatic_etcdbackup_object_count",
+		"kubermatic_etcdbackup_object_last_modified_time_seconds",
+		"kubermatic_etcdbackup_query_success",
+	}
+
+	if err := testutil.CollectAndCompare(registry, strings.NewReader(expected), metrics...); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -22,5 +22,9 @@ Package kubeletdnatcontroller contains the kubeletdnat controller which:
   - Must be used in conjunction with the openvpn client
   - Creates NAT rules for both the public and private node IP that tunnels access to them via the VPN
   - Its counterpart runs within the openvpn client pod in the usercluster, is part of the openvpn addon and written in bash
+
+Deprecated: the kubeletdnat controller is only used by clusters running OpenVPN. Konnectivity is the
+default for all expose strategies and existing clusters should be migrated to it, after which
+neither OpenVPN nor this controller are deployed anymore.
 */
 package kubeletdnatcontroller
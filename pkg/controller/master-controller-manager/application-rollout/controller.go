@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationrollout
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ControllerName is the name of this very controller.
+	ControllerName = "kkp-application-rollout-controller"
+
+	// progressInterval is how often a running rollout is checked, as the installations in the
+	// user clusters cannot be watched from the master cluster.
+	progressInterval = 30 * time.Second
+)
+
+// userClusterClientGetter returns a client for the given user cluster hosted on the given seed.
+type userClusterClientGetter func(ctx context.Context, seed *kubermaticv1.Seed, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
+
+// Reconciler upgrades ApplicationInstallations across user clusters.
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log                     *zap.SugaredLogger
+	recorder                record.EventRecorder
+	seedsGetter             provider.SeedsGetter
+	seedClientGetter        provider.SeedClientGetter
+	userClusterClientGetter userClusterClientGetter
+}
+
+// Add creates a new application rollout controller and sets up watches.
+func Add(
+	mgr manager.Manager,
+	numWorkers int,
+	log *zap.SugaredLogger,
+	seedsGetter provider.SeedsGetter,
+	seedKubeconfigGetter provider.SeedKubeconfigGetter,
+) error {
+	reconciler := &Reconciler{
+		Client:                  mgr.GetClient(),
+		log:                     log.Named(ControllerName),
+		recorder:                mgr.GetEventRecorderFor(ControllerName),
+		seedsGetter:             seedsGetter,
+		seedClientGetter:        kubernetesprovider.SeedClientGetterFactory(seedKubeconfigGetter),
+		userClusterClientGetter: externalUserClusterClientGetter(),
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), &appskubermaticv1.ApplicationRollout{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	return nil
+}
+
+// externalUserClusterClientGetter connects to the user clusters using their external address,
+// as the master cluster is not part of the seeds' networks. The connection providers are kept
+// per seed to reuse their REST mappings.
+func externalUserClusterClientGetter() userClusterClientGetter {
+	var (
+		lock      sync.Mutex
+		providers = map[string]*clusterclient.Provider{}
+	)
+
+	return func(ctx context.Context, seed *kubermaticv1.Seed, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error) {
+		lock.Lock()
+		clientProvider, ok := providers[seed.Name]
+		if !ok {
+			var err error
+			if clientProvider, err = clusterclient.NewExternal(seedClient); err != nil {
+				lock.Unlock()
+				return nil, err
+			}
+			providers[seed.Name] = clientProvider
+		}
+		lock.Unlock()
+
+		return clientProvider.GetClient(ctx, cluster)
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("rollout", request.Name)
+	log.Debug("Reconciling")
+
+	rollout := &appskubermaticv1.ApplicationRollout{}
+	if err := r.Get(ctx, request.NamespacedName, rollout); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get rollout: %w", err)
+	}
+
+	if rollout.DeletionTimestamp != nil || isFinished(rollout) {
+		return reconcile.Result{}, nil
+	}
+
+	// the status is patched even if the reconciliation failed, so that the progress made
+	// in the user clusters is not lost
+	oldRollout := rollout.DeepCopy()
+	reconcileErr := r.reconcile(ctx, log, rollout)
+
+	if err := r.Status().Patch(ctx, rollout, ctrlruntimeclient.MergeFrom(oldRollout)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	if reconcileErr != nil {
+		log.Errorw("Reconciling failed", zap.Error(reconcileErr))
+		r.recorder.Event(rollout, corev1.EventTypeWarning, "ReconcilingError", reconcileErr.Error())
+
+		return reconcile.Result{}, reconcileErr
+	}
+
+	if isFinished(rollout) {
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: progressInterval}, nil
+}
+
+// isFinished returns true if the rollout does not need any further reconciliation.
+func isFinished(rollout *appskubermaticv1.ApplicationRollout) bool {
+	switch rollout.Status.Phase {
+	case appskubermaticv1.ApplicationRolloutPhaseCompleted, appskubermaticv1.ApplicationRolloutPhaseRolledBack:
+		return true
+	case appskubermaticv1.ApplicationRolloutPhaseHalted:
+		return !rollout.Spec.Strategy.AutoRollback
+	default:
+		return false
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package applicationrollout contains a controller that upgrades the ApplicationInstallations
+of an application across the user clusters of all seeds, as requested by ApplicationRollout
+objects:
+
+  - when a rollout starts, the installations to upgrade are selected by cluster label and project,
+  - the installations are upgraded in waves, whose size is limited by the max-unavailable budget,
+  - a wave only starts once every installation of the previous wave is ready at the new version,
+  - when more upgrades fail than tolerated, the rollout is halted and, if requested, the
+    upgraded installations are reverted to their previous version.
+
+The progress of every installation is reflected in the status of the ApplicationRollout.
+*/
+package applicationrollout
@@ -0,0 +1,512 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationrollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultHealthTimeout is how long an upgraded installation may take to become ready if no
+// timeout is configured.
+const defaultHealthTimeout = 10 * time.Minute
+
+// reconcile advances the rollout by one step. It only modifies the status of the rollout in
+// memory, persisting it is up to the caller.
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout) error {
+	switch rollout.Status.Phase {
+	case "":
+		return r.start(ctx, log, rollout)
+	case appskubermaticv1.ApplicationRolloutPhaseProgressing:
+		return r.progress(ctx, log, rollout)
+	case appskubermaticv1.ApplicationRolloutPhaseHalted:
+		return r.rollback(ctx, log, rollout)
+	default:
+		return nil
+	}
+}
+
+// start selects the installations to upgrade.
+func (r *Reconciler) start(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout) error {
+	appRef := rollout.Spec.ApplicationRef
+
+	appDef := &appskubermaticv1.ApplicationDefinition{}
+	if err := r.Get(ctx, types.NamespacedName{Name: appRef.Name}, appDef); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.halt(rollout, fmt.Sprintf("ApplicationDefinition %q does not exist", appRef.Name))
+			return nil
+		}
+		return fmt.Errorf("failed to get ApplicationDefinition: %w", err)
+	}
+
+	if !hasVersion(appDef, appRef.Version) {
+		r.halt(rollout, fmt.Sprintf("ApplicationDefinition %q has no version %q", appRef.Name, appRef.Version))
+		return nil
+	}
+
+	selector := labels.Everything()
+	if rollout.Spec.ClusterSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(rollout.Spec.ClusterSelector); err != nil {
+			r.halt(rollout, fmt.Sprintf("invalid cluster selector: %v", err))
+			return nil
+		}
+	}
+
+	targets, err := r.selectTargets(ctx, log, rollout, selector)
+	if err != nil {
+		return err
+	}
+
+	log.Infow("Starting rollout", "installations", len(targets))
+
+	rollout.Status.Phase = appskubermaticv1.ApplicationRolloutPhaseProgressing
+	rollout.Status.StartTime = ptr.To(metav1.Now())
+	rollout.Status.Targets = targets
+
+	return r.progress(ctx, log, rollout)
+}
+
+// selectTargets returns the installations of the application that are not at the desired
+// version yet, in all selected clusters of all seeds.
+func (r *Reconciler) selectTargets(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout, selector labels.Selector) ([]appskubermaticv1.ApplicationRolloutTarget, error) {
+	seeds, err := r.seedsGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seeds: %w", err)
+	}
+
+	seedNames := make([]string, 0, len(seeds))
+	for name := range seeds {
+		seedNames = append(seedNames, name)
+	}
+	sort.Strings(seedNames)
+
+	targets := []appskubermaticv1.ApplicationRolloutTarget{}
+	skipped := 0
+
+	for _, seedName := range seedNames {
+		seed := seeds[seedName]
+
+		seedClient, err := r.seedClientGetter(seed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for seed %q: %w", seedName, err)
+		}
+
+		clusters := &kubermaticv1.ClusterList{}
+		if err := seedClient.List(ctx, clusters, ctrlruntimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list clusters on seed %q: %w", seedName, err)
+		}
+
+		sort.Slice(clusters.Items, func(i, j int) bool {
+			return clusters.Items[i].Name < clusters.Items[j].Name
+		})
+
+		for i := range clusters.Items {
+			cluster := &clusters.Items[i]
+
+			if !isSelectedProject(rollout, cluster) || cluster.DeletionTimestamp != nil || cluster.Spec.Pause {
+				continue
+			}
+
+			// installations in clusters that cannot be reached cannot be upgraded either
+			if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+				log.Debugw("Skipping cluster with unavailable API server", "seed", seedName, "cluster", cluster.Name)
+				skipped++
+				continue
+			}
+
+			userClient, err := r.userClusterClientGetter(ctx, seed, seedClient, cluster)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create client for cluster %q: %w", cluster.Name, err)
+			}
+
+			installations := &appskubermaticv1.ApplicationInstallationList{}
+			if err := userClient.List(ctx, installations); err != nil {
+				return nil, fmt.Errorf("failed to list ApplicationInstallations in cluster %q: %w", cluster.Name, err)
+			}
+
+			for _, installation := range installations.Items {
+				appRef := installation.Spec.ApplicationRef
+				if appRef.Name != rollout.Spec.ApplicationRef.Name || appRef.Version == rollout.Spec.ApplicationRef.Version || installation.DeletionTimestamp != nil {
+					continue
+				}
+
+				targets = append(targets, appskubermaticv1.ApplicationRolloutTarget{
+					Seed:               seedName,
+					Cluster:            cluster.Name,
+					Namespace:          installation.Namespace,
+					Name:               installation.Name,
+					PreviousVersion:    appRef.Version,
+					Phase:              appskubermaticv1.ApplicationRolloutTargetPhasePending,
+					LastTransitionTime: metav1.Now(),
+				})
+			}
+		}
+	}
+
+	if skipped > 0 {
+		r.recorder.Eventf(rollout, corev1.EventTypeWarning, "ClustersSkipped", "%d cluster(s) have been skipped because their API server is not available", skipped)
+	}
+
+	return targets, nil
+}
+
+// progress checks the health of the current wave, and starts the next one once the current
+// wave is healthy.
+func (r *Reconciler) progress(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout) error {
+	clients := newClientCache(r)
+
+	for i := range rollout.Status.Targets {
+		target := &rollout.Status.Targets[i]
+		if target.Phase != appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading {
+			continue
+		}
+
+		if err := r.checkHealth(ctx, clients, rollout, target); err != nil {
+			return err
+		}
+
+		if target.Phase == appskubermaticv1.ApplicationRolloutTargetPhaseFailed {
+			log.Warnw("Upgrade failed", "seed", target.Seed, "cluster", target.Cluster, "installation", target.Namespace+"/"+target.Name, "reason", target.Message)
+		}
+	}
+
+	if failed := countTargets(rollout, appskubermaticv1.ApplicationRolloutTargetPhaseFailed); failed > rollout.Spec.Strategy.FailureThreshold {
+		r.halt(rollout, fmt.Sprintf("%d upgrade(s) failed, exceeding the failure threshold of %d", failed, rollout.Spec.Strategy.FailureThreshold))
+		return r.rollback(ctx, log, rollout)
+	}
+
+	if countTargets(rollout, appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading) > 0 {
+		return nil
+	}
+
+	wave := nextWave(rollout)
+	if len(wave) == 0 {
+		log.Info("Rollout completed")
+		r.recorder.Eventf(rollout, corev1.EventTypeNormal, "RolloutCompleted", "%d installation(s) have been upgraded to version %s", len(rollout.Status.Targets), rollout.Spec.ApplicationRef.Version)
+
+		rollout.Status.Phase = appskubermaticv1.ApplicationRolloutPhaseCompleted
+		rollout.Status.CompletionTime = ptr.To(metav1.Now())
+		return nil
+	}
+
+	rollout.Status.CurrentWave++
+	log.Infow("Starting wave", "wave", rollout.Status.CurrentWave, "installations", len(wave))
+
+	for _, target := range wave {
+		if err := r.upgrade(ctx, clients, rollout, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkHealth updates the phase of an upgrading target according to its installation.
+func (r *Reconciler) checkHealth(ctx context.Context, clients *clientCache, rollout *appskubermaticv1.ApplicationRollout, target *appskubermaticv1.ApplicationRolloutTarget) error {
+	userClient, err := clients.get(ctx, target)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseFailed, "cluster has been deleted")
+			return nil
+		}
+		return err
+	}
+
+	installation := &appskubermaticv1.ApplicationInstallation{}
+	if err := userClient.Get(ctx, types.NamespacedName{Namespace: target.Namespace, Name: target.Name}, installation); err != nil {
+		if apierrors.IsNotFound(err) {
+			setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseFailed, "ApplicationInstallation has been deleted")
+			return nil
+		}
+		return fmt.Errorf("failed to get ApplicationInstallation in cluster %q: %w", target.Cluster, err)
+	}
+
+	version := rollout.Spec.ApplicationRef.Version
+	if installation.Spec.ApplicationRef.Version != version {
+		setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseFailed, fmt.Sprintf("version has been changed to %q outside of the rollout", installation.Spec.ApplicationRef.Version))
+		return nil
+	}
+
+	if isReadyAtVersion(installation, version) {
+		setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseHealthy, "")
+		return nil
+	}
+
+	timeout := rollout.Spec.Strategy.HealthTimeout.Duration
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+
+	if time.Since(target.LastTransitionTime.Time) > timeout {
+		message := fmt.Sprintf("not ready after %v", timeout)
+		if condition, ok := installation.Status.Conditions[appskubermaticv1.Ready]; ok && condition.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, condition.Message)
+		}
+		setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseFailed, message)
+	}
+
+	return nil
+}
+
+// upgrade sets the desired version on the installation of the target.
+func (r *Reconciler) upgrade(ctx context.Context, clients *clientCache, rollout *appskubermaticv1.ApplicationRollout, target *appskubermaticv1.ApplicationRolloutTarget) error {
+	target.Wave = rollout.Status.CurrentWave
+
+	found, err := r.setVersion(ctx, clients, target, rollout.Spec.ApplicationRef.Version)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseFailed, "ApplicationInstallation or cluster has been deleted")
+		return nil
+	}
+
+	setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading, "")
+	return nil
+}
+
+// rollback reverts all installations upgraded by the rollout to their previous version.
+func (r *Reconciler) rollback(ctx context.Context, log *zap.SugaredLogger, rollout *appskubermaticv1.ApplicationRollout) error {
+	if !rollout.Spec.Strategy.AutoRollback {
+		return nil
+	}
+
+	clients := newClientCache(r)
+
+	for i := range rollout.Status.Targets {
+		target := &rollout.Status.Targets[i]
+		if target.Phase == appskubermaticv1.ApplicationRolloutTargetPhasePending || target.Phase == appskubermaticv1.ApplicationRolloutTargetPhaseRolledBack {
+			continue
+		}
+
+		if _, err := r.setVersion(ctx, clients, target, target.PreviousVersion); err != nil {
+			return err
+		}
+
+		setTargetPhase(target, appskubermaticv1.ApplicationRolloutTargetPhaseRolledBack, target.Message)
+	}
+
+	log.Info("Rollout rolled back")
+	r.recorder.Eventf(rollout, corev1.EventTypeNormal, "RolloutRolledBack", "Upgraded installations have been reverted to their previous version")
+
+	rollout.Status.Phase = appskubermaticv1.ApplicationRolloutPhaseRolledBack
+	rollout.Status.CompletionTime = ptr.To(metav1.Now())
+
+	return nil
+}
+
+// setVersion updates the application version of the installation of the target. It returns
+// false if the installation or its cluster does not exist anymore.
+func (r *Reconciler) setVersion(ctx context.Context, clients *clientCache, target *appskubermaticv1.ApplicationRolloutTarget, version string) (bool, error) {
+	userClient, err := clients.get(ctx, target)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	installation := &appskubermaticv1.ApplicationInstallation{}
+	if err := userClient.Get(ctx, types.NamespacedName{Namespace: target.Namespace, Name: target.Name}, installation); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get ApplicationInstallation in cluster %q: %w", target.Cluster, err)
+	}
+
+	if installation.Spec.ApplicationRef.Version == version {
+		return true, nil
+	}
+
+	oldInstallation := installation.DeepCopy()
+	installation.Spec.ApplicationRef.Version = version
+
+	if err := userClient.Patch(ctx, installation, ctrlruntimeclient.MergeFrom(oldInstallation)); err != nil {
+		return false, fmt.Errorf("failed to update ApplicationInstallation in cluster %q: %w", target.Cluster, err)
+	}
+
+	return true, nil
+}
+
+// halt stops the rollout. Installations that have already been upgraded keep their new version
+// unless the rollout is configured to roll back.
+func (r *Reconciler) halt(rollout *appskubermaticv1.ApplicationRollout, message string) {
+	r.recorder.Event(rollout, corev1.EventTypeWarning, "RolloutHalted", message)
+
+	rollout.Status.Phase = appskubermaticv1.ApplicationRolloutPhaseHalted
+	rollout.Status.Message = message
+	rollout.Status.CompletionTime = ptr.To(metav1.Now())
+}
+
+// nextWave returns the pending targets to upgrade next.
+func nextWave(rollout *appskubermaticv1.ApplicationRollout) []*appskubermaticv1.ApplicationRolloutTarget {
+	size := waveSize(rollout)
+
+	var wave []*appskubermaticv1.ApplicationRolloutTarget
+	for i := range rollout.Status.Targets {
+		if len(wave) == size {
+			break
+		}
+
+		if target := &rollout.Status.Targets[i]; target.Phase == appskubermaticv1.ApplicationRolloutTargetPhasePending {
+			wave = append(wave, target)
+		}
+	}
+
+	return wave
+}
+
+// waveSize returns the number of installations upgraded at the same time.
+func waveSize(rollout *appskubermaticv1.ApplicationRollout) int {
+	maxUnavailable := rollout.Spec.Strategy.MaxUnavailable
+	if maxUnavailable == nil {
+		return 1
+	}
+
+	size, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, len(rollout.Status.Targets), false)
+	if err != nil || size < 1 {
+		return 1
+	}
+
+	return size
+}
+
+func countTargets(rollout *appskubermaticv1.ApplicationRollout, phase appskubermaticv1.ApplicationRolloutTargetPhase) int {
+	count := 0
+	for _, target := range rollout.Status.Targets {
+		if target.Phase == phase {
+			count++
+		}
+	}
+
+	return count
+}
+
+func setTargetPhase(target *appskubermaticv1.ApplicationRolloutTarget, phase appskubermaticv1.ApplicationRolloutTargetPhase, message string) {
+	if target.Phase != phase {
+		target.LastTransitionTime = metav1.Now()
+	}
+
+	target.Phase = phase
+	target.Message = message
+}
+
+// isReadyAtVersion returns true if the installation has been successfully installed at the
+// given version.
+func isReadyAtVersion(installation *appskubermaticv1.ApplicationInstallation, version string) bool {
+	condition := installation.Status.Conditions[appskubermaticv1.Ready]
+
+	return condition.Status == corev1.ConditionTrue &&
+		condition.ObservedGeneration == installation.Generation &&
+		installation.Status.ApplicationVersion != nil &&
+		installation.Status.ApplicationVersion.Version == version
+}
+
+func isSelectedProject(rollout *appskubermaticv1.ApplicationRollout, cluster *kubermaticv1.Cluster) bool {
+	if len(rollout.Spec.Projects) == 0 {
+		return true
+	}
+
+	projectID := cluster.Labels[kubermaticv1.ProjectIDLabelKey]
+	for _, project := range rollout.Spec.Projects {
+		if project == projectID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasVersion(appDef *appskubermaticv1.ApplicationDefinition, version string) bool {
+	for _, v := range appDef.Spec.Versions {
+		if v.Version == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clientCache creates the clients of the user clusters of the targets at most once per reconciliation.
+type clientCache struct {
+	reconciler *Reconciler
+	seeds      map[string]*kubermaticv1.Seed
+	clients    map[string]ctrlruntimeclient.Client
+}
+
+func newClientCache(r *Reconciler) *clientCache {
+	return &clientCache{
+		reconciler: r,
+		clients:    map[string]ctrlruntimeclient.Client{},
+	}
+}
+
+func (c *clientCache) get(ctx context.Context, target *appskubermaticv1.ApplicationRolloutTarget) (ctrlruntimeclient.Client, error) {
+	key := target.Seed + "/" + target.Cluster
+	if client, ok := c.clients[key]; ok {
+		return client, nil
+	}
+
+	if c.seeds == nil {
+		seeds, err := c.reconciler.seedsGetter()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seeds: %w", err)
+		}
+		c.seeds = seeds
+	}
+
+	seed, ok := c.seeds[target.Seed]
+	if !ok {
+		return nil, fmt.Errorf("seed %q does not exist anymore", target.Seed)
+	}
+
+	seedClient, err := c.reconciler.seedClientGetter(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for seed %q: %w", target.Seed, err)
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Name: target.Cluster}, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get cluster %q: %w", target.Cluster, err)
+	}
+
+	client, err := c.reconciler.userClusterClientGetter(ctx, seed, seedClient, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for cluster %q: %w", target.Cluster, err)
+	}
+
+	c.clients[key] = client
+	return client, nil
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationrollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	rolloutName   = "cert-manager-1.1.0"
+	appName       = "cert-manager"
+	oldVersion    = "1.0.0"
+	newVersion    = "1.1.0"
+	appNamespace  = "cert-manager"
+	installedName = "cert-manager"
+)
+
+type testEnv struct {
+	reconciler  *Reconciler
+	userClients map[string]ctrlruntimeclient.Client
+}
+
+func newTestEnv(t *testing.T, strategy appskubermaticv1.ApplicationRolloutStrategy, projects []string) *testEnv {
+	t.Helper()
+
+	seed := &kubermaticv1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "europe", Namespace: "kubermatic"}}
+
+	seedClient := fake.NewClientBuilder().WithObjects(
+		genCluster("aaa", "project-a", kubermaticv1.HealthStatusUp),
+		genCluster("bbb", "project-a", kubermaticv1.HealthStatusUp),
+		genCluster("ccc", "project-b", kubermaticv1.HealthStatusUp),
+		genCluster("ddd", "project-a", kubermaticv1.HealthStatusDown),
+	).Build()
+
+	userClients := map[string]ctrlruntimeclient.Client{
+		"aaa": fake.NewClientBuilder().WithObjects(genInstallation(oldVersion)).Build(),
+		"bbb": fake.NewClientBuilder().WithObjects(genInstallation(oldVersion)).Build(),
+		"ccc": fake.NewClientBuilder().WithObjects(genInstallation(oldVersion)).Build(),
+		"ddd": fake.NewClientBuilder().WithObjects(genInstallation(oldVersion)).Build(),
+	}
+
+	masterClient := fake.NewClientBuilder().WithObjects(
+		&appskubermaticv1.ApplicationDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: appName},
+			Spec: appskubermaticv1.ApplicationDefinitionSpec{
+				Versions: []appskubermaticv1.ApplicationVersion{{Version: oldVersion}, {Version: newVersion}},
+			},
+		},
+		&appskubermaticv1.ApplicationRollout{
+			ObjectMeta: metav1.ObjectMeta{Name: rolloutName},
+			Spec: appskubermaticv1.ApplicationRolloutSpec{
+				ApplicationRef: appskubermaticv1.ApplicationRef{Name: appName, Version: newVersion},
+				Projects:       projects,
+				Strategy:       strategy,
+			},
+		},
+	).Build()
+
+	return &testEnv{
+		userClients: userClients,
+		reconciler: &Reconciler{
+			Client:   masterClient,
+			log:      kubermaticlog.Logger,
+			recorder: record.NewFakeRecorder(100),
+			seedsGetter: func() (map[string]*kubermaticv1.Seed, error) {
+				return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+			},
+			seedClientGetter: func(*kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
+				return seedClient, nil
+			},
+			userClusterClientGetter: func(_ context.Context, _ *kubermaticv1.Seed, _ ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error) {
+				return userClients[cluster.Name], nil
+			},
+		},
+	}
+}
+
+func (e *testEnv) reconcile(t *testing.T) *appskubermaticv1.ApplicationRollout {
+	t.Helper()
+
+	ctx := context.Background()
+	if _, err := e.reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: rolloutName}}); err != nil {
+		t.Fatalf("Reconciling failed: %v", err)
+	}
+
+	rollout := &appskubermaticv1.ApplicationRollout{}
+	if err := e.reconciler.Get(ctx, types.NamespacedName{Name: rolloutName}, rollout); err != nil {
+		t.Fatalf("Failed to get rollout: %v", err)
+	}
+
+	return rollout
+}
+
+// markReady simulates the application-installation-controller having installed the current version.
+func (e *testEnv) markReady(t *testing.T, cluster string) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := e.userClients[cluster]
+
+	installation := &appskubermaticv1.ApplicationInstallation{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: appNamespace, Name: installedName}, installation); err != nil {
+		t.Fatalf("Failed to get installation: %v", err)
+	}
+
+	installation.Status.ApplicationVersion = &appskubermaticv1.ApplicationVersion{Version: installation.Spec.ApplicationRef.Version}
+	installation.SetReadyCondition(nil, false)
+
+	if err := client.Status().Update(ctx, installation); err != nil {
+		t.Fatalf("Failed to update installation: %v", err)
+	}
+}
+
+func (e *testEnv) expectVersion(t *testing.T, cluster, version string) {
+	t.Helper()
+
+	installation := &appskubermaticv1.ApplicationInstallation{}
+	if err := e.userClients[cluster].Get(context.Background(), types.NamespacedName{Namespace: appNamespace, Name: installedName}, installation); err != nil {
+		t.Fatalf("Failed to get installation: %v", err)
+	}
+
+	if installation.Spec.ApplicationRef.Version != version {
+		t.Fatalf("Expected installation in cluster %s to reference version %s, but got %s", cluster, version, installation.Spec.ApplicationRef.Version)
+	}
+}
+
+func expectTargets(t *testing.T, rollout *appskubermaticv1.ApplicationRollout, expected map[string]appskubermaticv1.ApplicationRolloutTargetPhase) {
+	t.Helper()
+
+	if len(rollout.Status.Targets) != len(expected) {
+		t.Fatalf("Expected %d targets, but got %d: %+v", len(expected), len(rollout.Status.Targets), rollout.Status.Targets)
+	}
+
+	for _, target := range rollout.Status.Targets {
+		if phase, ok := expected[target.Cluster]; !ok || phase != target.Phase {
+			t.Fatalf("Expected target in cluster %s to be %q, but got %q", target.Cluster, phase, target.Phase)
+		}
+	}
+}
+
+func TestRolloutInWaves(t *testing.T) {
+	env := newTestEnv(t, appskubermaticv1.ApplicationRolloutStrategy{
+		MaxUnavailable: ptr.To(intstr.FromInt(2)),
+	}, nil)
+
+	// first wave: the unhealthy cluster ddd is skipped
+	rollout := env.reconcile(t)
+	if rollout.Status.Phase != appskubermaticv1.ApplicationRolloutPhaseProgressing || rollout.Status.CurrentWave != 1 {
+		t.Fatalf("Expected rollout to be in wave 1, but got %q in wave %d", rollout.Status.Phase, rollout.Status.CurrentWave)
+	}
+	expectTargets(t, rollout, map[string]appskubermaticv1.ApplicationRolloutTargetPhase{
+		"aaa": appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading,
+		"bbb": appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading,
+		"ccc": appskubermaticv1.ApplicationRolloutTargetPhasePending,
+	})
+	env.expectVersion(t, "aaa", newVersion)
+	env.expectVersion(t, "ccc", oldVersion)
+	env.expectVersion(t, "ddd", oldVersion)
+
+	// the second wave must wait until the whole first wave is healthy
+	env.markReady(t, "aaa")
+	rollout = env.reconcile(t)
+	expectTargets(t, rollout, map[string]appskubermaticv1.ApplicationRolloutTargetPhase{
+		"aaa": appskubermaticv1.ApplicationRolloutTargetPhaseHealthy,
+		"bbb": appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading,
+		"ccc": appskubermaticv1.ApplicationRolloutTargetPhasePending,
+	})
+
+	env.markReady(t, "bbb")
+	rollout = env.reconcile(t)
+	if rollout.Status.CurrentWave != 2 {
+		t.Fatalf("Expected rollout to be in wave 2, but got wave %d", rollout.Status.CurrentWave)
+	}
+	env.expectVersion(t, "ccc", newVersion)
+
+	env.markReady(t, "ccc")
+	rollout = env.reconcile(t)
+	if rollout.Status.Phase != appskubermaticv1.ApplicationRolloutPhaseCompleted {
+		t.Fatalf("Expected rollout to be completed, but got %q", rollout.Status.Phase)
+	}
+}
+
+func TestRolloutProjects(t *testing.T) {
+	env := newTestEnv(t, appskubermaticv1.ApplicationRolloutStrategy{}, []string{"project-b"})
+
+	rollout := env.reconcile(t)
+	expectTargets(t, rollout, map[string]appskubermaticv1.ApplicationRolloutTargetPhase{
+		"ccc": appskubermaticv1.ApplicationRolloutTargetPhaseUpgrading,
+	})
+}
+
+func TestRolloutFailure(t *testing.T) {
+	testCases := []struct {
+		name            string
+		autoRollback    bool
+		expectedPhase   appskubermaticv1.ApplicationRolloutPhase
+		expectedTarget  appskubermaticv1.ApplicationRolloutTargetPhase
+		expectedVersion string
+	}{
+		{
+			name:            "halt without rollback",
+			expectedPhase:   appskubermaticv1.ApplicationRolloutPhaseHalted,
+			expectedTarget:  appskubermaticv1.ApplicationRolloutTargetPhaseFailed,
+			expectedVersion: newVersion,
+		},
+		{
+			name:            "halt with rollback",
+			autoRollback:    true,
+			expectedPhase:   appskubermaticv1.ApplicationRolloutPhaseRolledBack,
+			expectedTarget:  appskubermaticv1.ApplicationRolloutTargetPhaseRolledBack,
+			expectedVersion: oldVersion,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t, appskubermaticv1.ApplicationRolloutStrategy{
+				HealthTimeout: metav1.Duration{Duration: time.Nanosecond},
+				AutoRollback:  tc.autoRollback,
+			}, nil)
+
+			rollout := env.reconcile(t)
+			env.expectVersion(t, "aaa", newVersion)
+
+			// aaa never becomes ready
+			rollout = env.reconcile(t)
+			if rollout.Status.Phase != tc.expectedPhase {
+				t.Fatalf("Expected rollout to be %q, but got %q", tc.expectedPhase, rollout.Status.Phase)
+			}
+			expectTargets(t, rollout, map[string]appskubermaticv1.ApplicationRolloutTargetPhase{
+				"aaa": tc.expectedTarget,
+				"bbb": appskubermaticv1.ApplicationRolloutTargetPhasePending,
+				"ccc": appskubermaticv1.ApplicationRolloutTargetPhasePending,
+			})
+			env.expectVersion(t, "aaa", tc.expectedVersion)
+			env.expectVersion(t, "bbb", oldVersion)
+		})
+	}
+}
+
+func TestRolloutUnknownVersion(t *testing.T) {
+	env := newTestEnv(t, appskubermaticv1.ApplicationRolloutStrategy{}, nil)
+
+	rollout := &appskubermaticv1.ApplicationRollout{}
+	if err := env.reconciler.Get(context.Background(), types.NamespacedName{Name: rolloutName}, rollout); err != nil {
+		t.Fatalf("Failed to get rollout: %v", err)
+	}
+	rollout.Spec.ApplicationRef.Version = "9.9.9"
+	if err := env.reconciler.Update(context.Background(), rollout); err != nil {
+		t.Fatalf("Failed to update rollout: %v", err)
+	}
+
+	rollout = env.reconcile(t)
+	if rollout.Status.Phase != appskubermaticv1.ApplicationRolloutPhaseHalted || rollout.Status.Message == "" {
+		t.Fatalf("Expected rollout to be halted with a message, but got %q: %q", rollout.Status.Phase, rollout.Status.Message)
+	}
+	env.expectVersion(t, "aaa", oldVersion)
+}
+
+func TestWaveSize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		targets        int
+		expected       int
+	}{
+		{
+			name:     "defaults to one",
+			targets:  10,
+			expected: 1,
+		},
+		{
+			name:           "absolute number",
+			maxUnavailable: ptr.To(intstr.FromInt(3)),
+			targets:        10,
+			expected:       3,
+		},
+		{
+			name:           "percentage is rounded down",
+			maxUnavailable: ptr.To(intstr.FromString("25%")),
+			targets:        10,
+			expected:       2,
+		},
+		{
+			name:           "at least one installation",
+			maxUnavailable: ptr.To(intstr.FromString("5%")),
+			targets:        10,
+			expected:       1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rollout := &appskubermaticv1.ApplicationRollout{
+				Spec: appskubermaticv1.ApplicationRolloutSpec{
+					Strategy: appskubermaticv1.ApplicationRolloutStrategy{MaxUnavailable: tc.maxUnavailable},
+				},
+				Status: appskubermaticv1.ApplicationRolloutStatus{
+					Targets: make([]appskubermaticv1.ApplicationRolloutTarget, tc.targets),
+				},
+			}
+
+			if size := waveSize(rollout); size != tc.expected {
+				t.Fatalf("Expected wave size %d, but got %d", tc.expected, size)
+			}
+		})
+	}
+}
+
+func genCluster(name, project string, apiserver kubermaticv1.HealthStatus) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: project},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: apiserver,
+			},
+		},
+	}
+}
+
+func genInstallation(version string) *appskubermaticv1.ApplicationInstallation {
+	return &appskubermaticv1.ApplicationInstallation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installedName,
+			Namespace: appNamespace,
+		},
+		Spec: appskubermaticv1.ApplicationInstallationSpec{
+			Namespace:      appskubermaticv1.AppNamespaceSpec{Name: appNamespace},
+			ApplicationRef: appskubermaticv1.ApplicationRef{Name: appName, Version: version},
+		},
+		Status: appskubermaticv1.ApplicationInstallationStatus{
+			Conditions: map[appskubermaticv1.ApplicationInstallationConditionType]appskubermaticv1.ApplicationInstallationCondition{
+				appskubermaticv1.Ready: {Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ControllerName is the name of this very controller.
+	ControllerName = "kkp-cluster-migration-controller"
+
+	// progressInterval is how often a running migration is checked, as the objects on the
+	// seeds cannot be watched from the master cluster.
+	progressInterval = 15 * time.Second
+)
+
+// Reconciler moves clusters between seeds.
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	log              *zap.SugaredLogger
+	recorder         record.EventRecorder
+	seedsGetter      provider.SeedsGetter
+	seedClientGetter provider.SeedClientGetter
+}
+
+// Add creates a new cluster migration controller and sets up watches.
+func Add(
+	mgr manager.Manager,
+	numWorkers int,
+	log *zap.SugaredLogger,
+	seedsGetter provider.SeedsGetter,
+	seedKubeconfigGetter provider.SeedKubeconfigGetter,
+) error {
+	reconciler := &Reconciler{
+		Client:           mgr.GetClient(),
+		log:              log.Named(ControllerName),
+		recorder:         mgr.GetEventRecorderFor(ControllerName),
+		seedsGetter:      seedsGetter,
+		seedClientGetter: kubernetesprovider.SeedClientGetterFactory(seedKubeconfigGetter),
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), &kubermaticv1.ClusterMigration{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("migration", request.Name)
+	log.Debug("Reconciling")
+
+	migration := &kubermaticv1.ClusterMigration{}
+	if err := r.Get(ctx, request.NamespacedName, migration); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get migration: %w", err)
+	}
+
+	if migration.DeletionTimestamp != nil || migration.Status.Phase == kubermaticv1.ClusterMigrationPhaseCompleted || migration.Status.Phase == kubermaticv1.ClusterMigrationPhaseFailed {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, migration)
+	if err != nil {
+		var failure *migrationFailure
+		if errors.As(err, &failure) {
+			// the migration cannot continue, so do not retry
+			r.recorder.Event(migration, corev1.EventTypeWarning, "MigrationFailed", err.Error())
+			return reconcile.Result{}, nil
+		}
+
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(migration, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+
+		return reconcile.Result{}, err
+	}
+
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+
+	return *result, nil
+}
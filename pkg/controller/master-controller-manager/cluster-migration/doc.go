@@ -0,0 +1,36 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package clustermigration contains a controller that moves user clusters between
+seeds, as requested by ClusterMigration objects. It reuses the etcd backup and
+restore machinery of the seeds:
+
+  - the source cluster is paused and its API server is scaled down,
+  - once no API server pod is left, a final EtcdBackupConfig snapshot is taken into
+    a destination shared by both seeds,
+  - the cluster, its CAs and cloud credentials are created paused on the target seed,
+  - an EtcdRestore restores the snapshot into the new cluster; the restore controller
+    unpauses the cluster once etcd has been rebuilt,
+  - once the new cluster is exposed and healthy, its kubeconfigs are pointed to the new
+    address; if the address changed, an administrator has to update DNS records and
+    confirm this on the ClusterMigration,
+  - the source cluster is removed without cleaning up any resources the new cluster
+    still uses.
+
+Every step is reflected as a condition on the ClusterMigration.
+*/
+package clustermigration
@@ -0,0 +1,938 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// migratedSecrets are the secrets in the cluster namespace that make up the identity of a
+// cluster. They are copied to the target seed before it starts reconciling the cluster, so
+// that existing nodes, kubeconfigs and service account tokens remain valid and the restored
+// etcd data can be decrypted.
+var migratedSecrets = []string{
+	resources.CASecretName,
+	resources.FrontProxyCASecretName,
+	resources.ServiceAccountKeySecretName,
+	resources.OpenVPNCASecretName,
+	resources.EncryptionConfigurationSecretName,
+	resources.ViewerTokenSecretName,
+}
+
+// migrationFailure is returned by steps that cannot succeed without manual intervention.
+type migrationFailure struct {
+	message string
+}
+
+func (f *migrationFailure) Error() string {
+	return f.message
+}
+
+func failf(format string, args ...interface{}) error {
+	return &migrationFailure{message: fmt.Sprintf(format, args...)}
+}
+
+// manualActionRequired is returned by steps that can only continue after an administrator
+// performed the described action. Unlike a migrationFailure, it does not end the migration.
+type manualActionRequired struct {
+	message string
+}
+
+func (m *manualActionRequired) Error() string {
+	return m.message
+}
+
+// migrationContext contains everything the migration steps operate on.
+type migrationContext struct {
+	migration    *kubermaticv1.ClusterMigration
+	sourceSeed   *kubermaticv1.Seed
+	targetSeed   *kubermaticv1.Seed
+	sourceClient ctrlruntimeclient.Client
+	targetClient ctrlruntimeclient.Client
+	destination  string
+}
+
+// step performs one part of a migration. It returns a message describing what it is waiting
+// for, or an empty string once the step has been completed.
+type step func(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error)
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	steps := map[kubermaticv1.ClusterMigrationConditionType]step{
+		kubermaticv1.ClusterMigrationConditionSourceClusterPaused:  r.pauseSourceCluster,
+		kubermaticv1.ClusterMigrationConditionSnapshotCompleted:    r.takeSnapshot,
+		kubermaticv1.ClusterMigrationConditionTargetClusterCreated: r.createTargetCluster,
+		kubermaticv1.ClusterMigrationConditionEtcdRestored:         r.restoreEtcd,
+		kubermaticv1.ClusterMigrationConditionEndpointsUpdated:     r.updateEndpoints,
+		kubermaticv1.ClusterMigrationConditionSourceCleanedUp:      r.cleanupSource,
+	}
+
+	mc, err := r.prepare(ctx, log, migration)
+	if err != nil {
+		return nil, r.handleStepError(ctx, log, migration, nil, currentStep(migration), err)
+	}
+
+	for _, conditionType := range kubermaticv1.ClusterMigrationConditionTypes {
+		if migration.Status.HasConditionValue(conditionType, corev1.ConditionTrue) {
+			continue
+		}
+
+		waiting, err := steps[conditionType](ctx, log, mc)
+
+		var manualAction *manualActionRequired
+		if errors.As(err, &manualAction) {
+			log.Infow("Migration is waiting for manual action", "step", conditionType, "action", manualAction.message)
+			r.recorder.Event(migration, corev1.EventTypeWarning, "ManualActionRequired", manualAction.message)
+
+			// changing the migration triggers the next reconciliation
+			return nil, r.setCondition(ctx, migration, conditionType, corev1.ConditionFalse, "ManualActionRequired", manualAction.message)
+		}
+
+		if err != nil {
+			return nil, r.handleStepError(ctx, log, migration, mc, conditionType, err)
+		}
+
+		if waiting != "" {
+			return &reconcile.Result{RequeueAfter: progressInterval}, r.setCondition(ctx, migration, conditionType, corev1.ConditionFalse, "InProgress", waiting)
+		}
+
+		log.Infow("Migration step completed", "step", conditionType)
+		if err := r.setCondition(ctx, migration, conditionType, corev1.ConditionTrue, "Completed", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info("Migration completed")
+	r.recorder.Eventf(migration, corev1.EventTypeNormal, "MigrationCompleted", "Cluster %s has been moved to seed %s", migration.Spec.ClusterName, migration.Spec.TargetSeed)
+
+	return nil, kubermaticv1helper.UpdateClusterMigrationStatus(ctx, r, migration, func(m *kubermaticv1.ClusterMigration) {
+		m.Status.Phase = kubermaticv1.ClusterMigrationPhaseCompleted
+	})
+}
+
+// currentStep returns the first step that has not been completed yet.
+func currentStep(migration *kubermaticv1.ClusterMigration) kubermaticv1.ClusterMigrationConditionType {
+	for _, conditionType := range kubermaticv1.ClusterMigrationConditionTypes {
+		if !migration.Status.HasConditionValue(conditionType, corev1.ConditionTrue) {
+			return conditionType
+		}
+	}
+
+	return kubermaticv1.ClusterMigrationConditionTypes[len(kubermaticv1.ClusterMigrationConditionTypes)-1]
+}
+
+func (r *Reconciler) setCondition(ctx context.Context, migration *kubermaticv1.ClusterMigration, conditionType kubermaticv1.ClusterMigrationConditionType, status corev1.ConditionStatus, reason, message string) error {
+	return kubermaticv1helper.UpdateClusterMigrationStatus(ctx, r, migration, func(m *kubermaticv1.ClusterMigration) {
+		kubermaticv1helper.SetClusterMigrationCondition(m, conditionType, status, reason, message)
+	})
+}
+
+// handleStepError records the error on the step's condition. Failures end the migration and,
+// as long as the cluster has not been created on the target seed yet, resume the source cluster.
+func (r *Reconciler) handleStepError(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	migration *kubermaticv1.ClusterMigration,
+	mc *migrationContext,
+	conditionType kubermaticv1.ClusterMigrationConditionType,
+	stepErr error,
+) error {
+	var failure *migrationFailure
+	if !errors.As(stepErr, &failure) {
+		if err := r.setCondition(ctx, migration, conditionType, corev1.ConditionFalse, "Error", stepErr.Error()); err != nil {
+			log.Errorw("Failed to update migration status", zap.Error(err))
+		}
+
+		return fmt.Errorf("step %s failed: %w", conditionType, stepErr)
+	}
+
+	log.Errorw("Migration failed", "step", conditionType, zap.Error(failure))
+
+	if mc != nil && !migration.Status.HasConditionValue(kubermaticv1.ClusterMigrationConditionTargetClusterCreated, corev1.ConditionTrue) {
+		if err := r.resumeSourceCluster(ctx, mc); err != nil {
+			return fmt.Errorf("failed to resume source cluster after failure %q: %w", failure.message, err)
+		}
+	}
+
+	if err := kubermaticv1helper.UpdateClusterMigrationStatus(ctx, r, migration, func(m *kubermaticv1.ClusterMigration) {
+		kubermaticv1helper.SetClusterMigrationCondition(m, conditionType, corev1.ConditionFalse, "Failed", failure.message)
+		m.Status.Phase = kubermaticv1.ClusterMigrationPhaseFailed
+	}); err != nil {
+		return fmt.Errorf("failed to mark migration as failed: %w", err)
+	}
+
+	return failure
+}
+
+// prepare determines the source seed on the first reconciliation and validates that the
+// migration is possible at all.
+func (r *Reconciler) prepare(ctx context.Context, log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*migrationContext, error) {
+	seeds, err := r.seedsGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seeds: %w", err)
+	}
+
+	targetSeed, ok := seeds[migration.Spec.TargetSeed]
+	if !ok {
+		return nil, failf("target seed %q does not exist", migration.Spec.TargetSeed)
+	}
+
+	if migration.Status.SourceSeed == "" {
+		if err := r.locateSourceCluster(ctx, log, migration, seeds); err != nil {
+			return nil, err
+		}
+	}
+
+	sourceSeed, ok := seeds[migration.Status.SourceSeed]
+	if !ok {
+		return nil, failf("source seed %q does not exist anymore", migration.Status.SourceSeed)
+	}
+
+	destination := migration.Spec.Destination
+	if destination == "" && sourceSeed.Spec.EtcdBackupRestore != nil {
+		destination = sourceSeed.Spec.EtcdBackupRestore.DefaultDestination
+	}
+	if destination == "" {
+		return nil, failf("no backup destination configured and seed %q has no default destination", sourceSeed.Name)
+	}
+
+	for _, seed := range []*kubermaticv1.Seed{sourceSeed, targetSeed} {
+		if seed.Spec.EtcdBackupRestore == nil || seed.Spec.EtcdBackupRestore.Destinations[destination] == nil {
+			return nil, failf("backup destination %q is not configured on seed %q", destination, seed.Name)
+		}
+	}
+
+	sourceClient, err := r.seedClientGetter(sourceSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for seed %q: %w", sourceSeed.Name, err)
+	}
+
+	targetClient, err := r.seedClientGetter(targetSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for seed %q: %w", targetSeed.Name, err)
+	}
+
+	return &migrationContext{
+		migration:    migration,
+		sourceSeed:   sourceSeed,
+		targetSeed:   targetSeed,
+		sourceClient: sourceClient,
+		targetClient: targetClient,
+		destination:  destination,
+	}, nil
+}
+
+func (r *Reconciler) locateSourceCluster(ctx context.Context, log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration, seeds map[string]*kubermaticv1.Seed) error {
+	var (
+		source      *kubermaticv1.Cluster
+		sourceSeed  string
+		unreachable bool
+	)
+
+	for name, seed := range seeds {
+		client, err := r.seedClientGetter(seed)
+		if err != nil {
+			log.Warnw("Failed to create client for seed", "seed", name, zap.Error(err))
+			unreachable = true
+			continue
+		}
+
+		cluster := &kubermaticv1.Cluster{}
+		if err := client.Get(ctx, types.NamespacedName{Name: migration.Spec.ClusterName}, cluster); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Warnw("Failed to check seed for cluster", "seed", name, zap.Error(err))
+				unreachable = true
+			}
+			continue
+		}
+
+		source = cluster
+		sourceSeed = name
+		break
+	}
+
+	if source == nil {
+		if unreachable {
+			return fmt.Errorf("cluster %q not found on any reachable seed", migration.Spec.ClusterName)
+		}
+		return failf("cluster %q does not exist", migration.Spec.ClusterName)
+	}
+
+	if sourceSeed == migration.Spec.TargetSeed {
+		return failf("cluster %q is already running on seed %q", source.Name, sourceSeed)
+	}
+
+	if !source.Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] {
+		return failf("cluster %q does not use the etcd-launcher, which is required to restore etcd", source.Name)
+	}
+
+	datacenter, ok := seeds[migration.Spec.TargetSeed].Spec.Datacenters[migration.Spec.TargetDatacenter]
+	if !ok {
+		return failf("datacenter %q does not exist on seed %q", migration.Spec.TargetDatacenter, migration.Spec.TargetSeed)
+	}
+
+	clusterProvider, err := kubermaticv1helper.ClusterCloudProviderName(source.Spec.Cloud)
+	if err != nil {
+		return failf("failed to determine cloud provider of cluster: %v", err)
+	}
+
+	datacenterProvider, err := kubermaticv1helper.DatacenterCloudProviderName(&datacenter.Spec)
+	if err != nil {
+		return failf("failed to determine cloud provider of datacenter: %v", err)
+	}
+
+	if clusterProvider != datacenterProvider {
+		return failf("datacenter %q uses provider %q, but the cluster uses %q", migration.Spec.TargetDatacenter, datacenterProvider, clusterProvider)
+	}
+
+	return kubermaticv1helper.UpdateClusterMigrationStatus(ctx, r, migration, func(m *kubermaticv1.ClusterMigration) {
+		m.Status.SourceSeed = sourceSeed
+		m.Status.SourceDatacenter = source.Spec.Cloud.DatacenterName
+		m.Status.SourceAddress = source.Status.Address.URL
+		m.Status.Phase = kubermaticv1.ClusterMigrationPhaseRunning
+	})
+}
+
+func getCluster(ctx context.Context, client ctrlruntimeclient.Client, name string) (*kubermaticv1.Cluster, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, failf("cluster %q does not exist anymore", name)
+		}
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	return cluster, nil
+}
+
+// pauseSourceCluster stops all KKP controllers from reconciling the source cluster and shuts
+// down its API server, so that the final snapshot contains all changes.
+func (r *Reconciler) pauseSourceCluster(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error) {
+	cluster, err := getCluster(ctx, mc.sourceClient, mc.migration.Spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	if other := cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation]; other != "" && other != mc.migration.Name {
+		return "", failf("cluster is already being migrated by %q", other)
+	}
+
+	if !cluster.Spec.Pause || cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] == "" {
+		oldCluster := cluster.DeepCopy()
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] = mc.migration.Name
+		cluster.Spec.Pause = true
+		cluster.Spec.PauseReason = fmt.Sprintf("Cluster is being migrated to seed %s", mc.migration.Spec.TargetSeed)
+
+		if err := mc.sourceClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return "", fmt.Errorf("failed to pause cluster: %w", err)
+		}
+	}
+
+	// as the cluster is paused, nothing will scale the API server up again
+	deployment := &appsv1.Deployment{}
+	if err := mc.sourceClient.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, deployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get API server: %w", err)
+		}
+	} else if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+		oldDeployment := deployment.DeepCopy()
+		deployment.Spec.Replicas = ptr.To[int32](0)
+
+		if err := mc.sourceClient.Patch(ctx, deployment, ctrlruntimeclient.MergeFrom(oldDeployment)); err != nil {
+			return "", fmt.Errorf("failed to stop API server: %w", err)
+		}
+	}
+
+	stopped, err := apiserverStopped(ctx, mc.sourceClient, cluster.Status.NamespaceName)
+	if err != nil {
+		return "", err
+	}
+
+	if !stopped {
+		return "Waiting for the API server to shut down.", nil
+	}
+
+	return "", nil
+}
+
+// apiserverStopped returns true if the API server of a cluster is scaled down, the scale-down
+// has been rolled out and no API server pod, not even a terminating one, can accept writes anymore.
+func apiserverStopped(ctx context.Context, client ctrlruntimeclient.Client, namespace string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.ApiserverDeploymentName}, deployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get API server: %w", err)
+		}
+	} else {
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			return false, nil
+		}
+
+		if deployment.Status.ObservedGeneration < deployment.Generation || deployment.Status.Replicas > 0 {
+			return false, nil
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(namespace), ctrlruntimeclient.MatchingLabels(resources.BaseAppLabels(resources.ApiserverDeploymentName, nil))); err != nil {
+		return false, fmt.Errorf("failed to list API server pods: %w", err)
+	}
+
+	return len(pods.Items) == 0, nil
+}
+
+// resumeSourceCluster undoes pauseSourceCluster; the cluster controllers scale the API server
+// up again once the cluster is not paused anymore.
+func (r *Reconciler) resumeSourceCluster(ctx context.Context, mc *migrationContext) error {
+	cluster := &kubermaticv1.Cluster{}
+	if err := mc.sourceClient.Get(ctx, types.NamespacedName{Name: mc.migration.Spec.ClusterName}, cluster); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] != mc.migration.Name {
+		return nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, kubermaticv1.ClusterMigrationAnnotation)
+	cluster.Spec.Pause = false
+	cluster.Spec.PauseReason = ""
+
+	return mc.sourceClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+}
+
+func snapshotName(migration *kubermaticv1.ClusterMigration) string {
+	return fmt.Sprintf("migration-%s", migration.Name)
+}
+
+// takeSnapshot creates a one-shot EtcdBackupConfig on the source seed and waits for the backup
+// to be uploaded. The etcd backup controller makes an exception for these backups, as it
+// otherwise ignores paused clusters. The snapshot is only started while the API server is
+// stopped, so that no write can happen after it.
+func (r *Reconciler) takeSnapshot(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error) {
+	cluster, err := getCluster(ctx, mc.sourceClient, mc.migration.Spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: snapshotName(mc.migration)}
+
+	if err := mc.sourceClient.Get(ctx, key, backupConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get EtcdBackupConfig: %w", err)
+		}
+
+		stopped, err := apiserverStopped(ctx, mc.sourceClient, cluster.Status.NamespaceName)
+		if err != nil {
+			return "", err
+		}
+
+		if !stopped {
+			return "Waiting for the API server to be stopped before taking the final snapshot.", nil
+		}
+
+		backupConfig = &kubermaticv1.EtcdBackupConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					kubermaticv1.ProjectIDLabelKey: cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+				},
+				Annotations: map[string]string{
+					kubermaticv1.ClusterMigrationAnnotation: mc.migration.Name,
+				},
+			},
+			Spec: kubermaticv1.EtcdBackupConfigSpec{
+				Name:        key.Name,
+				Cluster:     clusterReference(cluster),
+				Destination: mc.destination,
+			},
+		}
+
+		if err := mc.sourceClient.Create(ctx, backupConfig); err != nil {
+			return "", fmt.Errorf("failed to create EtcdBackupConfig: %w", err)
+		}
+
+		return "Waiting for the final snapshot to be taken.", nil
+	}
+
+	if len(backupConfig.Status.CurrentBackups) == 0 {
+		return "Waiting for the final snapshot to be scheduled.", nil
+	}
+
+	backup := backupConfig.Status.CurrentBackups[0]
+
+	switch backup.BackupPhase {
+	case kubermaticv1.BackupStatusPhaseCompleted:
+		if err := kubermaticv1helper.UpdateClusterMigrationStatus(ctx, r, mc.migration, func(m *kubermaticv1.ClusterMigration) {
+			m.Status.BackupName = backup.BackupName
+		}); err != nil {
+			return "", fmt.Errorf("failed to record snapshot: %w", err)
+		}
+
+		return "", nil
+
+	case kubermaticv1.BackupStatusPhaseFailed:
+		return "", failf("final snapshot failed: %s", backup.BackupMessage)
+	}
+
+	return fmt.Sprintf("Waiting for the final snapshot %s to be uploaded.", backup.BackupName), nil
+}
+
+func clusterReference(cluster *kubermaticv1.Cluster) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind:       kubermaticv1.ClusterKindName,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+		APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+	}
+}
+
+// createTargetCluster creates the cluster on the target seed. The cluster is created paused
+// and stays paused until the etcd restore has rebuilt etcd from the final snapshot; in the
+// meantime its namespace is prepared with the secrets of the source cluster. As paused clusters
+// are not initialized by the target seed, the namespace is recorded in the cluster status here.
+func (r *Reconciler) createTargetCluster(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error) {
+	source, err := getCluster(ctx, mc.sourceClient, mc.migration.Spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	target := &kubermaticv1.Cluster{}
+	if err := mc.targetClient.Get(ctx, types.NamespacedName{Name: source.Name}, target); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get target cluster: %w", err)
+		}
+
+		target = newTargetCluster(source, mc.migration)
+		log.Infow("Creating cluster on target seed", "seed", mc.targetSeed.Name)
+
+		if err := mc.targetClient.Create(ctx, target); err != nil {
+			return "", fmt.Errorf("failed to create target cluster: %w", err)
+		}
+	} else if target.Annotations[kubermaticv1.ClusterMigrationAnnotation] != mc.migration.Name {
+		return "", failf("a different cluster named %q already exists on seed %q", target.Name, mc.targetSeed.Name)
+	}
+
+	if secretName := source.GetSecretName(); secretName != "" {
+		if err := copySecret(ctx, mc.sourceClient, mc.targetClient, resources.KubermaticNamespace, resources.KubermaticNamespace, secretName); err != nil {
+			return "", fmt.Errorf("failed to copy cloud credentials: %w", err)
+		}
+	}
+
+	namespace, err := ensureNamespace(ctx, mc.targetClient, target)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cluster namespace: %w", err)
+	}
+
+	for _, name := range migratedSecrets {
+		if err := copySecret(ctx, mc.sourceClient, mc.targetClient, source.Status.NamespaceName, namespace, name); err != nil {
+			return "", fmt.Errorf("failed to copy Secret %s: %w", name, err)
+		}
+	}
+
+	// keep the admin token, so that existing admin kubeconfigs remain valid
+	if target.Status.Address.AdminToken == "" || target.Status.NamespaceName == "" {
+		if err := kubermaticv1helper.UpdateClusterStatus(ctx, mc.targetClient, target, func(c *kubermaticv1.Cluster) {
+			c.Status.NamespaceName = namespace
+			if c.Status.Address.AdminToken == "" {
+				c.Status.Address.AdminToken = source.Status.Address.AdminToken
+			}
+		}); err != nil {
+			return "", fmt.Errorf("failed to initialize target cluster status: %w", err)
+		}
+	}
+
+	return "", nil
+}
+
+func newTargetCluster(source *kubermaticv1.Cluster, migration *kubermaticv1.ClusterMigration) *kubermaticv1.Cluster {
+	annotations := map[string]string{}
+	for k, v := range source.Annotations {
+		annotations[k] = v
+	}
+
+	// the initial resources have been created in the source cluster already
+	delete(annotations, kubermaticv1.InitialMachineDeploymentRequestAnnotation)
+	delete(annotations, kubermaticv1.InitialApplicationInstallationsRequestAnnotation)
+	delete(annotations, kubermaticv1.InitialCNIValuesRequestAnnotation)
+	annotations[kubermaticv1.ClusterMigrationAnnotation] = migration.Name
+
+	labels := map[string]string{}
+	for k, v := range source.Labels {
+		labels[k] = v
+	}
+
+	target := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	target.Spec.Cloud.DatacenterName = migration.Spec.TargetDatacenter
+	target.Spec.Pause = true
+	target.Spec.PauseReason = fmt.Sprintf("Cluster is being migrated from seed %s", migration.Status.SourceSeed)
+
+	return target
+}
+
+// ensureNamespace creates the cluster namespace the same way the cluster controller does.
+func ensureNamespace(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (string, error) {
+	name := kubernetesprovider.NamespaceName(cluster.Name)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cluster, kubermaticv1.SchemeGroupVersion.WithKind("Cluster"))},
+		},
+	}
+
+	if err := client.Create(ctx, ns); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// copySecret copies a secret between seeds, unless the target already exists. Missing source
+// secrets are skipped, as not every cluster uses all of them.
+func copySecret(ctx context.Context, source, target ctrlruntimeclient.Client, sourceNamespace, targetNamespace, name string) error {
+	secret := &corev1.Secret{}
+	if err := source.Get(ctx, types.NamespacedName{Namespace: sourceNamespace, Name: name}, secret); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	copied := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   targetNamespace,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+
+	return ctrlruntimeclient.IgnoreAlreadyExists(target.Create(ctx, copied))
+}
+
+// restoreEtcd restores the final snapshot into the target cluster. The restore controller
+// unpauses the cluster once it has prepared etcd; afterwards the pause reason set by
+// createTargetCluster is removed.
+func (r *Reconciler) restoreEtcd(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error) {
+	cluster, err := getCluster(ctx, mc.targetClient, mc.migration.Spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	restore := &kubermaticv1.EtcdRestore{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: snapshotName(mc.migration)}
+
+	if err := mc.targetClient.Get(ctx, key, restore); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get EtcdRestore: %w", err)
+		}
+
+		restore = &kubermaticv1.EtcdRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					kubermaticv1.ProjectIDLabelKey: cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+				},
+			},
+			Spec: kubermaticv1.EtcdRestoreSpec{
+				Name:        key.Name,
+				Cluster:     clusterReference(cluster),
+				BackupName:  mc.migration.Status.BackupName,
+				Destination: mc.destination,
+			},
+		}
+
+		if err := mc.targetClient.Create(ctx, restore); err != nil {
+			return "", fmt.Errorf("failed to create EtcdRestore: %w", err)
+		}
+
+		return "Waiting for the etcd restore to start.", nil
+	}
+
+	switch restore.Status.Phase {
+	case kubermaticv1.EtcdRestorePhaseCompleted:
+		if cluster.Spec.Pause || cluster.Spec.PauseReason != "" {
+			oldCluster := cluster.DeepCopy()
+			cluster.Spec.Pause = false
+			cluster.Spec.PauseReason = ""
+
+			if err := mc.targetClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+				return "", fmt.Errorf("failed to unpause target cluster: %w", err)
+			}
+		}
+
+		return "", nil
+	case kubermaticv1.EtcdRestorePhaseEtcdLauncherNotEnabled:
+		return "", failf("etcd-launcher is not enabled on the target cluster")
+	}
+
+	return fmt.Sprintf("Waiting for the etcd restore to complete (phase %q).", restore.Status.Phase), nil
+}
+
+// updateEndpoints waits for the target seed to expose the cluster and points the admin and
+// viewer kubeconfigs to the new address. KKP does not manage the DNS records of clusters, so if
+// the address changed, the step only completes once an administrator has updated everything that
+// refers to the old address and confirmed this on the migration.
+func (r *Reconciler) updateEndpoints(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error) {
+	cluster, err := getCluster(ctx, mc.targetClient, mc.migration.Spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	targetAddress := cluster.Status.Address.URL
+	if targetAddress == "" {
+		return "Waiting for the cluster to be exposed on the target seed.", nil
+	}
+
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return "Waiting for the API server to become healthy on the target seed.", nil
+	}
+
+	if mc.migration.Status.TargetAddress != targetAddress {
+		if err := kubermaticv1helper.UpdateClusterMigrationStatus(ctx, r, mc.migration, func(m *kubermaticv1.ClusterMigration) {
+			m.Status.TargetAddress = targetAddress
+		}); err != nil {
+			return "", fmt.Errorf("failed to record target address: %w", err)
+		}
+	}
+
+	for _, name := range []string{resources.AdminKubeconfigSecretName, resources.ViewerKubeconfigSecretName} {
+		exists, err := repointKubeconfig(ctx, mc.targetClient, cluster.Status.NamespaceName, name, targetAddress)
+		if err != nil {
+			return "", fmt.Errorf("failed to update kubeconfig %s: %w", name, err)
+		}
+
+		if !exists {
+			return fmt.Sprintf("Waiting for the kubeconfig %s to be created on the target seed.", name), nil
+		}
+	}
+
+	sourceAddress := mc.migration.Status.SourceAddress
+	if sourceAddress == "" || sourceAddress == targetAddress || mc.migration.Annotations[kubermaticv1.ClusterMigrationEndpointsUpdatedAnnotation] == "true" {
+		return "", nil
+	}
+
+	return "", &manualActionRequired{
+		message: fmt.Sprintf(
+			"The API server moved from %s to %s (%s). Update DNS records and kubeconfigs referring to the old address, then annotate the ClusterMigration with %s=true.",
+			sourceAddress, targetAddress, cluster.Status.Address.ExternalName, kubermaticv1.ClusterMigrationEndpointsUpdatedAnnotation,
+		),
+	}
+}
+
+// repointKubeconfig points all clusters in a kubeconfig secret to the given server. The seed
+// regenerates these secrets from the cluster address as well, but this ensures they are correct
+// before the source cluster is removed. It returns false if the secret does not exist yet.
+func repointKubeconfig(ctx context.Context, client ctrlruntimeclient.Client, namespace, name, server string) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	config, err := clientcmd.Load(secret.Data[resources.KubeconfigSecretKey])
+	if err != nil {
+		return false, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	changed := false
+	for _, cluster := range config.Clusters {
+		if cluster.Server != server {
+			cluster.Server = server
+			changed = true
+		}
+	}
+
+	if !changed {
+		return true, nil
+	}
+
+	encoded, err := clientcmd.Write(*config)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+
+	oldSecret := secret.DeepCopy()
+	secret.Data[resources.KubeconfigSecretKey] = encoded
+
+	return true, client.Patch(ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret))
+}
+
+// cleanupSource removes the cluster from the source seed. The regular cluster deletion is not
+// used, as it would clean up nodes, volumes, load balancers and cloud resources that are still
+// in use by the migrated cluster. Instead, all finalizers are removed and only the namespace and
+// the cloud credentials on the source seed are deleted.
+func (r *Reconciler) cleanupSource(ctx context.Context, log *zap.SugaredLogger, mc *migrationContext) (string, error) {
+	cluster := &kubermaticv1.Cluster{}
+	err := mc.sourceClient.Get(ctx, types.NamespacedName{Name: mc.migration.Spec.ClusterName}, cluster)
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return "", fmt.Errorf("failed to get source cluster: %w", err)
+	}
+
+	namespace := kubernetesprovider.NamespaceName(mc.migration.Spec.ClusterName)
+
+	if err == nil {
+		if cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] != mc.migration.Name {
+			return "", failf("source cluster is not paused by this migration anymore")
+		}
+
+		if cluster.Status.NamespaceName != "" {
+			namespace = cluster.Status.NamespaceName
+		}
+
+		if err := removeNamespaceFinalizers(ctx, mc.sourceClient, namespace); err != nil {
+			return "", err
+		}
+
+		if secretName := cluster.GetSecretName(); secretName != "" {
+			secret := &corev1.Secret{}
+			secret.Name = secretName
+			secret.Namespace = resources.KubermaticNamespace
+
+			if err := mc.sourceClient.Delete(ctx, secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return "", fmt.Errorf("failed to delete cloud credentials: %w", err)
+			}
+		}
+
+		if len(cluster.Finalizers) > 0 {
+			oldCluster := cluster.DeepCopy()
+			cluster.Finalizers = nil
+
+			if err := mc.sourceClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+				return "", fmt.Errorf("failed to remove finalizers: %w", err)
+			}
+		}
+
+		log.Infow("Deleting cluster from source seed", "seed", mc.sourceSeed.Name)
+		if err := mc.sourceClient.Delete(ctx, cluster); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed to delete source cluster: %w", err)
+		}
+	}
+
+	ns := &corev1.Namespace{}
+	if err := mc.sourceClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get cluster namespace: %w", err)
+		}
+	} else {
+		if ns.DeletionTimestamp == nil {
+			if err := mc.sourceClient.Delete(ctx, ns); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return "", fmt.Errorf("failed to delete cluster namespace: %w", err)
+			}
+		}
+
+		return "Waiting for the cluster namespace to be deleted on the source seed.", nil
+	}
+
+	// the migration is done, the target cluster is managed as usual from now on
+	target, err := getCluster(ctx, mc.targetClient, mc.migration.Spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := target.Annotations[kubermaticv1.ClusterMigrationAnnotation]; ok {
+		oldTarget := target.DeepCopy()
+		delete(target.Annotations, kubermaticv1.ClusterMigrationAnnotation)
+
+		if err := mc.targetClient.Patch(ctx, target, ctrlruntimeclient.MergeFrom(oldTarget)); err != nil {
+			return "", fmt.Errorf("failed to remove migration annotation: %w", err)
+		}
+	}
+
+	return "", nil
+}
+
+// removeNamespaceFinalizers removes the finalizers of KKP resources in the cluster namespace.
+// Their controllers ignore paused clusters, so the finalizers would block the namespace deletion
+// forever; the backups, addons and restores are not cleaned up on purpose, as they belong to the
+// migrated cluster.
+func removeNamespaceFinalizers(ctx context.Context, client ctrlruntimeclient.Client, namespace string) error {
+	var objects []ctrlruntimeclient.Object
+
+	backupConfigs := &kubermaticv1.EtcdBackupConfigList{}
+	if err := client.List(ctx, backupConfigs, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list EtcdBackupConfigs: %w", err)
+	}
+	for i := range backupConfigs.Items {
+		objects = append(objects, &backupConfigs.Items[i])
+	}
+
+	restores := &kubermaticv1.EtcdRestoreList{}
+	if err := client.List(ctx, restores, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list EtcdRestores: %w", err)
+	}
+	for i := range restores.Items {
+		objects = append(objects, &restores.Items[i])
+	}
+
+	addons := &kubermaticv1.AddonList{}
+	if err := client.List(ctx, addons, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list Addons: %w", err)
+	}
+	for i := range addons.Items {
+		objects = append(objects, &addons.Items[i])
+	}
+
+	for _, object := range objects {
+		if len(object.GetFinalizers()) == 0 {
+			continue
+		}
+
+		oldObject := object.DeepCopyObject().(ctrlruntimeclient.Object)
+		object.SetFinalizers(nil)
+
+		if err := client.Patch(ctx, object, ctrlruntimeclient.MergeFrom(oldObject)); err != nil {
+			return fmt.Errorf("failed to remove finalizers from %s: %w", object.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	clusterName   = "xyz123"
+	migrationName = "move-xyz123"
+	namespace     = "cluster-xyz123"
+)
+
+func genSeed(name, datacenter string) *kubermaticv1.Seed {
+	return &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kubermatic",
+		},
+		Spec: kubermaticv1.SeedSpec{
+			Datacenters: map[string]kubermaticv1.Datacenter{
+				datacenter: {
+					Spec: kubermaticv1.DatacenterSpec{
+						Hetzner: &kubermaticv1.DatacenterSpecHetzner{},
+					},
+				},
+			},
+			EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+				Destinations: map[string]*kubermaticv1.BackupDestination{
+					"shared": {
+						BucketName: "backups",
+					},
+				},
+				DefaultDestination: "shared",
+			},
+		},
+	}
+}
+
+func genSourceObjects() []ctrlruntimeclient.Object {
+	return []ctrlruntimeclient.Object{
+		&kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       clusterName,
+				Finalizers: []string{kubermaticv1.NodeDeletionFinalizer, kubermaticv1.NamespaceCleanupFinalizer},
+				Annotations: map[string]string{
+					kubermaticv1.InitialMachineDeploymentRequestAnnotation: "{}",
+				},
+			},
+			Spec: kubermaticv1.ClusterSpec{
+				Cloud: kubermaticv1.CloudSpec{
+					DatacenterName: "hetzner-fsn1",
+					Hetzner:        &kubermaticv1.HetznerCloudSpec{},
+				},
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEtcdLauncher: true,
+				},
+			},
+			Status: kubermaticv1.ClusterStatus{
+				NamespaceName: namespace,
+				Address: kubermaticv1.ClusterAddress{
+					URL:        "https://xyz123.hetzner-fsn1.europe.example.com:6443",
+					AdminToken: "abcdef.0123456789abcdef",
+				},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resources.ApiserverDeploymentName,
+				Namespace: namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](2),
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas: 2,
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver-0",
+				Namespace: namespace,
+				Labels:    resources.BaseAppLabels(resources.ApiserverDeploymentName, nil),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resources.CASecretName,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				resources.CACertSecretKey: []byte("the-ca"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "credential-hetzner-" + clusterName,
+				Namespace: resources.KubermaticNamespace,
+			},
+		},
+	}
+}
+
+func genMigration(targetDatacenter string) *kubermaticv1.ClusterMigration {
+	return &kubermaticv1.ClusterMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: migrationName,
+		},
+		Spec: kubermaticv1.ClusterMigrationSpec{
+			ClusterName:      clusterName,
+			TargetSeed:       "usa",
+			TargetDatacenter: targetDatacenter,
+		},
+	}
+}
+
+type testEnv struct {
+	reconciler   *Reconciler
+	masterClient ctrlruntimeclient.Client
+	sourceClient ctrlruntimeclient.Client
+	targetClient ctrlruntimeclient.Client
+}
+
+func newTestEnv(migration *kubermaticv1.ClusterMigration, targetSeed *kubermaticv1.Seed) *testEnv {
+	seeds := map[string]*kubermaticv1.Seed{
+		"europe": genSeed("europe", "hetzner-fsn1"),
+		"usa":    targetSeed,
+	}
+
+	env := &testEnv{
+		masterClient: fake.NewClientBuilder().WithObjects(migration).Build(),
+		sourceClient: fake.NewClientBuilder().WithObjects(genSourceObjects()...).Build(),
+		targetClient: fake.NewClientBuilder().Build(),
+	}
+
+	env.reconciler = &Reconciler{
+		Client:   env.masterClient,
+		log:      kubermaticlog.Logger,
+		recorder: &record.FakeRecorder{},
+		seedsGetter: func() (map[string]*kubermaticv1.Seed, error) {
+			return seeds, nil
+		},
+		seedClientGetter: func(seed *kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
+			if seed.Name == "europe" {
+				return env.sourceClient, nil
+			}
+			return env.targetClient, nil
+		},
+	}
+
+	return env
+}
+
+func (e *testEnv) reconcile(t *testing.T) *kubermaticv1.ClusterMigration {
+	t.Helper()
+
+	ctx := context.Background()
+	if _, err := e.reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: migrationName}}); err != nil {
+		t.Fatalf("Reconciling failed: %v", err)
+	}
+
+	migration := &kubermaticv1.ClusterMigration{}
+	if err := e.masterClient.Get(ctx, types.NamespacedName{Name: migrationName}, migration); err != nil {
+		t.Fatalf("Failed to get migration: %v", err)
+	}
+
+	return migration
+}
+
+func expectCondition(t *testing.T, migration *kubermaticv1.ClusterMigration, conditionType kubermaticv1.ClusterMigrationConditionType, status corev1.ConditionStatus) {
+	t.Helper()
+
+	if !migration.Status.HasConditionValue(conditionType, status) {
+		t.Fatalf("Expected condition %s to be %s, got %+v.", conditionType, status, migration.Status.Conditions[conditionType])
+	}
+}
+
+func genKubeconfig(server string) *clientcmdapi.Config {
+	return &clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			clusterName: {Server: server},
+		},
+	}
+}
+
+func TestMigration(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(genMigration("hetzner-ash"), genSeed("usa", "hetzner-ash"))
+
+	// the source cluster is paused and its API server is scaled down
+	migration := env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSourceClusterPaused, corev1.ConditionFalse)
+
+	if migration.Status.SourceSeed != "europe" || migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseRunning {
+		t.Fatalf("Expected running migration from seed europe, got %+v.", migration.Status)
+	}
+
+	source := &kubermaticv1.Cluster{}
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Name: clusterName}, source); err != nil {
+		t.Fatalf("Failed to get source cluster: %v", err)
+	}
+	if !source.Spec.Pause || source.Annotations[kubermaticv1.ClusterMigrationAnnotation] != migrationName {
+		t.Fatal("Expected source cluster to be paused by the migration.")
+	}
+
+	apiserver := &appsv1.Deployment{}
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("Failed to get API server: %v", err)
+	}
+	if *apiserver.Spec.Replicas != 0 {
+		t.Fatalf("Expected API server to be scaled down, but has %d replicas.", *apiserver.Spec.Replicas)
+	}
+
+	// no snapshot must be taken while an API server pod can still accept writes
+	backupConfig := &kubermaticv1.EtcdBackupConfig{}
+	backupConfigKey := types.NamespacedName{Namespace: namespace, Name: "migration-" + migrationName}
+	if err := env.sourceClient.Get(ctx, backupConfigKey, backupConfig); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected no EtcdBackupConfig before the API server is stopped, got %v.", err)
+	}
+
+	apiserver.Status.Replicas = 0
+	if err := env.sourceClient.Status().Update(ctx, apiserver); err != nil {
+		t.Fatalf("Failed to update API server: %v", err)
+	}
+
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSourceClusterPaused, corev1.ConditionFalse)
+
+	pod := &corev1.Pod{}
+	pod.Name = "apiserver-0"
+	pod.Namespace = namespace
+	if err := env.sourceClient.Delete(ctx, pod); err != nil {
+		t.Fatalf("Failed to delete API server pod: %v", err)
+	}
+
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSourceClusterPaused, corev1.ConditionTrue)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSnapshotCompleted, corev1.ConditionFalse)
+
+	// the final snapshot is taken by the seed's etcd backup controller
+	if err := env.sourceClient.Get(ctx, backupConfigKey, backupConfig); err != nil {
+		t.Fatalf("Failed to get EtcdBackupConfig: %v", err)
+	}
+	if backupConfig.Spec.Destination != "shared" || backupConfig.Spec.Schedule != "" {
+		t.Fatalf("Expected a one-shot backup into the default destination, got %+v.", backupConfig.Spec)
+	}
+
+	backupConfig.Status.CurrentBackups = []kubermaticv1.BackupStatus{{
+		BackupName:  "migration-" + migrationName + ".db",
+		BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+	}}
+	if err := env.sourceClient.Status().Update(ctx, backupConfig); err != nil {
+		t.Fatalf("Failed to update EtcdBackupConfig: %v", err)
+	}
+
+	// the cluster is created paused on the target seed with the certificates of the source
+	// cluster and the restore is started right away
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSnapshotCompleted, corev1.ConditionTrue)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionTargetClusterCreated, corev1.ConditionTrue)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionEtcdRestored, corev1.ConditionFalse)
+
+	target := &kubermaticv1.Cluster{}
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Name: clusterName}, target); err != nil {
+		t.Fatalf("Failed to get target cluster: %v", err)
+	}
+	if target.Spec.Cloud.DatacenterName != "hetzner-ash" || !target.Spec.Pause {
+		t.Fatalf("Expected paused target cluster in datacenter hetzner-ash, got %+v.", target.Spec)
+	}
+	if target.Status.NamespaceName != namespace {
+		t.Fatalf("Expected target cluster to use namespace %q, got %q.", namespace, target.Status.NamespaceName)
+	}
+	if _, ok := target.Annotations[kubermaticv1.InitialMachineDeploymentRequestAnnotation]; ok {
+		t.Fatal("Expected initial MachineDeployment request to not be copied.")
+	}
+	if target.Status.Address.AdminToken != "abcdef.0123456789abcdef" {
+		t.Fatalf("Expected admin token to be copied, got %q.", target.Status.Address.AdminToken)
+	}
+
+	ca := &corev1.Secret{}
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.CASecretName}, ca); err != nil {
+		t.Fatalf("Failed to get copied CA: %v", err)
+	}
+	if string(ca.Data[resources.CACertSecretKey]) != "the-ca" {
+		t.Fatal("Expected CA to be copied from the source cluster.")
+	}
+
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: "credential-hetzner-" + clusterName}, &corev1.Secret{}); err != nil {
+		t.Fatalf("Failed to get copied credentials: %v", err)
+	}
+
+	restore := &kubermaticv1.EtcdRestore{}
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "migration-" + migrationName}, restore); err != nil {
+		t.Fatalf("Failed to get EtcdRestore: %v", err)
+	}
+	if restore.Spec.BackupName != "migration-"+migrationName+".db" || restore.Spec.Destination != "shared" {
+		t.Fatalf("Expected restore of the final snapshot, got %+v.", restore.Spec)
+	}
+
+	restore.Status.Phase = kubermaticv1.EtcdRestorePhaseCompleted
+	if err := env.targetClient.Status().Update(ctx, restore); err != nil {
+		t.Fatalf("Failed to update EtcdRestore: %v", err)
+	}
+
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionEtcdRestored, corev1.ConditionTrue)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionEndpointsUpdated, corev1.ConditionFalse)
+
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Name: clusterName}, target); err != nil {
+		t.Fatalf("Failed to get target cluster: %v", err)
+	}
+	if target.Spec.Pause || target.Spec.PauseReason != "" {
+		t.Fatalf("Expected target cluster to be unpaused after the restore, got %+v.", target.Spec)
+	}
+
+	// the target seed exposes the cluster, but its kubeconfigs still use the old address
+	target.Status.Address.URL = "https://xyz123.hetzner-ash.usa.example.com:6443"
+	target.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+	if err := env.targetClient.Status().Update(ctx, target); err != nil {
+		t.Fatalf("Failed to update target cluster: %v", err)
+	}
+
+	for _, name := range []string{resources.AdminKubeconfigSecretName, resources.ViewerKubeconfigSecretName} {
+		kubeconfig, err := clientcmd.Write(*genKubeconfig("https://xyz123.hetzner-fsn1.europe.example.com:6443"))
+		if err != nil {
+			t.Fatalf("Failed to encode kubeconfig: %v", err)
+		}
+
+		if err := env.targetClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				resources.KubeconfigSecretKey: kubeconfig,
+			},
+		}); err != nil {
+			t.Fatalf("Failed to create kubeconfig: %v", err)
+		}
+	}
+
+	// DNS records are not managed by KKP, so the address change must be confirmed
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionEndpointsUpdated, corev1.ConditionFalse)
+	if reason := migration.Status.Conditions[kubermaticv1.ClusterMigrationConditionEndpointsUpdated].Reason; reason != "ManualActionRequired" {
+		t.Fatalf("Expected manual action to be required, got reason %q.", reason)
+	}
+	if migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseRunning {
+		t.Fatalf("Expected migration to still be running, got phase %q.", migration.Status.Phase)
+	}
+	if migration.Status.TargetAddress != target.Status.Address.URL {
+		t.Fatalf("Expected target address %q, got %q.", target.Status.Address.URL, migration.Status.TargetAddress)
+	}
+
+	for _, name := range []string{resources.AdminKubeconfigSecretName, resources.ViewerKubeconfigSecretName} {
+		secret := &corev1.Secret{}
+		if err := env.targetClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+			t.Fatalf("Failed to get kubeconfig: %v", err)
+		}
+
+		kubeconfig, err := clientcmd.Load(secret.Data[resources.KubeconfigSecretKey])
+		if err != nil {
+			t.Fatalf("Failed to parse kubeconfig: %v", err)
+		}
+		if server := kubeconfig.Clusters[clusterName].Server; server != target.Status.Address.URL {
+			t.Fatalf("Expected kubeconfig %s to point to %q, got %q.", name, target.Status.Address.URL, server)
+		}
+	}
+
+	migration.Annotations = map[string]string{
+		kubermaticv1.ClusterMigrationEndpointsUpdatedAnnotation: "true",
+	}
+	if err := env.masterClient.Update(ctx, migration); err != nil {
+		t.Fatalf("Failed to confirm endpoint update: %v", err)
+	}
+
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionEndpointsUpdated, corev1.ConditionTrue)
+
+	// the source cluster is removed without running the regular cluster cleanup
+	migration = env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSourceCleanedUp, corev1.ConditionTrue)
+
+	if migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseCompleted {
+		t.Fatalf("Expected migration to be completed, got phase %q.", migration.Status.Phase)
+	}
+
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Name: clusterName}, &kubermaticv1.Cluster{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected source cluster to be deleted, got %v.", err)
+	}
+
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Name: clusterName}, target); err != nil {
+		t.Fatalf("Failed to get target cluster: %v", err)
+	}
+	if _, ok := target.Annotations[kubermaticv1.ClusterMigrationAnnotation]; ok {
+		t.Fatal("Expected migration annotation to be removed from the target cluster.")
+	}
+}
+
+func TestMigrationFailsForIncompatibleDatacenter(t *testing.T) {
+	targetSeed := genSeed("usa", "aws-us-east-1")
+	targetSeed.Spec.Datacenters["aws-us-east-1"] = kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			AWS: &kubermaticv1.DatacenterSpecAWS{},
+		},
+	}
+
+	env := newTestEnv(genMigration("aws-us-east-1"), targetSeed)
+
+	migration := env.reconcile(t)
+	expectCondition(t, migration, kubermaticv1.ClusterMigrationConditionSourceClusterPaused, corev1.ConditionFalse)
+
+	if migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseFailed {
+		t.Fatalf("Expected migration to fail, got phase %q.", migration.Status.Phase)
+	}
+
+	source := &kubermaticv1.Cluster{}
+	if err := env.sourceClient.Get(context.Background(), types.NamespacedName{Name: clusterName}, source); err != nil {
+		t.Fatalf("Failed to get source cluster: %v", err)
+	}
+	if source.Spec.Pause {
+		t.Fatal("Expected source cluster to not be paused.")
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications"
+	applicationinstallationcontroller "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/application-installation-controller"
+	userclusterapplications "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/applications"
+	"k8c.io/kubermatic/v2/pkg/crd"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	kkpreconciling "k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ApplicationControllerName = "kkp-external-cluster-application-controller"
+)
+
+var userClusterScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(userClusterScheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(userClusterScheme))
+	utilruntime.Must(appskubermaticv1.AddToScheme(userClusterScheme))
+}
+
+// ApplicationReconciler reconciles the applications of imported clusters. It runs separately from the
+// cluster controller, so that slow application installations do not delay probing the clusters.
+type ApplicationReconciler struct {
+	ctrlruntimeclient.Client
+	log *zap.SugaredLogger
+
+	// namespace is the namespace KKP runs in, which contains the credentials for application sources.
+	namespace string
+	// applicationCache is the directory in which applications for imported clusters are downloaded.
+	applicationCache string
+}
+
+// AddApplicationController creates a controller reconciling the applications of imported clusters.
+func AddApplicationController(
+	mgr manager.Manager,
+	numWorkers int,
+	log *zap.SugaredLogger,
+	namespace string,
+	applicationCache string) error {
+	reconciler := &ApplicationReconciler{
+		log:              log.Named(ApplicationControllerName),
+		Client:           mgr.GetClient(),
+		namespace:        namespace,
+		applicationCache: applicationCache,
+	}
+	c, err := controller.New(ApplicationControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to imported clusters with applications enabled.
+	applicationsEnabled := predicate.NewPredicateFuncs(func(object ctrlruntimeclient.Object) bool {
+		externalCluster, ok := object.(*kubermaticv1.ExternalCluster)
+		return ok && hasApplicationsEnabled(externalCluster)
+	})
+
+	return c.Watch(source.Kind(mgr.GetCache(), &kubermaticv1.ExternalCluster{}), &handler.EnqueueRequestForObject{}, applicationsEnabled, predicate.GenerationChangedPredicate{})
+}
+
+func hasApplicationsEnabled(cluster *kubermaticv1.ExternalCluster) bool {
+	byo := cluster.Spec.CloudSpec.BringYourOwn
+	return byo != nil && byo.ApplicationsEnabled
+}
+
+func (r *ApplicationReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("externalcluster", request)
+	log.Debug("Processing...")
+
+	cluster := &kubermaticv1.ExternalCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if cluster.Spec.Pause || !cluster.DeletionTimestamp.IsZero() || !hasApplicationsEnabled(cluster) || cluster.Spec.KubeconfigReference == nil {
+		return reconcile.Result{}, nil
+	}
+
+	// the cluster controller probes the cluster; do not attempt to install anything while it is unreachable
+	if cluster.Status.Health == nil || cluster.Status.Health.APIServer != kubermaticv1.HealthStatusUp {
+		log.Debug("Cluster is not reachable, skipping applications")
+		return reconcile.Result{RequeueAfter: healthProbeInterval}, nil
+	}
+
+	secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)
+	rawKubeconfig, err := secretKeySelector(cluster.Spec.KubeconfigReference, resources.ExternalClusterKubeconfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig([]byte(rawKubeconfig))
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	result, err := r.reconcileApplications(ctx, log, cluster, restConfig, []byte(rawKubeconfig))
+	if err != nil {
+		return result, fmt.Errorf("failed to reconcile applications: %w", err)
+	}
+
+	return result, nil
+}
+
+// reconcileApplications installs the ApplicationInstallation CRD into an imported cluster and reconciles
+// all ApplicationInstallations in it. As no controller runs inside imported clusters, the installations
+// are reconciled periodically; the returned result requeues the cluster at the earliest time any of the
+// installations asked for, but at least every health probe interval.
+func (r *ApplicationReconciler) reconcileApplications(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.ExternalCluster, restConfig *rest.Config, rawKubeconfig []byte) (reconcile.Result, error) {
+	result := reconcile.Result{RequeueAfter: healthProbeInterval}
+
+	userClient, err := ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{Scheme: userClusterScheme})
+	if err != nil {
+		return result, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	appInstallationCRD, err := crd.CRDForObject(&appskubermaticv1.ApplicationInstallation{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appskubermaticv1.SchemeGroupVersion.String(),
+			Kind:       "ApplicationInstallation",
+		},
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to get ApplicationInstallation CRD: %w", err)
+	}
+
+	creators := []kkpreconciling.NamedCustomResourceDefinitionReconcilerFactory{
+		userclusterapplications.CRDReconciler(appInstallationCRD),
+	}
+	if err := kkpreconciling.ReconcileCustomResourceDefinitions(ctx, creators, "", userClient); err != nil {
+		return result, fmt.Errorf("failed to reconcile ApplicationInstallation CRD: %w", err)
+	}
+
+	appInstallations := &appskubermaticv1.ApplicationInstallationList{}
+	if err := userClient.List(ctx, appInstallations); err != nil {
+		return result, fmt.Errorf("failed to list ApplicationInstallations: %w", err)
+	}
+	if len(appInstallations.Items) == 0 {
+		return result, nil
+	}
+
+	if err := os.MkdirAll(r.applicationCache, 0750); err != nil {
+		return result, fmt.Errorf("failed to create application cache: %w", err)
+	}
+
+	// the helm client used to install applications requires the kubeconfig to be on disk
+	kubeconfigFile, err := os.CreateTemp(r.applicationCache, cluster.Name+"-kubeconfig-*")
+	if err != nil {
+		return result, fmt.Errorf("failed to create kubeconfig file: %w", err)
+	}
+	defer os.Remove(kubeconfigFile.Name())
+
+	if _, err := kubeconfigFile.Write(rawKubeconfig); err != nil {
+		kubeconfigFile.Close()
+		return result, fmt.Errorf("failed to write kubeconfig file: %w", err)
+	}
+	if err := kubeconfigFile.Close(); err != nil {
+		return result, fmt.Errorf("failed to write kubeconfig file: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return result, fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	// events about the ApplicationInstallations are recorded in the imported cluster, next to the objects
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+	defer broadcaster.Shutdown()
+
+	appInstaller := &applications.ApplicationManager{
+		ApplicationCache: r.applicationCache,
+		Kubeconfig:       kubeconfigFile.Name(),
+		SecretNamespace:  r.namespace,
+		ClusterName:      cluster.Name,
+	}
+
+	isPaused := func(context.Context) (bool, error) {
+		return cluster.Spec.Pause, nil
+	}
+
+	reconciler := applicationinstallationcontroller.NewReconciler(
+		log,
+		r.Client,
+		userClient,
+		broadcaster.NewRecorder(userClusterScheme, corev1.EventSource{Component: ControllerName}),
+		isPaused,
+		appInstaller,
+	)
+
+	var errs []error
+	for _, appInstallation := range appInstallations.Items {
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: appInstallation.Namespace, Name: appInstallation.Name}}
+		appResult, err := reconciler.Reconcile(ctx, request)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", request.NamespacedName, err))
+		}
+		if appResult.RequeueAfter > 0 && appResult.RequeueAfter < result.RequeueAfter {
+			result.RequeueAfter = appResult.RequeueAfter
+		}
+	}
+
+	return result, kerrors.NewAggregate(errs)
+}
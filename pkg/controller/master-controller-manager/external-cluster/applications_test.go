@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestApplicationReconcilerSkipsClusters(t *testing.T) {
+	tests := []struct {
+		name                 string
+		applicationsEnabled  bool
+		health               *kubermaticv1.ExternalClusterHealth
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			name:                 "applications disabled",
+			applicationsEnabled:  false,
+			health:               &kubermaticv1.ExternalClusterHealth{APIServer: kubermaticv1.HealthStatusUp},
+			expectedRequeueAfter: 0,
+		},
+		{
+			name:                 "cluster not probed yet",
+			applicationsEnabled:  true,
+			expectedRequeueAfter: healthProbeInterval,
+		},
+		{
+			name:                 "API server unreachable",
+			applicationsEnabled:  true,
+			health:               &kubermaticv1.ExternalClusterHealth{APIServer: kubermaticv1.HealthStatusDown},
+			expectedRequeueAfter: healthProbeInterval,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.ExternalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ExternalClusterSpec{
+					HumanReadableName: "test",
+					KubeconfigReference: &providerconfig.GlobalSecretKeySelector{
+						ObjectReference: corev1.ObjectReference{Name: "kubeconfig", Namespace: "kubermatic"},
+					},
+					CloudSpec: kubermaticv1.ExternalClusterCloudSpec{
+						ProviderName: kubermaticv1.ExternalClusterBringYourOwnProvider,
+						BringYourOwn: &kubermaticv1.ExternalClusterBringYourOwnCloudSpec{
+							ApplicationsEnabled: test.applicationsEnabled,
+						},
+					},
+				},
+				Status: kubermaticv1.ExternalClusterStatus{
+					Health: test.health,
+				},
+			}
+
+			target := ApplicationReconciler{
+				Client: fake.NewClientBuilder().WithObjects(cluster).Build(),
+				log:    kubermaticlog.Logger,
+			}
+
+			// the kubeconfig Secret does not exist, so reaching the cluster would fail
+			result, err := target.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}})
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			if result.RequeueAfter != test.expectedRequeueAfter {
+				t.Errorf("expected to be requeued after %v, got %v", test.expectedRequeueAfter, result.RequeueAfter)
+			}
+		})
+	}
+}
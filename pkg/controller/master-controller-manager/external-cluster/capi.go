@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/capi"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileCAPI mirrors the state of a Cluster API cluster from its management cluster.
+func (r *Reconciler) reconcileCAPI(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.ExternalCluster) (reconcile.Result, error) {
+	cloud := cluster.Spec.CloudSpec.CAPI
+	if cloud.CredentialsReference != nil {
+		if err := kuberneteshelper.TryAddFinalizer(ctx, r.Client, cluster, kubermaticv1.CredentialsSecretsCleanupFinalizer); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add credential secret finalizer: %w", err)
+		}
+	}
+
+	secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)
+	managementClient, err := capi.GetManagementClient(secretKeySelector, cloud)
+	if err != nil {
+		condition := kubermaticv1.ExternalClusterCondition{
+			Phase:   kubermaticv1.ExternalClusterPhaseConfigError,
+			Message: err.Error(),
+		}
+		return reconcile.Result{RequeueAfter: 5 * time.Minute}, r.updateCAPIStatus(ctx, cluster, condition, nil)
+	}
+
+	return r.reconcileCAPICluster(ctx, log, cluster, managementClient)
+}
+
+func (r *Reconciler) reconcileCAPICluster(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.ExternalCluster, managementClient ctrlruntimeclient.Client) (reconcile.Result, error) {
+	cloud := cluster.Spec.CloudSpec.CAPI
+
+	condition, phase, err := capi.GetClusterStatus(ctx, managementClient, cloud)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			condition := kubermaticv1.ExternalClusterCondition{
+				Phase:   kubermaticv1.ExternalClusterPhaseError,
+				Message: err.Error(),
+			}
+			return reconcile.Result{}, r.updateCAPIStatus(ctx, cluster, condition, nil)
+		}
+		return reconcile.Result{}, err
+	}
+
+	status := &kubermaticv1.ExternalClusterCAPIStatus{Phase: phase}
+
+	if condition.Phase == kubermaticv1.ExternalClusterPhaseProvisioning {
+		// repeat after some time to get/store kubeconfig
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, r.updateCAPIStatus(ctx, cluster, *condition, status)
+	}
+
+	if condition.Phase == kubermaticv1.ExternalClusterPhaseRunning {
+		config, err := capi.GetClusterConfig(ctx, managementClient, cloud)
+		if err == nil {
+			err = r.ensureKubeconfigSecret(ctx, config, cluster)
+		}
+		if err != nil {
+			condition := kubermaticv1.ExternalClusterCondition{
+				Phase:   kubermaticv1.ExternalClusterPhaseError,
+				Message: err.Error(),
+			}
+			if err := r.updateCAPIStatus(ctx, cluster, condition, status); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, err
+		}
+
+		if err := r.scaleCAPIMachineDeployments(ctx, log, managementClient, cloud); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to scale MachineDeployments: %w", err)
+		}
+
+		status.MachineDeployments, err = capi.ListMachineDeployments(ctx, managementClient, cloud)
+		if err != nil {
+			log.Warnw("Failed to list MachineDeployments", zap.Error(err))
+		}
+	}
+
+	// updating status every 5 minutes
+	return reconcile.Result{RequeueAfter: 5 * time.Minute}, r.updateCAPIStatus(ctx, cluster, *condition, status)
+}
+
+// scaleCAPIMachineDeployments applies the desired replicas from the cloud spec to the
+// MachineDeployments in the management cluster.
+func (r *Reconciler) scaleCAPIMachineDeployments(ctx context.Context, log *zap.SugaredLogger, managementClient ctrlruntimeclient.Client, cloud *kubermaticv1.ExternalClusterCAPICloudSpec) error {
+	if len(cloud.MachineDeploymentReplicas) == 0 {
+		return nil
+	}
+
+	machineDeployments, err := capi.ListMachineDeployments(ctx, managementClient, cloud)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]int32{}
+	for _, md := range machineDeployments {
+		current[md.Name] = md.Replicas
+	}
+
+	names := sets.List(sets.KeySet(cloud.MachineDeploymentReplicas))
+	for _, name := range names {
+		replicas := cloud.MachineDeploymentReplicas[name]
+
+		if existing, ok := current[name]; ok && existing == replicas {
+			continue
+		}
+
+		log.Infow("Scaling MachineDeployment", "machinedeployment", name, "replicas", replicas)
+		if err := capi.ScaleMachineDeployment(ctx, managementClient, cloud, name, replicas); err != nil {
+			return fmt.Errorf("failed to scale MachineDeployment %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateCAPIStatus(ctx context.Context, cluster *kubermaticv1.ExternalCluster, condition kubermaticv1.ExternalClusterCondition, status *kubermaticv1.ExternalClusterCAPIStatus) error {
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.Condition = condition
+	if status != nil {
+		cluster.Status.CAPI = status
+	}
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to patch cluster with new phase %q: %w", condition.Phase, err)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/provider/cloud/capi"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testCAPIKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: https://workload.example.com:6443
+contexts:
+- name: workload
+  context:
+    cluster: workload
+    user: admin
+current-context: workload
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestReconcileCAPICluster(t *testing.T) {
+	tests := []struct {
+		name                       string
+		managementObjects          []ctrlruntimeclient.Object
+		machineDeploymentReplicas  map[string]int32
+		expectedCondition          kubermaticv1.ExternalClusterCondition
+		expectedStatus             *kubermaticv1.ExternalClusterCAPIStatus
+		expectedKubeconfigRecorded bool
+	}{
+		{
+			name: "provisioned cluster",
+			managementObjects: []ctrlruntimeclient.Object{
+				genCAPICluster("Provisioned"),
+				genCAPIMachineDeployment("workers", 2),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "capi", Name: "workload-kubeconfig"},
+					Data:       map[string][]byte{"value": []byte(testCAPIKubeconfig)},
+				},
+			},
+			expectedCondition: kubermaticv1.ExternalClusterCondition{
+				Phase: kubermaticv1.ExternalClusterPhaseRunning,
+			},
+			expectedStatus: &kubermaticv1.ExternalClusterCAPIStatus{
+				Phase: "Provisioned",
+				MachineDeployments: []kubermaticv1.CAPIMachineDeployment{
+					{Name: "workers", Replicas: 2},
+				},
+			},
+			expectedKubeconfigRecorded: true,
+		},
+		{
+			name: "scaled MachineDeployment",
+			managementObjects: []ctrlruntimeclient.Object{
+				genCAPICluster("Provisioned"),
+				genCAPIMachineDeployment("workers", 2),
+				genCAPIMachineDeployment("infra", 1),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "capi", Name: "workload-kubeconfig"},
+					Data:       map[string][]byte{"value": []byte(testCAPIKubeconfig)},
+				},
+			},
+			machineDeploymentReplicas: map[string]int32{"workers": 5},
+			expectedCondition: kubermaticv1.ExternalClusterCondition{
+				Phase: kubermaticv1.ExternalClusterPhaseRunning,
+			},
+			expectedStatus: &kubermaticv1.ExternalClusterCAPIStatus{
+				Phase: "Provisioned",
+				MachineDeployments: []kubermaticv1.CAPIMachineDeployment{
+					{Name: "infra", Replicas: 1},
+					{Name: "workers", Replicas: 5},
+				},
+			},
+			expectedKubeconfigRecorded: true,
+		},
+		{
+			name: "provisioning cluster",
+			managementObjects: []ctrlruntimeclient.Object{
+				genCAPICluster("Provisioning"),
+			},
+			expectedCondition: kubermaticv1.ExternalClusterCondition{
+				Phase: kubermaticv1.ExternalClusterPhaseProvisioning,
+			},
+			expectedStatus: &kubermaticv1.ExternalClusterCAPIStatus{
+				Phase: "Provisioning",
+			},
+		},
+		{
+			name: "missing cluster",
+			expectedCondition: kubermaticv1.ExternalClusterCondition{
+				Phase:   kubermaticv1.ExternalClusterPhaseError,
+				Message: `clusters.cluster.x-k8s.io "workload" not found`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cluster := &kubermaticv1.ExternalCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ExternalClusterSpec{
+					HumanReadableName: "test",
+					CloudSpec: kubermaticv1.ExternalClusterCloudSpec{
+						ProviderName: kubermaticv1.ExternalClusterCAPIProvider,
+						CAPI: &kubermaticv1.ExternalClusterCAPICloudSpec{
+							Namespace:                 "capi",
+							Name:                      "workload",
+							MachineDeploymentReplicas: test.machineDeploymentReplicas,
+						},
+					},
+				},
+			}
+
+			kubermaticFakeClient := fake.NewClientBuilder().WithObjects(cluster).Build()
+			managementClient := fakectrlruntimeclient.NewClientBuilder().
+				WithScheme(capiScheme(t)).
+				WithObjects(test.managementObjects...).
+				Build()
+
+			target := Reconciler{
+				Client: kubermaticFakeClient,
+				log:    kubermaticlog.Logger,
+			}
+
+			if _, err := target.reconcileCAPICluster(ctx, target.log, cluster, managementClient); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			updated := &kubermaticv1.ExternalCluster{}
+			if err := kubermaticFakeClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cluster), updated); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			if updated.Status.Condition != test.expectedCondition {
+				t.Errorf("expected condition %+v, got %+v", test.expectedCondition, updated.Status.Condition)
+			}
+
+			if !diff.SemanticallyEqual(test.expectedStatus, updated.Status.CAPI) {
+				t.Errorf("CAPI status differs from expected:\n%v", diff.ObjectDiff(test.expectedStatus, updated.Status.CAPI))
+			}
+
+			if recorded := updated.Spec.KubeconfigReference != nil; recorded != test.expectedKubeconfigRecorded {
+				t.Fatalf("expected kubeconfig to be recorded: %v, got %v", test.expectedKubeconfigRecorded, recorded)
+			}
+
+			if test.expectedKubeconfigRecorded {
+				secret := &corev1.Secret{}
+				key := ctrlruntimeclient.ObjectKey{Namespace: resources.KubermaticNamespace, Name: updated.GetKubeconfigSecretName()}
+				if err := kubermaticFakeClient.Get(ctx, key, secret); err != nil {
+					t.Fatalf("failed to get kubeconfig secret: %v", err)
+				}
+				if len(secret.Data[resources.ExternalClusterKubeconfig]) == 0 {
+					t.Error("expected kubeconfig secret to contain a kubeconfig")
+				}
+			}
+		})
+	}
+}
+
+func capiScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	scheme.AddKnownTypeWithName(capi.ClusterGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(capi.MachineDeploymentGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(capi.MachineDeploymentListGVK, &unstructured.UnstructuredList{})
+
+	return scheme
+}
+
+func genCAPICluster(phase string) *unstructured.Unstructured {
+	cluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase": phase,
+		},
+	}}
+	cluster.SetGroupVersionKind(capi.ClusterGVK)
+	cluster.SetNamespace("capi")
+	cluster.SetName("workload")
+
+	return cluster
+}
+
+func genCAPIMachineDeployment(name string, replicas int64) *unstructured.Unstructured {
+	md := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	}}
+	md.SetGroupVersionKind(capi.MachineDeploymentGVK)
+	md.SetNamespace("capi")
+	md.SetName(name)
+	md.SetLabels(map[string]string{capi.ClusterNameLabel: "workload"})
+
+	return md
+}
@@ -77,10 +77,10 @@ func Add(
 		return err
 	}
 
-	// Watch for changes to ExternalCluster except KubeOne and generic clusters.
+	// Watch for changes to ExternalCluster except KubeOne clusters.
 	skipKubeOneClusters := predicate.NewPredicateFuncs(func(object ctrlruntimeclient.Object) bool {
 		externalCluster, ok := object.(*kubermaticv1.ExternalCluster)
-		return ok && externalCluster.Spec.CloudSpec.ProviderName != kubermaticv1.ExternalClusterKubeOneProvider && externalCluster.Spec.CloudSpec.ProviderName != kubermaticv1.ExternalClusterBringYourOwnProvider
+		return ok && externalCluster.Spec.CloudSpec.ProviderName != kubermaticv1.ExternalClusterKubeOneProvider
 	})
 
 	return c.Watch(source.Kind(mgr.GetCache(), &kubermaticv1.ExternalCluster{}), &handler.EnqueueRequestForObject{}, skipKubeOneClusters, predicate.GenerationChangedPredicate{})
@@ -138,12 +138,13 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)
 
 	if cloud.ProviderName == kubermaticv1.ExternalClusterBringYourOwnProvider {
-		if cluster.Spec.KubeconfigReference != nil {
-			if err := kuberneteshelper.TryAddFinalizer(ctx, r.Client, cluster, kubermaticv1.ExternalClusterKubeconfigCleanupFinalizer); err != nil {
-				return reconcile.Result{}, fmt.Errorf("failed to add kubeconfig secret finalizer: %w", err)
-			}
-		}
-		return reconcile.Result{}, nil
+		log.Debug("Reconciling imported cluster")
+		return r.reconcileBringYourOwn(ctx, cluster)
+	}
+
+	if cloud.CAPI != nil {
+		log.Debug("Reconciling Cluster API cluster")
+		return r.reconcileCAPI(ctx, log, cluster)
 	}
 
 	if cloud.GKE != nil {
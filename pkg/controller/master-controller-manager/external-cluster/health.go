@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	k8cequality "k8c.io/kubermatic/v2/pkg/apis/equality"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// healthProbeInterval is the interval in which imported clusters are probed.
+	healthProbeInterval = 5 * time.Minute
+
+	// kubeconfigExpiryWarningPeriod is the period before the expiry of a kubeconfig
+	// in which the cluster is marked with a warning.
+	kubeconfigExpiryWarningPeriod = 14 * 24 * time.Hour
+)
+
+// reconcileBringYourOwn probes an imported cluster and records its health in the status.
+func (r *Reconciler) reconcileBringYourOwn(ctx context.Context, cluster *kubermaticv1.ExternalCluster) (reconcile.Result, error) {
+	if cluster.Spec.KubeconfigReference == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if err := kuberneteshelper.TryAddFinalizer(ctx, r.Client, cluster, kubermaticv1.ExternalClusterKubeconfigCleanupFinalizer); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to add kubeconfig secret finalizer: %w", err)
+	}
+
+	secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, r.Client)
+	rawKubeconfig, err := secretKeySelector(cluster.Spec.KubeconfigReference, resources.ExternalClusterKubeconfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	health, condition := probeCluster(ctx, []byte(rawKubeconfig), time.Now())
+	if condition != cluster.Status.Condition && condition.Phase != kubermaticv1.ExternalClusterPhaseRunning && r.recorder != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ClusterUnhealthy", condition.Message)
+	}
+
+	if err := r.updateHealth(ctx, cluster, condition, health); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: healthProbeInterval}, nil
+}
+
+// probeCluster determines the health of the cluster reachable with the given kubeconfig.
+func probeCluster(ctx context.Context, rawKubeconfig []byte, now time.Time) (*kubermaticv1.ExternalClusterHealth, kubermaticv1.ExternalClusterCondition) {
+	health := &kubermaticv1.ExternalClusterHealth{
+		LastProbeTime: metav1.NewTime(now),
+		APIServer:     kubermaticv1.HealthStatusDown,
+	}
+
+	kubeconfig, err := clientcmd.Load(rawKubeconfig)
+	if err != nil {
+		return health, configError(fmt.Errorf("invalid kubeconfig: %w", err))
+	}
+
+	expiry, err := kubeconfigExpiry(kubeconfig)
+	if err != nil {
+		return health, configError(err)
+	}
+	if expiry != nil {
+		health.KubeconfigExpiry = &metav1.Time{Time: *expiry}
+		if !expiry.After(now) {
+			return health, configError(fmt.Errorf("the client certificate of the kubeconfig expired at %s", expiry.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*kubeconfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return health, configError(fmt.Errorf("invalid kubeconfig: %w", err))
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return health, configError(fmt.Errorf("failed to create client: %w", err))
+	}
+
+	version, err := kuberneteshelper.GetVersion(client)
+	if err != nil {
+		return health, kubermaticv1.ExternalClusterCondition{
+			Phase:   kubermaticv1.ExternalClusterPhaseConnectionError,
+			Message: fmt.Sprintf("failed to reach the API server: %v", err),
+		}
+	}
+	health.APIServer = kubermaticv1.HealthStatusUp
+	health.Version = version
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return health, kubermaticv1.ExternalClusterCondition{
+			Phase:   kubermaticv1.ExternalClusterPhaseKubeClientError,
+			Message: fmt.Sprintf("failed to list nodes: %v", err),
+		}
+	}
+	summarizeNodes(health, nodes.Items)
+
+	condition := kubermaticv1.ExternalClusterCondition{
+		Phase: kubermaticv1.ExternalClusterPhaseRunning,
+	}
+
+	switch {
+	case expiry != nil && expiry.Sub(now) < kubeconfigExpiryWarningPeriod:
+		condition = kubermaticv1.ExternalClusterCondition{
+			Phase:   kubermaticv1.ExternalClusterPhaseWarning,
+			Message: fmt.Sprintf("the client certificate of the kubeconfig expires at %s", expiry.UTC().Format(time.RFC3339)),
+		}
+	case health.ReadyNodes < health.Nodes:
+		condition = kubermaticv1.ExternalClusterCondition{
+			Phase:   kubermaticv1.ExternalClusterPhaseWarning,
+			Message: fmt.Sprintf("%d of %d nodes are not ready", health.Nodes-health.ReadyNodes, health.Nodes),
+		}
+	}
+
+	return health, condition
+}
+
+func configError(err error) kubermaticv1.ExternalClusterCondition {
+	return kubermaticv1.ExternalClusterCondition{
+		Phase:   kubermaticv1.ExternalClusterPhaseConfigError,
+		Message: err.Error(),
+	}
+}
+
+func summarizeNodes(health *kubermaticv1.ExternalClusterHealth, nodes []corev1.Node) {
+	health.Nodes = len(nodes)
+	health.Capacity = corev1.ResourceList{}
+	health.Allocatable = corev1.ResourceList{}
+
+	for _, node := range nodes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				health.ReadyNodes++
+			}
+		}
+
+		addResources(health.Capacity, node.Status.Capacity)
+		addResources(health.Allocatable, node.Status.Allocatable)
+	}
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum, ok := total[name]
+		if !ok {
+			sum = resource.Quantity{}
+		}
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// kubeconfigExpiry returns the expiry of the client certificate used by the current context of the
+// kubeconfig, or nil if it does not authenticate with an embedded client certificate.
+func kubeconfigExpiry(kubeconfig *api.Config) (*time.Time, error) {
+	kubeContext, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig has no context %q", kubeconfig.CurrentContext)
+	}
+
+	authInfo, ok := kubeconfig.AuthInfos[kubeContext.AuthInfo]
+	if !ok || len(authInfo.ClientCertificateData) == 0 {
+		return nil, nil
+	}
+
+	block, _ := pem.Decode(authInfo.ClientCertificateData)
+	if block == nil {
+		return nil, errors.New("client certificate of the kubeconfig is not PEM-encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate of the kubeconfig: %w", err)
+	}
+
+	return &cert.NotAfter, nil
+}
+
+// updateHealth updates the condition and health of the cluster. As every update of the status triggers a
+// new reconciliation, the probe time alone is only refreshed once per probe interval.
+func (r *Reconciler) updateHealth(ctx context.Context, cluster *kubermaticv1.ExternalCluster, condition kubermaticv1.ExternalClusterCondition, health *kubermaticv1.ExternalClusterHealth) error {
+	if old := cluster.Status.Health; old != nil && cluster.Status.Condition == condition {
+		probed := old.DeepCopy()
+		probed.LastProbeTime = health.LastProbeTime
+		if k8cequality.Semantic.DeepEqual(probed, health) && health.LastProbeTime.Sub(old.LastProbeTime.Time) < healthProbeInterval/2 {
+			return nil
+		}
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.Condition = condition
+	cluster.Status.Health = health
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to patch cluster health: %w", err)
+	}
+	return nil
+}
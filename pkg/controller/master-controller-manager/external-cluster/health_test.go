@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalcluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestProbeClusterDetectsExpiredKubeconfig(t *testing.T) {
+	now := time.Now()
+	kubeconfig := genKubeconfig(t, now.Add(-time.Hour))
+
+	health, condition := probeCluster(context.Background(), kubeconfig, now)
+	if condition.Phase != kubermaticv1.ExternalClusterPhaseConfigError {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.ExternalClusterPhaseConfigError, condition.Phase)
+	}
+	if health.APIServer != kubermaticv1.HealthStatusDown {
+		t.Fatalf("expected API server to be %q, got %q", kubermaticv1.HealthStatusDown, health.APIServer)
+	}
+	if health.KubeconfigExpiry == nil {
+		t.Fatal("expected kubeconfig expiry to be recorded")
+	}
+}
+
+func TestKubeconfigExpiry(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	kubeconfig, err := clientcmd.Load(genKubeconfig(t, notAfter))
+	if err != nil {
+		t.Fatalf("failed to load kubeconfig: %v", err)
+	}
+
+	expiry, err := kubeconfigExpiry(kubeconfig)
+	if err != nil {
+		t.Fatalf("failed to determine expiry: %v", err)
+	}
+	if expiry == nil || !expiry.Equal(notAfter) {
+		t.Fatalf("expected expiry %v, got %v", notAfter, expiry)
+	}
+
+	kubeconfig.AuthInfos["test"] = &api.AuthInfo{Token: "token"}
+	expiry, err = kubeconfigExpiry(kubeconfig)
+	if err != nil {
+		t.Fatalf("failed to determine expiry: %v", err)
+	}
+	if expiry != nil {
+		t.Fatalf("expected no expiry for token kubeconfig, got %v", expiry)
+	}
+}
+
+func TestSummarizeNodes(t *testing.T) {
+	nodes := []corev1.Node{
+		genNode(corev1.ConditionTrue, "4", "16Gi"),
+		genNode(corev1.ConditionFalse, "2", "8Gi"),
+		genNode(corev1.ConditionTrue, "2", "8Gi"),
+	}
+
+	health := &kubermaticv1.ExternalClusterHealth{}
+	summarizeNodes(health, nodes)
+
+	if health.Nodes != 3 || health.ReadyNodes != 2 {
+		t.Fatalf("expected 2 of 3 nodes to be ready, got %d of %d", health.ReadyNodes, health.Nodes)
+	}
+
+	cpu := health.Capacity[corev1.ResourceCPU]
+	if cpu.Cmp(resource.MustParse("8")) != 0 {
+		t.Errorf("expected CPU capacity of 8, got %s", cpu.String())
+	}
+
+	memory := health.Allocatable[corev1.ResourceMemory]
+	if memory.Cmp(resource.MustParse("32Gi")) != 0 {
+		t.Errorf("expected allocatable memory of 32Gi, got %s", memory.String())
+	}
+}
+
+func genNode(ready corev1.ConditionStatus, cpu, memory string) corev1.Node {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+
+	return corev1.Node{
+		Status: corev1.NodeStatus{
+			Capacity:    resources,
+			Allocatable: resources,
+			Conditions: []corev1.NodeCondition{{
+				Type:   corev1.NodeReady,
+				Status: ready,
+			}},
+		},
+	}
+}
+
+func genKubeconfig(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	kubeconfig, err := clientcmd.Write(api.Config{
+		CurrentContext: "test",
+		Clusters: map[string]*api.Cluster{
+			"test": {Server: "https://127.0.0.1:6443"},
+		},
+		AuthInfos: map[string]*api.AuthInfo{
+			"test": {ClientCertificateData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})},
+		},
+		Contexts: map[string]*api.Context{
+			"test": {Cluster: "test", AuthInfo: "test"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode kubeconfig: %v", err)
+	}
+
+	return kubeconfig
+}
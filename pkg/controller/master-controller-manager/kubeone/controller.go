@@ -80,6 +80,9 @@ const (
 	// MigrateContainerRuntimeAction is the action to migrate kubeone container-runtime.
 	MigrateContainerRuntimeAction = "migrate"
 
+	// ApplyAction is the action to apply changes of the kubeone manifest to the cluster.
+	ApplyAction = "apply"
+
 	// KubeOneImportJob is the name of kubeone job performing import.
 	KubeOneImportJob = "kubeone-import"
 
@@ -92,6 +95,12 @@ const (
 	// KubeOneMigrateJob is the name of kubeone job performing container-runtime migration.
 	KubeOneMigrateJob = "kubeone-migrate"
 
+	// KubeOneApplyJob is the name of kubeone job applying manifest changes.
+	KubeOneApplyJob = "kubeone-apply"
+
+	// kubeOneJobHistoryLimit is the number of kubeone jobs kept in the cluster status.
+	kubeOneJobHistoryLimit = 10
+
 	// KubeOneImportConfigMap is the name of kubeone configmap which stores import action script.
 	KubeOneImportConfigMap = "kubeone-import"
 
@@ -100,6 +109,9 @@ const (
 
 	// KubeOneMigrateConfigMap is the name of kubeone configmap which stores migrate action script.
 	KubeOneMigrateConfigMap = "kubeone-migrate"
+
+	// KubeOneApplyConfigMap is the name of kubeone configmap which stores apply action script.
+	KubeOneApplyConfigMap = "kubeone-apply"
 )
 
 type reconciler struct {
@@ -267,6 +279,10 @@ func (r *reconciler) reconcile(ctx context.Context, externalClusterName string,
 		return err
 	}
 
+	if err = r.applyAction(ctx, log, externalCluster); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -505,6 +521,14 @@ func (r *reconciler) initiateImportCluster(ctx context.Context,
 	// job failed.
 	if job.Status.Failed > KubeOneJobBackOffLimit {
 		log.Info("Kubeone import failed!")
+		if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+			Action:  kubermaticv1.KubeOneImportAction,
+			JobName: job.Name,
+			Phase:   kubermaticv1.KubeOneJobPhaseFailed,
+			Message: fmt.Sprintf("failed to import cluster %s", externalCluster.Name),
+		}); err != nil {
+			return err
+		}
 		// update kubeone externalcluster status.
 		if err := r.updateClusterStatus(ctx, externalCluster, kubermaticv1.ExternalClusterCondition{
 			Phase:   kubermaticv1.ExternalClusterPhaseError,
@@ -521,6 +545,14 @@ func (r *reconciler) initiateImportCluster(ctx context.Context,
 		return errors.New("kubeone import failed")
 	}
 
+	if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+		Action:  kubermaticv1.KubeOneImportAction,
+		JobName: job.Name,
+		Phase:   kubermaticv1.KubeOneJobPhaseRunning,
+	}); err != nil {
+		return err
+	}
+
 	podList := &corev1.PodList{}
 	err = r.List(ctx,
 		podList,
@@ -586,6 +618,13 @@ func (r *reconciler) initiateImportCluster(ctx context.Context,
 	}); err != nil {
 		return err
 	}
+	if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+		Action:  kubermaticv1.KubeOneImportAction,
+		JobName: job.Name,
+		Phase:   kubermaticv1.KubeOneJobPhaseSucceeded,
+	}); err != nil {
+		return err
+	}
 	// delete kubeone job alongwith its pods as no longer required.
 	propagationPolicy := metav1.DeletePropagationBackground
 	err = r.Delete(ctx, job, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &propagationPolicy})
@@ -637,6 +676,13 @@ func (r *reconciler) upgradeAction(ctx context.Context,
 		}); err != nil {
 			return err
 		}
+		if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+			Action:  kubermaticv1.KubeOneUpgradeAction,
+			JobName: KubeOneUpgradeJob,
+			Phase:   kubermaticv1.KubeOneJobPhaseSucceeded,
+		}); err != nil {
+			return err
+		}
 		// delete kubeone job alongwith its pods as no longer required.
 		propagationPolicy := metav1.DeletePropagationBackground
 
@@ -694,6 +740,14 @@ func (r *reconciler) upgradeAction(ctx context.Context,
 	}); err != nil {
 		return err
 	}
+	if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+		Action:       kubermaticv1.KubeOneUpgradeAction,
+		JobName:      KubeOneUpgradeJob,
+		Phase:        kubermaticv1.KubeOneJobPhaseRunning,
+		ManifestHash: manifestHash(patchManifest),
+	}); err != nil {
+		return err
+	}
 
 	err = r.initiateClusterUpgrade(ctx, log, *currentVersion, desiredVersion, externalCluster)
 	if err != nil {
@@ -739,6 +793,14 @@ func (r *reconciler) initiateClusterUpgrade(ctx context.Context,
 		}); err != nil {
 			return err
 		}
+		if err := r.recordJob(ctx, cluster, kubermaticv1.KubeOneJobStatus{
+			Action:  kubermaticv1.KubeOneUpgradeAction,
+			JobName: job.Name,
+			Phase:   kubermaticv1.KubeOneJobPhaseFailed,
+			Message: cluster.Status.Condition.Message,
+		}); err != nil {
+			return err
+		}
 		// delete kubeone job alongwith its pods as no longer required.
 		propagationPolicy := metav1.DeletePropagationBackground
 		err = r.Delete(ctx, job, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &propagationPolicy})
@@ -785,6 +847,13 @@ func (r *reconciler) migrateAction(ctx context.Context,
 		}); err != nil {
 			return err
 		}
+		if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+			Action:  kubermaticv1.KubeOneMigrateAction,
+			JobName: KubeOneMigrateJob,
+			Phase:   kubermaticv1.KubeOneJobPhaseSucceeded,
+		}); err != nil {
+			return err
+		}
 		// delete kubeone job alongwith its pods as no longer required.
 		propagationPolicy := metav1.DeletePropagationBackground
 
@@ -894,6 +963,14 @@ func (r *reconciler) initiateClusterMigration(ctx context.Context,
 		}); err != nil {
 			return err
 		}
+		if err := r.recordJob(ctx, cluster, kubermaticv1.KubeOneJobStatus{
+			Action:  kubermaticv1.KubeOneMigrateAction,
+			JobName: job.Name,
+			Phase:   kubermaticv1.KubeOneJobPhaseFailed,
+			Message: cluster.Status.Condition.Message,
+		}); err != nil {
+			return err
+		}
 		// delete kubeone job alongwith its pods as no longer required.
 		propagationPolicy := metav1.DeletePropagationBackground
 		err = r.Delete(ctx, job, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &propagationPolicy})
@@ -903,6 +980,157 @@ func (r *reconciler) initiateClusterMigration(ctx context.Context,
 		return errors.New("kubeone migration failed")
 	}
 
+	return r.recordJob(ctx, cluster, kubermaticv1.KubeOneJobStatus{
+		Action:  kubermaticv1.KubeOneMigrateAction,
+		JobName: job.Name,
+		Phase:   kubermaticv1.KubeOneJobPhaseRunning,
+	})
+}
+
+func (r *reconciler) applyAction(ctx context.Context,
+	log *zap.SugaredLogger,
+	externalCluster *kubermaticv1.ExternalCluster) error {
+	kubeOne := externalCluster.Spec.CloudSpec.KubeOne
+	if !hasManifestSpec(kubeOne) {
+		return nil
+	}
+
+	if externalCluster.Status.Condition.Phase == kubermaticv1.KubeOnePhaseReconcilingApply {
+		return r.checkApplyJob(ctx, log, externalCluster)
+	}
+
+	desiredPhases := []string{
+		string(kubermaticv1.ExternalClusterPhaseError),
+		string(kubermaticv1.ExternalClusterPhaseRunning),
+	}
+	if !sets.NewString(desiredPhases...).Has(string(externalCluster.Status.Condition.Phase)) {
+		return nil
+	}
+
+	manifestRef := kubeOne.ManifestReference
+	manifestSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: manifestRef.Namespace, Name: manifestRef.Name}, manifestSecret); err != nil {
+		return err
+	}
+
+	desiredManifest, err := renderManifest(manifestSecret.Data[resources.KubeOneManifest], kubeOne)
+	if err != nil {
+		return err
+	}
+	desiredHash := manifestHash(desiredManifest)
+
+	if status := externalCluster.Status.KubeOne; status != nil {
+		if status.ManifestHash == desiredHash {
+			return nil
+		}
+		// do not retry applying a manifest that already failed, the spec has to be changed first
+		if lastApply := lastJob(status, kubermaticv1.KubeOneApplyAction); lastApply != nil &&
+			lastApply.Phase == kubermaticv1.KubeOneJobPhaseFailed && lastApply.ManifestHash == desiredHash {
+			return nil
+		}
+	}
+
+	log.Info("Applying kubeone manifest...")
+
+	oldManifestSecret := manifestSecret.DeepCopy()
+	manifestSecret.Data = map[string][]byte{
+		resources.KubeOneManifest: desiredManifest,
+	}
+	if err := r.Patch(ctx, manifestSecret, ctrlruntimeclient.MergeFrom(oldManifestSecret)); err != nil {
+		return fmt.Errorf("failed to update kubeone manifest secret %s/%s: %w", manifestSecret.Name, manifestSecret.Namespace, err)
+	}
+	if _, err := kubernetesprovider.CreateOrUpdateSecretForCluster(ctx, r, externalCluster, manifestSecret.Data, manifestSecret.Name, externalCluster.GetKubeOneNamespaceName()); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+		return err
+	}
+
+	if err := r.updateClusterStatus(ctx, externalCluster, kubermaticv1.ExternalClusterCondition{
+		Phase:   kubermaticv1.KubeOnePhaseReconcilingApply,
+		Message: fmt.Sprintf("applying kubeone manifest to cluster %s", externalCluster.Name),
+	}); err != nil {
+		return err
+	}
+	if err := r.recordJob(ctx, externalCluster, kubermaticv1.KubeOneJobStatus{
+		Action:       kubermaticv1.KubeOneApplyAction,
+		JobName:      KubeOneApplyJob,
+		Phase:        kubermaticv1.KubeOneJobPhaseRunning,
+		ManifestHash: desiredHash,
+	}); err != nil {
+		return err
+	}
+
+	job, err := r.generateKubeOneActionJob(ctx, log, externalCluster, ApplyAction)
+	if err != nil {
+		return fmt.Errorf("could not generate kubeone job: %w", err)
+	}
+
+	log.Info("Creating kubeone job to apply manifest...")
+	if err := r.Create(ctx, job); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("could not create kubeone job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+
+	return nil
+}
+
+// checkApplyJob updates the cluster status once the job applying the kubeone manifest has finished.
+func (r *reconciler) checkApplyJob(ctx context.Context,
+	log *zap.SugaredLogger,
+	externalCluster *kubermaticv1.ExternalCluster) error {
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: externalCluster.GetKubeOneNamespaceName(), Name: KubeOneApplyJob}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the job is gone before we could observe its result, reset the phase to apply the manifest again
+			return r.updateClusterStatus(ctx, externalCluster, kubermaticv1.ExternalClusterCondition{
+				Phase: kubermaticv1.ExternalClusterPhaseRunning,
+			})
+		}
+		return err
+	}
+
+	var phase kubermaticv1.KubeOneJobPhase
+	switch {
+	case job.Status.Succeeded > 0:
+		log.Info("KubeOne manifest applied!")
+		phase = kubermaticv1.KubeOneJobPhaseSucceeded
+		if err := r.updateClusterStatus(ctx, externalCluster, kubermaticv1.ExternalClusterCondition{
+			Phase: kubermaticv1.ExternalClusterPhaseRunning,
+		}); err != nil {
+			return err
+		}
+	case job.Status.Failed > KubeOneJobBackOffLimit:
+		log.Info("Kubeone apply failed!")
+		phase = kubermaticv1.KubeOneJobPhaseFailed
+		if err := r.updateClusterStatus(ctx, externalCluster, kubermaticv1.ExternalClusterCondition{
+			Phase:   kubermaticv1.ExternalClusterPhaseError,
+			Message: fmt.Sprintf("applying kubeone manifest to cluster %s failed", externalCluster.Name),
+		}); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	entry := kubermaticv1.KubeOneJobStatus{
+		Action:  kubermaticv1.KubeOneApplyAction,
+		JobName: job.Name,
+		Phase:   phase,
+	}
+	if phase == kubermaticv1.KubeOneJobPhaseFailed {
+		entry.Message = externalCluster.Status.Condition.Message
+	}
+	if err := r.recordJob(ctx, externalCluster, entry); err != nil {
+		return err
+	}
+
+	// delete kubeone job alongwith its pods as no longer required.
+	propagationPolicy := metav1.DeletePropagationBackground
+	err := r.Delete(ctx, job, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if phase == kubermaticv1.KubeOneJobPhaseFailed {
+		return errors.New("kubeone apply failed")
+	}
+
 	return nil
 }
 
@@ -1003,6 +1231,9 @@ func (r *reconciler) generateKubeOneActionJob(ctx context.Context, log *zap.Suga
 	case action == MigrateContainerRuntimeAction:
 		kubeoneJobName = KubeOneMigrateJob
 		kubeoneCMName = KubeOneMigrateConfigMap
+	case action == ApplyAction:
+		kubeoneJobName = KubeOneApplyJob
+		kubeoneCMName = KubeOneApplyConfigMap
 	}
 
 	job := &batchv1.Job{
@@ -1399,6 +1630,9 @@ func generateConfigMap(namespace, action string) *corev1.ConfigMap {
 	case action == MigrateContainerRuntimeAction:
 		name = KubeOneMigrateConfigMap
 		scriptToRun += "kubeone migrate to-containerd --manifest kubeonemanifest/manifest --log-format json"
+	case action == ApplyAction:
+		name = KubeOneApplyConfigMap
+		scriptToRun += "kubeone apply --manifest kubeonemanifest/manifest -y --log-format json"
 	}
 
 	return &corev1.ConfigMap{
@@ -1482,6 +1716,61 @@ func (r *reconciler) updateClusterStatus(ctx context.Context,
 	return nil
 }
 
+// recordJob adds the given job to the kubeone job history of the cluster, or updates its entry
+// if the job is already recorded as running.
+func (r *reconciler) recordJob(ctx context.Context,
+	externalCluster *kubermaticv1.ExternalCluster,
+	entry kubermaticv1.KubeOneJobStatus) error {
+	original := externalCluster.DeepCopy()
+	if externalCluster.Status.KubeOne == nil {
+		externalCluster.Status.KubeOne = &kubermaticv1.ExternalClusterKubeOneStatus{}
+	}
+	status := externalCluster.Status.KubeOne
+
+	now := metav1.Now()
+	entry.StartTime = &now
+	if entry.Phase != kubermaticv1.KubeOneJobPhaseRunning {
+		entry.CompletionTime = &now
+	}
+
+	if len(status.Jobs) > 0 && status.Jobs[0].JobName == entry.JobName && status.Jobs[0].Phase == kubermaticv1.KubeOneJobPhaseRunning {
+		if entry.Phase == kubermaticv1.KubeOneJobPhaseRunning {
+			return nil
+		}
+		entry.StartTime = status.Jobs[0].StartTime
+		if entry.ManifestHash == "" {
+			entry.ManifestHash = status.Jobs[0].ManifestHash
+		}
+		status.Jobs[0] = entry
+	} else {
+		status.Jobs = append([]kubermaticv1.KubeOneJobStatus{entry}, status.Jobs...)
+	}
+
+	if len(status.Jobs) > kubeOneJobHistoryLimit {
+		status.Jobs = status.Jobs[:kubeOneJobHistoryLimit]
+	}
+
+	if entry.Phase == kubermaticv1.KubeOneJobPhaseSucceeded && entry.ManifestHash != "" {
+		status.ManifestHash = entry.ManifestHash
+	}
+
+	if err := r.Patch(ctx, externalCluster, ctrlruntimeclient.MergeFrom(original)); err != nil {
+		r.log.Errorw("failed to update external cluster kubeone job history", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// lastJob returns the most recent job of the given action, if any.
+func lastJob(status *kubermaticv1.ExternalClusterKubeOneStatus, action kubermaticv1.KubeOneAction) *kubermaticv1.KubeOneJobStatus {
+	for i := range status.Jobs {
+		if status.Jobs[i].Action == action {
+			return &status.Jobs[i]
+		}
+	}
+	return nil
+}
+
 func (r *reconciler) getKubeOneSecret(ctx context.Context, ref providerconfig.GlobalSecretKeySelector) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 	if err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeone
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	kubeonescheme "k8c.io/kubeone/pkg/apis/kubeone/scheme"
+	kubeonev1beta1 "k8c.io/kubeone/pkg/apis/kubeone/v1beta1"
+	kubeonev1beta2 "k8c.io/kubeone/pkg/apis/kubeone/v1beta2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// PoolLabel is added to all nodes of a KubeOne host pool.
+const PoolLabel = "kubeone.k8c.io/pool"
+
+// hasManifestSpec returns true if the KubeOne manifest of the cluster is (partially)
+// managed via the structured fields of the ExternalCluster.
+func hasManifestSpec(spec *kubermaticv1.ExternalClusterKubeOneCloudSpec) bool {
+	return spec.ControlPlane != nil || spec.WorkerPools != nil || spec.Addons != nil
+}
+
+// renderManifest applies the host pools and addons configured on the ExternalCluster to the
+// given KubeOne manifest. Everything not covered by the structured spec is left untouched.
+// Manifests using the v1beta1 API are rendered as v1beta2.
+func renderManifest(manifest []byte, spec *kubermaticv1.ExternalClusterKubeOneCloudSpec) ([]byte, error) {
+	kubeOneCluster, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode kubeone manifest: %w", err)
+	}
+
+	if spec.ControlPlane != nil {
+		kubeOneCluster.ControlPlane.Hosts = poolHosts(*spec.ControlPlane)
+	}
+
+	if spec.WorkerPools != nil {
+		hosts := []kubeonev1beta2.HostConfig{}
+		for _, pool := range spec.WorkerPools {
+			hosts = append(hosts, poolHosts(pool)...)
+		}
+		kubeOneCluster.StaticWorkers.Hosts = hosts
+	}
+
+	if spec.Addons != nil {
+		addons := &kubeonev1beta2.Addons{
+			Enable:       true,
+			GlobalParams: spec.Addons.GlobalParams,
+		}
+		for _, addon := range spec.Addons.Addons {
+			addons.Addons = append(addons.Addons, kubeonev1beta2.Addon{
+				Name:   addon.Name,
+				Params: addon.Params,
+				Delete: addon.Delete,
+			})
+		}
+		kubeOneCluster.Addons = addons
+	}
+
+	rendered, err := yaml.Marshal(kubeOneCluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode kubeone cluster manifest config as YAML: %w", err)
+	}
+
+	return rendered, nil
+}
+
+// decodeManifest decodes a KubeOne manifest into the v1beta2 API, converting it if it uses v1beta1.
+func decodeManifest(manifest []byte) (*kubeonev1beta2.KubeOneCluster, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(manifest, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	kubeOneCluster := &kubeonev1beta2.KubeOneCluster{}
+
+	if typeMeta.APIVersion != kubeonev1beta1.SchemeGroupVersion.String() {
+		if err := yaml.UnmarshalStrict(manifest, kubeOneCluster); err != nil {
+			return nil, err
+		}
+
+		return kubeOneCluster, nil
+	}
+
+	v1beta1Cluster := &kubeonev1beta1.KubeOneCluster{}
+	if err := yaml.UnmarshalStrict(manifest, v1beta1Cluster); err != nil {
+		return nil, err
+	}
+
+	// there is no direct conversion between the versions, only to and from the internal API
+	internalCluster := &kubeoneapi.KubeOneCluster{}
+	if err := kubeonescheme.Scheme.Convert(v1beta1Cluster, internalCluster, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert v1beta1 manifest: %w", err)
+	}
+	if err := kubeonescheme.Scheme.Convert(internalCluster, kubeOneCluster, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert v1beta1 manifest: %w", err)
+	}
+
+	kubeOneCluster.APIVersion = kubeonev1beta2.SchemeGroupVersion.String()
+	kubeOneCluster.Kind = "KubeOneCluster"
+
+	return kubeOneCluster, nil
+}
+
+func poolHosts(pool kubermaticv1.KubeOneHostPool) []kubeonev1beta2.HostConfig {
+	labels := map[string]string{}
+	for k, v := range pool.Labels {
+		labels[k] = v
+	}
+	labels[PoolLabel] = pool.Name
+
+	hosts := []kubeonev1beta2.HostConfig{}
+	for _, host := range pool.Hosts {
+		hosts = append(hosts, kubeonev1beta2.HostConfig{
+			PublicAddress:  host.PublicAddress,
+			PrivateAddress: host.PrivateAddress,
+			Hostname:       host.Hostname,
+			SSHUsername:    pool.SSHUsername,
+			SSHPort:        pool.SSHPort,
+			Bastion:        pool.Bastion,
+			BastionUser:    pool.BastionUser,
+			BastionPort:    pool.BastionPort,
+			Labels:         labels,
+			Taints:         pool.Taints,
+		})
+	}
+
+	return hosts
+}
+
+func manifestHash(manifest []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(manifest))
+}
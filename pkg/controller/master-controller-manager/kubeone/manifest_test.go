@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeone
+
+import (
+	"testing"
+
+	kubeonev1beta2 "k8c.io/kubeone/pkg/apis/kubeone/v1beta2"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	"sigs.k8s.io/yaml"
+)
+
+const testManifest = `apiVersion: kubeone.k8c.io/v1beta2
+kind: KubeOneCluster
+name: test
+versions:
+  kubernetes: 1.26.4
+cloudProvider:
+  none: {}
+controlPlane:
+  hosts:
+  - publicAddress: 10.0.0.1
+    privateAddress: 192.168.0.1
+staticWorkers:
+  hosts:
+  - publicAddress: 10.0.1.1
+`
+
+func TestRenderManifest(t *testing.T) {
+	testCases := []struct {
+		name         string
+		spec         kubermaticv1.ExternalClusterKubeOneCloudSpec
+		controlPlane []kubeonev1beta2.HostConfig
+		workers      []kubeonev1beta2.HostConfig
+		addons       *kubeonev1beta2.Addons
+	}{
+		{
+			name: "no structured spec keeps manifest",
+			controlPlane: []kubeonev1beta2.HostConfig{
+				{PublicAddress: "10.0.0.1", PrivateAddress: "192.168.0.1"},
+			},
+			workers: []kubeonev1beta2.HostConfig{
+				{PublicAddress: "10.0.1.1"},
+			},
+		},
+		{
+			name: "worker pools replace static workers",
+			spec: kubermaticv1.ExternalClusterKubeOneCloudSpec{
+				WorkerPools: []kubermaticv1.KubeOneHostPool{
+					{
+						Name:        "edge",
+						SSHUsername: "ubuntu",
+						Bastion:     "bastion.example.com",
+						Labels:      map[string]string{"zone": "edge"},
+						Hosts: []kubermaticv1.KubeOneHost{
+							{PublicAddress: "10.0.2.1"},
+							{PublicAddress: "10.0.2.2", Hostname: "edge-2"},
+						},
+					},
+					{
+						Name:  "default",
+						Hosts: []kubermaticv1.KubeOneHost{{PublicAddress: "10.0.3.1"}},
+					},
+				},
+			},
+			controlPlane: []kubeonev1beta2.HostConfig{
+				{PublicAddress: "10.0.0.1", PrivateAddress: "192.168.0.1"},
+			},
+			workers: []kubeonev1beta2.HostConfig{
+				{
+					PublicAddress: "10.0.2.1",
+					SSHUsername:   "ubuntu",
+					Bastion:       "bastion.example.com",
+					Labels:        map[string]string{"zone": "edge", PoolLabel: "edge"},
+				},
+				{
+					PublicAddress: "10.0.2.2",
+					Hostname:      "edge-2",
+					SSHUsername:   "ubuntu",
+					Bastion:       "bastion.example.com",
+					Labels:        map[string]string{"zone": "edge", PoolLabel: "edge"},
+				},
+				{
+					PublicAddress: "10.0.3.1",
+					Labels:        map[string]string{PoolLabel: "default"},
+				},
+			},
+		},
+		{
+			name: "control plane and addons",
+			spec: kubermaticv1.ExternalClusterKubeOneCloudSpec{
+				ControlPlane: &kubermaticv1.KubeOneHostPool{
+					Name:  "control-plane",
+					Hosts: []kubermaticv1.KubeOneHost{{PublicAddress: "10.0.0.2", PrivateAddress: "192.168.0.2"}},
+				},
+				Addons: &kubermaticv1.KubeOneAddons{
+					GlobalParams: map[string]string{"foo": "bar"},
+					Addons: []kubermaticv1.KubeOneAddon{
+						{Name: "cluster-autoscaler"},
+						{Name: "unattended-upgrades", Delete: true},
+					},
+				},
+			},
+			controlPlane: []kubeonev1beta2.HostConfig{
+				{
+					PublicAddress:  "10.0.0.2",
+					PrivateAddress: "192.168.0.2",
+					Labels:         map[string]string{PoolLabel: "control-plane"},
+				},
+			},
+			workers: []kubeonev1beta2.HostConfig{
+				{PublicAddress: "10.0.1.1"},
+			},
+			addons: &kubeonev1beta2.Addons{
+				Enable:       true,
+				GlobalParams: map[string]string{"foo": "bar"},
+				Addons: []kubeonev1beta2.Addon{
+					{Name: "cluster-autoscaler"},
+					{Name: "unattended-upgrades", Delete: true},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := renderManifest([]byte(testManifest), &tc.spec)
+			if err != nil {
+				t.Fatalf("failed to render manifest: %v", err)
+			}
+
+			cluster := &kubeonev1beta2.KubeOneCluster{}
+			if err := yaml.UnmarshalStrict(rendered, cluster); err != nil {
+				t.Fatalf("rendered manifest is invalid: %v", err)
+			}
+
+			if cluster.Name != "test" || cluster.Versions.Kubernetes != "1.26.4" {
+				t.Errorf("expected unmanaged fields to be kept, got name %q and version %q", cluster.Name, cluster.Versions.Kubernetes)
+			}
+
+			if !diff.SemanticallyEqual(tc.controlPlane, cluster.ControlPlane.Hosts) {
+				t.Errorf("control plane hosts differ:\n%v", diff.ObjectDiff(tc.controlPlane, cluster.ControlPlane.Hosts))
+			}
+
+			if !diff.SemanticallyEqual(tc.workers, cluster.StaticWorkers.Hosts) {
+				t.Errorf("static worker hosts differ:\n%v", diff.ObjectDiff(tc.workers, cluster.StaticWorkers.Hosts))
+			}
+
+			if !diff.SemanticallyEqual(tc.addons, cluster.Addons) {
+				t.Errorf("addons differ:\n%v", diff.ObjectDiff(tc.addons, cluster.Addons))
+			}
+
+			again, err := renderManifest(rendered, &tc.spec)
+			if err != nil {
+				t.Fatalf("failed to render manifest again: %v", err)
+			}
+			if manifestHash(again) != manifestHash(rendered) {
+				t.Error("expected rendering to be idempotent")
+			}
+		})
+	}
+}
+
+func TestRenderV1Beta1Manifest(t *testing.T) {
+	const v1beta1Manifest = `apiVersion: kubeone.io/v1beta1
+kind: KubeOneCluster
+name: test
+versions:
+  kubernetes: 1.26.4
+cloudProvider:
+  none: {}
+controlPlane:
+  hosts:
+  - publicAddress: 10.0.0.1
+    privateAddress: 192.168.0.1
+`
+
+	spec := &kubermaticv1.ExternalClusterKubeOneCloudSpec{
+		WorkerPools: []kubermaticv1.KubeOneHostPool{
+			{
+				Name:  "default",
+				Hosts: []kubermaticv1.KubeOneHost{{PublicAddress: "10.0.3.1"}},
+			},
+		},
+	}
+
+	rendered, err := renderManifest([]byte(v1beta1Manifest), spec)
+	if err != nil {
+		t.Fatalf("failed to render manifest: %v", err)
+	}
+
+	cluster := &kubeonev1beta2.KubeOneCluster{}
+	if err := yaml.UnmarshalStrict(rendered, cluster); err != nil {
+		t.Fatalf("rendered manifest is invalid: %v", err)
+	}
+
+	if cluster.APIVersion != kubeonev1beta2.SchemeGroupVersion.String() {
+		t.Errorf("expected manifest to be converted to %s, got %q", kubeonev1beta2.SchemeGroupVersion, cluster.APIVersion)
+	}
+
+	if cluster.Name != "test" || cluster.Versions.Kubernetes != "1.26.4" {
+		t.Errorf("expected unmanaged fields to be kept, got name %q and version %q", cluster.Name, cluster.Versions.Kubernetes)
+	}
+
+	expectedControlPlane := []kubeonev1beta2.HostConfig{
+		{PublicAddress: "10.0.0.1", PrivateAddress: "192.168.0.1"},
+	}
+	if !diff.SemanticallyEqual(expectedControlPlane, cluster.ControlPlane.Hosts) {
+		t.Errorf("control plane hosts differ:\n%v", diff.ObjectDiff(expectedControlPlane, cluster.ControlPlane.Hosts))
+	}
+
+	expectedWorkers := []kubeonev1beta2.HostConfig{
+		{PublicAddress: "10.0.3.1", Labels: map[string]string{PoolLabel: "default"}},
+	}
+	if !diff.SemanticallyEqual(expectedWorkers, cluster.StaticWorkers.Hosts) {
+		t.Errorf("static worker hosts differ:\n%v", diff.ObjectDiff(expectedWorkers, cluster.StaticWorkers.Hosts))
+	}
+}
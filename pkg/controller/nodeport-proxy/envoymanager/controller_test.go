@@ -285,6 +285,41 @@ func TestSync(t *testing.T) {
 				"tunneling_listener": makeTunnelingListener(t, 443, hostClusterName{Cluster: "test/my-service-https", Hostname: "my-service.test.svc.cluster.local:443"}),
 			},
 		},
+		{
+			name: "tunneling-openvpn-and-konnectivity-side-by-side",
+			resources: []ctrlruntimeclient.Object{
+				test.NewServiceBuilder(test.NamespacedName{Name: "konnectivity-server", Namespace: "cluster-abc"}).
+					WithCreationTimestamp(timeRef.Add(1*time.Hour)).
+					WithAnnotation(nodeportproxy.DefaultExposeAnnotationKey, "Tunneling").
+					WithServicePort("secure", 443, 0, intstr.FromString("secure"), corev1.ProtocolTCP).
+					Build(),
+				test.NewEndpointsBuilder(test.NamespacedName{Name: "konnectivity-server", Namespace: "cluster-abc"}).
+					WithEndpointsSubset().
+					WithEndpointPort("secure", 8132, corev1.ProtocolTCP).
+					WithReadyAddressIP("172.16.0.1").
+					DoneWithEndpointSubset().Build(),
+				test.NewServiceBuilder(test.NamespacedName{Name: "openvpn-server", Namespace: "cluster-abc"}).
+					WithCreationTimestamp(timeRef).
+					WithAnnotation(nodeportproxy.DefaultExposeAnnotationKey, "Tunneling").
+					WithServicePort("secure", 1194, 0, intstr.FromString("secure"), corev1.ProtocolTCP).
+					Build(),
+				test.NewEndpointsBuilder(test.NamespacedName{Name: "openvpn-server", Namespace: "cluster-abc"}).
+					WithEndpointsSubset().
+					WithEndpointPort("secure", 1194, corev1.ProtocolTCP).
+					WithReadyAddressIP("172.16.0.2").
+					DoneWithEndpointSubset().Build(),
+			},
+			tunnelingListenerPort: 443,
+			expectedClusters: map[string]*envoyclusterv3.Cluster{
+				"cluster-abc/openvpn-server-secure":      makeCluster(t, "cluster-abc/openvpn-server-secure", 1194, "172.16.0.2"),
+				"cluster-abc/konnectivity-server-secure": makeCluster(t, "cluster-abc/konnectivity-server-secure", 8132, "172.16.0.1"),
+			},
+			expectedListener: map[string]*envoylistenerv3.Listener{
+				"tunneling_listener": makeTunnelingListener(t, 443,
+					hostClusterName{Cluster: "cluster-abc/openvpn-server-secure", Hostname: "openvpn-server.cluster-abc.svc.cluster.local:1194"},
+					hostClusterName{Cluster: "cluster-abc/konnectivity-server-secure", Hostname: "konnectivity-server.cluster-abc.svc.cluster.local:443"}),
+			},
+		},
 		{
 			name: "both-sni-and-tunneling",
 			resources: []ctrlruntimeclient.Object{
@@ -328,6 +328,10 @@ func (sb *snapshotBuilder) makeTunnelingVirtualHosts(service *corev1.Service) (v
 	return
 }
 
+// makeTunnelingListener returns the HTTP/2 CONNECT listener for the given virtual hosts.
+// The listener does not know about the tunneling mechanism of a cluster: it routes to
+// every Service exposed with the Tunneling type, so the OpenVPN and Konnectivity routes
+// appear and disappear together with the Services reconciled by the seed controller.
 func (sb *snapshotBuilder) makeTunnelingListener(vhs ...*envoyroutev3.VirtualHost) *envoylistenerv3.Listener {
 	routerpb, err := anypb.New(&envoyrouterv3.Router{})
 	if err != nil {
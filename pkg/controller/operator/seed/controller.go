@@ -216,6 +216,7 @@ func createSeedWatches(controller controller.Controller, seedName string, seedMa
 		&appsv1.Deployment{},
 		&batchv1.CronJob{},
 		&corev1.ConfigMap{},
+		&corev1.PersistentVolumeClaim{},
 		&corev1.Secret{},
 		&corev1.Service{},
 		&corev1.ServiceAccount{},
@@ -36,6 +36,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/gateway"
 	kkpreconciling "k8c.io/kubermatic/v2/pkg/resources/reconciling"
 	crdutil "k8c.io/kubermatic/v2/pkg/util/crd"
 	kubermaticversion "k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -45,12 +46,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // Reconciler (re)stores all components required for running a Kubermatic
@@ -185,6 +190,10 @@ func (r *Reconciler) cleanupDeletedSeed(ctx context.Context, cfg *kubermaticv1.K
 
 	log.Debug("Seed was deleted, cleaning up cluster-wide resources")
 
+	if err := r.cleanupBackupDestinationVolumes(ctx, client, sets.New[string]()); err != nil {
+		return err
+	}
+
 	if err := common.CleanupClusterResource(ctx, client, &rbacv1.ClusterRoleBinding{}, kubermaticseed.ClusterRoleBindingName(cfg)); err != nil {
 		return fmt.Errorf("failed to clean up ClusterRoleBinding: %w", err)
 	}
@@ -321,7 +330,12 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *kubermaticv1.K
 		return err
 	}
 
-	if err := r.reconcileDeployments(ctx, cfg, seed, client, log, caBundle); err != nil {
+	backupDestinationClaims, err := r.reconcileBackupDestinationVolumes(ctx, seed, client, log)
+	if err != nil {
+		return err
+	}
+
+	if err := r.reconcileDeployments(ctx, cfg, seed, client, log, caBundle, backupDestinationClaims); err != nil {
 		return err
 	}
 
@@ -333,6 +347,10 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cfg *kubermaticv1.K
 		return err
 	}
 
+	if err := r.reconcileGateways(ctx, cfg, seed, client, log); err != nil {
+		return err
+	}
+
 	// Since the new standalone webhook, the old service is not required anymore.
 	// Once the webhooks are reconciled above, we can now clean up unneeded services.
 	common.CleanupWebhookServices(ctx, client, log, cfg.Namespace)
@@ -533,11 +551,118 @@ func (r *Reconciler) reconcileSecrets(ctx context.Context, cfg *kubermaticv1.Kub
 	return nil
 }
 
-func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *corev1.ConfigMap) error {
+// reconcileBackupDestinationVolumes gives the seed-controller-manager read-only access to all
+// filesystem backup destinations by binding clones of their volumes to PVCs in the KKP namespace.
+// It returns the claims that are bound and can be mounted without blocking the pod, keyed by
+// destination name.
+func (r *Reconciler) reconcileBackupDestinationVolumes(ctx context.Context, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) (map[string]string, error) {
+	log.Debug("reconciling backup destination volumes")
+
+	wanted := sets.New[string]()
+	boundClaims := map[string]string{}
+
+	if seed.IsEtcdAutomaticBackupEnabled() {
+		for destName, destination := range seed.Spec.EtcdBackupRestore.Destinations {
+			if destination.GetType() != kubermaticv1.BackupDestinationTypeFilesystem {
+				continue
+			}
+
+			wanted.Insert(destName)
+
+			claimName := kubermaticseed.BackupDestinationClaimName(destName)
+			labels := map[string]string{
+				common.ManagedByLabel:                 common.OperatorName,
+				kubermaticseed.BackupDestinationLabel: destName,
+			}
+
+			volume, err := resources.BackupDestinationVolume(ctx, client, destination, kubermaticseed.BackupDestinationVolumeName(r.namespace, destName), types.NamespacedName{
+				Namespace: r.namespace,
+				Name:      claimName,
+			})
+			if err != nil {
+				// do not block the other resources, the destination is just not monitored until its volume is ready
+				log.Infow("Backup destination volume is not available", "destination", destName, zap.Error(err))
+				continue
+			}
+			volume.Labels = labels
+
+			if err := client.Create(ctx, volume); err != nil && !apierrors.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("failed to create PersistentVolume for backup destination %q: %w", destName, err)
+			}
+
+			claim := resources.BackupDestinationVolumeClaim(volume)
+			claim.Labels = labels
+			if err := controllerutil.SetControllerReference(seed, claim, r.scheme); err != nil {
+				return nil, fmt.Errorf("failed to set owner reference: %w", err)
+			}
+
+			if err := client.Create(ctx, claim); err != nil && !apierrors.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("failed to create PersistentVolumeClaim for backup destination %q: %w", destName, err)
+			}
+
+			if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(claim), claim); err != nil {
+				return nil, fmt.Errorf("failed to get PersistentVolumeClaim for backup destination %q: %w", destName, err)
+			}
+
+			if claim.Status.Phase == corev1.ClaimBound {
+				boundClaims[destName] = claimName
+			}
+		}
+	}
+
+	if err := r.cleanupBackupDestinationVolumes(ctx, client, wanted); err != nil {
+		return nil, err
+	}
+
+	return boundClaims, nil
+}
+
+// cleanupBackupDestinationVolumes removes the volume clones of all filesystem destinations that
+// are not in the given set. The backups themselves are retained.
+func (r *Reconciler) cleanupBackupDestinationVolumes(ctx context.Context, client ctrlruntimeclient.Client, wanted sets.Set[string]) error {
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := client.List(ctx, claims, ctrlruntimeclient.InNamespace(r.namespace), ctrlruntimeclient.HasLabels{kubermaticseed.BackupDestinationLabel}); err != nil {
+		return fmt.Errorf("failed to list backup destination PersistentVolumeClaims: %w", err)
+	}
+
+	for i, claim := range claims.Items {
+		if wanted.Has(claim.Labels[kubermaticseed.BackupDestinationLabel]) {
+			continue
+		}
+
+		if err := client.Delete(ctx, &claims.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete PersistentVolumeClaim %s: %w", claim.Name, err)
+		}
+	}
+
+	volumes := &corev1.PersistentVolumeList{}
+	if err := client.List(ctx, volumes, ctrlruntimeclient.HasLabels{kubermaticseed.BackupDestinationLabel}); err != nil {
+		return fmt.Errorf("failed to list backup destination PersistentVolumes: %w", err)
+	}
+
+	for i, volume := range volumes.Items {
+		// the volumes are cluster-scoped and might belong to another KKP installation
+		if volume.Spec.ClaimRef == nil || volume.Spec.ClaimRef.Namespace != r.namespace {
+			continue
+		}
+
+		if wanted.Has(volume.Labels[kubermaticseed.BackupDestinationLabel]) {
+			continue
+		}
+
+		if err := client.Delete(ctx, &volumes.Items[i]); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete PersistentVolume %s: %w", volume.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileDeployments(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *corev1.ConfigMap, backupDestinationClaims map[string]string) error {
 	log.Debug("reconciling Deployments")
 
 	creators := []reconciling.NamedDeploymentReconcilerFactory{
-		kubermaticseed.SeedControllerManagerDeploymentReconciler(r.workerName, r.versions, cfg, seed),
+		kubermaticseed.SeedControllerManagerDeploymentReconciler(r.workerName, r.versions, cfg, seed, backupDestinationClaims),
 		common.WebhookDeploymentReconciler(cfg, r.versions, seed, false),
 	}
 
@@ -641,6 +766,49 @@ func (r *Reconciler) reconcileServices(ctx context.Context, cfg *kubermaticv1.Ku
 	return nil
 }
 
+func (r *Reconciler) reconcileGateways(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	if seed.Spec.Gateway == nil {
+		return r.cleanupGateway(ctx, cfg, client, log)
+	}
+
+	log.Debug("reconciling Gateways")
+
+	creators := []kkpreconciling.NamedGatewayReconcilerFactory{
+		gateway.GatewayReconciler(seed, cfg.Spec.Ingress.Domain),
+	}
+
+	// Just like the nodeport-proxy LoadBalancer, the Gateway is not given an owner reference,
+	// so its address is not lost if someone accidentally deletes the Seed resource.
+	if err := kkpreconciling.ReconcileGateways(ctx, creators, cfg.Namespace, client); err != nil {
+		return fmt.Errorf("failed to reconcile Gateways: %w", err)
+	}
+
+	return nil
+}
+
+// cleanupGateway removes the shared Gateway after it has been removed from the Seed. If the
+// Gateway API is not installed on the seed, there is nothing to clean up.
+func (r *Reconciler) cleanupGateway(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	gw := &gatewayapiv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gateway.GatewayName,
+			Namespace: cfg.Namespace,
+		},
+	}
+
+	if err := client.Delete(ctx, gw); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete Gateway: %w", err)
+	}
+
+	log.Info("Deleted Gateway, as the Seed has no Gateway configured anymore")
+
+	return nil
+}
+
 func (r *Reconciler) reconcileAdmissionWebhooks(ctx context.Context, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	log.Debug("reconciling Admission Webhooks")
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/gateway"
 	"k8c.io/kubermatic/v2/pkg/test"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -41,6 +43,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -48,6 +51,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 const (
@@ -72,6 +76,7 @@ var testScheme = fake.NewScheme()
 
 func init() {
 	utilruntime.Must(apiextensionsv1.AddToScheme(testScheme))
+	utilruntime.Must(gatewayapiv1beta1.AddToScheme(testScheme))
 }
 
 func getSeeds(now metav1.Time) map[string]*kubermaticv1.Seed {
@@ -483,3 +488,153 @@ func createTestReconciler(allSeeds map[string]*kubermaticv1.Seed, cfg *kubermati
 		versions:               versions,
 	}
 }
+
+func TestReconcileBackupDestinationVolumes(t *testing.T) {
+	ctx := context.Background()
+
+	seed := &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "europe",
+			Namespace: "kubermatic",
+		},
+		Spec: kubermaticv1.SeedSpec{
+			EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+				Destinations: map[string]*kubermaticv1.BackupDestination{
+					"nfs": {
+						Type:       kubermaticv1.BackupDestinationTypeFilesystem,
+						Filesystem: &kubermaticv1.BackupDestinationFilesystem{PersistentVolumeClaim: "etcd-backups"},
+					},
+					"s3": {
+						Endpoint:   "https://s3.example.com",
+						BucketName: "backups",
+					},
+				},
+			},
+		},
+	}
+
+	client := fake.
+		NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			seed,
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "etcd-backups",
+					Namespace: metav1.NamespaceSystem,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					VolumeName: "pv-etcd-backups",
+				},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pv-etcd-backups",
+				},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						NFS: &corev1.NFSVolumeSource{Server: "nfs.example.com", Path: "/backups"},
+					},
+				},
+			},
+		).
+		Build()
+
+	reconciler := createTestReconciler(nil, nil, nil, nil)
+	log := reconciler.log
+
+	claims, err := reconciler.reconcileBackupDestinationVolumes(ctx, seed, client, log)
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	// the claim is not bound yet and must not be mounted
+	if len(claims) != 0 {
+		t.Fatalf("Expected no mountable claims, got %v", claims)
+	}
+
+	volume := &corev1.PersistentVolume{}
+	must(t, client.Get(ctx, types.NamespacedName{Name: "kubermatic-backup-destination-nfs"}, volume))
+
+	if volume.Spec.NFS == nil || volume.Spec.NFS.Server != "nfs.example.com" {
+		t.Errorf("Expected the volume source to be cloned, got %+v", volume.Spec.PersistentVolumeSource)
+	}
+
+	if volume.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+		t.Errorf("Expected the cloned volume to be retained, got %q", volume.Spec.PersistentVolumeReclaimPolicy)
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	must(t, client.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: "backup-destination-nfs"}, claim))
+
+	claim.Status.Phase = corev1.ClaimBound
+	must(t, client.Status().Update(ctx, claim))
+
+	claims, err = reconciler.reconcileBackupDestinationVolumes(ctx, seed, client, log)
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if expected := map[string]string{"nfs": "backup-destination-nfs"}; !reflect.DeepEqual(claims, expected) {
+		t.Fatalf("Expected mountable claims %v, got %v", expected, claims)
+	}
+
+	// removing the destination must remove the clones, but never the original volume
+	delete(seed.Spec.EtcdBackupRestore.Destinations, "nfs")
+
+	if _, err := reconciler.reconcileBackupDestinationVolumes(ctx, seed, client, log); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if err := client.Get(ctx, types.NamespacedName{Namespace: "kubermatic", Name: "backup-destination-nfs"}, claim); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the claim to be deleted, but got %v", err)
+	}
+
+	if err := client.Get(ctx, types.NamespacedName{Name: "kubermatic-backup-destination-nfs"}, volume); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the cloned volume to be deleted, but got %v", err)
+	}
+
+	must(t, client.Get(ctx, types.NamespacedName{Name: "pv-etcd-backups"}, volume))
+}
+
+func TestReconcileGatewaysRemovesGateway(t *testing.T) {
+	ctx := context.Background()
+
+	seed := &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "europe",
+			Namespace: "kubermatic",
+		},
+	}
+
+	client := fake.
+		NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			seed,
+			&gatewayapiv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gateway.GatewayName,
+					Namespace: k8cConfig.Namespace,
+				},
+			},
+		).
+		Build()
+
+	reconciler := createTestReconciler(nil, nil, nil, nil)
+
+	if err := reconciler.reconcileGateways(ctx, &k8cConfig, seed, client, reconciler.log); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	gw := &gatewayapiv1beta1.Gateway{}
+	err := client.Get(ctx, types.NamespacedName{Namespace: k8cConfig.Namespace, Name: gateway.GatewayName}, gw)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected Gateway to be deleted, but got: %v", err)
+	}
+
+	// without a Gateway, reconciling again must not fail
+	if err := reconciler.reconcileGateways(ctx, &k8cConfig, seed, client, reconciler.log); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubermatic
+
+import (
+	"fmt"
+)
+
+const (
+	// BackupDestinationLabel is set on the clones of filesystem backup destination volumes
+	// and contains the name of the destination.
+	BackupDestinationLabel = "kubermatic.k8c.io/backup-destination"
+)
+
+// BackupDestinationVolumeName returns the name of the read-only clone of a filesystem
+// destination's PersistentVolume. PersistentVolumes are cluster-scoped, so the name
+// includes the KKP namespace.
+func BackupDestinationVolumeName(namespace string, destination string) string {
+	return fmt.Sprintf("%s-backup-destination-%s", namespace, destination)
+}
+
+// BackupDestinationClaimName returns the name of the PersistentVolumeClaim in the KKP
+// namespace that binds the clone of a filesystem destination's PersistentVolume.
+func BackupDestinationClaimName(destination string) string {
+	return fmt.Sprintf("backup-destination-%s", destination)
+}
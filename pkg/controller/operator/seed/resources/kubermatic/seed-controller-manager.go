@@ -23,6 +23,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
@@ -31,6 +32,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func seedControllerManagerPodLabels() map[string]string {
@@ -39,7 +41,10 @@ func seedControllerManagerPodLabels() map[string]string {
 	}
 }
 
-func SeedControllerManagerDeploymentReconciler(workerName string, versions kubermatic.Versions, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed) reconciling.NamedDeploymentReconcilerFactory {
+// SeedControllerManagerDeploymentReconciler returns the Deployment for the seed-controller-manager.
+// backupDestinationClaims maps the names of filesystem backup destinations to the PVCs in the KKP
+// namespace that give access to them; these are mounted read-only for the backup metrics.
+func SeedControllerManagerDeploymentReconciler(workerName string, versions kubermatic.Versions, cfg *kubermaticv1.KubermaticConfiguration, seed *kubermaticv1.Seed, backupDestinationClaims map[string]string) reconciling.NamedDeploymentReconcilerFactory {
 	return func() (string, reconciling.DeploymentReconciler) {
 		return common.SeedControllerManagerDeploymentName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
 			d.Spec.Replicas = cfg.Spec.SeedController.Replicas
@@ -146,6 +151,26 @@ func SeedControllerManagerDeploymentReconciler(workerName string, versions kuber
 				})
 			}
 
+			// sort the destinations to keep the volume names stable
+			for i, destination := range sets.List(sets.KeySet(backupDestinationClaims)) {
+				volumeName := fmt.Sprintf("backup-destination-%d", i)
+
+				volumes = append(volumes, corev1.Volume{
+					Name: volumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: backupDestinationClaims[destination],
+							ReadOnly:  true,
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      volumeName,
+					MountPath: backend.DestinationMountPath(destination),
+					ReadOnly:  true,
+				})
+			}
+
 			configureSeedLevelOIDCProvider := seed.Spec.OIDCProviderConfiguration != nil
 
 			if configureSeedLevelOIDCProvider {
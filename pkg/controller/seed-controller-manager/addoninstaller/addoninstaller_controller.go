@@ -319,8 +319,8 @@ func skipAddonInstallation(addon kubermaticv1.Addon, cluster *kubermaticv1.Clust
 	if addon.Name == kubeProxyAddonName && cluster.Spec.ClusterNetwork.ProxyMode == resources.EBPFProxyMode {
 		return true // skip kube-proxy if eBPF proxy mode is used
 	}
-	if addon.Name == openVPNAddonName && cluster.Spec.ClusterNetwork.KonnectivityEnabled != nil && *cluster.Spec.ClusterNetwork.KonnectivityEnabled { //nolint:staticcheck
-		return true // skip openvpn if Konnectivity is enabled
+	if addon.Name == openVPNAddonName && !kubermaticv1helper.IsOpenVPNDeployed(cluster) {
+		return true // skip openvpn if Konnectivity is enabled and the migration to it has been completed
 	}
 	if addon.Name == CSIAddonName && cluster.Spec.DisableCSIDriver {
 		return true // skip csi driver installation if DisableCSIDriver is true
@@ -0,0 +1,540 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kkp-ca-rotation-controller"
+
+	// CABundleHashAnnotation is set on the machine template of all MachineDeployments, so that
+	// the nodes are replaced whenever the CA bundle changes during a rotation.
+	CABundleHashAnnotation = "kubermatic.k8c.io/ca-bundle-hash"
+
+	// progressCheckPeriod is the interval in which the rollout of the control plane and
+	// the nodes is checked.
+	progressCheckPeriod = 30 * time.Second
+)
+
+// UserClusterClientProvider provides functionality to get a user cluster client.
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider UserClusterClientProvider
+	log                           *zap.SugaredLogger
+	versions                      kubermatic.Versions
+}
+
+// Add creates a new CA rotation controller.
+func Add(mgr manager.Manager, numWorkers int, workerName string, userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger, versions kubermatic.Versions) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		log:                           log.Named(ControllerName),
+		versions:                      versions,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %w", err)
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), &kubermaticv1.Cluster{}), &handler.EnqueueRequestForObject{}, predicateutil.Factory(func(o ctrlruntimeclient.Object) bool {
+		cluster := o.(*kubermaticv1.Cluster)
+		return rotationRequested(cluster) || rotationPhase(cluster) != ""
+	})); err != nil {
+		return fmt.Errorf("failed to create watch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionNone,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, log, cluster)
+		},
+	)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		return nil, nil
+	}
+
+	phase := rotationPhase(cluster)
+	if phase == "" {
+		if !rotationRequested(cluster) {
+			return nil, nil
+		}
+
+		return r.startRotation(ctx, log, cluster)
+	}
+
+	// the annotation is only removed after the rotation has been recorded in the status,
+	// so it might still be present if that failed
+	if rotationRequested(cluster) {
+		if err := r.removeRotationAnnotation(ctx, cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	nextCA, err := r.getNextCA(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := r.ensureCASecret(ctx, cluster, phase, nextCA)
+	if err != nil {
+		return nil, err
+	}
+
+	waitingFor, err := r.rolloutPending(ctx, cluster, phase, nextCA, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if waitingFor != "" {
+		log.Debugw("Waiting for CA rotation to progress", "phase", phase, "waitingFor", waitingFor)
+		if err := r.setPhase(ctx, cluster, phase, fmt.Sprintf("Waiting for %s", waitingFor)); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: progressCheckPeriod}, nil
+	}
+
+	switch phase {
+	case kubermaticv1.ReasonCARotationTrustingNewCA:
+		log.Info("New root CA is trusted, re-issuing certificates")
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "CARotationReissuing", "Re-issuing all certificates with the new root CA")
+		return &reconcile.Result{Requeue: true}, r.setPhase(ctx, cluster, kubermaticv1.ReasonCARotationReissuingCertificates, "Re-issuing certificates")
+
+	case kubermaticv1.ReasonCARotationReissuingCertificates:
+		log.Info("Certificates re-issued, dropping old root CA")
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "CARotationDroppingOldCA", "Removing the old root CA from the trust bundle")
+		return &reconcile.Result{Requeue: true}, r.setPhase(ctx, cluster, kubermaticv1.ReasonCARotationDroppingOldCA, "Dropping old root CA")
+
+	default:
+		return nil, r.completeRotation(ctx, log, cluster)
+	}
+}
+
+// startRotation generates the new CA and records the start of the rotation.
+func (r *Reconciler) startRotation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	// no rotation is in progress, so any existing next CA is a leftover of an interrupted cleanup
+	nextCASecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Status.NamespaceName,
+			Name:      resources.NextCASecretName,
+		},
+	}
+	if err := r.Delete(ctx, nextCASecret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to delete leftover next CA: %w", err)
+	}
+
+	commonName := fmt.Sprintf("root-ca-%d.%s", time.Now().Unix(), cluster.Status.Address.ExternalName)
+	creators := []reconciling.NamedSecretReconcilerFactory{
+		func() (string, reconciling.SecretReconciler) {
+			return resources.NextCASecretName, certificates.GetCAReconciler(commonName)
+		},
+	}
+
+	if err := reconciling.ReconcileSecrets(ctx, creators, cluster.Status.NamespaceName, r, resources.CertificateComponentLabeler); err != nil {
+		return nil, fmt.Errorf("failed to create next CA: %w", err)
+	}
+
+	log.Info("Starting root CA rotation")
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "CARotationStarted", "Adding a new root CA to the trust bundle")
+
+	if err := r.setPhase(ctx, cluster, kubermaticv1.ReasonCARotationTrustingNewCA, "Trusting new root CA"); err != nil {
+		return nil, err
+	}
+
+	return &reconcile.Result{Requeue: true}, r.removeRotationAnnotation(ctx, cluster)
+}
+
+func (r *Reconciler) completeRotation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionCARotation,
+			corev1.ConditionTrue,
+			kubermaticv1.ReasonCARotationCompleted,
+			"The root CA has been rotated",
+		)
+	}); err != nil {
+		return err
+	}
+
+	nextCASecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Status.NamespaceName,
+			Name:      resources.NextCASecretName,
+		},
+	}
+	if err := r.Delete(ctx, nextCASecret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete next CA: %w", err)
+	}
+
+	log.Info("Root CA rotation completed")
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "CARotationCompleted", "The root CA has been rotated")
+
+	return nil
+}
+
+func (r *Reconciler) getNextCA(ctx context.Context, cluster *kubermaticv1.Cluster) (*triple.KeyPair, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.NextCASecretName}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get next CA: %w", err)
+	}
+
+	return triple.ParseRSAKeyPair(secret.Data[resources.CACertSecretKey], secret.Data[resources.CAKeySecretKey])
+}
+
+// ensureCASecret updates the root CA secret of the cluster for the given phase and returns
+// the resulting CA bundle.
+func (r *Reconciler) ensureCASecret(ctx context.Context, cluster *kubermaticv1.Cluster, phase string, nextCA *triple.KeyPair) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CASecretName}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get CA: %w", err)
+	}
+
+	data, err := caSecretData(phase, secret.Data, nextCA)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(data[resources.CACertSecretKey], secret.Data[resources.CACertSecretKey]) ||
+		!bytes.Equal(data[resources.CAKeySecretKey], secret.Data[resources.CAKeySecretKey]) {
+		oldSecret := secret.DeepCopy()
+		secret.Data = data
+		if err := r.Patch(ctx, secret, ctrlruntimeclient.MergeFromWithOptions(oldSecret, ctrlruntimeclient.MergeFromWithOptimisticLock{})); err != nil {
+			return nil, fmt.Errorf("failed to update CA: %w", err)
+		}
+	}
+
+	return data[resources.CACertSecretKey], nil
+}
+
+// caSecretData returns the data of the root CA secret for the given phase. The first
+// certificate in the bundle is always the one belonging to the private key.
+func caSecretData(phase string, current map[string][]byte, nextCA *triple.KeyPair) (map[string][]byte, error) {
+	certs, err := certutil.ParseCertsPEM(current[resources.CACertSecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("CA secret contains an invalid certificate: %w", err)
+	}
+
+	nextCert := triple.EncodeCertPEM(nextCA.Cert)
+	nextKey := triple.EncodePrivateKeyPEM(nextCA.Key)
+
+	var oldCerts []byte
+	for _, cert := range certs {
+		if !cert.Equal(nextCA.Cert) {
+			oldCerts = append(oldCerts, triple.EncodeCertPEM(cert)...)
+		}
+	}
+
+	data := map[string][]byte{}
+	for k, v := range current {
+		data[k] = v
+	}
+
+	switch phase {
+	case kubermaticv1.ReasonCARotationTrustingNewCA:
+		data[resources.CACertSecretKey] = append(oldCerts, nextCert...)
+
+	case kubermaticv1.ReasonCARotationReissuingCertificates:
+		data[resources.CACertSecretKey] = append(nextCert, oldCerts...)
+		data[resources.CAKeySecretKey] = nextKey
+
+	case kubermaticv1.ReasonCARotationDroppingOldCA:
+		data[resources.CACertSecretKey] = nextCert
+		data[resources.CAKeySecretKey] = nextKey
+
+	default:
+		return nil, fmt.Errorf("unknown CA rotation phase %q", phase)
+	}
+
+	return data, nil
+}
+
+// rolloutPending returns a description of what the current phase is still waiting for, or
+// an empty string if the phase is done.
+func (r *Reconciler) rolloutPending(ctx context.Context, cluster *kubermaticv1.Cluster, phase string, nextCA *triple.KeyPair, bundle []byte) (string, error) {
+	namespace := cluster.Status.NamespaceName
+
+	// once the new CA is used for signing, the certificates are re-issued by the cluster controller
+	if phase != kubermaticv1.ReasonCARotationTrustingNewCA {
+		for _, cert := range []struct {
+			secret string
+			key    string
+		}{
+			{secret: resources.ApiserverTLSSecretName, key: resources.ApiserverTLSCertSecretKey},
+			{secret: resources.KubeletClientCertificatesSecretName, key: resources.KubeletClientCertSecretKey},
+		} {
+			signed, err := r.signedBy(ctx, namespace, cert.secret, cert.key, nextCA.Cert)
+			if err != nil {
+				return "", err
+			}
+			if !signed {
+				return fmt.Sprintf("certificate %s to be re-issued", cert.secret), nil
+			}
+		}
+	}
+
+	rolledOut, err := resources.ControlPlaneRolledOut(ctx, r, namespace)
+	if err != nil {
+		return "", err
+	}
+	if !rolledOut {
+		return "the control plane to roll out", nil
+	}
+
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return "the apiserver to become healthy", nil
+	}
+
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	trusted, err := clusterInfoTrustsBundle(ctx, userClusterClient, bundle)
+	if err != nil {
+		return "", err
+	}
+	if !trusted {
+		return "the cluster-info ConfigMap to be updated", nil
+	}
+
+	rolledOut, err = rollMachineDeployments(ctx, userClusterClient, bundleHash(bundle))
+	if err != nil {
+		return "", err
+	}
+	if !rolledOut {
+		return "the nodes to be replaced", nil
+	}
+
+	return "", nil
+}
+
+func (r *Reconciler) signedBy(ctx context.Context, namespace, secretName, key string, ca *x509.Certificate) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return false, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	certs, err := certutil.ParseCertsPEM(secret.Data[key])
+	if err != nil {
+		return false, nil
+	}
+
+	return certs[0].CheckSignatureFrom(ca) == nil, nil
+}
+
+// clusterInfoTrustsBundle checks that the cluster-info ConfigMap, which OSM uses to generate
+// the kubeconfigs of new nodes, contains the given CA bundle.
+func clusterInfoTrustsBundle(ctx context.Context, client ctrlruntimeclient.Client, bundle []byte) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespacePublic, Name: resources.ClusterInfoConfigMapName}, cm); err != nil {
+		return false, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	kubeconfig, err := clientcmd.Load([]byte(cm.Data["kubeconfig"]))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cluster-info kubeconfig: %w", err)
+	}
+
+	for _, cluster := range kubeconfig.Clusters {
+		if !bytes.Equal(cluster.CertificateAuthorityData, bundle) {
+			return false, nil
+		}
+	}
+
+	return len(kubeconfig.Clusters) > 0, nil
+}
+
+// rollMachineDeployments replaces all nodes whose machine template does not have the given
+// CA bundle hash yet and returns whether all MachineDeployments have been rolled out.
+func rollMachineDeployments(ctx context.Context, client ctrlruntimeclient.Client, hash string) (bool, error) {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return false, fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	rolledOut := true
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+
+		if md.Spec.Template.Annotations[CABundleHashAnnotation] != hash {
+			oldMD := md.DeepCopy()
+			if md.Spec.Template.Annotations == nil {
+				md.Spec.Template.Annotations = map[string]string{}
+			}
+			md.Spec.Template.Annotations[CABundleHashAnnotation] = hash
+
+			if err := client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+				return false, fmt.Errorf("failed to roll MachineDeployment %s: %w", md.Name, err)
+			}
+
+			rolledOut = false
+			continue
+		}
+
+		if !machineDeploymentRolledOut(md) {
+			rolledOut = false
+		}
+	}
+
+	return rolledOut, nil
+}
+
+func machineDeploymentRolledOut(md *clusterv1alpha1.MachineDeployment) bool {
+	replicas := ptr.Deref(md.Spec.Replicas, 0)
+
+	return md.Status.ObservedGeneration >= md.Generation &&
+		md.Status.Replicas == replicas &&
+		md.Status.UpdatedReplicas == replicas &&
+		md.Status.AvailableReplicas == replicas
+}
+
+func bundleHash(bundle []byte) string {
+	hash := sha1.New()
+	hash.Write(bundle)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (r *Reconciler) setPhase(ctx context.Context, cluster *kubermaticv1.Cluster, phase, message string) error {
+	return kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionCARotation,
+			corev1.ConditionFalse,
+			phase,
+			message,
+		)
+	})
+}
+
+func (r *Reconciler) removeRotationAnnotation(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	oldCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, kubermaticv1.RotateCAAnnotation)
+
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to remove %s annotation: %w", kubermaticv1.RotateCAAnnotation, err)
+	}
+
+	return nil
+}
+
+func rotationRequested(cluster *kubermaticv1.Cluster) bool {
+	_, ok := cluster.Annotations[kubermaticv1.RotateCAAnnotation]
+	return ok
+}
+
+// rotationPhase returns the phase of the ongoing CA rotation, or an empty string if
+// no rotation is in progress.
+func rotationPhase(cluster *kubermaticv1.Cluster) string {
+	condition, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionCARotation]
+	if !ok || condition.Status == corev1.ConditionTrue {
+		return ""
+	}
+
+	switch condition.Reason {
+	case kubermaticv1.ReasonCARotationTrustingNewCA,
+		kubermaticv1.ReasonCARotationReissuingCertificates,
+		kubermaticv1.ReasonCARotationDroppingOldCA:
+		return condition.Reason
+	}
+
+	return ""
+}
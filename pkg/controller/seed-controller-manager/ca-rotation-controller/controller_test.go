@@ -0,0 +1,349 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotationcontroller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var testScheme = fake.NewScheme()
+
+func init() {
+	utilruntime.Must(clusterv1alpha1.AddToScheme(testScheme))
+}
+
+const testNamespace = "cluster-test"
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
+
+func newCA(t *testing.T, name string) *triple.KeyPair {
+	ca, err := triple.NewCA(name)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	return ca
+}
+
+func genCASecret(name string, ca *triple.KeyPair) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+		},
+	}
+}
+
+func genClusterInfo(t *testing.T, bundle []byte) *corev1.ConfigMap {
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"": {
+				Server:                   "https://example.com",
+				CertificateAuthorityData: bundle,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode kubeconfig: %v", err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespacePublic,
+			Name:      resources.ClusterInfoConfigMapName,
+		},
+		Data: map[string]string{"kubeconfig": string(kubeconfig)},
+	}
+}
+
+func TestCASecretData(t *testing.T) {
+	oldCA := newCA(t, "old")
+	nextCA := newCA(t, "next")
+
+	current := genCASecret(resources.CASecretName, oldCA).Data
+
+	testCases := []struct {
+		phase         string
+		expectedCerts []*triple.KeyPair
+		expectedKey   *triple.KeyPair
+	}{
+		{
+			phase:         kubermaticv1.ReasonCARotationTrustingNewCA,
+			expectedCerts: []*triple.KeyPair{oldCA, nextCA},
+			expectedKey:   oldCA,
+		},
+		{
+			phase:         kubermaticv1.ReasonCARotationReissuingCertificates,
+			expectedCerts: []*triple.KeyPair{nextCA, oldCA},
+			expectedKey:   nextCA,
+		},
+		{
+			phase:         kubermaticv1.ReasonCARotationDroppingOldCA,
+			expectedCerts: []*triple.KeyPair{nextCA},
+			expectedKey:   nextCA,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.phase, func(t *testing.T) {
+			data, err := caSecretData(tc.phase, current, nextCA)
+			if err != nil {
+				t.Fatalf("failed to get CA secret data: %v", err)
+			}
+
+			certs, err := certutil.ParseCertsPEM(data[resources.CACertSecretKey])
+			if err != nil {
+				t.Fatalf("failed to parse CA bundle: %v", err)
+			}
+
+			if len(certs) != len(tc.expectedCerts) {
+				t.Fatalf("expected %d certificates, got %d", len(tc.expectedCerts), len(certs))
+			}
+			for i, expected := range tc.expectedCerts {
+				if !certs[i].Equal(expected.Cert) {
+					t.Errorf("expected certificate %d to be %q, got %q", i, expected.Cert.Subject.CommonName, certs[i].Subject.CommonName)
+				}
+			}
+
+			if !bytes.Equal(data[resources.CAKeySecretKey], triple.EncodePrivateKeyPEM(tc.expectedKey.Key)) {
+				t.Errorf("expected the key of %q", tc.expectedKey.Cert.Subject.CommonName)
+			}
+
+			// the phases must be idempotent
+			again, err := caSecretData(tc.phase, data, nextCA)
+			if err != nil {
+				t.Fatalf("failed to get CA secret data: %v", err)
+			}
+			if !bytes.Equal(again[resources.CACertSecretKey], data[resources.CACertSecretKey]) {
+				t.Error("CA bundle changed when applying the phase twice")
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	oldCA := newCA(t, "old")
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Annotations: map[string]string{kubermaticv1.RotateCAAnnotation: ""},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: testNamespace,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+
+	seedClient := fake.NewClientBuilder().WithObjects(cluster, genCASecret(resources.CASecretName, oldCA)).Build()
+	userClusterClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceSystem,
+			Name:      "workers",
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+	}).Build()
+
+	r := &Reconciler{
+		Client:                        seedClient,
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeClientProvider{client: userClusterClient},
+		log:                           kubermaticlog.Logger,
+		versions:                      kubermatic.NewFakeVersions(),
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}}
+	reconcileAndCheck := func(expectedReason string) *kubermaticv1.Cluster {
+		t.Helper()
+
+		if _, err := r.Reconcile(ctx, request); err != nil {
+			t.Fatalf("reconciling failed: %v", err)
+		}
+
+		cluster := &kubermaticv1.Cluster{}
+		if err := seedClient.Get(ctx, request.NamespacedName, cluster); err != nil {
+			t.Fatalf("failed to get cluster: %v", err)
+		}
+
+		condition := cluster.Status.Conditions[kubermaticv1.ClusterConditionCARotation]
+		if condition.Reason != expectedReason {
+			t.Fatalf("expected CA rotation to be in %q, got %q (%s)", expectedReason, condition.Reason, condition.Message)
+		}
+
+		return cluster
+	}
+
+	caBundle := func() []byte {
+		t.Helper()
+
+		secret := &corev1.Secret{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.CASecretName}, secret); err != nil {
+			t.Fatalf("failed to get CA secret: %v", err)
+		}
+		return secret.Data[resources.CACertSecretKey]
+	}
+
+	// updateClusterInfo simulates the user cluster controller manager updating cluster-info
+	updateClusterInfo := func(bundle []byte) {
+		t.Helper()
+
+		if err := userClusterClient.Create(ctx, genClusterInfo(t, bundle)); err != nil {
+			if err := userClusterClient.Update(ctx, genClusterInfo(t, bundle)); err != nil {
+				t.Fatalf("failed to update cluster-info: %v", err)
+			}
+		}
+	}
+
+	// rolloutNodes simulates machine-controller replacing all nodes
+	rolloutNodes := func(bundle []byte) {
+		t.Helper()
+
+		md := &clusterv1alpha1.MachineDeployment{}
+		if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "workers"}, md); err != nil {
+			t.Fatalf("failed to get MachineDeployment: %v", err)
+		}
+		if md.Spec.Template.Annotations[CABundleHashAnnotation] != bundleHash(bundle) {
+			t.Fatal("expected MachineDeployment to be rolled for the current CA bundle")
+		}
+
+		md.Status = clusterv1alpha1.MachineDeploymentStatus{
+			ObservedGeneration: md.Generation,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+		}
+		if err := userClusterClient.Update(ctx, md); err != nil {
+			t.Fatalf("failed to update MachineDeployment status: %v", err)
+		}
+	}
+
+	// the rotation starts by generating the next CA
+	updated := reconcileAndCheck(kubermaticv1.ReasonCARotationTrustingNewCA)
+	if _, ok := updated.Annotations[kubermaticv1.RotateCAAnnotation]; ok {
+		t.Error("expected rotation annotation to be removed")
+	}
+
+	nextCA, err := r.getNextCA(ctx, updated)
+	if err != nil {
+		t.Fatalf("failed to get next CA: %v", err)
+	}
+
+	// the new CA is trusted, but cluster-info and the nodes are not updated yet
+	updated = reconcileAndCheck(kubermaticv1.ReasonCARotationTrustingNewCA)
+	if message := updated.Status.Conditions[kubermaticv1.ClusterConditionCARotation].Message; message != "Waiting for the cluster-info ConfigMap to be updated" {
+		t.Fatalf("unexpected condition message %q", message)
+	}
+	updateClusterInfo(caBundle())
+	reconcileAndCheck(kubermaticv1.ReasonCARotationTrustingNewCA)
+	rolloutNodes(caBundle())
+	reconcileAndCheck(kubermaticv1.ReasonCARotationReissuingCertificates)
+
+	// the new CA signs now, so the rotation waits for the certificates to be re-issued
+	updated = reconcileAndCheck(kubermaticv1.ReasonCARotationReissuingCertificates)
+	if message := updated.Status.Conditions[kubermaticv1.ClusterConditionCARotation].Message; message != "Waiting for certificate apiserver-tls to be re-issued" {
+		t.Fatalf("unexpected condition message %q", message)
+	}
+
+	for _, cert := range []struct {
+		secret string
+		key    string
+	}{
+		{secret: resources.ApiserverTLSSecretName, key: resources.ApiserverTLSCertSecretKey},
+		{secret: resources.KubeletClientCertificatesSecretName, key: resources.KubeletClientCertSecretKey},
+	} {
+		kp, err := triple.NewClientKeyPair(nextCA, cert.secret, nil)
+		if err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+		if err := seedClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: cert.secret},
+			Data:       map[string][]byte{cert.key: triple.EncodeCertPEM(kp.Cert)},
+		}); err != nil {
+			t.Fatalf("failed to create certificate secret: %v", err)
+		}
+	}
+
+	updateClusterInfo(caBundle())
+	reconcileAndCheck(kubermaticv1.ReasonCARotationReissuingCertificates)
+	rolloutNodes(caBundle())
+	reconcileAndCheck(kubermaticv1.ReasonCARotationDroppingOldCA)
+
+	// finally, only the new CA remains
+	reconcileAndCheck(kubermaticv1.ReasonCARotationDroppingOldCA)
+	updateClusterInfo(caBundle())
+	reconcileAndCheck(kubermaticv1.ReasonCARotationDroppingOldCA)
+	rolloutNodes(caBundle())
+	updated = reconcileAndCheck(kubermaticv1.ReasonCARotationCompleted)
+
+	if status := updated.Status.Conditions[kubermaticv1.ClusterConditionCARotation].Status; status != corev1.ConditionTrue {
+		t.Errorf("expected CA rotation condition to be true, got %s", status)
+	}
+
+	if !bytes.Equal(caBundle(), triple.EncodeCertPEM(nextCA.Cert)) {
+		t.Error("expected CA bundle to only contain the new CA")
+	}
+
+	rootCA, err := resources.GetClusterRootCA(ctx, testNamespace, seedClient)
+	if err != nil {
+		t.Fatalf("failed to get root CA: %v", err)
+	}
+	if !rootCA.Cert.Equal(nextCA.Cert) {
+		t.Error("expected the new CA to be the root CA")
+	}
+
+	err = seedClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: resources.NextCASecretName}, &corev1.Secret{})
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("expected next CA secret to be deleted, got %v", err)
+	}
+}
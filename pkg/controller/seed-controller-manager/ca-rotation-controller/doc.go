@@ -0,0 +1,33 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package carotationcontroller contains a controller that rotates the root CA of
+a user cluster when the cluster is annotated with kubermatic.k8c.io/rotate-ca.
+
+The rotation is done in three phases, each of which waits for the control plane
+to roll and the nodes to be replaced before moving on:
+
+  - TrustingNewCA: a new CA is generated and added to the CA bundle, so that all
+    kubeconfigs, the cluster-info ConfigMap used by OSM to bootstrap nodes and
+    the control plane trust both CAs.
+  - ReissuingCertificates: the new CA becomes the signing CA, which makes the
+    cluster controller re-issue all certificates and kubeconfigs signed by it.
+  - DroppingOldCA: the old CA is removed from the CA bundle.
+
+The progress is tracked in the CARotation cluster condition.
+*/
+package carotationcontroller
@@ -0,0 +1,324 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhibernationcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kkp-cluster-hibernation-controller"
+
+	// progressCheckPeriod is the interval in which machines are checked while scaling down
+	// and the apiserver while resuming.
+	progressCheckPeriod = 10 * time.Second
+
+	// scheduleCheckPeriod is the interval in which the hibernation schedule is evaluated.
+	scheduleCheckPeriod = time.Minute
+)
+
+// UserClusterClientProvider provides functionality to get a user cluster client.
+type UserClusterClientProvider interface {
+	GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider UserClusterClientProvider
+	log                           *zap.SugaredLogger
+	versions                      kubermatic.Versions
+	now                           func() time.Time
+}
+
+// Add creates a new cluster hibernation controller.
+func Add(mgr manager.Manager, numWorkers int, workerName string, userClusterConnectionProvider UserClusterClientProvider, log *zap.SugaredLogger, versions kubermatic.Versions) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		log:                           log,
+		versions:                      versions,
+		now:                           time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %w", err)
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), &kubermaticv1.Cluster{}), &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Reconciling")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
+		ctx,
+		r.Client,
+		r.workerName,
+		cluster,
+		r.versions,
+		kubermaticv1.ClusterConditionNone,
+		func() (*reconcile.Result, error) {
+			return r.reconcile(ctx, log, cluster)
+		},
+	)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		return nil, nil
+	}
+
+	hibernate, err := r.shouldHibernate(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.reconcileHibernation(ctx, log, cluster, hibernate)
+	if err != nil || result != nil {
+		return result, err
+	}
+
+	if cluster.Spec.HibernationSchedule != nil {
+		return &reconcile.Result{RequeueAfter: scheduleCheckPeriod}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *Reconciler) shouldHibernate(cluster *kubermaticv1.Cluster) (bool, error) {
+	if cluster.Spec.Hibernated {
+		return true, nil
+	}
+
+	window := cluster.Spec.HibernationSchedule
+	if window == nil || window.Start == "" || window.Length == "" {
+		return false, nil
+	}
+
+	schedule, err := kubermaticv1helper.ParseUpdateWindow(window)
+	if err != nil {
+		return false, fmt.Errorf("invalid hibernation schedule: %w", err)
+	}
+
+	return schedule.Active(r.now()), nil
+}
+
+func (r *Reconciler) reconcileHibernation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, hibernate bool) (*reconcile.Result, error) {
+	status := cluster.Status.Hibernation
+
+	if hibernate {
+		switch {
+		case status == nil || status.Phase == kubermaticv1.ClusterHibernationResuming:
+			log.Info("Hibernating cluster")
+			r.recorder.Event(cluster, corev1.EventTypeNormal, "Hibernating", "Scaling MachineDeployments to zero")
+			return &reconcile.Result{Requeue: true}, r.setHibernationPhase(ctx, cluster, kubermaticv1.ClusterHibernationScalingDown)
+
+		case status.Phase == kubermaticv1.ClusterHibernationScalingDown:
+			return r.scaleDown(ctx, log, cluster)
+		}
+
+		return nil, nil
+	}
+
+	if status == nil {
+		return nil, nil
+	}
+
+	if status.Phase != kubermaticv1.ClusterHibernationResuming {
+		log.Info("Resuming cluster")
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "Resuming", "Starting the control plane")
+		return &reconcile.Result{Requeue: true}, r.setHibernationPhase(ctx, cluster, kubermaticv1.ClusterHibernationResuming)
+	}
+
+	return r.resume(ctx, log, cluster)
+}
+
+// scaleDown records the replicas of all MachineDeployments and scales them to zero. Once
+// all machines are gone, the cluster is marked as hibernated, which makes the other
+// controllers scale the control plane to zero.
+func (r *Reconciler) scaleDown(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		log.Debug("Waiting for the apiserver to scale down MachineDeployments")
+		return &reconcile.Result{RequeueAfter: progressCheckPeriod}, nil
+	}
+
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	// record the replicas before touching any MachineDeployment, so they are never lost
+	err = kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		for _, md := range machineDeployments.Items {
+			if replicas := ptr.Deref(md.Spec.Replicas, 0); replicas > 0 {
+				if c.Status.Hibernation.MachineDeploymentReplicas == nil {
+					c.Status.Hibernation.MachineDeploymentReplicas = map[string]int32{}
+				}
+				if _, recorded := c.Status.Hibernation.MachineDeploymentReplicas[md.Name]; !recorded {
+					c.Status.Hibernation.MachineDeploymentReplicas[md.Name] = replicas
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record MachineDeployment replicas: %w", err)
+	}
+
+	for i := range machineDeployments.Items {
+		if err := scaleMachineDeployment(ctx, userClusterClient, &machineDeployments.Items[i], 0); err != nil {
+			return nil, err
+		}
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := userClusterClient.List(ctx, machines, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	if len(machines.Items) > 0 {
+		log.Debugw("Waiting for machines to be deleted", "machines", len(machines.Items))
+		return &reconcile.Result{RequeueAfter: progressCheckPeriod}, nil
+	}
+
+	log.Info("All machines are gone, scaling control plane to zero")
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Hibernated", "Scaling the control plane to zero")
+
+	return nil, r.setHibernationPhase(ctx, cluster, kubermaticv1.ClusterHibernationHibernated)
+}
+
+// resume restores the MachineDeployments once the control plane is back.
+func (r *Reconciler) resume(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp ||
+		cluster.Status.ExtendedHealth.MachineController != kubermaticv1.HealthStatusUp {
+		log.Debug("Waiting for the control plane to become healthy")
+		return &reconcile.Result{RequeueAfter: progressCheckPeriod}, nil
+	}
+
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	for name, replicas := range cluster.Status.Hibernation.MachineDeploymentReplicas {
+		md := &clusterv1alpha1.MachineDeployment{}
+		if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, md); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get MachineDeployment %s: %w", name, err)
+		}
+
+		// do not override replicas that were changed while the cluster was resuming
+		if ptr.Deref(md.Spec.Replicas, 0) != 0 {
+			continue
+		}
+
+		if err := scaleMachineDeployment(ctx, userClusterClient, md, replicas); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info("Cluster resumed")
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Resumed", "Restored the MachineDeployments")
+
+	return nil, kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Hibernation = nil
+	})
+}
+
+func (r *Reconciler) setHibernationPhase(ctx context.Context, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterHibernationPhase) error {
+	return kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		if c.Status.Hibernation == nil {
+			c.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{}
+		}
+		c.Status.Hibernation.Phase = phase
+		c.Status.Hibernation.LastTransitionTime = metav1.NewTime(r.now())
+	})
+}
+
+func scaleMachineDeployment(ctx context.Context, client ctrlruntimeclient.Client, md *clusterv1alpha1.MachineDeployment, replicas int32) error {
+	if ptr.Deref(md.Spec.Replicas, 0) == replicas {
+		return nil
+	}
+
+	oldMD := md.DeepCopy()
+	md.Spec.Replicas = ptr.To(replicas)
+	if err := client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+		return fmt.Errorf("failed to scale MachineDeployment %s: %w", md.Name, err)
+	}
+
+	return nil
+}
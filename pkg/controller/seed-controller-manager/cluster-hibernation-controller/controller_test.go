@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterhibernationcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	clusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var testScheme = fake.NewScheme()
+
+func init() {
+	utilruntime.Must(clusterv1alpha1.AddToScheme(testScheme))
+}
+
+// saturday is a Saturday noon.
+var saturday = time.Date(2023, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeClientProvider) GetClient(ctx context.Context, c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
+
+func genCluster(mutate func(*kubermaticv1.Cluster)) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test",
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver:         kubermaticv1.HealthStatusUp,
+				MachineController: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+	mutate(cluster)
+
+	return cluster
+}
+
+func genMachineDeployment(name string, replicas int32) *clusterv1alpha1.MachineDeployment {
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceSystem,
+			Name:      name,
+		},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Replicas: ptr.To(replicas),
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name               string
+		cluster            *kubermaticv1.Cluster
+		userClusterObjects []ctrlruntimeclient.Object
+		// reconciles is the number of times the controller is run
+		reconciles          int
+		expectedHibernation *kubermaticv1.ClusterHibernationStatus
+		expectedReplicas    map[string]int32
+	}{
+		{
+			name: "hibernating scales MachineDeployments to zero and waits for machines",
+			cluster: genCluster(func(c *kubermaticv1.Cluster) {
+				c.Spec.Hibernated = true
+			}),
+			userClusterObjects: []ctrlruntimeclient.Object{
+				genMachineDeployment("workers", 3),
+				genMachineDeployment("idle", 0),
+				&clusterv1alpha1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "workers-1"},
+				},
+			},
+			reconciles: 3,
+			expectedHibernation: &kubermaticv1.ClusterHibernationStatus{
+				Phase:                     kubermaticv1.ClusterHibernationScalingDown,
+				MachineDeploymentReplicas: map[string]int32{"workers": 3},
+			},
+			expectedReplicas: map[string]int32{"workers": 0, "idle": 0},
+		},
+		{
+			name: "hibernation completes once all machines are gone",
+			cluster: genCluster(func(c *kubermaticv1.Cluster) {
+				c.Spec.Hibernated = true
+			}),
+			userClusterObjects: []ctrlruntimeclient.Object{
+				genMachineDeployment("workers", 3),
+			},
+			reconciles: 2,
+			expectedHibernation: &kubermaticv1.ClusterHibernationStatus{
+				Phase:                     kubermaticv1.ClusterHibernationHibernated,
+				MachineDeploymentReplicas: map[string]int32{"workers": 3},
+			},
+			expectedReplicas: map[string]int32{"workers": 0},
+		},
+		{
+			name: "hibernation schedule hibernates the cluster",
+			cluster: genCluster(func(c *kubermaticv1.Cluster) {
+				c.Spec.HibernationSchedule = &kubermaticv1.UpdateWindow{Start: "Sat 00:00", Length: "48h"}
+			}),
+			userClusterObjects: []ctrlruntimeclient.Object{
+				genMachineDeployment("workers", 2),
+			},
+			reconciles: 1,
+			expectedHibernation: &kubermaticv1.ClusterHibernationStatus{
+				Phase: kubermaticv1.ClusterHibernationScalingDown,
+			},
+			expectedReplicas: map[string]int32{"workers": 2},
+		},
+		{
+			name: "hibernation schedule outside of the window leaves the cluster running",
+			cluster: genCluster(func(c *kubermaticv1.Cluster) {
+				c.Spec.HibernationSchedule = &kubermaticv1.UpdateWindow{Start: "Mon 20:00", Length: "10h"}
+			}),
+			userClusterObjects: []ctrlruntimeclient.Object{
+				genMachineDeployment("workers", 2),
+			},
+			reconciles:       2,
+			expectedReplicas: map[string]int32{"workers": 2},
+		},
+		{
+			name: "resuming restores the MachineDeployments",
+			cluster: genCluster(func(c *kubermaticv1.Cluster) {
+				c.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{
+					Phase:                     kubermaticv1.ClusterHibernationHibernated,
+					MachineDeploymentReplicas: map[string]int32{"workers": 3, "deleted": 1},
+				}
+			}),
+			userClusterObjects: []ctrlruntimeclient.Object{
+				genMachineDeployment("workers", 0),
+			},
+			reconciles:       2,
+			expectedReplicas: map[string]int32{"workers": 3},
+		},
+		{
+			name: "resuming waits for the control plane",
+			cluster: genCluster(func(c *kubermaticv1.Cluster) {
+				c.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusDown
+				c.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{
+					Phase:                     kubermaticv1.ClusterHibernationHibernated,
+					MachineDeploymentReplicas: map[string]int32{"workers": 3},
+				}
+			}),
+			userClusterObjects: []ctrlruntimeclient.Object{
+				genMachineDeployment("workers", 0),
+			},
+			reconciles: 2,
+			expectedHibernation: &kubermaticv1.ClusterHibernationStatus{
+				Phase:                     kubermaticv1.ClusterHibernationResuming,
+				MachineDeploymentReplicas: map[string]int32{"workers": 3},
+			},
+			expectedReplicas: map[string]int32{"workers": 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			seedClient := fake.NewClientBuilder().WithObjects(tc.cluster).Build()
+			userClusterClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tc.userClusterObjects...).Build()
+
+			r := &Reconciler{
+				Client:                        seedClient,
+				recorder:                      record.NewFakeRecorder(10),
+				userClusterConnectionProvider: &fakeClientProvider{client: userClusterClient},
+				log:                           kubermaticlog.Logger,
+				versions:                      kubermatic.NewFakeVersions(),
+				now:                           func() time.Time { return saturday },
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.cluster.Name}}
+			for i := 0; i < tc.reconciles; i++ {
+				if _, err := r.Reconcile(ctx, request); err != nil {
+					t.Fatalf("reconciling failed: %v", err)
+				}
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := seedClient.Get(ctx, request.NamespacedName, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			hibernation := cluster.Status.Hibernation
+			if hibernation != nil {
+				hibernation.LastTransitionTime = metav1.Time{}
+			}
+			if !diff.SemanticallyEqual(tc.expectedHibernation, hibernation) {
+				t.Fatalf("Hibernation status differs from expected:\n%v", diff.ObjectDiff(tc.expectedHibernation, hibernation))
+			}
+
+			for name, expected := range tc.expectedReplicas {
+				md := &clusterv1alpha1.MachineDeployment{}
+				if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, md); err != nil {
+					t.Fatalf("failed to get MachineDeployment: %v", err)
+				}
+				if replicas := ptr.Deref(md.Spec.Replicas, 0); replicas != expected {
+					t.Errorf("expected MachineDeployment %s to have %d replicas, got %d", name, expected, replicas)
+				}
+			}
+		})
+	}
+}
@@ -102,6 +102,11 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return r.setClusterPhase(ctx, cluster, kubermaticv1.ClusterTerminating)
 	}
 
+	// a hibernated cluster has no control plane running, so no other phase applies
+	if cluster.Status.IsHibernated() {
+		return r.setClusterPhase(ctx, cluster, kubermaticv1.ClusterHibernated)
+	}
+
 	// if this cluster was never fully reconciled (yet), it is in Creating phase
 	if !kubermaticv1helper.IsClusterInitialized(cluster, r.versions) {
 		return r.setClusterPhase(ctx, cluster, kubermaticv1.ClusterCreating)
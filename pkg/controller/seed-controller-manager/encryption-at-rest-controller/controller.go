@@ -317,6 +317,12 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 			}); err != nil {
 				return &reconcile.Result{}, err
 			}
+
+			return &reconcile.Result{}, nil
+		}
+
+		if cluster.Status.Encryption.ActiveKey == configuredKey {
+			return r.reconcileKeyRotation(ctx, log, cluster)
 		}
 
 		return &reconcile.Result{}, nil
@@ -0,0 +1,263 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptionatrestcontroller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// maxKeyRotationHistory is the number of key rotations kept in the cluster status.
+	maxKeyRotationHistory = 10
+
+	// rotatedKeySecretDataKey is the key in the generated Secrets holding the encryption key.
+	rotatedKeySecretDataKey = "key"
+	// rotatedKeyLabelValue marks Secrets holding keys that were generated by this controller.
+	rotatedKeyLabelValue = "encryption-key"
+)
+
+// reconcileKeyRotation drives the automated rotation of secretbox keys. It is only called while
+// encryption is in the Active phase with the configured primary key, so the regular phase handling
+// (Pending -> EncryptionNeeded -> Active) takes care of rolling the apiservers and re-encrypting the
+// data once a new key has been prepended to the configuration.
+func (r *Reconciler) reconcileKeyRotation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if !cluster.IsEncryptionEnabled() || cluster.Spec.EncryptionConfiguration.Secretbox == nil || cluster.Spec.EncryptionConfiguration.Secretbox.Rotation == nil {
+		return &reconcile.Result{}, nil
+	}
+
+	if rotation := currentKeyRotation(cluster); rotation != nil {
+		return r.continueKeyRotation(ctx, log, cluster, *rotation)
+	}
+
+	trigger, requeueAfter := getKeyRotationTrigger(cluster, time.Now())
+	if trigger == "" {
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	now := metav1.Now()
+	rotation := kubermaticv1.EncryptionKeyRotation{
+		Key:        fmt.Sprintf("rotated-%d", now.Unix()),
+		RetiredKey: cluster.Spec.EncryptionConfiguration.Secretbox.Keys[0].Name,
+		Trigger:    trigger,
+		StartTime:  now,
+	}
+
+	log.Infow("Rotating encryption key", "trigger", trigger, "key", rotation.Key, "retired", rotation.RetiredKey)
+
+	// record the rotation first, so that an interrupted rotation is picked up again on the next reconcile
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r.Client, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Encryption.KeyRotations = append(c.Status.Encryption.KeyRotations, rotation)
+		if len(c.Status.Encryption.KeyRotations) > maxKeyRotationHistory {
+			c.Status.Encryption.KeyRotations = c.Status.Encryption.KeyRotations[len(c.Status.Encryption.KeyRotations)-maxKeyRotationHistory:]
+		}
+	}); err != nil {
+		return &reconcile.Result{}, err
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EncryptionKeyRotationStarted", "Rotating encryption key %q to %q", rotation.RetiredKey, rotation.Key)
+
+	return r.continueKeyRotation(ctx, log, cluster, rotation)
+}
+
+// continueKeyRotation moves an ongoing key rotation forward: it adds the new key as primary key if that has
+// not happened yet, and removes the retired key once all data has been re-encrypted with the new one.
+func (r *Reconciler) continueKeyRotation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, rotation kubermaticv1.EncryptionKeyRotation) (*reconcile.Result, error) {
+	keys := cluster.Spec.EncryptionConfiguration.Secretbox.Keys
+
+	if keys[0].Name != rotation.Key {
+		secretRef, err := r.ensureKeySecret(ctx, cluster, rotation.Key)
+		if err != nil {
+			return &reconcile.Result{}, fmt.Errorf("failed to create secret for encryption key: %w", err)
+		}
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.EncryptionConfiguration.Secretbox.Keys = append([]kubermaticv1.SecretboxKey{
+			{
+				Name:      rotation.Key,
+				SecretRef: secretRef,
+			},
+		}, keys...)
+		delete(cluster.Annotations, kubermaticv1.RotateEncryptionKeyAnnotation)
+
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFromWithOptions(oldCluster, ctrlruntimeclient.MergeFromWithOptimisticLock{})); err != nil {
+			return &reconcile.Result{}, fmt.Errorf("failed to add new encryption key: %w", err)
+		}
+
+		// the cluster will now go through the regular Pending/EncryptionNeeded phases
+		return &reconcile.Result{}, nil
+	}
+
+	// we are only called when the new key is active, i.e. all data has been re-encrypted with it
+	var (
+		retainedKeys []kubermaticv1.SecretboxKey
+		retiredKey   *kubermaticv1.SecretboxKey
+	)
+
+	for i, key := range keys {
+		if key.Name == rotation.RetiredKey {
+			retiredKey = &keys[i]
+			continue
+		}
+		retainedKeys = append(retainedKeys, key)
+	}
+
+	if retiredKey != nil {
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.EncryptionConfiguration.Secretbox.Keys = retainedKeys
+
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFromWithOptions(oldCluster, ctrlruntimeclient.MergeFromWithOptimisticLock{})); err != nil {
+			return &reconcile.Result{}, fmt.Errorf("failed to remove retired encryption key: %w", err)
+		}
+
+		if err := r.deleteKeySecret(ctx, cluster, retiredKey); err != nil {
+			return &reconcile.Result{}, fmt.Errorf("failed to delete secret of retired encryption key: %w", err)
+		}
+	}
+
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r.Client, cluster, func(c *kubermaticv1.Cluster) {
+		for i := range c.Status.Encryption.KeyRotations {
+			if c.Status.Encryption.KeyRotations[i].Key == rotation.Key {
+				c.Status.Encryption.KeyRotations[i].CompletionTime = ptr.To(metav1.Now())
+			}
+		}
+	}); err != nil {
+		return &reconcile.Result{}, err
+	}
+
+	log.Infow("Encryption key rotation completed", "key", rotation.Key)
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EncryptionKeyRotationCompleted", "Encryption key has been rotated to %q", rotation.Key)
+
+	return &reconcile.Result{}, nil
+}
+
+// ensureKeySecret creates a Secret with a random key in the cluster namespace, unless it exists already.
+func (r *Reconciler) ensureKeySecret(ctx context.Context, cluster *kubermaticv1.Cluster, keyName string) (*corev1.SecretKeySelector, error) {
+	secretName := fmt.Sprintf("encryption-key-%s", keyName)
+
+	ref := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Key:                  rotatedKeySecretDataKey,
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: cluster.Status.NamespaceName, Name: secretName}, secret)
+	if err == nil {
+		return ref, nil
+	}
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+
+	key := make([]byte, EARKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: cluster.Status.NamespaceName,
+			Labels: map[string]string{
+				resources.AppLabelKey: rotatedKeyLabelValue,
+			},
+		},
+		Data: map[string][]byte{
+			rotatedKeySecretDataKey: []byte(base64.StdEncoding.EncodeToString(key)),
+		},
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
+
+// deleteKeySecret removes the Secret of a retired key, if it was generated by this controller.
+func (r *Reconciler) deleteKeySecret(ctx context.Context, cluster *kubermaticv1.Cluster, key *kubermaticv1.SecretboxKey) error {
+	if key.SecretRef == nil {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: cluster.Status.NamespaceName, Name: key.SecretRef.Name}, secret); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if secret.Labels[resources.AppLabelKey] != rotatedKeyLabelValue {
+		return nil
+	}
+
+	return ctrlruntimeclient.IgnoreNotFound(r.Delete(ctx, secret))
+}
+
+// currentKeyRotation returns the key rotation that is still in progress, if any.
+func currentKeyRotation(cluster *kubermaticv1.Cluster) *kubermaticv1.EncryptionKeyRotation {
+	rotations := cluster.Status.Encryption.KeyRotations
+	if len(rotations) == 0 || rotations[len(rotations)-1].CompletionTime != nil {
+		return nil
+	}
+
+	return &rotations[len(rotations)-1]
+}
+
+// getKeyRotationTrigger returns why a key rotation needs to happen now. If no rotation is needed,
+// it returns an empty trigger and the time until the next rotation is due (0 if none is scheduled).
+func getKeyRotationTrigger(cluster *kubermaticv1.Cluster, now time.Time) (kubermaticv1.EncryptionKeyRotationTrigger, time.Duration) {
+	if _, ok := cluster.Annotations[kubermaticv1.RotateEncryptionKeyAnnotation]; ok {
+		return kubermaticv1.EncryptionKeyRotationTriggerManual, 0
+	}
+
+	interval := cluster.Spec.EncryptionConfiguration.Secretbox.Rotation.Interval
+	if interval == nil || interval.Duration <= 0 {
+		return "", 0
+	}
+
+	// the age of the primary key is determined by the last rotation or, if there was none
+	// yet, by the time encryption was initialized on the cluster.
+	var lastRotation time.Time
+	if rotations := cluster.Status.Encryption.KeyRotations; len(rotations) > 0 {
+		lastRotation = rotations[len(rotations)-1].StartTime.Time
+	} else if condition, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionEncryptionInitialized]; ok && !condition.LastTransitionTime.IsZero() {
+		lastRotation = condition.LastTransitionTime.Time
+	} else {
+		lastRotation = cluster.CreationTimestamp.Time
+	}
+
+	next := lastRotation.Add(interval.Duration)
+	if !now.Before(next) {
+		return kubermaticv1.EncryptionKeyRotationTriggerInterval, 0
+	}
+
+	return "", next.Sub(now)
+}
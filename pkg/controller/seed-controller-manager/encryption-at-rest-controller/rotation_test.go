@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptionatrestcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func rotationCluster(interval time.Duration, annotated bool) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Annotations: map[string]string{},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Features: map[string]bool{
+				kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+			},
+			EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+				Enabled:   true,
+				Resources: []string{"secrets"},
+				Secretbox: &kubermaticv1.SecretboxEncryptionConfiguration{
+					Keys: []kubermaticv1.SecretboxKey{
+						{
+							Name:  "initial",
+							Value: "RGolflgAc+eBbm1lys87pTNQZVf0i67rlpPZGtTkVjQ=",
+						},
+					},
+					Rotation: &kubermaticv1.SecretboxKeyRotationPolicy{},
+				},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test",
+			Encryption: &kubermaticv1.ClusterEncryptionStatus{
+				ActiveKey:          "secretbox/initial",
+				EncryptedResources: []string{"secrets"},
+				Phase:              kubermaticv1.ClusterEncryptionPhaseActive,
+			},
+			Conditions: map[kubermaticv1.ClusterConditionType]kubermaticv1.ClusterCondition{
+				kubermaticv1.ClusterConditionEncryptionInitialized: {
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				},
+			},
+		},
+	}
+
+	if interval > 0 {
+		cluster.Spec.EncryptionConfiguration.Secretbox.Rotation.Interval = &metav1.Duration{Duration: interval}
+	}
+
+	if annotated {
+		cluster.Annotations[kubermaticv1.RotateEncryptionKeyAnnotation] = ""
+	}
+
+	return cluster
+}
+
+func TestGetKeyRotationTrigger(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name            string
+		cluster         *kubermaticv1.Cluster
+		expectedTrigger kubermaticv1.EncryptionKeyRotationTrigger
+		expectRequeue   bool
+	}{
+		{
+			name:    "no interval and no annotation",
+			cluster: rotationCluster(0, false),
+		},
+		{
+			name:            "manual trigger",
+			cluster:         rotationCluster(0, true),
+			expectedTrigger: kubermaticv1.EncryptionKeyRotationTriggerManual,
+		},
+		{
+			name:            "key older than interval",
+			cluster:         rotationCluster(time.Hour, false),
+			expectedTrigger: kubermaticv1.EncryptionKeyRotationTriggerInterval,
+		},
+		{
+			name:          "key younger than interval",
+			cluster:       rotationCluster(3*time.Hour, false),
+			expectRequeue: true,
+		},
+		{
+			name: "interval measured from last rotation",
+			cluster: func() *kubermaticv1.Cluster {
+				c := rotationCluster(time.Hour, false)
+				c.Status.Encryption.KeyRotations = []kubermaticv1.EncryptionKeyRotation{
+					{Key: "rotated", StartTime: metav1.NewTime(now.Add(-30 * time.Minute))},
+				}
+				return c
+			}(),
+			expectRequeue: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trigger, requeueAfter := getKeyRotationTrigger(tc.cluster, now)
+			if trigger != tc.expectedTrigger {
+				t.Errorf("Expected trigger %q, got %q", tc.expectedTrigger, trigger)
+			}
+
+			if tc.expectRequeue != (requeueAfter > 0) {
+				t.Errorf("Expected requeue: %v, got requeue after %v", tc.expectRequeue, requeueAfter)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+
+	client := fake.NewClientBuilder().WithObjects(rotationCluster(0, true)).Build()
+	r := &Reconciler{
+		Client:   client,
+		log:      zap.NewNop().Sugar(),
+		recorder: record.NewFakeRecorder(10),
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "test"}, cluster); err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	// start the rotation
+	if _, err := r.reconcileKeyRotation(ctx, r.log, cluster); err != nil {
+		t.Fatalf("Failed to start key rotation: %v", err)
+	}
+
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "test"}, cluster); err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	rotation := currentKeyRotation(cluster)
+	if rotation == nil {
+		t.Fatal("Expected a key rotation to be in progress")
+	}
+
+	if rotation.Trigger != kubermaticv1.EncryptionKeyRotationTriggerManual || rotation.RetiredKey != "initial" {
+		t.Errorf("Unexpected key rotation: %+v", rotation)
+	}
+
+	if _, ok := cluster.Annotations[kubermaticv1.RotateEncryptionKeyAnnotation]; ok {
+		t.Error("Expected trigger annotation to be removed")
+	}
+
+	keys := cluster.Spec.EncryptionConfiguration.Secretbox.Keys
+	if len(keys) != 2 || keys[0].Name != rotation.Key || keys[0].SecretRef == nil || keys[1].Name != "initial" {
+		t.Fatalf("Expected new key to be prepended, got %+v", keys)
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: "cluster-test", Name: keys[0].SecretRef.Name}, secret); err != nil {
+		t.Fatalf("Failed to get secret for new key: %v", err)
+	}
+
+	if err := validateKeyLength(string(secret.Data[keys[0].SecretRef.Key])); err != nil {
+		t.Errorf("Generated key is invalid: %v", err)
+	}
+
+	// nothing must happen until the new key is active
+	if _, err := r.reconcileKeyRotation(ctx, r.log, cluster); err != nil {
+		t.Fatalf("Failed to reconcile key rotation: %v", err)
+	}
+
+	// simulate that the data has been re-encrypted with the new key
+	cluster.Status.Encryption.ActiveKey = "secretbox/" + rotation.Key
+	if err := client.Status().Update(ctx, cluster); err != nil {
+		t.Fatalf("Failed to update cluster status: %v", err)
+	}
+
+	if _, err := r.reconcileKeyRotation(ctx, r.log, cluster); err != nil {
+		t.Fatalf("Failed to complete key rotation: %v", err)
+	}
+
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "test"}, cluster); err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+
+	keys = cluster.Spec.EncryptionConfiguration.Secretbox.Keys
+	if len(keys) != 1 || keys[0].Name != rotation.Key {
+		t.Errorf("Expected retired key to be removed, got %+v", keys)
+	}
+
+	if currentKeyRotation(cluster) != nil {
+		t.Error("Expected key rotation to be completed")
+	}
+
+	if len(cluster.Status.Encryption.KeyRotations) != 1 || cluster.Status.Encryption.KeyRotations[0].CompletionTime == nil {
+		t.Errorf("Expected completed rotation in history, got %+v", cluster.Status.Encryption.KeyRotations)
+	}
+}
@@ -99,11 +99,11 @@ func getActiveConfiguration(ctx context.Context, client ctrlruntimeclient.Client
 		}
 	}
 
-	// we expect two providers, (1) the configured encryption provider as per the ClusterSpec (secretbox or KMS plugins)
-	// and (2) the "identity" provider, which is there for reading (and if at the top of the list, writing) resources as
+	// we expect the configured encryption provider(s) as per the ClusterSpec (secretbox or one entry per KMS plugin)
+	// and the "identity" provider, which is there for reading (and if at the top of the list, writing) resources as
 	// unencrypted.
-	if len(config.Resources) != 1 || (len(config.Resources[0].Providers) != 1 && len(config.Resources[0].Providers) != 2) {
-		return "", []string{}, errors.New("unexpected apiserverconfigv1.EncryptionConfiguration: too many items in .resources or .resources[0].providers")
+	if len(config.Resources) != 1 || len(config.Resources[0].Providers) == 0 {
+		return "", []string{}, errors.New("unexpected apiserverconfigv1.EncryptionConfiguration: unexpected number of items in .resources or .resources[0].providers")
 	}
 
 	providerConfig := &config.Resources[0].Providers[0]
@@ -111,6 +111,8 @@ func getActiveConfiguration(ctx context.Context, client ctrlruntimeclient.Client
 	switch {
 	case providerConfig.Secretbox != nil:
 		keyName = fmt.Sprintf("%s/%s", encryptionresources.SecretboxPrefix, providerConfig.Secretbox.Keys[0].Name)
+	case providerConfig.KMS != nil:
+		keyName = fmt.Sprintf("%s/%s", encryptionresources.KMSPrefix, providerConfig.KMS.Name)
 	case providerConfig.Identity != nil:
 		keyName = encryptionresources.IdentityKey
 	}
@@ -129,6 +131,8 @@ func getConfiguredKey(cluster *kubermaticv1.Cluster) (string, error) {
 	switch {
 	case cluster.Spec.EncryptionConfiguration.Secretbox != nil:
 		return fmt.Sprintf("%s/%s", encryptionresources.SecretboxPrefix, cluster.Spec.EncryptionConfiguration.Secretbox.Keys[0].Name), nil
+	case cluster.Spec.EncryptionConfiguration.KMS != nil:
+		return fmt.Sprintf("%s/%s", encryptionresources.KMSPrefix, cluster.Spec.EncryptionConfiguration.KMS.Providers[0].Name), nil
 	}
 
 	return "", errors.New("no supported encryption provider found")
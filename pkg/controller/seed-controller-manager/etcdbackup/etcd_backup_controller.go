@@ -18,6 +18,7 @@ package etcdbackup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/reconciler/pkg/reconciling"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -76,6 +78,9 @@ const (
 
 	// maximum number of simultaneously running backup delete jobs per BackupConfig.
 	maxSimultaneousDeleteJobsPerConfig = 3
+
+	// maximum number of simultaneously running backup verify jobs per BackupConfig.
+	maxSimultaneousVerifyJobsPerConfig = 3
 )
 
 // Reconciler stores necessary components that are required to create etcd backups.
@@ -196,24 +201,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	var suppressedError error
 
-	// Add a wrapping here so we can emit an event on error
-	result, err := kubermaticv1helper.ClusterReconcileWrapper(
-		ctx,
-		r.Client,
-		r.workerName,
-		cluster,
-		r.versions,
-		kubermaticv1.ClusterConditionNone,
-		func() (*reconcile.Result, error) {
-			result, err := r.reconcile(ctx, log, backupConfig, cluster, seed, config)
-			if apierrors.IsConflict(err) {
-				// benign update conflict -- remember this so we can
-				// suppress log.Error and event generation below
-				suppressedError = err
-			}
-			return result, err
-		},
-	)
+	reconcileBackups := func() (*reconcile.Result, error) {
+		result, err := r.reconcile(ctx, log, backupConfig, cluster, seed, config)
+		if apierrors.IsConflict(err) {
+			// benign update conflict -- remember this so we can
+			// suppress log.Error and event generation below
+			suppressedError = err
+		}
+		return result, err
+	}
+
+	var result *reconcile.Result
+
+	// A ClusterMigration pauses the cluster before taking its final snapshot,
+	// so its backup has to be processed even though the cluster is paused.
+	if isMigrationSnapshot(cluster, backupConfig) && cluster.Labels[kubermaticv1.WorkerNameLabelKey] == r.workerName {
+		result, err = reconcileBackups()
+	} else {
+		// Add a wrapping here so we can emit an event on error
+		result, err = kubermaticv1helper.ClusterReconcileWrapper(
+			ctx,
+			r.Client,
+			r.workerName,
+			cluster,
+			r.versions,
+			kubermaticv1.ClusterConditionNone,
+			reconcileBackups,
+		)
+	}
 	if err != nil {
 		if suppressedError != nil {
 			// we know that err is a 1-element Aggregate containing just suppressedError
@@ -235,6 +250,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return *result, err
 }
 
+// isMigrationSnapshot returns true if the backup config takes the final snapshot of a cluster
+// that is paused by a ClusterMigration.
+func isMigrationSnapshot(cluster *kubermaticv1.Cluster, backupConfig *kubermaticv1.EtcdBackupConfig) bool {
+	migration := cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation]
+
+	return cluster.Spec.Pause && migration != "" && backupConfig.Annotations[kubermaticv1.ClusterMigrationAnnotation] == migration
+}
+
 func (r *Reconciler) reconcile(
 	ctx context.Context,
 	log *zap.SugaredLogger,
@@ -256,6 +279,10 @@ func (r *Reconciler) reconcile(
 		return nil, fmt.Errorf("failed to create backup configmaps: %w", err)
 	}
 
+	if err := r.ensureEncryptionKey(ctx, log, data, backupConfig, cluster); err != nil {
+		return nil, fmt.Errorf("failed to ensure backup encryption key: %w", err)
+	}
+
 	var nextReconcile, totalReconcile *reconcile.Result
 	errorReconcile := &reconcile.Result{RequeueAfter: 1 * time.Minute}
 
@@ -271,6 +298,12 @@ func (r *Reconciler) reconcile(
 
 	totalReconcile = minReconcile(totalReconcile, nextReconcile)
 
+	if nextReconcile, err = r.verifyCompletedBackups(ctx, data, backupConfig); err != nil {
+		return errorReconcile, fmt.Errorf("failed to verify completed backups: %w", err)
+	}
+
+	totalReconcile = minReconcile(totalReconcile, nextReconcile)
+
 	if nextReconcile, err = r.startPendingBackupDeleteJobs(ctx, data, backupConfig); err != nil {
 		return errorReconcile, fmt.Errorf("failed to start pending backup delete jobs: %w", err)
 	}
@@ -289,6 +322,12 @@ func (r *Reconciler) reconcile(
 
 	totalReconcile = minReconcile(totalReconcile, nextReconcile)
 
+	if nextReconcile, err = r.ensureRevisionStreamer(ctx, data, backupConfig, cluster); err != nil {
+		return errorReconcile, fmt.Errorf("failed to ensure revision streamer: %w", err)
+	}
+
+	totalReconcile = minReconcile(totalReconcile, nextReconcile)
+
 	if nextReconcile, err = r.handleFinalization(ctx, backupConfig); err != nil {
 		return errorReconcile, fmt.Errorf("failed to clean up EtcdBackupConfig: %w", err)
 	}
@@ -544,6 +583,139 @@ func (r *Reconciler) startPendingBackupJobs(ctx context.Context, data *resources
 	return returnReconcile, nil
 }
 
+// create verify jobs for completed backups, if verification is enabled, and record the results
+// of verify jobs that have finished.
+func (r *Reconciler) verifyCompletedBackups(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig) (*reconcile.Result, error) {
+	var returnReconcile *reconcile.Result
+
+	oldBackupConfig := backupConfig.DeepCopy()
+
+	runningVerifyJobsCount := 0
+	var backupsToVerify []*kubermaticv1.BackupStatus
+	for i := range backupConfig.Status.CurrentBackups {
+		backup := &backupConfig.Status.CurrentBackups[i]
+
+		switch {
+		case backup.VerifyPhase == kubermaticv1.BackupStatusPhaseRunning:
+			job := &batchv1.Job{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backup.VerifyJobName}, job)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, fmt.Errorf("error getting verify job for backup %s: %w", backup.BackupName, err)
+				}
+				// job not found. Apparently deleted externally.
+				r.setVerifyFailed(backupConfig, backup, metav1.NewTime(r.clock.Now()), "verify job deleted externally")
+				continue
+			}
+
+			if cond := getJobConditionIfTrue(job, batchv1.JobComplete); cond != nil {
+				message, err := r.getVerifyJobMessage(ctx, job)
+				if err != nil {
+					return nil, err
+				}
+
+				result, err := etcdbackup.ParseVerificationResult(message)
+				if err != nil {
+					r.setVerifyFailed(backupConfig, backup, cond.LastTransitionTime, err.Error())
+					continue
+				}
+
+				backup.VerifyPhase = kubermaticv1.BackupStatusPhaseCompleted
+				backup.VerifyMessage = cond.Message
+				backup.VerifyFinishedTime = cond.LastTransitionTime
+				backup.Checksum = result.Checksum
+				backup.Revision = result.Revision
+				if result.KeyCount != nil {
+					backup.KeyCount = *result.KeyCount
+				}
+			} else if cond := getJobConditionIfTrue(job, batchv1.JobFailed); cond != nil {
+				message, err := r.getVerifyJobMessage(ctx, job)
+				if err != nil {
+					return nil, err
+				}
+				if message == "" {
+					message = cond.Message
+				}
+
+				r.setVerifyFailed(backupConfig, backup, cond.LastTransitionTime, message)
+			} else {
+				// job still running
+				runningVerifyJobsCount++
+				returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: assumedJobRuntime})
+			}
+
+		// backups that are about to be deleted are not worth verifying anymore
+		case backup.VerifyPhase == "" && backup.BackupPhase == kubermaticv1.BackupStatusPhaseCompleted && backup.DeletePhase == "":
+			if backupConfig.Spec.Verification != nil && backupConfig.DeletionTimestamp == nil {
+				backupsToVerify = append(backupsToVerify, backup)
+			}
+		}
+	}
+
+	for _, backup := range backupsToVerify {
+		if runningVerifyJobsCount >= maxSimultaneousVerifyJobsPerConfig {
+			returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: assumedJobRuntime})
+			break
+		}
+
+		backup.VerifyJobName = r.limitNameLength(fmt.Sprintf("%s-backup-%s-verify-%s", data.Cluster().Name, backupConfig.Name, r.randStringGenerator()))
+
+		job := etcdbackup.BackupVerifyJob(data, backupConfig, backup)
+		if err := r.Create(ctx, job); ctrlruntimeclient.IgnoreAlreadyExists(err) != nil {
+			return nil, fmt.Errorf("error creating verify job for backup %s: %w", backup.BackupName, err)
+		}
+
+		backup.VerifyPhase = kubermaticv1.BackupStatusPhaseRunning
+		runningVerifyJobsCount++
+		returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: assumedJobRuntime})
+	}
+
+	if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+		return nil, fmt.Errorf("failed to update backup status: %w", err)
+	}
+
+	return returnReconcile, nil
+}
+
+func (r *Reconciler) setVerifyFailed(backupConfig *kubermaticv1.EtcdBackupConfig, backup *kubermaticv1.BackupStatus, finishedTime metav1.Time, message string) {
+	backup.VerifyPhase = kubermaticv1.BackupStatusPhaseFailed
+	backup.VerifyMessage = message
+	backup.VerifyFinishedTime = finishedTime
+
+	r.recorder.Eventf(backupConfig, corev1.EventTypeWarning, "BackupVerificationFailed", "backup %s failed verification: %s", backup.BackupName, message)
+}
+
+// getVerifyJobMessage returns the termination message of the verify job's most recently terminated
+// container, which contains either the verification result or the reason why it failed.
+func (r *Reconciler) getVerifyJobMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, ctrlruntimeclient.InNamespace(job.Namespace), ctrlruntimeclient.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return "", fmt.Errorf("failed to list pods of job %s: %w", job.Name, err)
+	}
+
+	var (
+		message    string
+		finishedAt time.Time
+	)
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != etcdbackup.BackupVerifyContainerName {
+				continue
+			}
+
+			for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+				if terminated != nil && terminated.Message != "" && !terminated.FinishedAt.Time.Before(finishedAt) {
+					message = terminated.Message
+					finishedAt = terminated.FinishedAt.Time
+				}
+			}
+		}
+	}
+
+	return message, nil
+}
+
 // create any backup delete jobs that can be created, i.e. for all completed backups older than the last backupConfig.GetKeptBackupsCount() ones.
 func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig) (*reconcile.Result, error) {
 	// one-shot backups are not deleted until their backupConfig is deleted
@@ -768,7 +940,44 @@ func (r *Reconciler) deleteFinishedBackupJobs(ctx context.Context, log *zap.Suga
 			}
 		}
 
-		if backupJobDeleted && deleteJobDeleted {
+		verifyJobDeleted := backup.VerifyJobName == ""
+		if !backup.VerifyFinishedTime.IsZero() {
+			var retentionTime time.Duration
+			switch {
+			case !backupConfig.DeletionTimestamp.IsZero():
+				retentionTime = 0
+			case backup.VerifyPhase == kubermaticv1.BackupStatusPhaseCompleted:
+				retentionTime = succeededJobRetentionTime
+			default:
+				retentionTime = failedJobRetentionTime
+			}
+
+			age := r.clock.Now().Sub(backup.VerifyFinishedTime.Time)
+
+			if age < retentionTime {
+				// don't delete the job yet, but reconcile when the time has come to delete it
+				returnReconcile = minReconcile(returnReconcile, &reconcile.Result{RequeueAfter: retentionTime - age})
+			} else {
+				// delete job
+				job := &batchv1.Job{}
+
+				err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backup.VerifyJobName}, job)
+				switch {
+				case apierrors.IsNotFound(err):
+					verifyJobDeleted = true
+				case err == nil:
+					err := r.Delete(ctx, job, ctrlruntimeclient.PropagationPolicy(metav1.DeletePropagationBackground))
+					if err != nil && !apierrors.IsNotFound(err) {
+						return nil, fmt.Errorf("backup %s: failed to delete verify job %s: %w", backup.BackupName, backup.VerifyJobName, err)
+					}
+					verifyJobDeleted = true
+				case !apierrors.IsNotFound(err):
+					return nil, fmt.Errorf("backup %s: failed to get verify job %s: %w", backup.BackupName, backup.VerifyJobName, err)
+				}
+			}
+		}
+
+		if backupJobDeleted && deleteJobDeleted && verifyJobDeleted {
 			// don't add backup to newBackups, which ends up deleting it from backupConfig.Status.CurrentBackups below
 			modified = true
 			continue
@@ -805,6 +1014,64 @@ func (r *Reconciler) handleFinalization(ctx context.Context, backupConfig *kuber
 	return nil, err
 }
 
+// ensureRevisionStreamer runs the revision streamer for continuous backup configs and removes it
+// once the config is not continuous anymore or is being deleted.
+func (r *Reconciler) ensureRevisionStreamer(ctx context.Context, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	oldBackupConfig := backupConfig.DeepCopy()
+
+	if !backupConfig.IsContinuous() || backupConfig.DeletionTimestamp != nil || cluster.DeletionTimestamp != nil {
+		deployment := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: etcdbackup.RevisionStreamerDeploymentName(backupConfig)}, deployment)
+		if err == nil {
+			if err := r.Delete(ctx, deployment); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to delete revision streamer: %w", err)
+			}
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get revision streamer: %w", err)
+		}
+
+		if _, ok := backupConfig.Status.Conditions[kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive]; ok {
+			delete(backupConfig.Status.Conditions, kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive)
+			if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+				return nil, fmt.Errorf("failed to update backup status: %w", err)
+			}
+		}
+
+		return nil, nil
+	}
+
+	creators := []reconciling.NamedDeploymentReconcilerFactory{
+		etcdbackup.RevisionStreamerDeploymentReconciler(data, backupConfig),
+	}
+
+	if err := reconciling.ReconcileDeployments(ctx, creators, metav1.NamespaceSystem, r); err != nil {
+		return nil, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: etcdbackup.RevisionStreamerDeploymentName(backupConfig)}, deployment); err != nil {
+		return nil, fmt.Errorf("failed to get revision streamer: %w", err)
+	}
+
+	var result *reconcile.Result
+
+	var changed bool
+	if deployment.Status.AvailableReplicas > 0 {
+		changed = r.setBackupConfigCondition(backupConfig, kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive, corev1.ConditionTrue, "StreamerAvailable", "")
+	} else {
+		changed = r.setBackupConfigCondition(backupConfig, kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive, corev1.ConditionFalse, "StreamerUnavailable", "revision streamer is not running")
+		result = &reconcile.Result{RequeueAfter: assumedJobRuntime}
+	}
+
+	if changed {
+		if err := r.Status().Patch(ctx, backupConfig, ctrlruntimeclient.MergeFrom(oldBackupConfig)); err != nil {
+			return nil, fmt.Errorf("failed to update backup status: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
 func (r *Reconciler) ensureSecrets(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	secretName := etcdbackup.GetEtcdBackupSecretName(cluster)
 
@@ -826,6 +1093,108 @@ func (r *Reconciler) ensureSecrets(ctx context.Context, cluster *kubermaticv1.Cl
 	return reconciling.ReconcileSecrets(ctx, creators, metav1.NamespaceSystem, r.Client, common.OwnershipModifierFactory(cluster, r.scheme))
 }
 
+// ensureEncryptionKey provides the data key that is used to encrypt the cluster's backups, if
+// encryption is enabled for the destination. The data key is kept in a Secret in the seed and,
+// wrapped with the active seed key, next to the backups. Whenever the active seed key changes,
+// only the wrapped copy in the bucket is replaced; backups are never re-encrypted.
+func (r *Reconciler) ensureEncryptionKey(ctx context.Context, log *zap.SugaredLogger, data *resources.TemplateData, backupConfig *kubermaticv1.EtcdBackupConfig, cluster *kubermaticv1.Cluster) error {
+	destination := data.EtcdBackupDestination()
+	if destination == nil || destination.Encryption == nil {
+		return nil
+	}
+
+	// the wrapped data key is kept next to the backups, which the controller cannot reach
+	// for filesystem destinations
+	if destination.GetType() == kubermaticv1.BackupDestinationTypeFilesystem {
+		return errors.New("encryption is not supported for filesystem backup destinations")
+	}
+
+	encryption := destination.Encryption
+	secretName := etcdbackup.EncryptionKeySecretName(cluster, backupConfig.Spec.Destination)
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: secretName}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get data key Secret: %w", err)
+		}
+		secret = nil
+	}
+
+	// nothing to do, the data key is already wrapped with the active seed key
+	if secret != nil && secret.Annotations[etcdbackup.EncryptionKeyIDAnnotation] == encryption.ActiveKey {
+		return nil
+	}
+
+	seedKeys, err := etcdbackup.GetSeedKeys(ctx, r, encryption)
+	if err != nil {
+		return err
+	}
+
+	b, err := resources.GetEtcdBackupBackend(ctx, r, cluster, destination)
+	if err != nil {
+		return fmt.Errorf("failed to obtain backup storage backend: %w", err)
+	}
+
+	var dataKey []byte
+	if secret != nil {
+		dataKey = secret.Data[etcdbackup.EncryptionDataKeySecretKey]
+	} else {
+		// the Secret might have been lost while the backups still exist, so do not simply
+		// generate a new key if there is one in the bucket already
+		wrapped, err := etcdbackup.DownloadWrappedDataKey(ctx, b, cluster.Name)
+		if err != nil {
+			return err
+		}
+
+		if wrapped != nil {
+			if dataKey, err = etcdbackup.UnwrapDataKey(wrapped, seedKeys); err != nil {
+				return err
+			}
+		} else if dataKey, err = etcdbackup.GenerateDataKey(); err != nil {
+			return err
+		}
+	}
+
+	wrapped, err := etcdbackup.WrapDataKey(encryption.ActiveKey, seedKeys[encryption.ActiveKey], dataKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	// upload first, so that the annotation is only updated once the destination is up-to-date
+	if err := etcdbackup.UploadWrappedDataKey(ctx, b, cluster.Name, wrapped); err != nil {
+		return err
+	}
+
+	creators := []reconciling.NamedSecretReconcilerFactory{
+		encryptionKeySecretReconciler(secretName, encryption.ActiveKey, dataKey),
+	}
+
+	if err := reconciling.ReconcileSecrets(ctx, creators, metav1.NamespaceSystem, r.Client, common.OwnershipModifierFactory(cluster, r.scheme)); err != nil {
+		return err
+	}
+
+	log.Infow("Wrapped backup data key", "destination", backupConfig.Spec.Destination, "key", encryption.ActiveKey)
+
+	return nil
+}
+
+func encryptionKeySecretReconciler(name, keyID string, dataKey []byte) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return name, func(s *corev1.Secret) (*corev1.Secret, error) {
+			if s.Annotations == nil {
+				s.Annotations = map[string]string{}
+			}
+			s.Annotations[etcdbackup.EncryptionKeyIDAnnotation] = keyID
+
+			s.Data = map[string][]byte{
+				etcdbackup.EncryptionDataKeySecretKey: dataKey,
+			}
+
+			return s, nil
+		}
+	}
+}
+
 func caBundleConfigMapName(cluster *kubermaticv1.Cluster) string {
 	return fmt.Sprintf("cluster-%s-ca-bundle", cluster.Name)
 }
@@ -890,7 +1259,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kuberm
 	if destination == nil {
 		return nil, fmt.Errorf("cannot find backup destination %q", backupConfig.Spec.Destination)
 	}
-	if destination.Credentials == nil {
+	if destination.Credentials == nil && destination.GetType() != kubermaticv1.BackupDestinationTypeFilesystem {
 		return nil, fmt.Errorf("credentials not set for backup destination %q", backupConfig.Spec.Destination)
 	}
 
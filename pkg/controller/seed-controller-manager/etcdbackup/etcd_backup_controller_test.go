@@ -41,8 +41,10 @@ import (
 	"k8c.io/kubermatic/v2/pkg/test/generator"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -1780,6 +1782,85 @@ func genDefaultBackupDestination() *kubermaticv1.BackupDestination {
 	}
 }
 
+func TestEncryptedBackupJobs(t *testing.T) {
+	cluster := genTestCluster()
+	backupConfig := genBackupConfig(cluster, "testbackup")
+	backupConfig.Spec.Destination = "encrypted"
+
+	destination := genDefaultBackupDestination()
+	destination.Encryption = &kubermaticv1.BackupEncryption{
+		KeySecret: corev1.SecretReference{
+			Name:      "backup-encryption",
+			Namespace: metav1.NamespaceSystem,
+		},
+		ActiveKey: "key-1",
+	}
+
+	// the data key has already been wrapped with the active key, so no bucket access is needed
+	dataKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdbackup.EncryptionKeySecretName(cluster, "encrypted"),
+			Namespace: metav1.NamespaceSystem,
+			Annotations: map[string]string{
+				etcdbackup.EncryptionKeyIDAnnotation: "key-1",
+			},
+		},
+		Data: map[string][]byte{
+			etcdbackup.EncryptionDataKeySecretKey: make([]byte, etcdbackup.EncryptionKeySize),
+		},
+	}
+
+	reconciler := Reconciler{
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client:   fake.NewClientBuilder().WithObjects(cluster, backupConfig, genClusterRootCaSecret(), dataKeySecret).Build(),
+		scheme:   scheme.Scheme,
+		recorder: record.NewFakeRecorder(10),
+		clock:    clocktesting.NewFakeClock(time.Unix(60, 0).UTC()),
+		caBundle: certificates.NewFakeCABundle(),
+		seedGetter: func() (*kubermaticv1.Seed, error) {
+			return generator.GenTestSeed(func(seed *kubermaticv1.Seed) {
+				addSeedDestinations(seed)
+				seed.Spec.EtcdBackupRestore.Destinations["encrypted"] = destination
+			}), nil
+		},
+		randStringGenerator: constRandStringGenerator("bob"),
+		configGetter:        getConfigGetter(t),
+
+		etcdLauncherImage: defaulting.DefaultEtcdLauncherImage,
+	}
+
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: backupConfig.Namespace, Name: backupConfig.Name}}); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	jobList := batchv1.JobList{}
+	if err := reconciler.List(ctx, &jobList); err != nil {
+		t.Fatalf("Error reading created joblist: %v", err)
+	}
+
+	if len(jobList.Items) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobList.Items))
+	}
+
+	podSpec := jobList.Items[0].Spec.Template.Spec
+
+	hasVolume := false
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == dataKeySecret.Name {
+			hasVolume = true
+		}
+	}
+	if !hasVolume {
+		t.Fatalf("Expected job to mount the data key Secret %s, but got volumes %v", dataKeySecret.Name, podSpec.Volumes)
+	}
+
+	command := strings.Join(podSpec.InitContainers[0].Command, " ")
+	if !strings.Contains(command, "--encryption-key-file=") {
+		t.Fatalf("Expected snapshot command to encrypt the snapshot, but got %q", command)
+	}
+}
+
 func containsEnvVar(envVars []corev1.EnvVar, envVar corev1.EnvVar) bool {
 	for _, e := range envVars {
 		if len(deep.Equal(e, envVar)) == 0 {
@@ -1826,3 +1907,285 @@ func isInsecureURL(u string) bool {
 
 	return strings.ToLower(parsed.Scheme) == "http" && parsed.Host != ""
 }
+
+func TestRevisionStreamer(t *testing.T) {
+	cluster := genTestCluster()
+	backupConfig := genBackupConfig(cluster, "testbackup")
+	backupConfig.Spec.Destination = "s3"
+	backupConfig.Spec.Schedule = "*/10 * * * *"
+	backupConfig.Spec.Continuous = &kubermaticv1.EtcdContinuousBackup{}
+
+	clock := clocktesting.NewFakeClock(time.Unix(60, 0).UTC())
+	backupConfig.SetCreationTimestamp(metav1.Time{Time: clock.Now()})
+
+	reconciler := Reconciler{
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client:   fake.NewClientBuilder().WithObjects(cluster, backupConfig, genClusterRootCaSecret()).Build(),
+		scheme:   scheme.Scheme,
+		recorder: record.NewFakeRecorder(10),
+		clock:    clock,
+		caBundle: certificates.NewFakeCABundle(),
+		seedGetter: func() (*kubermaticv1.Seed, error) {
+			return generator.GenTestSeed(addSeedDestinations), nil
+		},
+		randStringGenerator: constRandStringGenerator("bob"),
+		configGetter:        getConfigGetter(t),
+
+		etcdLauncherImage: defaulting.DefaultEtcdLauncherImage,
+	}
+
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: backupConfig.Namespace, Name: backupConfig.Name}}
+	deploymentKey := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: etcdbackup.RevisionStreamerDeploymentName(backupConfig)}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := reconciler.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("Expected revision streamer to be created: %v", err)
+	}
+
+	envVars := deployment.Spec.Template.Spec.Containers[0].Env
+	expectedEnvVar := corev1.EnvVar{Name: etcdbackup.BucketNameEnvVarKey, Value: genDefaultBackupDestination().BucketName}
+	if !containsEnvVar(envVars, expectedEnvVar) {
+		t.Fatalf("Expected streamer env vars %v to contain %v", envVars, expectedEnvVar)
+	}
+
+	if err := reconciler.Get(ctx, request.NamespacedName, backupConfig); err != nil {
+		t.Fatalf("Failed to get backup config: %v", err)
+	}
+
+	condition, ok := backupConfig.Status.Conditions[kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive]
+	if !ok || condition.Status != corev1.ConditionFalse {
+		t.Fatalf("Expected %s condition to be false while the streamer is unavailable, got %+v", kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive, condition)
+	}
+
+	backupConfig.Spec.Continuous = nil
+	if err := reconciler.Update(ctx, backupConfig); err != nil {
+		t.Fatalf("Failed to update backup config: %v", err)
+	}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+
+	if err := reconciler.Get(ctx, deploymentKey, deployment); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected revision streamer to be removed, but got err=%v", err)
+	}
+
+	if err := reconciler.Get(ctx, request.NamespacedName, backupConfig); err != nil {
+		t.Fatalf("Failed to get backup config: %v", err)
+	}
+
+	if _, ok := backupConfig.Status.Conditions[kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive]; ok {
+		t.Fatalf("Expected %s condition to be removed", kubermaticv1.EtcdBackupConfigConditionContinuousBackupActive)
+	}
+}
+
+func genVerifyJobPod(jobName string, exitCode int32, message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-xyz",
+			Namespace: metav1.NamespaceSystem,
+			Labels: map[string]string{
+				batchv1.JobNameLabel: jobName,
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: etcdbackup.BackupVerifyContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: exitCode,
+							Message:  message,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestVerifyCompletedBackups(t *testing.T) {
+	testCases := []struct {
+		name              string
+		verification      *kubermaticv1.EtcdBackupVerification
+		existingBackups   []kubermaticv1.BackupStatus
+		existingObjects   []ctrlruntimeclient.Object
+		expectedBackups   []kubermaticv1.BackupStatus
+		expectedJobNames  []string
+		expectedReconcile *reconcile.Result
+	}{
+		{
+			name: "no verify jobs are created if verification is disabled",
+			existingBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:  "testbackup-1",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+			},
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:  "testbackup-1",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+			},
+		},
+		{
+			name:         "verify jobs are only created for completed backups",
+			verification: &kubermaticv1.EtcdBackupVerification{},
+			existingBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:  "testbackup-1",
+					BackupPhase: kubermaticv1.BackupStatusPhaseCompleted,
+				},
+				{
+					BackupName:  "testbackup-2",
+					BackupPhase: kubermaticv1.BackupStatusPhaseRunning,
+				},
+			},
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:    "testbackup-1",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+					VerifyJobName: "testcluster-backup-testbackup-verify-bob",
+					VerifyPhase:   kubermaticv1.BackupStatusPhaseRunning,
+				},
+				{
+					BackupName:  "testbackup-2",
+					BackupPhase: kubermaticv1.BackupStatusPhaseRunning,
+				},
+			},
+			expectedJobNames:  []string{"testcluster-backup-testbackup-verify-bob"},
+			expectedReconcile: &reconcile.Result{RequeueAfter: assumedJobRuntime},
+		},
+		{
+			name:         "results of completed verify jobs are recorded",
+			verification: &kubermaticv1.EtcdBackupVerification{RestoreDrill: true},
+			existingBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:    "testbackup-1",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+					VerifyJobName: "verify-1",
+					VerifyPhase:   kubermaticv1.BackupStatusPhaseRunning,
+				},
+			},
+			existingObjects: []ctrlruntimeclient.Object{
+				jobAddCondition(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "verify-1", Namespace: metav1.NamespaceSystem}}, batchv1.JobComplete, corev1.ConditionTrue, time.Unix(90, 0), ""),
+				genVerifyJobPod("verify-1", 0, `{"checksum":"abc123","revision":42,"keyCount":7}`),
+			},
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:         "testbackup-1",
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					VerifyJobName:      "verify-1",
+					VerifyPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					VerifyFinishedTime: metav1.Time{Time: time.Unix(90, 0)},
+					Checksum:           "abc123",
+					Revision:           42,
+					KeyCount:           7,
+				},
+			},
+			expectedJobNames: []string{"verify-1"},
+		},
+		{
+			name:         "failed verify jobs are recorded with their termination message",
+			verification: &kubermaticv1.EtcdBackupVerification{},
+			existingBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:    "testbackup-1",
+					BackupPhase:   kubermaticv1.BackupStatusPhaseCompleted,
+					VerifyJobName: "verify-1",
+					VerifyPhase:   kubermaticv1.BackupStatusPhaseRunning,
+				},
+			},
+			existingObjects: []ctrlruntimeclient.Object{
+				jobAddCondition(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "verify-1", Namespace: metav1.NamespaceSystem}}, batchv1.JobFailed, corev1.ConditionTrue, time.Unix(90, 0), "BackoffLimitExceeded"),
+				genVerifyJobPod("verify-1", 1, "snapshot is invalid: file too small"),
+			},
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					BackupName:         "testbackup-1",
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					VerifyJobName:      "verify-1",
+					VerifyPhase:        kubermaticv1.BackupStatusPhaseFailed,
+					VerifyFinishedTime: metav1.Time{Time: time.Unix(90, 0)},
+					VerifyMessage:      "snapshot is invalid: file too small",
+				},
+			},
+			expectedJobNames: []string{"verify-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			cluster := genTestCluster()
+			backupConfig := genBackupConfig(cluster, "testbackup")
+			backupConfig.Spec.Verification = tc.verification
+			backupConfig.Status.CurrentBackups = tc.existingBackups
+
+			clock := clocktesting.NewFakeClock(time.Unix(120, 0).UTC())
+			backupConfig.SetCreationTimestamp(metav1.Time{Time: clock.Now()})
+
+			td := resources.NewTemplateDataBuilder().
+				WithContext(ctx).
+				WithCluster(cluster).
+				WithVersions(kubermatic.NewFakeVersions()).
+				WithEtcdLauncherImage(defaulting.DefaultEtcdLauncherImage).
+				WithEtcdBackupStoreContainer(genStoreContainer()).
+				WithEtcdBackupDeleteContainer(genDeleteContainer()).
+				WithEtcdBackupDestination(genDefaultBackupDestination()).
+				Build()
+
+			initObjs := append([]ctrlruntimeclient.Object{cluster, backupConfig}, tc.existingObjects...)
+
+			reconciler := Reconciler{
+				log:                 kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client:              fake.NewClientBuilder().WithObjects(initObjs...).Build(),
+				scheme:              scheme.Scheme,
+				recorder:            record.NewFakeRecorder(10),
+				clock:               clock,
+				randStringGenerator: constRandStringGenerator("bob"),
+			}
+
+			reconcileAfter, err := reconciler.verifyCompletedBackups(ctx, td, backupConfig)
+			if err != nil {
+				t.Fatalf("verifyCompletedBackups returned an error: %v", err)
+			}
+
+			readbackBackupConfig := &kubermaticv1.EtcdBackupConfig{}
+			if err := reconciler.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(backupConfig), readbackBackupConfig); err != nil {
+				t.Fatalf("Error reading back backupConfig: %v", err)
+			}
+
+			if d := diff.ObjectDiff(tc.expectedBackups, readbackBackupConfig.Status.CurrentBackups); d != "" {
+				t.Errorf("backups differ from expected ones:\n%v", d)
+			}
+
+			jobList := batchv1.JobList{}
+			if err := reconciler.List(ctx, &jobList); err != nil {
+				t.Fatalf("Error listing jobs: %v", err)
+			}
+
+			var jobNames []string
+			for _, job := range jobList.Items {
+				jobNames = append(jobNames, job.Name)
+			}
+
+			if d := diff.ObjectDiff(tc.expectedJobNames, jobNames); d != "" {
+				t.Errorf("jobs differ from expected ones:\n%v", d)
+			}
+
+			if !diff.SemanticallyEqual(reconcileAfter, tc.expectedReconcile) {
+				t.Errorf("reconcile time differs from expected, expected: %v, actual: %v", tc.expectedReconcile, reconcileAfter)
+			}
+		})
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"reflect"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -31,6 +30,8 @@ import (
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -177,7 +178,7 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 	}
 
 	if restore.Status.Phase == kubermaticv1.EtcdRestorePhaseCompleted {
-		return nil, nil
+		return nil, r.cleanupRestoreVolume(ctx, cluster)
 	}
 
 	log.Infof("performing etcd restore from backup %v", restore.Spec.BackupName)
@@ -198,20 +199,28 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, rest
 		if !ok {
 			return nil, fmt.Errorf("can't find backup restore destination %q in Seed %q", restore.Spec.Destination, seed.Name)
 		}
-		if destination.Credentials == nil {
+		if destination.Credentials == nil && destination.GetType() != kubermaticv1.BackupDestinationTypeFilesystem {
 			return nil, fmt.Errorf("credentials not set for backup destination %q in Seed %q", restore.Spec.Destination, seed.Name)
 		}
 	}
 
-	// check that the backup to restore from exists and is accessible
-	s3Client, bucketName, err := resources.GetEtcdRestoreS3Client(ctx, restore, true, r.Client, cluster, destination)
+	if restore.Spec.TargetRevision != nil && restore.Spec.TargetTime != nil {
+		return nil, errors.New("only one of targetRevision and targetTime can be set")
+	}
+
+	b, err := resources.GetEtcdRestoreBackend(ctx, restore, true, r.Client, cluster, destination)
 	if err != nil {
-		return nil, fmt.Errorf("failed to obtain S3 client: %w", err)
+		return nil, fmt.Errorf("failed to obtain backup storage backend: %w", err)
 	}
 
-	objectName := fmt.Sprintf("%s-%s", cluster.GetName(), restore.Spec.BackupName)
-	if _, err := s3Client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{}); err != nil {
-		return nil, fmt.Errorf("could not access backup object %s: %w", objectName, err)
+	// filesystem destinations are only mounted into the etcd pods, so the backup cannot be
+	// checked upfront; the etcd-launcher fails the restore if it does not exist
+	if destination != nil && destination.GetType() == kubermaticv1.BackupDestinationTypeFilesystem {
+		if err := r.ensureRestoreVolume(ctx, restore, cluster, destination); err != nil {
+			return nil, fmt.Errorf("failed to provide backup volume: %w", err)
+		}
+	} else if err := r.checkBackup(ctx, log, restore, cluster, destination, b); err != nil {
+		return nil, err
 	}
 
 	// before proceeding, ensure restore's namespace/name is stored in the ActiveRestoreAnnotationName cluster annotation
@@ -386,3 +395,87 @@ func (r *Reconciler) updateRestore(ctx context.Context, restore *kubermaticv1.Et
 
 	return nil
 }
+
+// checkBackup verifies that the backup to restore from exists and is accessible.
+func (r *Reconciler) checkBackup(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster,
+	destination *kubermaticv1.BackupDestination, b backend.Backend) error {
+	objectName := fmt.Sprintf("%s-%s", cluster.GetName(), restore.Spec.BackupName)
+	if _, err := b.Stat(ctx, objectName); err != nil {
+		return fmt.Errorf("could not access backup object %s: %w", objectName, err)
+	}
+
+	// encrypted backups are decrypted by the etcd-launcher, which has no access to the seed keys,
+	// so hand it the unwrapped data key via the download Secret
+	if destination != nil && destination.Encryption != nil {
+		if err := r.ensureRestoreDataKey(ctx, log, restore, cluster, destination, b); err != nil {
+			return fmt.Errorf("failed to provide backup data key: %w", err)
+		}
+	}
+
+	// point-in-time restores replay the revisions recorded by a continuous backup on top of the
+	// snapshot, so at least some revisions need to exist; whether they actually reach the target
+	// can only be determined once the snapshot has been restored
+	if restore.Spec.IsPointInTime() {
+		found, err := hasRecordedRevisions(ctx, b, cluster)
+		if err != nil {
+			return fmt.Errorf("failed to list recorded revisions: %w", err)
+		}
+		if !found {
+			return fmt.Errorf("no recorded revisions found for cluster %s, cannot restore to a point in time", cluster.Name)
+		}
+	}
+
+	return nil
+}
+
+func hasRecordedRevisions(ctx context.Context, b backend.Backend, cluster *kubermaticv1.Cluster) (bool, error) {
+	objects, err := b.List(ctx, etcdbackup.RevisionSegmentPrefix(cluster.Name))
+	if err != nil {
+		return false, err
+	}
+
+	return len(objects) > 0, nil
+}
+
+// ensureRestoreDataKey unwraps the cluster's backup data key with the seed keys and stores it in
+// the restore's download Secret. Clusters whose backups were never encrypted have no wrapped
+// data key, in which case nothing is done.
+func (r *Reconciler) ensureRestoreDataKey(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster,
+	destination *kubermaticv1.BackupDestination, b backend.Backend) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: restore.Spec.BackupDownloadCredentialsSecret}, secret); err != nil {
+		return fmt.Errorf("failed to get BackupDownloadCredentialsSecret: %w", err)
+	}
+
+	if _, ok := secret.Data[resources.EtcdRestoreEncryptionDataKeyKey]; ok {
+		return nil
+	}
+
+	wrapped, err := etcdbackup.DownloadWrappedDataKey(ctx, b, cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	if wrapped == nil {
+		log.Info("No backup data key found, assuming unencrypted backups")
+		return nil
+	}
+
+	seedKeys, err := etcdbackup.GetSeedKeys(ctx, r, destination.Encryption)
+	if err != nil {
+		return err
+	}
+
+	dataKey, err := etcdbackup.UnwrapDataKey(wrapped, seedKeys)
+	if err != nil {
+		return err
+	}
+
+	oldSecret := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[resources.EtcdRestoreEncryptionDataKeyKey] = dataKey
+
+	return r.Patch(ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdrestore
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// restoreVolumeClaimName is the PVC in the cluster namespace that gives the etcd pods access
+	// to the backups of a filesystem destination while a restore is running.
+	restoreVolumeClaimName = "etcd-restore-backup"
+)
+
+func restoreVolumeName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("%s-etcd-restore-backup", cluster.Status.NamespaceName)
+}
+
+// ensureRestoreVolume makes the volume of a filesystem destination available in the cluster
+// namespace by binding a read-only clone of it to a PVC next to the etcd StatefulSet.
+func (r *Reconciler) ensureRestoreVolume(ctx context.Context, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster, destination *kubermaticv1.BackupDestination) error {
+	if destination.Filesystem == nil {
+		return fmt.Errorf("no PersistentVolumeClaim configured for filesystem destination %q", restore.Spec.Destination)
+	}
+
+	volume, err := resources.BackupDestinationVolume(ctx, r, destination, restoreVolumeName(cluster), types.NamespacedName{
+		Namespace: cluster.Status.NamespaceName,
+		Name:      restoreVolumeClaimName,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, volume); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create restore PersistentVolume: %w", err)
+	}
+
+	claim := resources.BackupDestinationVolumeClaim(volume)
+	claim.OwnerReferences = []metav1.OwnerReference{resources.GetEtcdRestoreRef(restore)}
+
+	if err := r.Create(ctx, claim); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create restore PersistentVolumeClaim: %w", err)
+	}
+
+	return r.updateCluster(ctx, cluster, func(cluster *kubermaticv1.Cluster) {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[resources.EtcdRestoreVolumeClaimAnnotation] = restoreVolumeClaimName
+	})
+}
+
+// cleanupRestoreVolume removes the volume created by ensureRestoreVolume once the restore has
+// completed. The backups themselves are retained.
+func (r *Reconciler) cleanupRestoreVolume(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if _, ok := cluster.Annotations[resources.EtcdRestoreVolumeClaimAnnotation]; !ok {
+		return nil
+	}
+
+	// unmount the volume from the etcd pods first
+	if err := r.updateCluster(ctx, cluster, func(cluster *kubermaticv1.Cluster) {
+		delete(cluster.Annotations, resources.EtcdRestoreVolumeClaimAnnotation)
+	}); err != nil {
+		return fmt.Errorf("failed to remove restore volume annotation: %w", err)
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      restoreVolumeClaimName,
+			Namespace: cluster.Status.NamespaceName,
+		},
+	}
+	if err := r.Delete(ctx, claim); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete restore PersistentVolumeClaim: %w", err)
+	}
+
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: restoreVolumeName(cluster),
+		},
+	}
+	if err := r.Delete(ctx, volume); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete restore PersistentVolume: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	metricsserver "k8c.io/kubermatic/v2/pkg/resources/metrics-server"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// certificateRenewalAnnotation is set on renewed Secrets and holds the ID of the renewal
+	// they were regenerated for, so that every Secret is only renewed once per renewal.
+	certificateRenewalAnnotation = "kubermatic.k8c.io/certificate-renewal"
+
+	certificateRenewalCheckPeriod = 10 * time.Second
+)
+
+type certificateRenewalStage struct {
+	reason  string
+	message string
+	secrets sets.Set[string]
+}
+
+// certificateRenewalStages are processed in order; a stage is only started once the control
+// plane has been rolled out with the certificates of the previous one. CAs and the service
+// account key are never renewed, as that would invalidate credentials outside the control plane.
+var certificateRenewalStages = []certificateRenewalStage{
+	{
+		reason:  kubermaticv1.ReasonCertificateRenewalEtcd,
+		message: "Renewing the etcd serving and client certificates",
+		secrets: sets.New(
+			resources.EtcdTLSCertificateSecretName,
+			resources.ApiserverEtcdClientCertificateSecretName,
+		),
+	},
+	{
+		reason:  kubermaticv1.ReasonCertificateRenewalApiserver,
+		message: "Renewing the apiserver serving and client certificates",
+		secrets: sets.New(
+			resources.ApiserverTLSSecretName,
+			resources.KubeletClientCertificatesSecretName,
+			resources.ApiserverFrontProxyClientCertificateSecretName,
+		),
+	},
+	{
+		reason:  kubermaticv1.ReasonCertificateRenewalClients,
+		message: "Renewing the certificates of the remaining control plane components",
+		secrets: sets.New(
+			resources.SchedulerKubeconfigSecretName,
+			resources.ControllerManagerKubeconfigSecretName,
+			resources.MachineControllerKubeconfigSecretName,
+			resources.OperatingSystemManagerKubeconfigSecretName,
+			resources.OperatingSystemManagerWebhookKubeconfigSecretName,
+			resources.KubeStateMetricsKubeconfigSecretName,
+			resources.InternalUserClusterAdminKubeconfigSecretName,
+			resources.ClusterAutoscalerKubeconfigSecretName,
+			resources.KubernetesDashboardKubeconfigSecretName,
+			resources.KubeLBCCMKubeconfigSecretName,
+			resources.KonnectivityKubeconfigSecretName,
+			resources.MetricsServerKubeconfigSecretName,
+			resources.KubeletDnatControllerKubeconfigSecretName,
+			resources.MachineControllerWebhookServingCertSecretName,
+			resources.OperatingSystemManagerWebhookServingCertSecretName,
+			resources.UserClusterWebhookServingCertSecretName,
+			resources.KonnectivityProxyTLSSecretName,
+			resources.OpenVPNServerCertificatesSecretName,
+			resources.OpenVPNClientCertificatesSecretName,
+			metricsserver.ServingCertSecretName,
+		),
+	},
+}
+
+// certificateRenewal holds the state of a certificate renewal during a single reconciliation.
+type certificateRenewal struct {
+	// id identifies the renewal, it is derived from the time the renewal was started.
+	id    string
+	stage int
+	// renewed is set when certificates were regenerated during this reconciliation.
+	renewed bool
+}
+
+// newCertificateRenewal returns the renewal that is currently in progress for the cluster,
+// or nil if there is none.
+func newCertificateRenewal(cluster *kubermaticv1.Cluster) *certificateRenewal {
+	if _, ok := cluster.Annotations[kubermaticv1.RenewCertificatesAnnotation]; !ok {
+		return nil
+	}
+
+	condition, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionCertificateRenewal]
+	if !ok || condition.Status != corev1.ConditionFalse {
+		return nil
+	}
+
+	for i, stage := range certificateRenewalStages {
+		if stage.reason == condition.Reason {
+			return &certificateRenewal{
+				id:    strconv.FormatInt(condition.LastTransitionTime.Unix(), 10),
+				stage: i,
+			}
+		}
+	}
+
+	return nil
+}
+
+// modifiers returns the object modifiers that make the Secret reconcilers regenerate the
+// certificates of the current stage.
+func (cr *certificateRenewal) modifiers() []reconciling.ObjectModifier {
+	if cr == nil {
+		return nil
+	}
+
+	return []reconciling.ObjectModifier{cr.renewSecret}
+}
+
+func (cr *certificateRenewal) renewSecret(create reconciling.ObjectReconciler) reconciling.ObjectReconciler {
+	secrets := certificateRenewalStages[cr.stage].secrets
+
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if secret, ok := existing.(*corev1.Secret); ok && secrets.Has(secret.Name) && secret.Annotations[certificateRenewalAnnotation] != cr.id {
+			// Drop everything except the CA, so the reconcilers issue new certificates
+			// and kubeconfigs instead of keeping the existing ones.
+			for key := range secret.Data {
+				if key != resources.CACertSecretKey && key != resources.CAKeySecretKey {
+					delete(secret.Data, key)
+				}
+			}
+			cr.renewed = true
+		}
+
+		obj, err := create(existing)
+		if err != nil {
+			return nil, err
+		}
+
+		if secrets.Has(obj.GetName()) {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[certificateRenewalAnnotation] = cr.id
+			obj.SetAnnotations(annotations)
+		}
+
+		return obj, nil
+	}
+}
+
+// reconcileCertificateRenewal starts and advances a certificate renewal requested via the
+// RenewCertificatesAnnotation. renewal is the renewal that was in progress while the Secrets
+// were reconciled.
+func (r *Reconciler) reconcileCertificateRenewal(ctx context.Context, cluster *kubermaticv1.Cluster, renewal *certificateRenewal) (*reconcile.Result, error) {
+	if _, ok := cluster.Annotations[kubermaticv1.RenewCertificatesAnnotation]; !ok {
+		return &reconcile.Result{}, nil
+	}
+
+	if renewal == nil {
+		if err := r.setCertificateRenewalStage(ctx, cluster, 0); err != nil {
+			return nil, err
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "CertificateRenewalStarted", "Started renewing the control plane certificates")
+
+		return &reconcile.Result{Requeue: true}, nil
+	}
+
+	// Give the cache time to catch up with the renewed Secrets and the Deployments that were
+	// updated for them, otherwise the rollout check below could pass too early.
+	if renewal.renewed {
+		return &reconcile.Result{RequeueAfter: certificateRenewalCheckPeriod}, nil
+	}
+
+	rolledOut, err := resources.ControlPlaneRolledOut(ctx, r, cluster.Status.NamespaceName)
+	if err != nil {
+		return nil, err
+	}
+	if !rolledOut {
+		return &reconcile.Result{RequeueAfter: certificateRenewalCheckPeriod}, nil
+	}
+
+	if next := renewal.stage + 1; next < len(certificateRenewalStages) {
+		if err := r.setCertificateRenewalStage(ctx, cluster, next); err != nil {
+			return nil, err
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "CertificateRenewalProgressing", certificateRenewalStages[next].message)
+
+		return &reconcile.Result{Requeue: true}, nil
+	}
+
+	if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionCertificateRenewal,
+			corev1.ConditionTrue,
+			kubermaticv1.ReasonCertificateRenewalCompleted,
+			"All control plane certificates have been renewed",
+		)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update cluster status: %w", err)
+	}
+
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		delete(c.Annotations, kubermaticv1.RenewCertificatesAnnotation)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to remove %s annotation: %w", kubermaticv1.RenewCertificatesAnnotation, err)
+	}
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "CertificateRenewalCompleted", "All control plane certificates have been renewed")
+
+	return &reconcile.Result{}, nil
+}
+
+func (r *Reconciler) setCertificateRenewalStage(ctx context.Context, cluster *kubermaticv1.Cluster, stage int) error {
+	err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionCertificateRenewal,
+			corev1.ConditionFalse,
+			certificateRenewalStages[stage].reason,
+			certificateRenewalStages[stage].message,
+		)
+
+		// The transition time is the ID of the renewal, so it must change whenever
+		// a new renewal is started.
+		if stage == 0 {
+			condition := c.Status.Conditions[kubermaticv1.ClusterConditionCertificateRenewal]
+			condition.LastTransitionTime = metav1.Now()
+			c.Status.Conditions[kubermaticv1.ClusterConditionCertificateRenewal] = condition
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update cluster status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const renewalTestNamespace = "cluster-test"
+
+func TestCertificateRenewalModifier(t *testing.T) {
+	ctx := context.Background()
+
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	getCA := func() (*triple.KeyPair, error) { return ca, nil }
+
+	factories := []reconciling.NamedSecretReconcilerFactory{
+		certificates.GetClientCertificateReconciler(resources.ApiserverEtcdClientCertificateSecretName, "apiserver", nil, resources.ApiserverEtcdClientCertificateCertSecretKey, resources.ApiserverEtcdClientCertificateKeySecretKey, getCA),
+		certificates.GetClientCertificateReconciler(resources.ApiserverFrontProxyClientCertificateSecretName, "front-proxy", nil, resources.ApiserverProxyClientCertificateCertSecretKey, resources.ApiserverProxyClientCertificateKeySecretKey, getCA),
+	}
+
+	client := fake.NewClientBuilder().Build()
+	if err := reconciling.ReconcileSecrets(ctx, factories, renewalTestNamespace, client); err != nil {
+		t.Fatalf("failed to create secrets: %v", err)
+	}
+	etcdClientCert := getSecretData(t, client, resources.ApiserverEtcdClientCertificateSecretName, resources.ApiserverEtcdClientCertificateCertSecretKey)
+	proxyClientCert := getSecretData(t, client, resources.ApiserverFrontProxyClientCertificateSecretName, resources.ApiserverProxyClientCertificateCertSecretKey)
+
+	// the etcd stage must only renew the etcd client certificate
+	renewal := &certificateRenewal{id: "1", stage: 0}
+	if err := reconciling.ReconcileSecrets(ctx, factories, renewalTestNamespace, client, renewal.modifiers()...); err != nil {
+		t.Fatalf("failed to reconcile secrets: %v", err)
+	}
+	if !renewal.renewed {
+		t.Error("expected certificates to be renewed")
+	}
+
+	renewedCert := getSecretData(t, client, resources.ApiserverEtcdClientCertificateSecretName, resources.ApiserverEtcdClientCertificateCertSecretKey)
+	if bytes.Equal(renewedCert, etcdClientCert) {
+		t.Error("expected etcd client certificate to be renewed")
+	}
+	if cert := getSecretData(t, client, resources.ApiserverFrontProxyClientCertificateSecretName, resources.ApiserverProxyClientCertificateCertSecretKey); !bytes.Equal(cert, proxyClientCert) {
+		t.Error("expected front proxy client certificate to be kept")
+	}
+
+	// further reconciliations of the same renewal must not issue new certificates
+	renewal = &certificateRenewal{id: "1", stage: 0}
+	if err := reconciling.ReconcileSecrets(ctx, factories, renewalTestNamespace, client, renewal.modifiers()...); err != nil {
+		t.Fatalf("failed to reconcile secrets: %v", err)
+	}
+	if renewal.renewed {
+		t.Error("expected certificates not to be renewed again")
+	}
+	if cert := getSecretData(t, client, resources.ApiserverEtcdClientCertificateSecretName, resources.ApiserverEtcdClientCertificateCertSecretKey); !bytes.Equal(cert, renewedCert) {
+		t.Error("expected etcd client certificate to be kept")
+	}
+}
+
+func TestReconcileCertificateRenewal(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Annotations: map[string]string{
+				kubermaticv1.RenewCertificatesAnnotation: "",
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: renewalTestNamespace,
+		},
+	}
+	apiserver := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  renewalTestNamespace,
+			Name:       resources.ApiserverDeploymentName,
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](2),
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+		},
+	}
+
+	client := fake.NewClientBuilder().WithObjects(cluster, apiserver).Build()
+	r := &Reconciler{
+		Client:   client,
+		recorder: record.NewFakeRecorder(10),
+		versions: kubermatic.NewFakeVersions(),
+	}
+
+	reconcileRenewal := func(renewed bool) {
+		t.Helper()
+
+		if err := client.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+			t.Fatalf("failed to get cluster: %v", err)
+		}
+
+		renewal := newCertificateRenewal(cluster)
+		if renewal != nil {
+			renewal.renewed = renewed
+		}
+
+		if _, err := r.reconcileCertificateRenewal(ctx, cluster, renewal); err != nil {
+			t.Fatalf("failed to reconcile certificate renewal: %v", err)
+		}
+	}
+
+	expectReason := func(reason string) {
+		t.Helper()
+
+		condition := cluster.Status.Conditions[kubermaticv1.ClusterConditionCertificateRenewal]
+		if condition.Reason != reason {
+			t.Fatalf("expected condition reason %q, got %q", reason, condition.Reason)
+		}
+	}
+
+	reconcileRenewal(false)
+	expectReason(kubermaticv1.ReasonCertificateRenewalEtcd)
+	if condition := cluster.Status.Conditions[kubermaticv1.ClusterConditionCertificateRenewal]; condition.LastTransitionTime.IsZero() {
+		t.Fatal("expected the renewal to have a start time")
+	}
+
+	// the stage must not advance while certificates are renewed or the apiserver rolls out
+	reconcileRenewal(true)
+	expectReason(kubermaticv1.ReasonCertificateRenewalEtcd)
+	reconcileRenewal(false)
+	expectReason(kubermaticv1.ReasonCertificateRenewalEtcd)
+
+	apiserver.Status.ObservedGeneration = 2
+	if err := client.Status().Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update deployment: %v", err)
+	}
+
+	reconcileRenewal(false)
+	expectReason(kubermaticv1.ReasonCertificateRenewalApiserver)
+	reconcileRenewal(false)
+	expectReason(kubermaticv1.ReasonCertificateRenewalClients)
+	reconcileRenewal(false)
+	expectReason(kubermaticv1.ReasonCertificateRenewalCompleted)
+
+	if _, ok := cluster.Annotations[kubermaticv1.RenewCertificatesAnnotation]; ok {
+		t.Error("expected the renewal annotation to be removed")
+	}
+	if status := cluster.Status.Conditions[kubermaticv1.ClusterConditionCertificateRenewal].Status; status != corev1.ConditionTrue {
+		t.Errorf("expected condition to be true, got %q", status)
+	}
+}
+
+func getSecretData(t *testing.T, client ctrlruntimeclient.Client, name, key string) []byte {
+	t.Helper()
+
+	secret := &corev1.Secret{}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: renewalTestNamespace, Name: name}, secret); err != nil {
+		t.Fatalf("failed to get secret %s: %v", name, err)
+	}
+
+	return secret.Data[key]
+}
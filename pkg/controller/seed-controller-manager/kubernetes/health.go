@@ -48,9 +48,7 @@ func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cl
 		resources.UserClusterControllerDeploymentName: {healthStatus: &extendedHealth.UserClusterControllerManager, minReady: 1},
 	}
 
-	showKonnectivity := cluster.Spec.ClusterNetwork.KonnectivityEnabled != nil && *cluster.Spec.ClusterNetwork.KonnectivityEnabled //nolint:staticcheck
-
-	if !showKonnectivity {
+	if kubermaticv1helper.IsOpenVPNDeployed(cluster) {
 		healthMapping[resources.OpenVPNServerDeploymentName] = &depInfo{healthStatus: &extendedHealth.OpenVPN, minReady: 1}
 	}
 
@@ -66,7 +64,7 @@ func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cl
 		*healthMapping[name].healthStatus = kubermaticv1helper.GetHealthStatus(status, cluster, r.versions)
 	}
 
-	if showKonnectivity {
+	if kubermaticv1helper.IsKonnectivityDeployed(cluster) {
 		// because konnectivity server is in apiserver pod
 		extendedHealth.Konnectivity = extendedHealth.Apiserver
 	}
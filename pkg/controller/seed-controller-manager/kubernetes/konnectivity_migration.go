@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	konnectivityMigrationCheckPeriod = 10 * time.Second
+
+	// openVPNClientSidecarName is the name of the OpenVPN client sidecar in control plane pods.
+	openVPNClientSidecarName = "openvpn-client"
+)
+
+// syncKonnectivityMigration starts a migration when Konnectivity gets enabled for a cluster
+// that is still running OpenVPN, and aborts an unfinished migration when Konnectivity gets
+// disabled again. It must run before the template data is built, as the migration phase
+// determines which of both setups is deployed.
+func (r *Reconciler) syncKonnectivityMigration(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	enabled := cluster.Spec.ClusterNetwork.GetTunnelingMechanism() == kubermaticv1.TunnelingMechanismKonnectivity
+	_, hasCondition := cluster.Status.Conditions[kubermaticv1.ClusterConditionKonnectivityMigration]
+
+	if !enabled {
+		if !hasCondition {
+			return nil
+		}
+
+		// UpdateClusterStatus refreshes the cluster, so the phase has to be read beforehand.
+		phase := kubermaticv1helper.KonnectivityMigrationPhase(cluster)
+
+		if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			delete(c.Status.Conditions, kubermaticv1.ClusterConditionKonnectivityMigration)
+		}); err != nil {
+			return fmt.Errorf("failed to update cluster status: %w", err)
+		}
+		if phase != "" {
+			r.recorder.Event(cluster, corev1.EventTypeWarning, "KonnectivityMigrationAborted", "Konnectivity was disabled before the migration was completed")
+		}
+
+		return nil
+	}
+
+	// Clusters that were created with Konnectivity or that have been migrated already
+	// do not need a migration.
+	if hasCondition {
+		return nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.OpenVPNServerDeploymentName}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get OpenVPN server Deployment: %w", err)
+	}
+
+	if err := r.setKonnectivityMigrationPhase(ctx, cluster, kubermaticv1.ReasonKonnectivityMigrationDeployingAgents, "Deploying the Konnectivity agents next to OpenVPN"); err != nil {
+		return err
+	}
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "KonnectivityMigrationStarted", "Started migrating the cluster from OpenVPN to Konnectivity")
+
+	return nil
+}
+
+// reconcileKonnectivityMigration advances a running migration from OpenVPN to Konnectivity.
+// The control plane is only switched once the Konnectivity agents are running, and OpenVPN is
+// only removed once no control plane pod relies on it anymore.
+func (r *Reconciler) reconcileKonnectivityMigration(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) (*reconcile.Result, error) {
+	switch kubermaticv1helper.KonnectivityMigrationPhase(cluster) {
+	case kubermaticv1.ReasonKonnectivityMigrationDeployingAgents:
+		ready, err := r.konnectivityAgentsReady(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if !ready {
+			return &reconcile.Result{RequeueAfter: konnectivityMigrationCheckPeriod}, nil
+		}
+
+		if err := r.setKonnectivityMigrationPhase(ctx, cluster, kubermaticv1.ReasonKonnectivityMigrationSwitchingControlPlane, "Switching the control plane to Konnectivity"); err != nil {
+			return nil, err
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "KonnectivityMigrationProgressing", "Konnectivity agents are ready, switching the control plane to Konnectivity")
+
+		return &reconcile.Result{Requeue: true}, nil
+
+	case kubermaticv1.ReasonKonnectivityMigrationSwitchingControlPlane:
+		switched, err := r.controlPlaneSwitchedToKonnectivity(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if !switched {
+			return &reconcile.Result{RequeueAfter: konnectivityMigrationCheckPeriod}, nil
+		}
+
+		if err := r.ensureOpenVPNSetupIsRemoved(ctx, data); err != nil {
+			return nil, err
+		}
+
+		if err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+			kubermaticv1helper.SetClusterCondition(
+				c,
+				r.versions,
+				kubermaticv1.ClusterConditionKonnectivityMigration,
+				corev1.ConditionTrue,
+				kubermaticv1.ReasonKonnectivityMigrationCompleted,
+				"The cluster has been migrated from OpenVPN to Konnectivity",
+			)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update cluster status: %w", err)
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "KonnectivityMigrationCompleted", "The cluster has been migrated from OpenVPN to Konnectivity")
+	}
+
+	return &reconcile.Result{}, nil
+}
+
+func (r *Reconciler) setKonnectivityMigrationPhase(ctx context.Context, cluster *kubermaticv1.Cluster, reason, message string) error {
+	err := kubermaticv1helper.UpdateClusterStatus(ctx, r, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionKonnectivityMigration,
+			corev1.ConditionFalse,
+			reason,
+			message,
+		)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update cluster status: %w", err)
+	}
+
+	return nil
+}
+
+// konnectivityAgentsReady checks that the apiserver runs the Konnectivity server and that all
+// Konnectivity agents in the user cluster are available.
+func (r *Reconciler) konnectivityAgentsReady(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	apiserver := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := r.Get(ctx, key, apiserver); err != nil {
+		return false, fmt.Errorf("failed to get apiserver Deployment: %w", err)
+	}
+
+	if !hasContainer(apiserver, resources.KonnectivityServerContainer) || !resources.DeploymentRolledOut(apiserver) {
+		return false, nil
+	}
+
+	userClusterClient, err := r.userClusterConnProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user cluster client: %w", err)
+	}
+
+	key = types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: resources.KonnectivityDeploymentName}
+	status, err := resources.HealthyDeployment(ctx, userClusterClient, key, -1)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine health of the Konnectivity agents: %w", err)
+	}
+
+	return status == kubermaticv1.HealthStatusUp, nil
+}
+
+// controlPlaneSwitchedToKonnectivity checks that the apiserver no longer runs the OpenVPN
+// client and that the whole control plane has been rolled out since.
+func (r *Reconciler) controlPlaneSwitchedToKonnectivity(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	apiserver := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := r.Get(ctx, key, apiserver); err != nil {
+		return false, fmt.Errorf("failed to get apiserver Deployment: %w", err)
+	}
+
+	if hasContainer(apiserver, openVPNClientSidecarName) {
+		return false, nil
+	}
+
+	return resources.ControlPlaneRolledOut(ctx, r, cluster.Status.NamespaceName)
+}
+
+func hasContainer(deployment *appsv1.Deployment, name string) bool {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	autoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type staticUserClusterConnectionProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (p *staticUserClusterConnectionProvider) GetClient(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return p.client, nil
+}
+
+func TestKonnectivityMigration(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				TunnelingMechanism: kubermaticv1.TunnelingMechanismKonnectivity,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: renewalTestNamespace,
+		},
+	}
+	openvpnServer := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: renewalTestNamespace,
+			Name:      resources.OpenVPNServerDeploymentName,
+		},
+	}
+	apiserver := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: renewalTestNamespace,
+			Name:      resources.ApiserverDeploymentName,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: openVPNClientSidecarName},
+						{Name: resources.ApiserverDeploymentName},
+					},
+				},
+			},
+		},
+	}
+	agents := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceSystem,
+			Name:      resources.KonnectivityDeploymentName,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](2),
+		},
+	}
+
+	// removing OpenVPN also removes its VerticalPodAutoscaler
+	scheme := fake.NewScheme()
+	utilruntime.Must(autoscalingv1.AddToScheme(scheme))
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, openvpnServer, apiserver).Build()
+	userClusterClient := fake.NewClientBuilder().WithObjects(agents).Build()
+	r := &Reconciler{
+		Client:                  client,
+		userClusterConnProvider: &staticUserClusterConnectionProvider{client: userClusterClient},
+		recorder:                record.NewFakeRecorder(10),
+		versions:                kubermatic.NewFakeVersions(),
+	}
+
+	sync := func() {
+		t.Helper()
+
+		if err := client.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+			t.Fatalf("failed to get cluster: %v", err)
+		}
+		if err := r.syncKonnectivityMigration(ctx, cluster); err != nil {
+			t.Fatalf("failed to sync migration: %v", err)
+		}
+		data := resources.NewTemplateDataBuilder().WithCluster(cluster).Build()
+		if _, err := r.reconcileKonnectivityMigration(ctx, cluster, data); err != nil {
+			t.Fatalf("failed to reconcile migration: %v", err)
+		}
+	}
+
+	setStatus := func(c ctrlruntimeclient.Client, deployment *appsv1.Deployment, ready int32) {
+		t.Helper()
+
+		deployment.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deployment.Generation,
+			Replicas:           ready,
+			UpdatedReplicas:    ready,
+			ReadyReplicas:      ready,
+		}
+		if err := c.Status().Update(ctx, deployment); err != nil {
+			t.Fatalf("failed to update Deployment status: %v", err)
+		}
+	}
+
+	setStatus(client, openvpnServer, 1)
+
+	// enabling Konnectivity for a cluster running OpenVPN must start the migration, but
+	// the control plane must not switch before the agents are ready
+	sync()
+	if phase := kubermaticv1helper.KonnectivityMigrationPhase(cluster); phase != kubermaticv1.ReasonKonnectivityMigrationDeployingAgents {
+		t.Fatalf("expected migration phase %q, got %q", kubermaticv1.ReasonKonnectivityMigrationDeployingAgents, phase)
+	}
+	if kubermaticv1helper.IsKonnectivityEnabled(cluster) {
+		t.Fatal("expected control plane to keep using OpenVPN while the agents are deployed")
+	}
+
+	apiserver.Spec.Template.Spec.Containers = append(apiserver.Spec.Template.Spec.Containers, corev1.Container{Name: resources.KonnectivityServerContainer})
+	if err := client.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+	setStatus(client, apiserver, 1)
+	setStatus(userClusterClient, agents, 1)
+
+	sync()
+	if phase := kubermaticv1helper.KonnectivityMigrationPhase(cluster); phase != kubermaticv1.ReasonKonnectivityMigrationDeployingAgents {
+		t.Fatalf("expected migration to wait for all agents, got phase %q", phase)
+	}
+
+	setStatus(userClusterClient, agents, 2)
+	sync()
+	if phase := kubermaticv1helper.KonnectivityMigrationPhase(cluster); phase != kubermaticv1.ReasonKonnectivityMigrationSwitchingControlPlane {
+		t.Fatalf("expected migration phase %q, got %q", kubermaticv1.ReasonKonnectivityMigrationSwitchingControlPlane, phase)
+	}
+	if !kubermaticv1helper.IsKonnectivityEnabled(cluster) {
+		t.Fatal("expected control plane to use Konnectivity once the agents are ready")
+	}
+
+	// OpenVPN must be kept as long as the apiserver still runs the OpenVPN client
+	sync()
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(openvpnServer), &appsv1.Deployment{}); err != nil {
+		t.Fatalf("expected OpenVPN server to be kept: %v", err)
+	}
+
+	apiserver.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: resources.KonnectivityServerContainer},
+		{Name: resources.ApiserverDeploymentName},
+	}
+	if err := client.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+	setStatus(client, apiserver, 1)
+
+	sync()
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionKonnectivityMigration, corev1.ConditionTrue) {
+		t.Fatalf("expected migration to be completed, got conditions %+v", cluster.Status.Conditions)
+	}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(openvpnServer), &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected OpenVPN server to be removed, got %v", err)
+	}
+
+	// disabling Konnectivity again must reset the migration, so that it can be repeated
+	cluster.Spec.ClusterNetwork.TunnelingMechanism = kubermaticv1.TunnelingMechanismOpenVPN
+	if err := client.Update(ctx, cluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+	sync()
+	if _, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionKonnectivityMigration]; ok {
+		t.Fatal("expected migration condition to be removed")
+	}
+}
+
+func TestKonnectivityMigrationAborted(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				TunnelingMechanism: kubermaticv1.TunnelingMechanismOpenVPN,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: renewalTestNamespace,
+			Conditions: map[kubermaticv1.ClusterConditionType]kubermaticv1.ClusterCondition{
+				kubermaticv1.ClusterConditionKonnectivityMigration: {
+					Status: corev1.ConditionFalse,
+					Reason: kubermaticv1.ReasonKonnectivityMigrationDeployingAgents,
+				},
+			},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithObjects(cluster).Build(),
+		recorder: recorder,
+		versions: kubermatic.NewFakeVersions(),
+	}
+
+	if err := r.syncKonnectivityMigration(ctx, cluster); err != nil {
+		t.Fatalf("failed to sync migration: %v", err)
+	}
+	if _, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionKonnectivityMigration]; ok {
+		t.Fatal("expected migration condition to be removed")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "KonnectivityMigrationAborted") {
+			t.Fatalf("expected KonnectivityMigrationAborted event, got %q", event)
+		}
+	default:
+		t.Fatal("expected KonnectivityMigrationAborted event, got none")
+	}
+}
+
+func TestKonnectivityMigrationNotNeeded(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				TunnelingMechanism: kubermaticv1.TunnelingMechanismKonnectivity,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: renewalTestNamespace,
+		},
+	}
+
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithObjects(cluster).Build(),
+		recorder: record.NewFakeRecorder(10),
+		versions: kubermatic.NewFakeVersions(),
+	}
+
+	if err := r.syncKonnectivityMigration(ctx, cluster); err != nil {
+		t.Fatalf("failed to sync migration: %v", err)
+	}
+	if _, ok := cluster.Status.Conditions[kubermaticv1.ClusterConditionKonnectivityMigration]; ok {
+		t.Fatal("expected no migration for a cluster without OpenVPN")
+	}
+	if !kubermaticv1helper.IsKonnectivityEnabled(cluster) {
+		t.Fatal("expected Konnectivity to be enabled")
+	}
+}
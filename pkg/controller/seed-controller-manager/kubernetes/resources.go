@@ -41,6 +41,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/dns"
 	"k8c.io/kubermatic/v2/pkg/resources/etcd"
 	"k8c.io/kubermatic/v2/pkg/resources/gatekeeper"
+	"k8c.io/kubermatic/v2/pkg/resources/gateway"
 	"k8c.io/kubermatic/v2/pkg/resources/konnectivity"
 	kubernetesdashboard "k8c.io/kubermatic/v2/pkg/resources/kubernetes-dashboard"
 	"k8c.io/kubermatic/v2/pkg/resources/machinecontroller"
@@ -59,8 +60,10 @@ import (
 	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -79,6 +82,9 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 	if err != nil {
 		return nil, err
 	}
+	if err := r.syncKonnectivityMigration(ctx, cluster); err != nil {
+		return nil, err
+	}
 	data, err := r.getClusterTemplateData(ctx, cluster, seed, config)
 	if err != nil {
 		return nil, err
@@ -94,6 +100,11 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return nil, fmt.Errorf("failed to sync address: %w", err)
 	}
 
+	// Routes on the seed's Gateway depend on the external name.
+	if err := r.ensureTLSRoutes(ctx, cluster, data, seed); err != nil {
+		return nil, err
+	}
+
 	// We should not proceed without having an IP address unless tunneling
 	// strategy is used. Its required for all Kubeconfigs & triggers errors
 	// otherwise.
@@ -105,7 +116,8 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 	}
 
 	// check that all secrets are available // New way of handling secrets
-	if err := r.ensureSecrets(ctx, cluster, data); err != nil {
+	renewal := newCertificateRenewal(cluster)
+	if err := r.ensureSecrets(ctx, cluster, data, renewal.modifiers()...); err != nil {
 		return nil, err
 	}
 
@@ -169,12 +181,15 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 	}
 
 	// This code supports switching between OpenVPN and Konnectivity setup (in both directions).
-	// It can be removed one release after deprecating OpenVPN.
-	if cluster.Spec.ClusterNetwork.KonnectivityEnabled != nil && *cluster.Spec.ClusterNetwork.KonnectivityEnabled { //nolint:staticcheck
+	// It can be removed one release after deprecating OpenVPN. While a cluster is migrated to
+	// Konnectivity, OpenVPN is only removed by reconcileKonnectivityMigration.
+	switch {
+	case data.IsKonnectivityMigrationInProgress():
+	case data.IsKonnectivityEnabled():
 		if err := r.ensureOpenVPNSetupIsRemoved(ctx, data); err != nil {
 			return nil, err
 		}
-	} else {
+	default:
 		if err := r.ensureKonnectivitySetupIsRemoved(ctx, data); err != nil {
 			return nil, err
 		}
@@ -182,7 +197,7 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 
 	// clean up NetworkPolicy created before konnectivity-server's kubeconfig was changed
 	// to use the internal API server endpoint.
-	if data.IsKonnectivityEnabled() {
+	if data.IsKonnectivityDeployed() {
 		if err := r.ensureKonnectivityNetworkPolicyIsRemoved(ctx, data); err != nil {
 			return nil, err
 		}
@@ -209,7 +224,20 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		}
 	}
 
-	return &reconcile.Result{}, nil
+	migrationResult, err := r.reconcileKonnectivityMigration(ctx, cluster, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.reconcileCertificateRenewal(ctx, cluster, renewal)
+	if err != nil {
+		return nil, err
+	}
+	if result.IsZero() {
+		return migrationResult, nil
+	}
+
+	return result, nil
 }
 
 func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed, config *kubermaticv1.KubermaticConfiguration) (*resources.TemplateData, error) {
@@ -223,7 +251,8 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kuberm
 		return nil, err
 	}
 
-	konnectivityEnabled := cluster.Spec.ClusterNetwork.KonnectivityEnabled != nil && *cluster.Spec.ClusterNetwork.KonnectivityEnabled //nolint:staticcheck
+	konnectivityEnabled := kubermaticv1helper.IsKonnectivityEnabled(cluster)
+	konnectivityMigrating := kubermaticv1helper.KonnectivityMigrationPhase(cluster) != ""
 
 	return resources.NewTemplateDataBuilder().
 		WithContext(ctx).
@@ -238,6 +267,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kuberm
 		WithEtcdDiskSize(r.etcdDiskSize).
 		WithUserClusterMLAEnabled(r.userClusterMLAEnabled).
 		WithKonnectivityEnabled(konnectivityEnabled).
+		WithKonnectivityMigration(konnectivityMigrating).
 		WithTunnelingAgentIP(r.tunnelingAgentIP).
 		WithCABundle(r.caBundle).
 		WithOIDCIssuerURL(r.oidcIssuerURL).
@@ -345,9 +375,10 @@ func GetServiceReconcilers(data *resources.TemplateData) []reconciling.NamedServ
 		userclusterwebhook.ServiceReconciler(),
 	}
 
-	if data.IsKonnectivityEnabled() {
+	if data.IsKonnectivityDeployed() {
 		creators = append(creators, konnectivity.ServiceReconciler(data.Cluster().Spec.ExposeStrategy, extName))
-	} else {
+	}
+	if data.IsOpenVPNDeployed() {
 		creators = append(creators,
 			openvpn.ServiceReconciler(data.Cluster().Spec.ExposeStrategy),
 			metricsserver.ServiceReconciler(),
@@ -371,6 +402,79 @@ func (r *Reconciler) ensureServices(ctx context.Context, c *kubermaticv1.Cluster
 	return reconciling.ReconcileServices(ctx, creators, c.Status.NamespaceName, r)
 }
 
+// GetTLSRouteReconcilers returns all TLSRoute creators that are currently in use.
+// TLSRoutes are only required for the Gateway expose strategy and attach to the
+// Gateway in the given namespace.
+func GetTLSRouteReconcilers(data *resources.TemplateData, gatewayNamespace string) []kkpreconciling.NamedTLSRouteReconcilerFactory {
+	cluster := data.Cluster()
+	extName := cluster.Status.Address.ExternalName
+
+	// the hostnames of all routes are based on the external name, which is
+	// not known until the cluster address has been synced
+	if cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyGateway || extName == "" {
+		return nil
+	}
+
+	creators := []kkpreconciling.NamedTLSRouteReconcilerFactory{
+		gateway.TLSRouteReconciler(gatewayNamespace, resources.ApiserverServiceName, 443, extName),
+	}
+
+	if data.IsKonnectivityDeployed() {
+		creators = append(creators, gateway.TLSRouteReconciler(gatewayNamespace, resources.KonnectivityProxyServiceName, 443, fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, extName)))
+	}
+
+	if data.UserClusterMLAEnabled() && cluster.Spec.MLA != nil && (cluster.Spec.MLA.MonitoringEnabled || cluster.Spec.MLA.LoggingEnabled) {
+		creators = append(creators, gateway.TLSRouteReconciler(gatewayNamespace, resources.MLAGatewayExternalServiceName, 80, resources.MLAGatewaySNIPrefix+extName))
+	}
+
+	return creators
+}
+
+func (r *Reconciler) ensureTLSRoutes(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData, seed *kubermaticv1.Seed) error {
+	if seed.Spec.Gateway == nil {
+		if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway {
+			return fmt.Errorf("the %s expose strategy requires a Gateway to be configured for Seed %q", c.Spec.ExposeStrategy, seed.Name)
+		}
+
+		// the Gateway might have been removed from the Seed, leaving routes behind
+		return r.cleanupTLSRoutes(ctx, c, sets.New[string]())
+	}
+
+	creators := GetTLSRouteReconcilers(data, seed.Namespace)
+	if err := kkpreconciling.ReconcileTLSRoutes(ctx, creators, c.Status.NamespaceName, r); err != nil {
+		return err
+	}
+
+	// remove routes that are not required anymore, e.g. after switching expose strategies
+	inUse := sets.New[string]()
+	for _, creator := range creators {
+		name, _ := creator()
+		inUse.Insert(name)
+	}
+
+	return r.cleanupTLSRoutes(ctx, c, inUse)
+}
+
+// cleanupTLSRoutes removes all TLSRoutes of the cluster that are not in use. If the Gateway API
+// is not installed on the seed, there is nothing to clean up.
+func (r *Reconciler) cleanupTLSRoutes(ctx context.Context, c *kubermaticv1.Cluster, inUse sets.Set[string]) error {
+	for _, route := range gateway.ResourcesForDeletion(c.Status.NamespaceName) {
+		if inUse.Has(route.GetName()) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, route); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil
+			}
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to ensure TLSRoute %s is removed/not present: %w", route.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // GetDeploymentReconcilers returns all DeploymentReconcilers that are currently in use.
 func GetDeploymentReconcilers(data *resources.TemplateData, enableAPIserverOIDCAuthentication bool, versions kubermatic.Versions) []reconciling.NamedDeploymentReconcilerFactory {
 	deployments := []reconciling.NamedDeploymentReconcilerFactory{
@@ -388,7 +492,7 @@ func GetDeploymentReconcilers(data *resources.TemplateData, enableAPIserverOIDCA
 		deployments = append(deployments, kubernetesdashboard.DeploymentReconciler(data))
 	}
 
-	if !data.IsKonnectivityEnabled() {
+	if data.IsOpenVPNDeployed() {
 		deployments = append(deployments,
 			openvpn.DeploymentReconciler(data),
 			metricsserver.DeploymentReconciler(data),
@@ -422,7 +526,7 @@ func GetDeploymentReconcilers(data *resources.TemplateData, enableAPIserverOIDCA
 
 func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetDeploymentReconcilers(data, r.features.KubernetesOIDCAuthentication, r.versions)
-	return reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r)
+	return reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r, resources.HibernationModifiers(cluster)...)
 }
 
 // GetSecretReconcilers returns all SecretReconcilers that are currently in use.
@@ -477,12 +581,13 @@ func (r *Reconciler) GetSecretReconcilers(ctx context.Context, data *resources.T
 		)
 	}
 
-	if data.IsKonnectivityEnabled() {
+	if data.IsKonnectivityDeployed() {
 		creators = append(creators,
 			konnectivity.TLSServingCertificateReconciler(data),
 			resources.GetInternalKubeconfigReconciler(namespace, resources.KonnectivityKubeconfigSecretName, resources.KonnectivityKubeconfigUsername, nil, data, r.log),
 		)
-	} else {
+	}
+	if data.IsOpenVPNDeployed() {
 		creators = append(creators,
 			openvpn.CAReconciler(),
 			openvpn.TLSServingCertificateReconciler(data),
@@ -495,6 +600,10 @@ func (r *Reconciler) GetSecretReconcilers(ctx context.Context, data *resources.T
 
 	if data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled {
 		creators = append(creators, apiserver.FluentBitSecretReconciler(data))
+
+		if apiserver.AuditWebhookBackend(data.Cluster(), data.Seed()) != nil {
+			creators = append(creators, apiserver.AuditWebhookBackendSecretReconciler(data))
+		}
 	}
 
 	if data.Cluster().IsEncryptionEnabled() || data.Cluster().IsEncryptionActive() {
@@ -518,10 +627,11 @@ func (r *Reconciler) GetSecretReconcilers(ctx context.Context, data *resources.T
 	return creators
 }
 
-func (r *Reconciler) ensureSecrets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
+func (r *Reconciler) ensureSecrets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData, modifiers ...reconciling.ObjectModifier) error {
 	namedSecretReconcilerFactorys := r.GetSecretReconcilers(ctx, data)
 
-	if err := reconciling.ReconcileSecrets(ctx, namedSecretReconcilerFactorys, c.Status.NamespaceName, r.Client); err != nil {
+	modifiers = append(modifiers, resources.CertificateComponentLabeler)
+	if err := reconciling.ReconcileSecrets(ctx, namedSecretReconcilerFactorys, c.Status.NamespaceName, r.Client, modifiers...); err != nil {
 		return fmt.Errorf("failed to ensure that the Secret exists: %w", err)
 	}
 
@@ -635,9 +745,10 @@ func (r *Reconciler) ensureNetworkPolicies(ctx context.Context, c *kubermaticv1.
 		resolverCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 		defer cancel()
 
-		if data.IsKonnectivityEnabled() {
+		if data.IsKonnectivityDeployed() {
 			namedNetworkPolicyReconcilerFactorys = append(namedNetworkPolicyReconcilerFactorys, apiserver.ApiserverInternalAllowReconciler())
-		} else {
+		}
+		if data.IsOpenVPNDeployed() {
 			namedNetworkPolicyReconcilerFactorys = append(namedNetworkPolicyReconcilerFactorys,
 				apiserver.OpenVPNServerAllowReconciler(c),
 				apiserver.MetricsServerAllowReconciler(c),
@@ -688,9 +799,10 @@ func GetConfigMapReconcilers(data *resources.TemplateData) []reconciling.NamedCo
 	}
 	creators = append(creators, csi.ConfigMapsReconcilers(data)...)
 
-	if data.IsKonnectivityEnabled() {
+	if data.IsKonnectivityDeployed() {
 		creators = append(creators, apiserver.EgressSelectorConfigReconciler())
-	} else {
+	}
+	if data.IsOpenVPNDeployed() {
 		creators = append(creators,
 			openvpn.ServerClientConfigsConfigMapReconciler(data),
 			dns.ConfigMapReconciler(data),
@@ -731,7 +843,7 @@ func GetPodDisruptionBudgetReconcilers(data *resources.TemplateData) []reconcili
 		etcd.PodDisruptionBudgetReconciler(data),
 		apiserver.PodDisruptionBudgetReconciler(),
 	}
-	if !data.IsKonnectivityEnabled() {
+	if data.IsOpenVPNDeployed() {
 		creators = append(creators,
 			metricsserver.PodDisruptionBudgetReconciler(),
 			dns.PodDisruptionBudgetReconciler(),
@@ -780,7 +892,7 @@ func (r *Reconciler) ensureVerticalPodAutoscalers(ctx context.Context, c *kuberm
 		resources.ControllerManagerDeploymentName,
 		resources.SchedulerDeploymentName,
 	}
-	if !data.IsKonnectivityEnabled() {
+	if data.IsOpenVPNDeployed() {
 		controlPlaneDeploymentNames = append(controlPlaneDeploymentNames,
 			resources.OpenVPNServerDeploymentName,
 			resources.MetricsServerDeploymentName,
@@ -804,7 +916,7 @@ func (r *Reconciler) ensureStatefulSets(ctx context.Context, c *kubermaticv1.Clu
 
 	creators := GetStatefulSetReconcilers(data, r.features.EtcdDataCorruptionChecks, useTLSOnly)
 
-	return reconciling.ReconcileStatefulSets(ctx, creators, c.Status.NamespaceName, r.Client)
+	return reconciling.ReconcileStatefulSets(ctx, creators, c.Status.NamespaceName, r.Client, resources.HibernationModifiers(c)...)
 }
 
 func (r *Reconciler) ensureEtcdBackupConfigs(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData,
@@ -39,6 +39,7 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 type testUserClusterConnectionProvider struct {
@@ -81,6 +82,9 @@ func TestEnsureResourcesAreDeployedIdempotency(t *testing.T) {
 	if err := autoscalingv1.AddToScheme(mgr.GetScheme()); err != nil {
 		t.Fatalf("failed to register vertical pod autoscaler resources to scheme: %v", err)
 	}
+	if err := gatewayapiv1alpha2.AddToScheme(mgr.GetScheme()); err != nil {
+		t.Fatalf("failed to register Gateway API resources to scheme: %v", err)
+	}
 
 	crdInstallOpts := envtest.CRDInstallOptions{
 		Paths: []string{
@@ -25,13 +25,17 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudcontroller"
+	"k8c.io/kubermatic/v2/pkg/resources/gateway"
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 func TestCloudControllerManagerDeployment(t *testing.T) {
@@ -368,3 +372,46 @@ func (k KCMDeploymentConfig) Create(td *resources.TemplateData) *appsv1.Deployme
 	d.Spec.Template.Spec = *wrappedPodSpec
 	return &d
 }
+
+func TestEnsureTLSRoutesWithoutGateway(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			ExposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test",
+		},
+	}
+
+	route := &gatewayapiv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.ApiserverServiceName,
+			Namespace: cluster.Status.NamespaceName,
+		},
+	}
+
+	scheme := fake.NewScheme()
+	if err := gatewayapiv1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to register scheme: %v", err)
+	}
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, route).Build(),
+	}
+
+	// the Gateway has been removed from the Seed
+	seed := &kubermaticv1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "europe", Namespace: "kubermatic"}}
+
+	if err := r.ensureTLSRoutes(ctx, cluster, nil, seed); err != nil {
+		t.Fatalf("failed to ensure TLSRoutes: %v", err)
+	}
+
+	for _, obj := range gateway.ResourcesForDeletion(cluster.Status.NamespaceName) {
+		if err := r.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+			t.Errorf("expected TLSRoute %s to be removed, got: %v", obj.GetName(), err)
+		}
+	}
+}
@@ -366,7 +366,7 @@ func (r *datasourceGrafanaController) ensureDeployments(ctx context.Context, c *
 	creators := []reconciling.NamedDeploymentReconcilerFactory{
 		GatewayDeploymentReconciler(data, settings),
 	}
-	if err := reconciling.ReconcileDeployments(ctx, creators, c.Status.NamespaceName, r.Client); err != nil {
+	if err := reconciling.ReconcileDeployments(ctx, creators, c.Status.NamespaceName, r.Client, resources.HibernationModifiers(c)...); err != nil {
 		return err
 	}
 	return nil
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/kubestatemetrics"
@@ -48,7 +49,8 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, client ctrlrunt
 		return nil, fmt.Errorf("failed to get datacenter %s", cluster.Spec.Cloud.DatacenterName)
 	}
 
-	konnectivityEnabled := cluster.Spec.ClusterNetwork.KonnectivityEnabled != nil && *cluster.Spec.ClusterNetwork.KonnectivityEnabled //nolint:staticcheck
+	konnectivityEnabled := kubermaticv1helper.IsKonnectivityEnabled(cluster)
+	konnectivityMigrating := kubermaticv1helper.KonnectivityMigrationPhase(cluster) != ""
 
 	return resources.NewTemplateDataBuilder().
 		WithContext(ctx).
@@ -64,6 +66,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, client ctrlrunt
 		WithBackupPeriod(20 * time.Minute).
 		WithVersions(r.versions).
 		WithKonnectivityEnabled(konnectivityEnabled).
+		WithKonnectivityMigration(konnectivityMigrating).
 		Build(), nil
 }
 
@@ -95,7 +98,7 @@ func GetDeploymentReconcilers(data *resources.TemplateData) []reconciling.NamedD
 func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetDeploymentReconcilers(data)
 
-	return reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r.Client)
+	return reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r.Client, resources.HibernationModifiers(cluster)...)
 }
 
 // GetSecretReconcilerOperations returns all SecretReconcilers that are currently in use.
@@ -148,7 +151,7 @@ func GetStatefulSetReconcilers(data *resources.TemplateData) []reconciling.Named
 func (r *Reconciler) ensureStatefulSets(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetStatefulSetReconcilers(data)
 
-	return reconciling.ReconcileStatefulSets(ctx, creators, cluster.Status.NamespaceName, r.Client)
+	return reconciling.ReconcileStatefulSets(ctx, creators, cluster.Status.NamespaceName, r.Client, resources.HibernationModifiers(cluster)...)
 }
 
 func (r *Reconciler) ensureVerticalPodAutoscalers(ctx context.Context, cluster *kubermaticv1.Cluster) error {
@@ -72,16 +72,7 @@ type reconciler struct {
 }
 
 func Add(ctx context.Context, log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterIsPaused userclustercontrollermanager.IsPausedChecker, appInstaller applications.ApplicationInstaller) error {
-	log = log.Named(controllerName)
-
-	r := &reconciler{
-		log:             log,
-		seedClient:      seedMgr.GetClient(),
-		userClient:      userMgr.GetClient(),
-		userRecorder:    userMgr.GetEventRecorderFor(controllerName),
-		clusterIsPaused: clusterIsPaused,
-		appInstaller:    appInstaller,
-	}
+	r := newReconciler(log, seedMgr.GetClient(), userMgr.GetClient(), userMgr.GetEventRecorderFor(controllerName), clusterIsPaused, appInstaller)
 
 	c, err := controller.New(controllerName, userMgr, controller.Options{
 		Reconciler: r,
@@ -108,9 +99,32 @@ func Add(ctx context.Context, log *zap.SugaredLogger, seedMgr, userMgr manager.M
 		return fmt.Errorf("failed to watch applicationDefinition: %w", err)
 	}
 
+	// Every change of an ApplicationInstallation (including its status) wakes up the ApplicationInstallations waiting
+	// for it, either to be ready before installing or to be uninstalled before uninstalling.
+	if err = c.Watch(source.Kind(userMgr.GetCache(), &appskubermaticv1.ApplicationInstallation{}), handler.EnqueueRequestsFromMapFunc(enqueueDependencyWaiters(r.userClient))); err != nil {
+		return fmt.Errorf("failed to create watch for ApplicationInstallation dependencies: %w", err)
+	}
+
 	return nil
 }
 
+// NewReconciler returns a reconciler for the ApplicationInstallations in the user cluster reachable via userClient.
+// It allows to reconcile ApplicationInstallations in clusters that do not run a user-cluster-controller-manager.
+func NewReconciler(log *zap.SugaredLogger, seedClient, userClient ctrlruntimeclient.Client, userRecorder record.EventRecorder, clusterIsPaused userclustercontrollermanager.IsPausedChecker, appInstaller applications.ApplicationInstaller) reconcile.Reconciler {
+	return newReconciler(log, seedClient, userClient, userRecorder, clusterIsPaused, appInstaller)
+}
+
+func newReconciler(log *zap.SugaredLogger, seedClient, userClient ctrlruntimeclient.Client, userRecorder record.EventRecorder, clusterIsPaused userclustercontrollermanager.IsPausedChecker, appInstaller applications.ApplicationInstaller) *reconciler {
+	return &reconciler{
+		log:             log.Named(controllerName),
+		seedClient:      seedClient,
+		userClient:      userClient,
+		userRecorder:    userRecorder,
+		clusterIsPaused: clusterIsPaused,
+		appInstaller:    appInstaller,
+	}
+}
+
 // Reconcile ApplicationInstallation (i.e. install / update or uninstall application into the user-cluster).
 func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := r.log.With("applicationinstallation", request)
@@ -141,7 +155,7 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	}
 
 	log.Debug("Processed")
-	return reconcile.Result{RequeueAfter: appInstallation.Spec.ReconciliationInterval.Duration}, err
+	return reconcile.Result{RequeueAfter: requeueAfter(appInstallation)}, err
 }
 
 func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) error {
@@ -193,7 +207,8 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appI
 		}
 	}
 
-	if !equality.Semantic.DeepEqual(appVersion, appInstallation.Status.ApplicationVersion) || appInstallation.Status.Method != applicationDef.Spec.Method {
+	statusOutdated := !equality.Semantic.DeepEqual(appVersion, appInstallation.Status.ApplicationVersion) || appInstallation.Status.Method != applicationDef.Spec.Method
+	if statusOutdated {
 		oldAppInstallation := appInstallation.DeepCopy()
 		appInstallation.Status.ApplicationVersion = appVersion
 		appInstallation.Status.Method = applicationDef.Spec.Method
@@ -203,6 +218,20 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, appI
 		}
 	}
 
+	// wait for the dependencies to be ready. Once they are, the watch on ApplicationInstallation enqueues this one again.
+	if waiting, err := r.waitForDependencies(ctx, log, appInstallation); err != nil || waiting {
+		return err
+	}
+
+	// with drift detection, reconciliations that are not caused by a change of the application only detect drift
+	if appInstallation.Spec.DriftDetection != nil && !statusOutdated && isInstalledAtCurrentGeneration(appInstallation) {
+		remediate, err := r.handleDriftDetection(ctx, log, appInstallation)
+		if err != nil || !remediate {
+			return err
+		}
+		log.Info("Upgrading application to remediate drift")
+	}
+
 	// install application into the user-cluster
 	if err := r.handleInstallation(ctx, log, applicationDef, appInstallation); err != nil {
 		return fmt.Errorf("handling installation of application installation: %w", err)
@@ -274,6 +303,9 @@ func (r *reconciler) handleInstallation(ctx context.Context, log *zap.SugaredLog
 
 	statusUpdater(&appInstallation.Status)
 	appInstallation.SetReadyCondition(installErr, hasLimitedRetries(appDefinition, appInstallation))
+	if appInstallation.Spec.DriftDetection == nil {
+		appInstallation.Status.Drift = nil
+	}
 
 	// we set condition in every case and condition update the LastHeartbeatTime. So patch will not be empty.
 	if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
@@ -312,6 +344,11 @@ func (r reconciler) resetFailuresIfSpecHasChanged(ctx context.Context, appInstal
 // handleDeletion uninstalls the application in the user cluster.
 func (r *reconciler) handleDeletion(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) error {
 	if kuberneteshelper.HasFinalizer(appInstallation, appskubermaticv1.ApplicationInstallationCleanupFinalizer) {
+		// uninstall in reverse dependency order
+		if waiting, err := r.waitForDependents(ctx, log, appInstallation); err != nil || waiting {
+			return err
+		}
+
 		statusUpdater, uninstallErr := r.appInstaller.Delete(ctx, log, r.seedClient, r.userClient, appInstallation)
 		oldAppInstallation := appInstallation.DeepCopy()
 		if uninstallErr != nil {
@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// Reason of the Ready condition while the application waits for its dependencies to be ready.
+	waitingForDependenciesReason = "WaitingForDependencies"
+
+	// Reason of the Ready condition while the uninstallation waits for the applications depending on it to be uninstalled.
+	waitingForDependentsReason = "WaitingForDependents"
+)
+
+// waitForDependencies returns true if at least one dependency of the appInstallation is not Ready yet. In this case,
+// the Ready condition is updated with the missing dependencies.
+func (r *reconciler) waitForDependencies(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) (bool, error) {
+	if len(appInstallation.Spec.DependsOn) == 0 {
+		return false, nil
+	}
+
+	appList := &appskubermaticv1.ApplicationInstallationList{}
+	if err := r.userClient.List(ctx, appList); err != nil {
+		return false, fmt.Errorf("failed to list applicationInstallation: %w", err)
+	}
+
+	var pending []string
+	for _, dep := range appInstallation.Spec.DependsOn {
+		if !isDependencyReady(appInstallation, dep, appList.Items) {
+			pending = append(pending, describeDependency(appInstallation, dep))
+		}
+	}
+
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	log.Debugw("Waiting for dependencies", "dependencies", pending)
+
+	return true, r.setWaitingCondition(ctx, appInstallation, waitingForDependenciesReason, "waiting for dependencies to be ready: "+strings.Join(pending, ", "))
+}
+
+// waitForDependents returns true if applications depending on the appInstallation are being uninstalled too. The
+// appInstallation is only uninstalled once they are gone, so that applications are removed in reverse dependency order.
+// Dependents that are not being deleted do not block the uninstallation.
+func (r *reconciler) waitForDependents(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) (bool, error) {
+	appList := &appskubermaticv1.ApplicationInstallationList{}
+	if err := r.userClient.List(ctx, appList); err != nil {
+		return false, fmt.Errorf("failed to list applicationInstallation: %w", err)
+	}
+
+	var pending []string
+	for i := range appList.Items {
+		dependent := &appList.Items[i]
+		if !dependent.DeletionTimestamp.IsZero() && dependsOn(dependent, appInstallation) && !isSameInstallation(dependent, appInstallation) {
+			pending = append(pending, types.NamespacedName{Namespace: dependent.Namespace, Name: dependent.Name}.String())
+		}
+	}
+
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	log.Debugw("Waiting for dependent applications to be uninstalled", "dependents", pending)
+
+	return true, r.setWaitingCondition(ctx, appInstallation, waitingForDependentsReason, "waiting for dependent applications to be uninstalled: "+strings.Join(pending, ", "))
+}
+
+// setWaitingCondition sets the Ready condition to false. The status is only patched if the reason or message changed,
+// as every status update wakes up the applications depending on this one.
+func (r *reconciler) setWaitingCondition(ctx context.Context, appInstallation *appskubermaticv1.ApplicationInstallation, reason, message string) error {
+	condition := appInstallation.Status.Conditions[appskubermaticv1.Ready]
+	if condition.Status == corev1.ConditionFalse && condition.Reason == reason && condition.Message == message {
+		return nil
+	}
+
+	oldAppInstallation := appInstallation.DeepCopy()
+	appInstallation.SetCondition(appskubermaticv1.Ready, corev1.ConditionFalse, reason, message)
+	if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// isDependencyReady returns true if an ApplicationInstallation matching the dependency is Ready and not being deleted.
+func isDependencyReady(appInstallation *appskubermaticv1.ApplicationInstallation, dep appskubermaticv1.ApplicationDependency, installations []appskubermaticv1.ApplicationInstallation) bool {
+	for i := range installations {
+		candidate := &installations[i]
+		if dep.Matches(appInstallation, candidate) && candidate.DeletionTimestamp.IsZero() && candidate.Status.Conditions[appskubermaticv1.Ready].Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOn returns true if one of the dependencies of appInstallation matches candidate.
+func dependsOn(appInstallation, candidate *appskubermaticv1.ApplicationInstallation) bool {
+	for _, dep := range appInstallation.Spec.DependsOn {
+		if dep.Matches(appInstallation, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSameInstallation(a, b *appskubermaticv1.ApplicationInstallation) bool {
+	return a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+func describeDependency(appInstallation *appskubermaticv1.ApplicationInstallation, dep appskubermaticv1.ApplicationDependency) string {
+	if dep.ApplicationRef != nil {
+		return fmt.Sprintf("application %s@%s", dep.ApplicationRef.Name, dep.ApplicationRef.Version)
+	}
+
+	namespace := dep.Namespace
+	if namespace == "" {
+		namespace = appInstallation.Namespace
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: dep.Name}.String()
+}
+
+// enqueueDependencyWaiters fan-out updates from an ApplicationInstallation to the ApplicationInstallations that wait for it:
+// the ones depending on it and waiting for it to be ready, and its dependencies waiting for it to be uninstalled.
+func enqueueDependencyWaiters(userClient ctrlruntimeclient.Client) func(context.Context, ctrlruntimeclient.Object) []reconcile.Request {
+	return func(ctx context.Context, obj ctrlruntimeclient.Object) []reconcile.Request {
+		changed, ok := obj.(*appskubermaticv1.ApplicationInstallation)
+		if !ok {
+			return []reconcile.Request{}
+		}
+
+		appList := &appskubermaticv1.ApplicationInstallationList{}
+		if err := userClient.List(ctx, appList); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list applicationInstallation: %w", err))
+			return []reconcile.Request{}
+		}
+
+		var res []reconcile.Request
+		for i := range appList.Items {
+			appInstallation := &appList.Items[i]
+			if isSameInstallation(appInstallation, changed) {
+				continue
+			}
+
+			switch appInstallation.Status.Conditions[appskubermaticv1.Ready].Reason {
+			case waitingForDependenciesReason:
+				if !dependsOn(appInstallation, changed) {
+					continue
+				}
+			case waitingForDependentsReason:
+				if !dependsOn(changed, appInstallation) {
+					continue
+				}
+			default:
+				continue
+			}
+
+			res = append(res, reconcile.Request{NamespacedName: types.NamespacedName{Name: appInstallation.Name, Namespace: appInstallation.Namespace}})
+		}
+		return res
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWaitForDependencies(t *testing.T) {
+	testCases := []struct {
+		name            string
+		dependsOn       []appskubermaticv1.ApplicationDependency
+		objects         []ctrlruntimeclient.Object
+		expectedWaiting bool
+		expectedMessage string
+	}{
+		{
+			name:            "scenario 1: application without dependencies is not waiting",
+			expectedWaiting: false,
+		},
+		{
+			name:            "scenario 2: missing dependency",
+			dependsOn:       []appskubermaticv1.ApplicationDependency{{Name: "cert-manager"}},
+			expectedWaiting: true,
+			expectedMessage: "waiting for dependencies to be ready: apps/cert-manager",
+		},
+		{
+			name:            "scenario 3: dependency is not ready",
+			dependsOn:       []appskubermaticv1.ApplicationDependency{{Name: "cert-manager"}},
+			objects:         []ctrlruntimeclient.Object{genDependency("cert-manager", corev1.ConditionFalse, false)},
+			expectedWaiting: true,
+			expectedMessage: "waiting for dependencies to be ready: apps/cert-manager",
+		},
+		{
+			name:            "scenario 4: dependency is ready",
+			dependsOn:       []appskubermaticv1.ApplicationDependency{{Name: "cert-manager"}},
+			objects:         []ctrlruntimeclient.Object{genDependency("cert-manager", corev1.ConditionTrue, false)},
+			expectedWaiting: false,
+		},
+		{
+			name:            "scenario 5: dependency is ready but being deleted",
+			dependsOn:       []appskubermaticv1.ApplicationDependency{{Name: "cert-manager"}},
+			objects:         []ctrlruntimeclient.Object{genDependency("cert-manager", corev1.ConditionTrue, true)},
+			expectedWaiting: true,
+			expectedMessage: "waiting for dependencies to be ready: apps/cert-manager",
+		},
+		{
+			name: "scenario 6: dependency by applicationRef is ready",
+			dependsOn: []appskubermaticv1.ApplicationDependency{
+				{ApplicationRef: &appskubermaticv1.ApplicationRef{Name: "cert-manager", Version: "1.0.0"}},
+			},
+			objects:         []ctrlruntimeclient.Object{genDependency("cert-manager", corev1.ConditionTrue, false)},
+			expectedWaiting: false,
+		},
+		{
+			name: "scenario 7: only the unsatisfied dependencies are reported",
+			dependsOn: []appskubermaticv1.ApplicationDependency{
+				{Name: "cert-manager"},
+				{ApplicationRef: &appskubermaticv1.ApplicationRef{Name: "cert-manager", Version: "2.0.0"}},
+			},
+			objects:         []ctrlruntimeclient.Object{genDependency("cert-manager", corev1.ConditionTrue, false)},
+			expectedWaiting: true,
+			expectedMessage: "waiting for dependencies to be ready: application cert-manager@2.0.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			appInstall := genApplicationInstallation("ingress", "ingress", "1.0.0", 0, 1, 0)
+			appInstall.Spec.DependsOn = tc.dependsOn
+
+			userClient := kubermaticfake.NewClientBuilder().WithObjects(append(tc.objects, appInstall)...).Build()
+			r := reconciler{log: kubermaticlog.Logger, userClient: userClient}
+
+			waiting, err := r.waitForDependencies(ctx, kubermaticlog.Logger, appInstall)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if waiting != tc.expectedWaiting {
+				t.Fatalf("expected waiting=%v, got %v", tc.expectedWaiting, waiting)
+			}
+
+			if tc.expectedWaiting {
+				current := &appskubermaticv1.ApplicationInstallation{}
+				if err := userClient.Get(ctx, types.NamespacedName{Name: "ingress", Namespace: applicationNamespace}, current); err != nil {
+					t.Fatalf("failed to get application installation: %v", err)
+				}
+
+				condition := current.Status.Conditions[appskubermaticv1.Ready]
+				if condition.Status != corev1.ConditionFalse || condition.Reason != waitingForDependenciesReason || condition.Message != tc.expectedMessage {
+					t.Fatalf("unexpected ready condition: %+v", condition)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForDependents(t *testing.T) {
+	testCases := []struct {
+		name            string
+		dependent       *appskubermaticv1.ApplicationInstallation
+		expectedWaiting bool
+	}{
+		{
+			name:            "scenario 1: dependent being deleted blocks the uninstallation",
+			dependent:       genDependent("ingress", "cert-manager", true),
+			expectedWaiting: true,
+		},
+		{
+			name:            "scenario 2: dependent not being deleted does not block the uninstallation",
+			dependent:       genDependent("ingress", "cert-manager", false),
+			expectedWaiting: false,
+		},
+		{
+			name:            "scenario 3: unrelated application being deleted does not block the uninstallation",
+			dependent:       genDependent("ingress", "external-dns", true),
+			expectedWaiting: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appInstall := genDependency("cert-manager", corev1.ConditionTrue, true)
+
+			userClient := kubermaticfake.NewClientBuilder().WithObjects(appInstall, tc.dependent).Build()
+			r := reconciler{log: kubermaticlog.Logger, userClient: userClient}
+
+			waiting, err := r.waitForDependents(context.Background(), kubermaticlog.Logger, appInstall)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if waiting != tc.expectedWaiting {
+				t.Fatalf("expected waiting=%v, got %v", tc.expectedWaiting, waiting)
+			}
+		})
+	}
+}
+
+func TestEnqueueDependencyWaiters(t *testing.T) {
+	waitingFor := func(appInstall *appskubermaticv1.ApplicationInstallation, reason string) *appskubermaticv1.ApplicationInstallation {
+		appInstall.SetCondition(appskubermaticv1.Ready, corev1.ConditionFalse, reason, "")
+		return appInstall
+	}
+
+	g := gomega.NewGomegaWithT(t)
+
+	userClient := kubermaticfake.
+		NewClientBuilder().
+		WithObjects(
+			waitingFor(genDependent("ingress", "cert-manager", false), waitingForDependenciesReason),
+			waitingFor(genDependent("dashboard", "ingress", false), waitingForDependenciesReason),
+			genDependent("monitoring", "cert-manager", false),
+			waitingFor(genDependency("trust-manager", corev1.ConditionTrue, true), waitingForDependentsReason),
+			waitingFor(genDependency("external-dns", corev1.ConditionTrue, true), waitingForDependentsReason),
+		).
+		Build()
+
+	changed := genDependent("cert-manager", "trust-manager", false)
+
+	actual := enqueueDependencyWaiters(userClient)(context.Background(), changed)
+
+	g.Expect(actual).Should(gomega.ConsistOf(
+		// depends on cert-manager and waits for it to be ready
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "ingress", Namespace: applicationNamespace}},
+		// dependency of cert-manager waiting for it to be uninstalled
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "trust-manager", Namespace: applicationNamespace}},
+	))
+}
+
+func genDependency(name string, ready corev1.ConditionStatus, deleting bool) *appskubermaticv1.ApplicationInstallation {
+	appInstall := genApplicationInstallation(name, name, "1.0.0", 0, 1, 1)
+	appInstall.SetCondition(appskubermaticv1.Ready, ready, "", "")
+
+	if deleting {
+		appInstall.Finalizers = []string{appskubermaticv1.ApplicationInstallationCleanupFinalizer}
+		appInstall.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+	}
+
+	return appInstall
+}
+
+func genDependent(name string, dependency string, deleting bool) *appskubermaticv1.ApplicationInstallation {
+	appInstall := genDependency(name, corev1.ConditionTrue, deleting)
+	appInstall.Spec.DependsOn = []appskubermaticv1.ApplicationDependency{{Name: dependency}}
+
+	return appInstall
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Event raised when the objects of an applicationInstallation differ from their desired state.
+	applicationDriftDetectedEvent = "ApplicationDriftDetected"
+
+	// defaultDriftDetectionInterval is the drift detection interval used if none is configured.
+	defaultDriftDetectionInterval = 10 * time.Minute
+)
+
+// handleDriftDetection detects the drift of the installed application and records it in the status. It returns true if
+// the drift must be remediated by upgrading the application.
+func (r *reconciler) handleDriftDetection(ctx context.Context, log *zap.SugaredLogger, appInstallation *appskubermaticv1.ApplicationInstallation) (bool, error) {
+	log.Debug("Detecting drift")
+
+	drifted, err := r.appInstaller.DetectDrift(ctx, log, r.seedClient, r.userClient, appInstallation)
+	if err != nil {
+		return false, fmt.Errorf("failed to detect drift: %w", err)
+	}
+
+	remediate := len(drifted) > 0 && appInstallation.Spec.DriftDetection.RemediationPolicy == appskubermaticv1.DriftRemediationPolicyUpgrade
+
+	oldAppInstallation := appInstallation.DeepCopy()
+	if appInstallation.Status.Drift == nil {
+		appInstallation.Status.Drift = &appskubermaticv1.ApplicationDrift{}
+	}
+	appInstallation.Status.Drift.LastCheckTime = metav1.Now()
+	appInstallation.Status.Drift.Objects = drifted
+	if remediate {
+		appInstallation.Status.Drift.LastRemediationTime = metav1.Now()
+	}
+
+	if err := r.userClient.Status().Patch(ctx, appInstallation, ctrlruntimeclient.MergeFrom(oldAppInstallation)); err != nil {
+		return false, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	if len(drifted) > 0 {
+		message := fmt.Sprintf("%d object(s) differ from their desired state", len(drifted))
+		if remediate {
+			message += ", upgrading application"
+		}
+		r.traceWarning(appInstallation, log, applicationDriftDetectedEvent, message)
+	}
+
+	return remediate, nil
+}
+
+// isInstalledAtCurrentGeneration returns true if the current spec of the appInstallation has been successfully installed.
+func isInstalledAtCurrentGeneration(appInstallation *appskubermaticv1.ApplicationInstallation) bool {
+	condition := appInstallation.Status.Conditions[appskubermaticv1.Ready]
+	return condition.Status == corev1.ConditionTrue && condition.ObservedGeneration == appInstallation.Generation
+}
+
+// requeueAfter returns the duration after which the appInstallation must be reconciled again, i.e. the shortest of the
+// reconciliation interval and the drift detection interval. Zero means no periodic reconciliation.
+func requeueAfter(appInstallation *appskubermaticv1.ApplicationInstallation) time.Duration {
+	interval := appInstallation.Spec.ReconciliationInterval.Duration
+
+	if driftDetection := appInstallation.Spec.DriftDetection; driftDetection != nil {
+		driftInterval := driftDetection.Interval.Duration
+		if driftInterval <= 0 {
+			driftInterval = defaultDriftDetectionInterval
+		}
+
+		if interval <= 0 || driftInterval < interval {
+			interval = driftInterval
+		}
+	}
+
+	return interval
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationinstallationcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/applications/fake"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	kubermaticfake "k8c.io/kubermatic/v2/pkg/test/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHandleDriftDetection(t *testing.T) {
+	driftedConfigMap := appskubermaticv1.DriftedObject{
+		ManagedResource: appskubermaticv1.ManagedResource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "apps", Name: "settings"},
+		Fields:          []string{"data.key"},
+		Managers:        []string{"kubectl-edit"},
+	}
+
+	testCases := []struct {
+		name              string
+		policy            appskubermaticv1.DriftRemediationPolicy
+		drifted           []appskubermaticv1.DriftedObject
+		expectedRemediate bool
+		expectedEvents    int
+	}{
+		{
+			name:              "scenario 1: no drift",
+			policy:            appskubermaticv1.DriftRemediationPolicyUpgrade,
+			expectedRemediate: false,
+			expectedEvents:    0,
+		},
+		{
+			name:              "scenario 2: drift is only reported with the Report policy",
+			policy:            appskubermaticv1.DriftRemediationPolicyReport,
+			drifted:           []appskubermaticv1.DriftedObject{driftedConfigMap},
+			expectedRemediate: false,
+			expectedEvents:    1,
+		},
+		{
+			name:              "scenario 3: drift is remediated with the Upgrade policy",
+			policy:            appskubermaticv1.DriftRemediationPolicyUpgrade,
+			drifted:           []appskubermaticv1.DriftedObject{driftedConfigMap},
+			expectedRemediate: true,
+			expectedEvents:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			appInstall := genApplicationInstallation("ingress", "ingress", "1.0.0", 0, 1, 1)
+			appInstall.Spec.DriftDetection = &appskubermaticv1.DriftDetection{RemediationPolicy: tc.policy}
+
+			userClient := kubermaticfake.NewClientBuilder().WithObjects(appInstall).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := reconciler{
+				log:          kubermaticlog.Logger,
+				userClient:   userClient,
+				userRecorder: recorder,
+				appInstaller: fake.CustomApplicationInstaller{
+					DetectDriftFunc: func(ctx context.Context, log *zap.SugaredLogger, seedClient ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client, applicationInstallation *appskubermaticv1.ApplicationInstallation) ([]appskubermaticv1.DriftedObject, error) {
+						return tc.drifted, nil
+					},
+				},
+			}
+
+			remediate, err := r.handleDriftDetection(ctx, kubermaticlog.Logger, appInstall)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if remediate != tc.expectedRemediate {
+				t.Fatalf("expected remediate=%v, got %v", tc.expectedRemediate, remediate)
+			}
+			if len(recorder.Events) != tc.expectedEvents {
+				t.Fatalf("expected %d event(s), got %d", tc.expectedEvents, len(recorder.Events))
+			}
+
+			current := &appskubermaticv1.ApplicationInstallation{}
+			if err := userClient.Get(ctx, types.NamespacedName{Name: "ingress", Namespace: applicationNamespace}, current); err != nil {
+				t.Fatalf("failed to get application installation: %v", err)
+			}
+
+			drift := current.Status.Drift
+			if drift == nil || drift.LastCheckTime.IsZero() {
+				t.Fatalf("expected drift check to be recorded in status, got %+v", drift)
+			}
+			if len(drift.Objects) != len(tc.drifted) {
+				t.Fatalf("expected %d drifted object(s) in status, got %d", len(tc.drifted), len(drift.Objects))
+			}
+			if drift.LastRemediationTime.IsZero() == tc.expectedRemediate {
+				t.Fatalf("expected remediation time to be set=%v, got %v", tc.expectedRemediate, drift.LastRemediationTime)
+			}
+		})
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		reconciliationInterval time.Duration
+		driftDetection         *appskubermaticv1.DriftDetection
+		expected               time.Duration
+	}{
+		{
+			name:     "scenario 1: no periodic reconciliation",
+			expected: 0,
+		},
+		{
+			name:                   "scenario 2: reconciliation interval only",
+			reconciliationInterval: time.Hour,
+			expected:               time.Hour,
+		},
+		{
+			name:           "scenario 3: drift detection with default interval",
+			driftDetection: &appskubermaticv1.DriftDetection{},
+			expected:       defaultDriftDetectionInterval,
+		},
+		{
+			name:                   "scenario 4: shorter drift detection interval wins",
+			reconciliationInterval: time.Hour,
+			driftDetection:         &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: 5 * time.Minute}},
+			expected:               5 * time.Minute,
+		},
+		{
+			name:                   "scenario 5: shorter reconciliation interval wins",
+			reconciliationInterval: time.Minute,
+			driftDetection:         &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: 5 * time.Minute}},
+			expected:               time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appInstall := genApplicationInstallation("ingress", "ingress", "1.0.0", 0, 1, 1)
+			appInstall.Spec.ReconciliationInterval = metav1.Duration{Duration: tc.reconciliationInterval}
+			appInstall.Spec.DriftDetection = tc.driftDetection
+
+			if actual := requeueAfter(appInstall); actual != tc.expected {
+				t.Fatalf("expected requeue after %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
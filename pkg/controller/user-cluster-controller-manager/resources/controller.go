@@ -98,6 +98,7 @@ func Add(
 	clusterName string,
 	nutanixCSIEnabled bool,
 	konnectivity bool,
+	konnectivityMigration bool,
 	konnectivityServerHost string,
 	konnectivityServerPort int,
 	konnectivityKeepaliveTime string,
@@ -129,6 +130,7 @@ func Add(
 		clusterName:               clusterName,
 		nutanixCSIEnabled:         nutanixCSIEnabled,
 		isKonnectivityEnabled:     konnectivity,
+		isKonnectivityMigrating:   konnectivityMigration,
 		konnectivityServerHost:    konnectivityServerHost,
 		konnectivityServerPort:    konnectivityServerPort,
 		konnectivityKeepaliveTime: konnectivityKeepaliveTime,
@@ -285,6 +287,7 @@ type reconciler struct {
 	clusterName               string
 	nutanixCSIEnabled         bool
 	isKonnectivityEnabled     bool
+	isKonnectivityMigrating   bool
 	konnectivityServerHost    string
 	konnectivityServerPort    int
 	konnectivityKeepaliveTime string
@@ -323,6 +326,10 @@ func (r *reconciler) caCert(ctx context.Context) (*triple.KeyPair, error) {
 	return resources.GetClusterRootCA(ctx, r.namespace, r.seedClient)
 }
 
+func (r *reconciler) rootCABundle(ctx context.Context) ([]byte, error) {
+	return resources.GetClusterRootCABundle(ctx, r.namespace, r.seedClient)
+}
+
 func (r *reconciler) openVPNCA(ctx context.Context) (*resources.ECDSAKeyPair, error) {
 	return resources.GetOpenVPNCA(ctx, r.namespace, r.seedClient)
 }
@@ -54,6 +54,7 @@ import (
 	operatingsystemmanager "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/operating-system-manager"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/prometheus"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/scheduler"
+	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/service"
 	systembasicuser "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/system-basic-user"
 	userauth "k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/user-auth"
 	"k8c.io/kubermatic/v2/pkg/controller/user-cluster-controller-manager/resources/resources/usersshkeys"
@@ -79,6 +80,10 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get caCert: %w", err)
 	}
+	rootCABundle, err := r.rootCABundle(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rootCABundle: %w", err)
+	}
 	userSSHKeys, err := r.userSSHKeys(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get userSSHKeys: %w", err)
@@ -90,6 +95,7 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 
 	data := reconcileData{
 		caCert:       caCert,
+		rootCABundle: rootCABundle,
 		userSSHKeys:  userSSHKeys,
 		cloudConfig:  cloudConfig,
 		ccmMigration: r.ccmMigration || r.ccmMigrationCompleted,
@@ -107,7 +113,7 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 		return fmt.Errorf("failed to fetch cluster networking data: %w", err)
 	}
 
-	if !r.isKonnectivityEnabled {
+	if r.isOpenVPNClientDeployed() {
 		data.openVPNCACert, err = r.openVPNCA(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get openVPN CA cert: %w", err)
@@ -270,12 +276,15 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 	}
 
 	// This code supports switching between OpenVPN and Konnectivity setup (in both directions).
-	// It can be removed one release after deprecating OpenVPN.
-	if r.isKonnectivityEnabled {
+	// It can be removed one release after deprecating OpenVPN. While the cluster is migrated to
+	// Konnectivity, both setups are kept.
+	switch {
+	case r.isKonnectivityMigrating:
+	case r.isKonnectivityEnabled:
 		if err := r.ensureOpenVPNSetupIsRemoved(ctx); err != nil {
 			return err
 		}
-	} else {
+	default:
 		if err := r.ensureKonnectivitySetupIsRemoved(ctx); err != nil {
 			return err
 		}
@@ -354,10 +363,12 @@ func (r *reconciler) reconcileServiceAccounts(ctx context.Context, data reconcil
 		}
 	}
 
-	if r.isKonnectivityEnabled {
+	if r.isKonnectivityAgentDeployed() {
 		creators = []reconciling.NamedServiceAccountReconcilerFactory{
 			konnectivity.ServiceAccountReconciler(),
-			metricsserver.ServiceAccountReconciler(), // required only if metrics-server is running in user cluster
+		}
+		if r.isKonnectivityEnabled {
+			creators = append(creators, metricsserver.ServiceAccountReconciler()) // required only if metrics-server is running in user cluster
 		}
 		if err := reconciling.ReconcileServiceAccounts(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 			return fmt.Errorf("failed to reconcile ServiceAccounts in the namespace %s: %w", metav1.NamespaceSystem, err)
@@ -623,7 +634,7 @@ func (r *reconciler) reconcileClusterRoleBindings(ctx context.Context, data reco
 		creators = append(creators, mlamonitoringagent.ClusterRoleBindingReconciler())
 	}
 
-	if r.isKonnectivityEnabled {
+	if r.isKonnectivityAgentDeployed() {
 		creators = append(creators, konnectivity.ClusterRoleBindingReconciler())
 	}
 
@@ -702,6 +713,7 @@ func (r *reconciler) reconcileValidatingWebhookConfigurations(ctx context.Contex
 	creators := []reconciling.NamedValidatingWebhookConfigurationReconcilerFactory{
 		applications.ApplicationInstallationValidatingWebhookConfigurationReconciler(data.caCert.Cert, r.namespace),
 		machine.ValidatingWebhookConfigurationReconciler(data.caCert.Cert, r.namespace),
+		service.ValidatingWebhookConfigurationReconciler(data.caCert.Cert, r.namespace),
 	}
 	if r.opaIntegration {
 		creators = append(creators, gatekeeper.ValidatingWebhookConfigurationReconciler(r.opaWebhookTimeout))
@@ -786,7 +798,7 @@ func (r *reconciler) reconcileEndpoints(ctx context.Context, data reconcileData)
 
 func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData) error {
 	creators := []reconciling.NamedConfigMapReconcilerFactory{
-		machinecontroller.ClusterInfoConfigMapReconciler(r.clusterURL.String(), data.caCert.Cert),
+		machinecontroller.ClusterInfoConfigMapReconciler(r.clusterURL.String(), data.rootCABundle),
 	}
 
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, metav1.NamespacePublic, r.Client); err != nil {
@@ -806,7 +818,7 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 				},
 			},
 		}
-		if !r.isKonnectivityEnabled {
+		if r.isOpenVPNClientDeployed() {
 			// add OpenVPN server port listener if Konnectivity is NOT enabled
 			envoyConfig.Listeners = append(envoyConfig.Listeners,
 				envoyagent.Listener{
@@ -819,14 +831,14 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 			cabundle.ConfigMapReconciler(r.caBundle),
 			envoyagent.ConfigMapReconciler(envoyConfig),
 		}
-		if !r.isKonnectivityEnabled {
+		if r.isOpenVPNClientDeployed() {
 			creators = append(creators, openvpn.ClientConfigConfigMapReconciler(r.tunnelingAgentIP.String(), r.openvpnServerPort))
 		}
 	} else {
 		creators = []reconciling.NamedConfigMapReconcilerFactory{
 			cabundle.ConfigMapReconciler(r.caBundle),
 		}
-		if !r.isKonnectivityEnabled {
+		if r.isOpenVPNClientDeployed() {
 			creators = append(creators, openvpn.ClientConfigConfigMapReconciler(r.clusterURL.Hostname(), r.openvpnServerPort))
 		}
 	}
@@ -873,9 +885,10 @@ func (r *reconciler) reconcileSecrets(ctx context.Context, data reconcileData) e
 	creators := []reconciling.NamedSecretReconcilerFactory{
 		cloudcontroller.CloudConfig(data.cloudConfig, resources.CloudConfigSecretName),
 	}
-	if !r.isKonnectivityEnabled {
+	if r.isOpenVPNClientDeployed() {
 		creators = append(creators, openvpn.ClientCertificate(data.openVPNCACert))
-	} else {
+	}
+	if r.isKonnectivityEnabled {
 		// required only if metrics-server is running in user cluster
 		creators = append(creators, metricsserver.TLSServingCertSecretReconciler(
 			func() (*triple.KeyPair, error) {
@@ -1083,10 +1096,12 @@ func (r *reconciler) reconcileDeployments(ctx context.Context, data reconcileDat
 		}
 	}
 
-	if r.isKonnectivityEnabled {
+	if r.isKonnectivityAgentDeployed() {
 		creators := []reconciling.NamedDeploymentReconcilerFactory{
 			konnectivity.DeploymentReconciler(data.clusterVersion, r.konnectivityServerHost, r.konnectivityServerPort, r.konnectivityKeepaliveTime, r.imageRewriter),
-			metricsserver.DeploymentReconciler(r.imageRewriter), // deploy metrics-server in user cluster
+		}
+		if r.isKonnectivityEnabled {
+			creators = append(creators, metricsserver.DeploymentReconciler(r.imageRewriter)) // deploy metrics-server in user cluster
 		}
 		if err := reconciling.ReconcileDeployments(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 			return fmt.Errorf("failed to reconcile Deployments in namespace %s: %w", metav1.NamespaceSystem, err)
@@ -1107,8 +1122,11 @@ func (r *reconciler) reconcileNetworkPolicies(ctx context.Context, data reconcil
 			usersshkeys.NetworkPolicyReconciler(data.k8sServiceEndpointAddress, int(data.k8sServiceEndpointPort), data.k8sServiceApiIP.String()))
 	}
 
+	if r.isKonnectivityAgentDeployed() {
+		namedNetworkPolicyReconcilerFactorys = append(namedNetworkPolicyReconcilerFactorys, konnectivity.NetworkPolicyReconciler())
+	}
 	if r.isKonnectivityEnabled {
-		namedNetworkPolicyReconcilerFactorys = append(namedNetworkPolicyReconcilerFactorys, metricsserver.NetworkPolicyReconciler(), konnectivity.NetworkPolicyReconciler())
+		namedNetworkPolicyReconcilerFactorys = append(namedNetworkPolicyReconcilerFactorys, metricsserver.NetworkPolicyReconciler())
 	}
 
 	if err := reconciling.ReconcileNetworkPolicies(ctx, namedNetworkPolicyReconcilerFactorys, metav1.NamespaceSystem, r.Client); err != nil {
@@ -1131,11 +1149,11 @@ func (r *reconciler) reconcilePodDisruptionBudgets(ctx context.Context) error {
 			return fmt.Errorf("failed to reconcile PodDisruptionBudgets in namespace %s: %w", resources.GatekeeperNamespace, err)
 		}
 	}
+	if r.isKonnectivityAgentDeployed() {
+		creators = append(creators, konnectivity.PodDisruptionBudgetReconciler())
+	}
 	if r.isKonnectivityEnabled {
-		creators = append(creators,
-			konnectivity.PodDisruptionBudgetReconciler(),
-			metricsserver.PodDisruptionBudgetReconciler(),
-		)
+		creators = append(creators, metricsserver.PodDisruptionBudgetReconciler())
 	}
 	if err := reconciling.ReconcilePodDisruptionBudgets(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile PodDisruptionBudgets: %w", err)
@@ -1145,6 +1163,7 @@ func (r *reconciler) reconcilePodDisruptionBudgets(ctx context.Context) error {
 
 type reconcileData struct {
 	caCert           *triple.KeyPair
+	rootCABundle     []byte
 	openVPNCACert    *resources.ECDSAKeyPair
 	mlaGatewayCACert *resources.ECDSAKeyPair
 	userSSHKeys      map[string][]byte
@@ -1363,6 +1382,18 @@ func (r *reconciler) ensureMLAIsRemoved(ctx context.Context) error {
 	return nil
 }
 
+// isKonnectivityAgentDeployed returns true if the Konnectivity agent has to be deployed, which
+// is also the case while the control plane still uses OpenVPN during a migration.
+func (r *reconciler) isKonnectivityAgentDeployed() bool {
+	return r.isKonnectivityEnabled || r.isKonnectivityMigrating
+}
+
+// isOpenVPNClientDeployed returns true if the OpenVPN client has to be kept, which is also the
+// case while the control plane already uses Konnectivity during a migration.
+func (r *reconciler) isOpenVPNClientDeployed() bool {
+	return !r.isKonnectivityEnabled || r.isKonnectivityMigrating
+}
+
 func (r *reconciler) ensureOpenVPNSetupIsRemoved(ctx context.Context) error {
 	for _, resource := range openvpn.ResourcesForDeletion() {
 		err := r.Client.Delete(ctx, resource)
@@ -17,11 +17,9 @@ limitations under the License.
 package machinecontroller
 
 import (
-	"crypto/x509"
 	"fmt"
 
 	"k8c.io/kubermatic/v2/pkg/resources"
-	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,8 +27,10 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
-// ClusterInfoConfigMapReconciler returns the func to create/update the ConfigMap.
-func ClusterInfoConfigMapReconciler(url string, caCert *x509.Certificate) reconciling.NamedConfigMapReconcilerFactory {
+// ClusterInfoConfigMapReconciler returns the func to create/update the ConfigMap. The kubeconfig
+// trusts all certificates in the PEM-encoded caBundle, so that nodes bootstrapped during a CA
+// rotation trust both the old and the new root CA.
+func ClusterInfoConfigMapReconciler(url string, caBundle []byte) reconciling.NamedConfigMapReconcilerFactory {
 	return func() (string, reconciling.ConfigMapReconciler) {
 		return resources.ClusterInfoConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Data == nil {
@@ -43,7 +43,7 @@ func ClusterInfoConfigMapReconciler(url string, caCert *x509.Certificate) reconc
 			kubeconfig.Clusters = map[string]*clientcmdapi.Cluster{
 				"": {
 					Server:                   url,
-					CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+					CertificateAuthorityData: caBundle,
 				},
 			}
 
@@ -94,11 +94,12 @@ func DefaultClusterSpec(ctx context.Context, spec *kubermaticv1.ClusterSpec, tem
 		return fieldErr
 	}
 
-	// Enforce audit logging
+	// Enforce audit logging, but keep a custom policy or backend
 	if datacenter.Spec.EnforceAuditLogging {
-		spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-			Enabled: true,
+		if spec.AuditLogging == nil {
+			spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{}
 		}
+		spec.AuditLogging.Enabled = true
 	}
 
 	// Enforce PodSecurityPolicy
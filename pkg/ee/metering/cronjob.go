@@ -25,7 +25,11 @@
 package metering
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"path"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
@@ -36,27 +40,33 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
+const (
+	// ReportConfigurationLabel is set on the Jobs of scheduled report runs and references their report configuration.
+	ReportConfigurationLabel = "metering.k8c.io/report-configuration"
+	// ReportLabel is set on the Jobs of ad-hoc reports and references their MeteringReport.
+	ReportLabel = "metering.k8c.io/report"
+
+	// adhocReportTolerance is how far the end of an ad-hoc report's time range may be from the
+	// time the report is generated.
+	adhocReportTolerance = time.Hour
+)
+
 // CronJobReconciler returns the func to create/update the metering report cronjob.
 func CronJobReconciler(reportName string, mrc *kubermaticv1.MeteringReportConfiguration, caBundleName string, getRegistry registry.ImageRewriter, seed *kubermaticv1.Seed) reconciling.NamedCronJobReconcilerFactory {
 	return func() (string, reconciling.CronJobReconciler) {
 		return reportName, func(job *batchv1.CronJob) (*batchv1.CronJob, error) {
-			var args []string
-			args = append(args, fmt.Sprintf("--ca-bundle=%s", "/opt/ca-bundle/ca-bundle.pem"))
-			args = append(args, fmt.Sprintf("--prometheus-api=http://%s.%s.svc", prometheus.Name, seed.Namespace))
-			args = append(args, fmt.Sprintf("--output-dir=%s", reportName))
-			args = append(args, fmt.Sprintf("--output-prefix=%s", seed.Name))
-
+			var timeRange []string
 			if mrc.Monthly {
-				args = append(args, "--last-month")
+				timeRange = append(timeRange, "--last-month")
 			} else {
-				args = append(args, fmt.Sprintf("--last-number-of-days=%d", mrc.Interval))
+				timeRange = append(timeRange, fmt.Sprintf("--last-number-of-days=%d", mrc.Interval))
 			}
 
-			// needs to be last
-			args = append(args, mrc.Types...)
+			args := reportArgs(seed, reportName, timeRange, mrc.Types)
 
 			if job.Labels == nil {
 				job.Labels = make(map[string]string)
@@ -64,89 +74,176 @@ func CronJobReconciler(reportName string, mrc *kubermaticv1.MeteringReportConfig
 			job.Labels[common.NameLabel] = reportName
 			job.Labels[common.ComponentLabel] = meteringName
 
+			// the labels allow the report controller to record every scheduled run as MeteringReport
+			job.Spec.JobTemplate.Labels = map[string]string{
+				common.ComponentLabel:    meteringName,
+				ReportConfigurationLabel: reportName,
+			}
+
 			job.Spec.Schedule = mrc.Schedule
-			job.Spec.JobTemplate.Spec.Parallelism = ptr.To[int32](1)
-			job.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName = ""
-			job.Spec.JobTemplate.Spec.Template.Spec.DeprecatedServiceAccount = ""
-			job.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
-			job.Spec.JobTemplate.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
+			reconcileReportJobSpec(&job.Spec.JobTemplate.Spec, reportName, args, caBundleName, getRegistry)
+
+			return job, nil
+		}
+	}
+}
+
+// ReportJob returns the Job generating an ad-hoc MeteringReport at the given time. The report files are
+// written below adhoc/<report name> in the bucket. If the report references a report configuration, its
+// report types are used unless the report specifies its own.
+func ReportJob(report *kubermaticv1.MeteringReport, jobName string, caBundleName string, getRegistry registry.ImageRewriter, seed *kubermaticv1.Seed, now time.Time) (*batchv1.Job, error) {
+	var mrc kubermaticv1.MeteringReportConfiguration
+	if seed.Spec.Metering != nil && seed.Spec.Metering.ReportConfigurations[report.Spec.ReportConfiguration] != nil {
+		mrc = *seed.Spec.Metering.ReportConfigurations[report.Spec.ReportConfiguration]
+	}
+
+	types := mrc.Types
+	if len(report.Spec.Types) > 0 {
+		types = report.Spec.Types
+	}
+
+	timeRange, err := adhocTimeRange(report.Spec.From.Time, report.Spec.To.Time, now)
+	if err != nil {
+		return nil, err
+	}
+
+	args := reportArgs(seed, AdhocReportOutputDir(report), timeRange, types)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: seed.Namespace,
+			Labels: map[string]string{
+				common.ComponentLabel: meteringName,
+				ReportLabel:           report.Name,
+			},
+		},
+	}
+	job.Spec.BackoffLimit = ptr.To[int32](3)
+	reconcileReportJobSpec(&job.Spec, "report", args, caBundleName, getRegistry)
+
+	return job, nil
+}
+
+// adhocTimeRange returns the metering tool arguments for the time range of an ad-hoc report. Like for
+// scheduled reports, the tool can only report relative to the time it runs: either the previous calendar
+// month, or a number of days up to now. Other time ranges are rejected.
+func adhocTimeRange(from, to, now time.Time) ([]string, error) {
+	from, to, now = from.UTC(), to.UTC(), now.UTC()
+
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if from.Equal(thisMonth.AddDate(0, -1, 0)) && to.Equal(thisMonth) {
+		return []string{"--last-month"}, nil
+	}
+
+	if offset := now.Sub(to); offset > adhocReportTolerance || offset < -adhocReportTolerance {
+		return nil, errors.New("the reported time range must either be the previous calendar month or end at the time the report is requested")
+	}
+
+	// the range is rounded up to full days
+	days := int(math.Ceil(to.Sub(from).Hours() / 24))
+
+	return []string{fmt.Sprintf("--last-number-of-days=%d", days)}, nil
+}
 
-			job.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{
+// AdhocReportOutputDir returns the directory in the bucket the files of an ad-hoc report are written to.
+func AdhocReportOutputDir(report *kubermaticv1.MeteringReport) string {
+	return path.Join("adhoc", report.Name)
+}
+
+func reportArgs(seed *kubermaticv1.Seed, outputDir string, timeRange []string, types []string) []string {
+	var args []string
+	args = append(args, fmt.Sprintf("--ca-bundle=%s", "/opt/ca-bundle/ca-bundle.pem"))
+	args = append(args, fmt.Sprintf("--prometheus-api=http://%s.%s.svc", prometheus.Name, seed.Namespace))
+	args = append(args, fmt.Sprintf("--output-dir=%s", outputDir))
+	args = append(args, fmt.Sprintf("--output-prefix=%s", seed.Name))
+	args = append(args, timeRange...)
+
+	// needs to be last
+	return append(args, types...)
+}
+
+// reconcileReportJobSpec sets up the pod running the metering tool with the given arguments.
+func reconcileReportJobSpec(spec *batchv1.JobSpec, containerName string, args []string, caBundleName string, getRegistry registry.ImageRewriter) {
+	spec.Parallelism = ptr.To[int32](1)
+	spec.Template.Spec.ServiceAccountName = ""
+	spec.Template.Spec.DeprecatedServiceAccount = ""
+	spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
+
+	spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:            containerName,
+			Image:           getMeteringImage(getRegistry),
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"/metering"},
+			Args:            args,
+			Env: []corev1.EnvVar{
 				{
-					Name:            reportName,
-					Image:           getMeteringImage(getRegistry),
-					ImagePullPolicy: corev1.PullIfNotPresent,
-					Command:         []string{"/metering"},
-					Args:            args,
-					Env: []corev1.EnvVar{
-						{
-							Name: "S3_ENDPOINT",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: Endpoint,
-								},
-							},
-						},
-						{
-							Name: "S3_BUCKET",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: Bucket,
-								},
-							},
-						},
-						{
-							Name: "ACCESS_KEY_ID",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: AccessKey,
-								},
+					Name: "S3_ENDPOINT",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
 							},
+							Key: Endpoint,
 						},
-						{
-							Name: "SECRET_ACCESS_KEY",
-							ValueFrom: &corev1.EnvVarSource{
-								SecretKeyRef: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: SecretName,
-									},
-									Key: SecretKey,
-								},
+					},
+				},
+				{
+					Name: "S3_BUCKET",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
 							},
+							Key: Bucket,
 						},
 					},
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      "ca-bundle",
-							MountPath: "/opt/ca-bundle/",
-							ReadOnly:  true,
+				},
+				{
+					Name: "ACCESS_KEY_ID",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: SecretName,
+							},
+							Key: AccessKey,
 						},
 					},
 				},
-			}
-
-			job.Spec.JobTemplate.Spec.Template.Spec.Volumes = []corev1.Volume{
 				{
-					Name: "ca-bundle",
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
+					Name: "SECRET_ACCESS_KEY",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
 							LocalObjectReference: corev1.LocalObjectReference{
-								Name: caBundleName,
+								Name: SecretName,
 							},
+							Key: SecretKey,
 						},
 					},
 				},
-			}
-			return job, nil
-		}
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "ca-bundle",
+					MountPath: "/opt/ca-bundle/",
+					ReadOnly:  true,
+				},
+			},
+		},
+	}
+
+	spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: caBundleName,
+					},
+				},
+			},
+		},
 	}
 }
@@ -0,0 +1,346 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2023 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package reportcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/util/predicate"
+	"k8c.io/kubermatic/v2/pkg/ee/metering"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/registry"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller generates ad-hoc MeteringReports and records scheduled report runs as MeteringReports.
+	ControllerName = "kkp-metering-report-controller"
+
+	adhocJobPrefix = "metering-report-"
+)
+
+// reportFilesFunc looks up the files of a finished report, see metering.ReportFiles.
+type reportFilesFunc func(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed, outputDir string, start, end time.Time) (string, []string, string, error)
+
+type reconciler struct {
+	log               *zap.SugaredLogger
+	client            ctrlruntimeclient.Client
+	scheme            *runtime.Scheme
+	recorder          record.EventRecorder
+	seedGetter        provider.SeedGetter
+	overwriteRegistry string
+	reportFiles       reportFilesFunc
+	now               func() time.Time
+}
+
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	namespace string,
+	numWorkers int,
+	seedGetter provider.SeedGetter,
+	overwriteRegistry string,
+) error {
+	reconciler := &reconciler{
+		log:               log.Named(ControllerName),
+		client:            mgr.GetClient(),
+		scheme:            mgr.GetScheme(),
+		recorder:          mgr.GetEventRecorderFor(ControllerName),
+		seedGetter:        seedGetter,
+		overwriteRegistry: overwriteRegistry,
+		reportFiles:       metering.ReportFiles,
+		now:               time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &kubermaticv1.MeteringReport{}),
+		&handler.EnqueueRequestForObject{},
+		predicate.ByNamespace(namespace),
+	); err != nil {
+		return fmt.Errorf("failed to create watch for metering reports: %w", err)
+	}
+
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &batchv1.Job{}),
+		enqueueMeteringReport(),
+		predicate.ByNamespace(namespace),
+		predicate.Factory(func(o ctrlruntimeclient.Object) bool {
+			labels := o.GetLabels()
+			return labels[metering.ReportLabel] != "" || labels[metering.ReportConfigurationLabel] != ""
+		}),
+	); err != nil {
+		return fmt.Errorf("failed to create watch for jobs: %w", err)
+	}
+
+	return nil
+}
+
+// enqueueMeteringReport maps report Jobs to their MeteringReport. Scheduled runs are recorded as
+// MeteringReports with the name of their Job.
+func enqueueMeteringReport() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, job ctrlruntimeclient.Object) []reconcile.Request {
+		if name, ok := job.GetLabels()[metering.ReportLabel]; ok {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: job.GetNamespace(), Name: name}}}
+		}
+
+		if _, ok := job.GetLabels()[metering.ReportConfigurationLabel]; ok {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: job.GetNamespace(), Name: job.GetName()}}}
+		}
+
+		return nil
+	})
+}
+
+// Reconcile generates the requested MeteringReports and keeps their status up to date.
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Reconciling")
+
+	seed, err := r.seedGetter()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %w", err)
+	}
+
+	report := &kubermaticv1.MeteringReport{}
+	if err := r.client.Get(ctx, request.NamespacedName, report); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("failed to get metering report: %w", err)
+		}
+
+		return reconcile.Result{}, r.recordScheduledRun(ctx, log, seed, request.NamespacedName)
+	}
+
+	if !report.DeletionTimestamp.IsZero() || report.Status.IsFinished() {
+		return reconcile.Result{}, nil
+	}
+
+	err = r.reconcile(ctx, log, seed, report)
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+		r.recorder.Event(report, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, seed *kubermaticv1.Seed, report *kubermaticv1.MeteringReport) error {
+	if report.Status.JobName == "" {
+		return r.createReportJob(ctx, log, seed, report)
+	}
+
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: report.Namespace, Name: report.Status.JobName}, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+
+		return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+			s.Phase = kubermaticv1.MeteringReportPhaseFailed
+			s.Message = fmt.Sprintf("Job %s does not exist anymore.", report.Status.JobName)
+		})
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			completionTime := condition.LastTransitionTime
+			message := condition.Message
+
+			return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+				s.Phase = kubermaticv1.MeteringReportPhaseFailed
+				s.StartTime = job.Status.StartTime
+				s.CompletionTime = &completionTime
+				s.Message = message
+			})
+		}
+	}
+
+	if job.Status.CompletionTime != nil {
+		outputDir := report.Spec.ReportConfiguration
+		if _, ok := job.Labels[metering.ReportLabel]; ok {
+			outputDir = metering.AdhocReportOutputDir(report)
+		}
+
+		start := job.CreationTimestamp
+		if job.Status.StartTime != nil {
+			start = *job.Status.StartTime
+		}
+
+		location, files, checksum, err := r.reportFiles(ctx, r.client, seed, outputDir, start.Time, job.Status.CompletionTime.Time)
+		if err != nil {
+			return fmt.Errorf("failed to determine report files: %w", err)
+		}
+
+		log.Debugw("Report generated", "location", location, "files", len(files))
+
+		return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+			s.Phase = kubermaticv1.MeteringReportPhaseSucceeded
+			s.StartTime = job.Status.StartTime
+			s.CompletionTime = job.Status.CompletionTime
+			s.Location = location
+			s.Files = files
+			s.Checksum = checksum
+		})
+	}
+
+	if job.Status.StartTime != nil {
+		return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+			s.Phase = kubermaticv1.MeteringReportPhaseRunning
+			s.StartTime = job.Status.StartTime
+		})
+	}
+
+	return nil
+}
+
+func (r *reconciler) createReportJob(ctx context.Context, log *zap.SugaredLogger, seed *kubermaticv1.Seed, report *kubermaticv1.MeteringReport) error {
+	if seed.Spec.Metering == nil || !seed.Spec.Metering.Enabled {
+		return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+			s.Phase = kubermaticv1.MeteringReportPhaseFailed
+			s.Message = "Metering is not enabled for this seed."
+		})
+	}
+
+	if !report.Spec.To.After(report.Spec.From.Time) {
+		return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+			s.Phase = kubermaticv1.MeteringReportPhaseFailed
+			s.Message = "The end of the reported time range must be after its start."
+		})
+	}
+
+	job, err := metering.ReportJob(report, "", resources.CABundleConfigMapName, registry.GetImageRewriterFunc(r.overwriteRegistry), seed, r.now())
+	if err != nil {
+		return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+			s.Phase = kubermaticv1.MeteringReportPhaseFailed
+			s.Message = fmt.Sprintf("The time range cannot be reported: %v.", err)
+		})
+	}
+
+	job.GenerateName = adhocJobPrefix
+	if err := controllerutil.SetControllerReference(report, job, r.scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	if err := r.client.Create(ctx, job); err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	log.Infow("Created report job", "job", job.Name)
+
+	return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+		s.Phase = kubermaticv1.MeteringReportPhasePending
+		s.JobName = job.Name
+	})
+}
+
+// recordScheduledRun creates the MeteringReport for a Job started by a report CronJob.
+func (r *reconciler) recordScheduledRun(ctx context.Context, log *zap.SugaredLogger, seed *kubermaticv1.Seed, key types.NamespacedName) error {
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, key, job); err != nil {
+		return ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	configurationName := job.Labels[metering.ReportConfigurationLabel]
+	if configurationName == "" || !job.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	var configuration kubermaticv1.MeteringReportConfiguration
+	if seed.Spec.Metering != nil && seed.Spec.Metering.ReportConfigurations[configurationName] != nil {
+		configuration = *seed.Spec.Metering.ReportConfigurations[configurationName]
+	}
+
+	from, to := scheduledTimeRange(job.CreationTimestamp.Time, &configuration)
+
+	report := &kubermaticv1.MeteringReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name,
+			Namespace: job.Namespace,
+			Labels: map[string]string{
+				metering.ReportConfigurationLabel: configurationName,
+			},
+		},
+		Spec: kubermaticv1.MeteringReportSpec{
+			From:                metav1.NewTime(from),
+			To:                  metav1.NewTime(to),
+			ReportConfiguration: configurationName,
+			Types:               configuration.Types,
+		},
+	}
+
+	if err := r.client.Create(ctx, report); err != nil {
+		return ctrlruntimeclient.IgnoreAlreadyExists(err)
+	}
+
+	log.Infow("Recorded scheduled report run", "job", job.Name)
+
+	return r.updateStatus(ctx, report, func(s *kubermaticv1.MeteringReportStatus) {
+		s.Phase = kubermaticv1.MeteringReportPhasePending
+		s.JobName = job.Name
+	})
+}
+
+// scheduledTimeRange returns the time range covered by a scheduled report run started at the given time.
+func scheduledTimeRange(started time.Time, configuration *kubermaticv1.MeteringReportConfiguration) (time.Time, time.Time) {
+	started = started.UTC()
+
+	if configuration.Monthly {
+		to := time.Date(started.Year(), started.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return to.AddDate(0, -1, 0), to
+	}
+
+	return started.AddDate(0, 0, -int(configuration.Interval)), started
+}
+
+func (r *reconciler) updateStatus(ctx context.Context, report *kubermaticv1.MeteringReport, patch func(*kubermaticv1.MeteringReportStatus)) error {
+	oldReport := report.DeepCopy()
+	patch(&report.Status)
+
+	return r.client.Status().Patch(ctx, report, ctrlruntimeclient.MergeFrom(oldReport))
+}
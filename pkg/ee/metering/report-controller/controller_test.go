@@ -0,0 +1,304 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2023 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package reportcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/ee/metering"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+	"k8c.io/kubermatic/v2/pkg/test/generator"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/strings/slices"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const namespace = "kubermatic"
+
+var (
+	jobStart      = metav1.NewTime(time.Date(2023, 10, 7, 1, 0, 0, 0, time.UTC))
+	jobCompletion = metav1.NewTime(time.Date(2023, 10, 7, 1, 5, 0, 0, time.UTC))
+)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestName    string
+		objects        []ctrlruntimeclient.Object
+		expectedSpec   *kubermaticv1.MeteringReportSpec
+		expectedStatus kubermaticv1.MeteringReportStatus
+		expectedJob    bool
+		expectedArg    string
+	}{
+		{
+			name:        "scenario 1: create job for ad-hoc report",
+			requestName: "adhoc",
+			objects: []ctrlruntimeclient.Object{
+				genReport("adhoc", "", kubermaticv1.MeteringReportStatus{}),
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase: kubermaticv1.MeteringReportPhasePending,
+			},
+			expectedJob: true,
+			expectedArg: "--last-number-of-days=7",
+		},
+		{
+			name:        "scenario 2: create job for ad-hoc report over the previous month",
+			requestName: "adhoc",
+			objects: []ctrlruntimeclient.Object{
+				func() *kubermaticv1.MeteringReport {
+					report := genReport("adhoc", "", kubermaticv1.MeteringReportStatus{})
+					report.Spec.From = metav1.NewTime(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC))
+					report.Spec.To = metav1.NewTime(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC))
+					return report
+				}(),
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase: kubermaticv1.MeteringReportPhasePending,
+			},
+			expectedJob: true,
+			expectedArg: "--last-month",
+		},
+		{
+			name:        "scenario 3: fail ad-hoc report with a time range the metering tool cannot report",
+			requestName: "adhoc",
+			objects: []ctrlruntimeclient.Object{
+				func() *kubermaticv1.MeteringReport {
+					report := genReport("adhoc", "", kubermaticv1.MeteringReportStatus{})
+					report.Spec.From = metav1.NewTime(report.Spec.From.AddDate(0, 0, -14))
+					report.Spec.To = metav1.NewTime(report.Spec.To.AddDate(0, 0, -14))
+					return report
+				}(),
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase:   kubermaticv1.MeteringReportPhaseFailed,
+				Message: "The time range cannot be reported: the reported time range must either be the previous calendar month or end at the time the report is requested.",
+			},
+		},
+		{
+			name:        "scenario 4: fail ad-hoc report with invalid time range",
+			requestName: "adhoc",
+			objects: []ctrlruntimeclient.Object{
+				func() *kubermaticv1.MeteringReport {
+					report := genReport("adhoc", "", kubermaticv1.MeteringReportStatus{})
+					report.Spec.To = report.Spec.From
+					return report
+				}(),
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase:   kubermaticv1.MeteringReportPhaseFailed,
+				Message: "The end of the reported time range must be after its start.",
+			},
+		},
+		{
+			name:        "scenario 5: record scheduled report run",
+			requestName: "weekly-28276860",
+			objects: []ctrlruntimeclient.Object{
+				genScheduledJob("weekly-28276860", batchv1.JobStatus{}),
+			},
+			expectedSpec: &kubermaticv1.MeteringReportSpec{
+				From:                metav1.NewTime(jobStart.AddDate(0, 0, -7)),
+				To:                  jobStart,
+				ReportConfiguration: "weekly",
+				Types:               []string{"cluster"},
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase:   kubermaticv1.MeteringReportPhasePending,
+				JobName: "weekly-28276860",
+			},
+		},
+		{
+			name:        "scenario 6: record finished report",
+			requestName: "weekly-28276860",
+			objects: []ctrlruntimeclient.Object{
+				genReport("weekly-28276860", "weekly", kubermaticv1.MeteringReportStatus{
+					Phase:   kubermaticv1.MeteringReportPhasePending,
+					JobName: "weekly-28276860",
+				}),
+				genScheduledJob("weekly-28276860", batchv1.JobStatus{
+					StartTime:      &jobStart,
+					CompletionTime: &jobCompletion,
+					Succeeded:      1,
+				}),
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase:          kubermaticv1.MeteringReportPhaseSucceeded,
+				JobName:        "weekly-28276860",
+				StartTime:      &jobStart,
+				CompletionTime: &jobCompletion,
+				Location:       "s3://metering/weekly",
+				Files:          []string{"weekly/seed-cluster.csv"},
+				Checksum:       "sha256:1234",
+			},
+		},
+		{
+			name:        "scenario 7: record failed report",
+			requestName: "weekly-28276860",
+			objects: []ctrlruntimeclient.Object{
+				genReport("weekly-28276860", "weekly", kubermaticv1.MeteringReportStatus{
+					Phase:   kubermaticv1.MeteringReportPhaseRunning,
+					JobName: "weekly-28276860",
+				}),
+				genScheduledJob("weekly-28276860", batchv1.JobStatus{
+					StartTime: &jobStart,
+					Conditions: []batchv1.JobCondition{{
+						Type:               batchv1.JobFailed,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: jobCompletion,
+						Message:            "Job has reached the specified backoff limit",
+					}},
+				}),
+			},
+			expectedStatus: kubermaticv1.MeteringReportStatus{
+				Phase:          kubermaticv1.MeteringReportPhaseFailed,
+				JobName:        "weekly-28276860",
+				StartTime:      &jobStart,
+				CompletionTime: &jobCompletion,
+				Message:        "Job has reached the specified backoff limit",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+
+			seed := generator.GenTestSeed()
+			seed.Namespace = namespace
+			seed.Spec.Metering = &kubermaticv1.MeteringConfiguration{
+				Enabled: true,
+				ReportConfigurations: map[string]*kubermaticv1.MeteringReportConfiguration{
+					"weekly": {
+						Schedule: "0 1 * * 6",
+						Interval: 7,
+						Types:    []string{"cluster"},
+					},
+				},
+			}
+
+			r := &reconciler{
+				log:        kubermaticlog.Logger,
+				client:     client,
+				scheme:     fake.NewScheme(),
+				recorder:   &record.FakeRecorder{},
+				seedGetter: func() (*kubermaticv1.Seed, error) { return seed, nil },
+				reportFiles: func(_ context.Context, _ ctrlruntimeclient.Client, _ *kubermaticv1.Seed, outputDir string, _, _ time.Time) (string, []string, string, error) {
+					return "s3://metering/" + outputDir, []string{outputDir + "/seed-cluster.csv"}, "sha256:1234", nil
+				},
+				now: func() time.Time { return jobStart.Time },
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: tc.requestName}}
+			if _, err := r.Reconcile(ctx, request); err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+
+			report := &kubermaticv1.MeteringReport{}
+			if err := client.Get(ctx, request.NamespacedName, report); err != nil {
+				t.Fatalf("failed to get metering report: %v", err)
+			}
+
+			if tc.expectedSpec != nil && !diff.SemanticallyEqual(*tc.expectedSpec, report.Spec) {
+				t.Fatalf("Spec differs:\n%v", diff.ObjectDiff(*tc.expectedSpec, report.Spec))
+			}
+
+			if tc.expectedJob {
+				job := &batchv1.Job{}
+				if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: report.Status.JobName}, job); err != nil {
+					t.Fatalf("failed to get report job: %v", err)
+				}
+
+				if job.Labels[metering.ReportLabel] != report.Name {
+					t.Fatalf("expected job to reference report %q, got labels %v", report.Name, job.Labels)
+				}
+
+				if args := job.Spec.Template.Spec.Containers[0].Args; !slices.Contains(args, tc.expectedArg) {
+					t.Fatalf("expected job arguments to contain %q, got %v", tc.expectedArg, args)
+				}
+
+				tc.expectedStatus.JobName = report.Status.JobName
+			}
+
+			if !diff.SemanticallyEqual(tc.expectedStatus, report.Status) {
+				t.Fatalf("Status differs:\n%v", diff.ObjectDiff(tc.expectedStatus, report.Status))
+			}
+		})
+	}
+}
+
+func TestScheduledTimeRange(t *testing.T) {
+	started := time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC)
+
+	from, to := scheduledTimeRange(started, &kubermaticv1.MeteringReportConfiguration{Monthly: true})
+	if !from.Equal(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected monthly report to cover February, got %v - %v", from, to)
+	}
+
+	from, to = scheduledTimeRange(started, &kubermaticv1.MeteringReportConfiguration{Interval: 7})
+	if !from.Equal(started.AddDate(0, 0, -7)) || !to.Equal(started) {
+		t.Fatalf("expected report to cover the last 7 days, got %v - %v", from, to)
+	}
+}
+
+func genReport(name, configuration string, status kubermaticv1.MeteringReportStatus) *kubermaticv1.MeteringReport {
+	return &kubermaticv1.MeteringReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kubermaticv1.MeteringReportSpec{
+			From:                metav1.NewTime(jobStart.AddDate(0, 0, -7)),
+			To:                  jobStart,
+			ReportConfiguration: configuration,
+		},
+		Status: status,
+	}
+}
+
+func genScheduledJob(name string, status batchv1.JobStatus) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: jobStart,
+			Labels: map[string]string{
+				metering.ReportConfigurationLabel: "weekly",
+			},
+		},
+		Status: status,
+	}
+}
@@ -0,0 +1,99 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2023 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package metering
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reportFileTimeTolerance accounts for clock skew between the seed and the object storage when
+// matching report files to the time a report Job was running.
+const reportFileTimeTolerance = time.Minute
+
+// ReportFiles looks up the report files that were written to outputDir in the metering bucket between
+// start and end. It returns the location of outputDir, the keys of the files and a SHA-256 checksum
+// over the keys and contents of all files.
+func ReportFiles(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed, outputDir string, start, end time.Time) (string, []string, string, error) {
+	mc, bucket, err := getS3DataFromSeed(ctx, seed, client)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	var files []string
+	for object := range mc.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: outputDir + "/", Recursive: true}) {
+		if object.Err != nil {
+			return "", nil, "", fmt.Errorf("failed to list report files: %w", object.Err)
+		}
+
+		if object.LastModified.Before(start.Add(-reportFileTimeTolerance)) || object.LastModified.After(end.Add(reportFileTimeTolerance)) {
+			continue
+		}
+
+		files = append(files, object.Key)
+	}
+	sort.Strings(files)
+
+	hash := sha256.New()
+	for _, file := range files {
+		if err := hashObject(ctx, mc, bucket, file, hash); err != nil {
+			return "", nil, "", err
+		}
+	}
+
+	location := fmt.Sprintf("s3://%s/%s", bucket, outputDir)
+	checksum := fmt.Sprintf("sha256:%s", hex.EncodeToString(hash.Sum(nil)))
+
+	return location, files, checksum, nil
+}
+
+func hashObject(ctx context.Context, mc *minio.Client, bucket string, key string, w io.Writer) error {
+	object, err := mc.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get report file %s: %w", key, err)
+	}
+	defer object.Close()
+
+	if _, err := io.WriteString(w, key); err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, object); err != nil {
+		return fmt.Errorf("failed to read report file %s: %w", key, err)
+	}
+
+	return nil
+}
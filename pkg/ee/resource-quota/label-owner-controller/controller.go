@@ -38,6 +38,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -93,10 +94,14 @@ func resourceQuotaLabelOwnerRefReconcilerFactory(rq *kubermaticv1.ResourceQuota)
 	return func() (string, reconciling.ResourceQuotaReconciler) {
 		return rq.Name, func(c *kubermaticv1.ResourceQuota) (*kubermaticv1.ResourceQuota, error) {
 			// ensure labels and owner ref
-			kuberneteshelper.EnsureLabels(c, map[string]string{
+			subjectLabels := map[string]string{
 				kubermaticv1.ResourceQuotaSubjectKindLabelKey: rq.Spec.Subject.Kind,
-				kubermaticv1.ResourceQuotaSubjectNameLabelKey: rq.Spec.Subject.Name,
-			})
+			}
+			// group names come from the identity provider and are not necessarily valid label values
+			if len(validation.IsValidLabelValue(rq.Spec.Subject.Name)) == 0 {
+				subjectLabels[kubermaticv1.ResourceQuotaSubjectNameLabelKey] = rq.Spec.Subject.Name
+			}
+			kuberneteshelper.EnsureLabels(c, subjectLabels)
 			c.OwnerReferences = rq.OwnerReferences
 
 			return c, nil
@@ -38,6 +38,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -85,9 +86,36 @@ func Add(mgr manager.Manager,
 		return fmt.Errorf("failed to create watch for resource quota: %w", err)
 	}
 
+	// user and group quotas need to be updated when the projects their subject is bound to change
+	for _, t := range []ctrlruntimeclient.Object{&kubermaticv1.User{}, &kubermaticv1.UserProjectBinding{}, &kubermaticv1.GroupProjectBinding{}} {
+		if err := c.Watch(source.Kind(mgr.GetCache(), t), enqueueUserAndGroupResourceQuotas(reconciler.masterClient)); err != nil {
+			return fmt.Errorf("failed to create watch for %T: %w", t, err)
+		}
+	}
+
 	return nil
 }
 
+func enqueueUserAndGroupResourceQuotas(client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ ctrlruntimeclient.Object) []reconcile.Request {
+		var requests []reconcile.Request
+
+		resourceQuotaList := &kubermaticv1.ResourceQuotaList{}
+		if err := client.List(ctx, resourceQuotaList); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list resource quotas: %w", err))
+			return requests
+		}
+
+		for _, rq := range resourceQuotaList.Items {
+			if rq.Spec.Subject.Kind == kubermaticv1.UserSubjectKind || rq.Spec.Subject.Kind == kubermaticv1.GroupSubjectKind {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rq.Name}})
+			}
+		}
+
+		return requests
+	})
+}
+
 // Reconcile calculates the resource usage for a resource quota and sets the local usage.
 func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := r.log.With("request", request)
@@ -114,6 +142,10 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 		return nil
 	}
 
+	if err := r.ensureProjects(ctx, log, resourceQuota); err != nil {
+		return err
+	}
+
 	// for all related resource quotas on seeds, calculate global usage
 	globalUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
 	for seed, seedClient := range r.seedClients {
@@ -126,16 +158,7 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 			}
 			return fmt.Errorf("error getting seed %q resource quota: %w", seed, err)
 		}
-		localUsage := seedResourceQuota.Status.LocalUsage
-		if localUsage.CPU != nil {
-			globalUsage.CPU.Add(*localUsage.CPU)
-		}
-		if localUsage.Memory != nil {
-			globalUsage.Memory.Add(*localUsage.Memory)
-		}
-		if localUsage.Storage != nil {
-			globalUsage.Storage.Add(*localUsage.Storage)
-		}
+		globalUsage.Add(seedResourceQuota.Status.LocalUsage)
 	}
 
 	if err := r.ensureGlobalUsage(ctx, log, resourceQuota, globalUsage); err != nil {
@@ -145,6 +168,24 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 	return nil
 }
 
+// ensureProjects records the projects whose resources count towards a user or group quota, so that the
+// seed controllers, which have no access to the group project bindings, can calculate the local usage.
+func (r *reconciler) ensureProjects(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota) error {
+	projects, err := getSubjectProjects(ctx, r.masterClient, resourceQuota.Spec.Subject)
+	if err != nil {
+		return fmt.Errorf("failed to determine projects of subject: %w", err)
+	}
+
+	if k8cequality.Semantic.DeepEqual(projects, resourceQuota.Status.Projects) {
+		return nil
+	}
+	log.Debugw("projects for resource quota need update", "projects", projects)
+
+	return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, r.masterClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
+		rq.Status.Projects = projects
+	})
+}
+
 func (r *reconciler) ensureGlobalUsage(ctx context.Context, log *zap.SugaredLogger, resourceQuota *kubermaticv1.ResourceQuota,
 	globalUsage *kubermaticv1.ResourceDetails) error {
 	if k8cequality.Semantic.DeepEqual(*globalUsage, resourceQuota.Status.GlobalUsage) {
@@ -45,11 +45,12 @@ const rqName = "resourceQuota"
 
 func TestReconcile(t *testing.T) {
 	testCases := []struct {
-		name          string
-		requestName   string
-		expectedUsage kubermaticv1.ResourceDetails
-		masterClient  ctrlruntimeclient.Client
-		seedClients   map[string]ctrlruntimeclient.Client
+		name             string
+		requestName      string
+		expectedUsage    kubermaticv1.ResourceDetails
+		expectedProjects []string
+		masterClient     ctrlruntimeclient.Client
+		seedClients      map[string]ctrlruntimeclient.Client
 	}{
 		{
 			name:          "scenario 1: calculate rq global usage",
@@ -70,6 +71,51 @@ func TestReconcile(t *testing.T) {
 					Build(),
 			},
 		},
+		{
+			name:             "scenario 2: determine projects of user rq",
+			requestName:      rqName,
+			expectedUsage:    *genResourceDetails("2", "5G", "10G"),
+			expectedProjects: []string{"project1", "project2", "project3"},
+			masterClient: fake.
+				NewClientBuilder().
+				WithObjects(
+					genSubjectResourceQuota(rqName, kubermaticv1.Subject{Name: "john", Kind: kubermaticv1.UserSubjectKind}),
+					genUser("john", "john@acme.com", "developers"),
+					genUserProjectBinding("b1", "john@acme.com", "project1"),
+					genUserProjectBinding("b2", "john@acme.com", "project2"),
+					genUserProjectBinding("b3", "jane@acme.com", "project4"),
+					genGroupProjectBinding("g1", "developers", "project3"),
+					genGroupProjectBinding("g2", "admins", "project5"),
+				).
+				Build(),
+			seedClients: map[string]ctrlruntimeclient.Client{
+				"first": fake.
+					NewClientBuilder().
+					WithObjects(genResourceQuota(rqName, *genResourceDetails("2", "5G", "10G"))).
+					Build(),
+			},
+		},
+		{
+			name:             "scenario 3: determine projects of group rq",
+			requestName:      rqName,
+			expectedUsage:    *genResourceDetails("2", "5G", "10G"),
+			expectedProjects: []string{"project3"},
+			masterClient: fake.
+				NewClientBuilder().
+				WithObjects(
+					genSubjectResourceQuota(rqName, kubermaticv1.Subject{Name: "developers", Kind: kubermaticv1.GroupSubjectKind}),
+					genUserProjectBinding("b1", "john@acme.com", "project1"),
+					genGroupProjectBinding("g1", "developers", "project3"),
+					genGroupProjectBinding("g2", "admins", "project5"),
+				).
+				Build(),
+			seedClients: map[string]ctrlruntimeclient.Client{
+				"first": fake.
+					NewClientBuilder().
+					WithObjects(genResourceQuota(rqName, *genResourceDetails("2", "5G", "10G"))).
+					Build(),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -97,6 +143,10 @@ func TestReconcile(t *testing.T) {
 			if !diff.SemanticallyEqual(tc.expectedUsage, rq.Status.GlobalUsage) {
 				t.Fatalf("Objects differ:\n%v", diff.ObjectDiff(tc.expectedUsage, rq.Status.GlobalUsage))
 			}
+
+			if !diff.SemanticallyEqual(tc.expectedProjects, rq.Status.Projects) {
+				t.Fatalf("Projects differ:\n%v", diff.ObjectDiff(tc.expectedProjects, rq.Status.Projects))
+			}
 		})
 	}
 }
@@ -115,6 +165,40 @@ func genResourceQuota(name string, localUsage kubermaticv1.ResourceDetails) *kub
 	return rq
 }
 
+func genSubjectResourceQuota(name string, subject kubermaticv1.Subject) *kubermaticv1.ResourceQuota {
+	rq := genResourceQuota(name, kubermaticv1.ResourceDetails{})
+	rq.Spec.Subject = subject
+
+	return rq
+}
+
+func genUser(name, email string, groups ...string) *kubermaticv1.User {
+	user := &kubermaticv1.User{}
+	user.Name = name
+	user.Spec.Email = email
+	user.Spec.Groups = groups
+
+	return user
+}
+
+func genUserProjectBinding(name, email, projectID string) *kubermaticv1.UserProjectBinding {
+	binding := &kubermaticv1.UserProjectBinding{}
+	binding.Name = name
+	binding.Spec.UserEmail = email
+	binding.Spec.ProjectID = projectID
+
+	return binding
+}
+
+func genGroupProjectBinding(name, group, projectID string) *kubermaticv1.GroupProjectBinding {
+	binding := &kubermaticv1.GroupProjectBinding{}
+	binding.Name = name
+	binding.Spec.Group = group
+	binding.Spec.ProjectID = projectID
+
+	return binding
+}
+
 func genResourceDetails(cpu, mem, storage string) *kubermaticv1.ResourceDetails {
 	return kubermaticv1.NewResourceDetails(resource.MustParse(cpu), resource.MustParse(mem), resource.MustParse(storage))
 }
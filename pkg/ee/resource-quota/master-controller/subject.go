@@ -0,0 +1,94 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2023 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package mastercontroller
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getSubjectProjects returns the sorted IDs of all projects whose resources count towards the quota
+// of a user or group subject. Users count all projects they are bound to, either directly or through
+// one of their groups.
+func getSubjectProjects(ctx context.Context, client ctrlruntimeclient.Client, subject kubermaticv1.Subject) ([]string, error) {
+	projects := sets.New[string]()
+	groups := sets.New[string]()
+
+	switch subject.Kind {
+	case kubermaticv1.UserSubjectKind:
+		user := &kubermaticv1.User{}
+		if err := client.Get(ctx, types.NamespacedName{Name: subject.Name}, user); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get user %q: %w", subject.Name, err)
+		}
+
+		bindings := &kubermaticv1.UserProjectBindingList{}
+		if err := client.List(ctx, bindings); err != nil {
+			return nil, fmt.Errorf("failed to list user project bindings: %w", err)
+		}
+
+		for _, binding := range bindings.Items {
+			if binding.Spec.UserEmail == user.Spec.Email {
+				projects.Insert(binding.Spec.ProjectID)
+			}
+		}
+
+		groups.Insert(user.Spec.Groups...)
+
+	case kubermaticv1.GroupSubjectKind:
+		groups.Insert(subject.Name)
+
+	default:
+		return nil, nil
+	}
+
+	if groups.Len() > 0 {
+		bindings := &kubermaticv1.GroupProjectBindingList{}
+		if err := client.List(ctx, bindings); err != nil {
+			return nil, fmt.Errorf("failed to list group project bindings: %w", err)
+		}
+
+		for _, binding := range bindings.Items {
+			if groups.Has(binding.Spec.Group) {
+				projects.Insert(binding.Spec.ProjectID)
+			}
+		}
+	}
+
+	if projects.Len() == 0 {
+		return nil, nil
+	}
+
+	return sets.List(projects), nil
+}
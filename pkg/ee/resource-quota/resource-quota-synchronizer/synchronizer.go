@@ -145,8 +145,10 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, reso
 
 		// ensure status
 		globalUsage := resourceQuota.Status.GlobalUsage.DeepCopy()
+		projects := resourceQuota.Status.Projects
 		return kubermaticv1helper.UpdateResourceQuotaStatus(ctx, seedClient, resourceQuota, func(rq *kubermaticv1.ResourceQuota) {
 			rq.Status.GlobalUsage = *globalUsage
+			rq.Status.Projects = projects
 		})
 	})
 }
@@ -122,7 +122,7 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 	// If the controller is in worker-name mode, ignore all non-Cluster-RQ's
 	// (i.e. all RQ's that span multiple clusters), as it makes no sense to
 	// update an RQ's status with data that spans only a subset of subjects.
-	// As of now, only project RQ's exist and so there is no single-cluster-RQ.
+	// As of now, only project, user and group RQ's exist and so there is no single-cluster-RQ.
 	if r.workerName != "" /* resourceQuota.Spec.Subject.Kind != "cluster" */ {
 		log.Debug("Ignoring request because worker-name is set.")
 		return nil
@@ -134,34 +134,33 @@ func (r *reconciler) reconcile(ctx context.Context, resourceQuota *kubermaticv1.
 		return nil
 	}
 
-	projectIdReq, err := labels.NewRequirement(kubermaticv1.ProjectIDLabelKey, selection.Equals, []string{resourceQuota.Spec.Subject.Name})
-	if err != nil {
-		return fmt.Errorf("error creating project id req: %w", err)
-	}
-
-	clusterList := &kubermaticv1.ClusterList{}
-	if err := r.seedClient.List(ctx, clusterList,
-		&ctrlruntimeclient.ListOptions{LabelSelector: labels.NewSelector().Add(*projectIdReq)}); err != nil {
-		return fmt.Errorf("failed listing clusters: %w", err)
+	// user and group quotas span all projects their subject is bound to, as determined by the master
+	projectIDs := resourceQuota.Status.Projects
+	if resourceQuota.Spec.Subject.Kind == kubermaticv1.ProjectSubjectKind {
+		projectIDs = []string{resourceQuota.Spec.Subject.Name}
 	}
 
 	localUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
-	for _, cluster := range clusterList.Items {
-		if cluster.Status.ResourceUsage != nil {
-			clusterUsage := cluster.Status.ResourceUsage
-			if clusterUsage.CPU != nil {
-				localUsage.CPU.Add(*clusterUsage.CPU)
-			}
-			if clusterUsage.Memory != nil {
-				localUsage.Memory.Add(*clusterUsage.Memory)
-			}
-			if clusterUsage.Storage != nil {
-				localUsage.Storage.Add(*clusterUsage.Storage)
+	if len(projectIDs) > 0 {
+		projectIdReq, err := labels.NewRequirement(kubermaticv1.ProjectIDLabelKey, selection.In, projectIDs)
+		if err != nil {
+			return fmt.Errorf("error creating project id req: %w", err)
+		}
+
+		clusterList := &kubermaticv1.ClusterList{}
+		if err := r.seedClient.List(ctx, clusterList,
+			&ctrlruntimeclient.ListOptions{LabelSelector: labels.NewSelector().Add(*projectIdReq)}); err != nil {
+			return fmt.Errorf("failed listing clusters: %w", err)
+		}
+
+		for _, cluster := range clusterList.Items {
+			if cluster.Status.ResourceUsage != nil {
+				localUsage.Add(*cluster.Status.ResourceUsage)
 			}
 		}
 	}
 
-	if err = r.ensureLocalUsage(ctx, log, resourceQuota, localUsage); err != nil {
+	if err := r.ensureLocalUsage(ctx, log, resourceQuota, localUsage); err != nil {
 		return err
 	}
 
@@ -224,35 +223,24 @@ func enqueueResourceQuota(client ctrlruntimeclient.Client, log *zap.SugaredLogge
 			return requests
 		}
 
-		subjectNameReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectNameLabelKey, selection.Equals, []string{projectId})
-		if err != nil {
-			utilruntime.HandleError(fmt.Errorf("error creating subject name req: %w", err))
-			return requests
-		}
-
-		subjectKindReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectKindLabelKey, selection.Equals, []string{kubermaticv1.ProjectSubjectKind})
-		if err != nil {
-			utilruntime.HandleError(fmt.Errorf("error creating subject name req: %w", err))
-			return requests
-		}
-
 		resourceQuotaList := &kubermaticv1.ResourceQuotaList{}
-
-		if err := client.List(ctx, resourceQuotaList,
-			&ctrlruntimeclient.ListOptions{LabelSelector: labels.NewSelector().Add(*subjectKindReq, *subjectNameReq)},
-		); err != nil {
+		if err := client.List(ctx, resourceQuotaList); err != nil {
 			utilruntime.HandleError(fmt.Errorf("failed to list resourceQuotas: %w", err))
 			return requests
 		}
 
 		for _, rq := range resourceQuotaList.Items {
+			if !rq.AppliesToProject(projectId) {
+				continue
+			}
+
 			// If a worker-name is given, we want to only reconcile clusters that have that label;
 			// this means for multi-cluster resources (e.g. project quotas for projects), we should
 			// skip them, as they will contain data for both worker-named and unnamed clusters;
 			// otherwise this controller (with a worker-name) would fight another controller (without
 			// a worker-name) about the current status of the resource quota.
-			// As of now, only project quotas exist though.
-			if workerName == "" || rq.Spec.Subject.Kind != kubermaticv1.ProjectSubjectKind {
+			// As of now, only project, user and group quotas exist though.
+			if workerName == "" {
 				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
 					Name:      rq.Name,
 					Namespace: rq.Namespace,
@@ -64,6 +64,30 @@ func TestReconcile(t *testing.T) {
 				Build(),
 			expectedUsage: *genResourceDetails("7", "7G", "18G"),
 		},
+		{
+			name:          "scenario 2: calculate user rq local usage across the projects of the user",
+			requestName:   rqName,
+			resourceQuota: genUserResourceQuota(rqName, projectId, "project2"),
+			seedClient: fake.
+				NewClientBuilder().
+				WithObjects(genUserResourceQuota(rqName, projectId, "project2"),
+					genCluster("c1", projectId, "2", "5G", "10G"),
+					genCluster("c2", "project2", "5", "2G", "8G"),
+					genCluster("notSameProjectCluster", "impostor", "3", "3G", "3G")).
+				Build(),
+			expectedUsage: *genResourceDetails("7", "7G", "18G"),
+		},
+		{
+			name:          "scenario 3: set zero usage for user rq without projects",
+			requestName:   rqName,
+			resourceQuota: genUserResourceQuota(rqName),
+			seedClient: fake.
+				NewClientBuilder().
+				WithObjects(genUserResourceQuota(rqName),
+					genCluster("c1", projectId, "2", "5G", "10G")).
+				Build(),
+			expectedUsage: *genResourceDetails("0", "0", "0"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -107,6 +131,20 @@ func genResourceQuota(name string) *kubermaticv1.ResourceQuota {
 	return rq
 }
 
+func genUserResourceQuota(name string, projects ...string) *kubermaticv1.ResourceQuota {
+	rq := &kubermaticv1.ResourceQuota{}
+	rq.Name = name
+	rq.Spec = kubermaticv1.ResourceQuotaSpec{
+		Subject: kubermaticv1.Subject{
+			Name: "john",
+			Kind: kubermaticv1.UserSubjectKind,
+		},
+	}
+	rq.Status.Projects = projects
+
+	return rq
+}
+
 func genResourceDetails(cpu, mem, storage string) *kubermaticv1.ResourceDetails {
 	return kubermaticv1.NewResourceDetails(resource.MustParse(cpu), resource.MustParse(mem), resource.MustParse(storage))
 }
@@ -39,14 +39,17 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlruntimepredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -88,6 +91,37 @@ func Add(log *zap.SugaredLogger, seedMgr, userMgr manager.Manager, clusterName s
 		return fmt.Errorf("failed to establish watch for Machines: %w", err)
 	}
 
+	// Watch for changes to the capacity of Nodes, as extended resources are only advertised once device plugins registered
+	if err = c.Watch(source.Kind(userMgr.GetCache(), &corev1.Node{}), &handler.EnqueueRequestForObject{}, ctrlruntimepredicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !equality.Semantic.DeepEqual(e.ObjectOld.(*corev1.Node).Status.Capacity, e.ObjectNew.(*corev1.Node).Status.Capacity)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to establish watch for Nodes: %w", err)
+	}
+
+	// Watch for changes to Services, to count LoadBalancers; updates must also be considered when
+	// a Service stops being a LoadBalancer
+	isLoadBalancer := func(o ctrlruntimeclient.Object) bool {
+		return o.(*corev1.Service).Spec.Type == corev1.ServiceTypeLoadBalancer
+	}
+	if err = c.Watch(source.Kind(userMgr.GetCache(), &corev1.Service{}), &handler.EnqueueRequestForObject{}, ctrlruntimepredicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isLoadBalancer(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isLoadBalancer(e.ObjectOld) || isLoadBalancer(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isLoadBalancer(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isLoadBalancer(e.Object)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to establish watch for Services: %w", err)
+	}
+
 	return nil
 }
 
@@ -125,20 +159,53 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 func (r *reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster, machines *clusterv1alpha1.MachineList) error {
 	resourceUsage := kubermaticv1.NewResourceDetails(resource.Quantity{}, resource.Quantity{}, resource.Quantity{})
+	resourceUsage.Nodes = resource.NewQuantity(int64(len(machines.Items)), resource.DecimalSI)
+	resourceUsage.GPUs = &resource.Quantity{}
+
 	for _, machine := range machines.Items {
 		resourceDetails, err := machinevalidation.GetMachineResourceUsage(ctx, r.userClient, &machine, r.caBundle)
 		if err != nil {
 			return fmt.Errorf("error getting machine resource usage for machine %q: %w", machine.Name, err)
 		}
 
+		extendedResources, err := machinevalidation.GetNodeExtendedResources(ctx, r.userClient, &machine)
+		if err != nil {
+			return fmt.Errorf("error getting extended resources for machine %q: %w", machine.Name, err)
+		}
+		resourceDetails.WithExtendedResources(extendedResources)
+
 		resourceUsage.CPU.Add(*resourceDetails.Cpu())
 		resourceUsage.Memory.Add(*resourceDetails.Memory())
 		resourceUsage.Storage.Add(*resourceDetails.Storage())
+		resourceUsage.GPUs.Add(*resourceDetails.GPUs())
+		resourceUsage.Add(kubermaticv1.ResourceDetails{ExtendedResources: resourceDetails.ExtendedResources()})
 	}
 
+	loadBalancers, err := r.countLoadBalancers(ctx)
+	if err != nil {
+		return err
+	}
+	resourceUsage.LoadBalancers = resource.NewQuantity(int64(loadBalancers), resource.DecimalSI)
+
 	cluster.Status.ResourceUsage = resourceUsage
 
 	return kubermaticv1helper.UpdateClusterStatus(ctx, r.seedClient, cluster, func(c *kubermaticv1.Cluster) {
 		c.Status.ResourceUsage = resourceUsage
 	})
 }
+
+func (r *reconciler) countLoadBalancers(ctx context.Context) (int, error) {
+	services := &corev1.ServiceList{}
+	if err := r.userClient.List(ctx, services); err != nil {
+		return 0, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	count := 0
+	for _, service := range services.Items {
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			count++
+		}
+	}
+
+	return count, nil
+}
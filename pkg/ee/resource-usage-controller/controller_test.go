@@ -36,10 +36,13 @@ import (
 	"k8c.io/kubermatic/v2/pkg/test/fake"
 	"k8c.io/kubermatic/v2/pkg/test/generator"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -48,6 +51,7 @@ func TestReconcile(t *testing.T) {
 		name                  string
 		cluster               *kubermaticv1.Cluster
 		machines              []*clusterv1alpha1.Machine
+		userObjects           []ctrlruntimeclient.Object
 		expectedResourceUsage *kubermaticv1.ResourceDetails
 	}{
 		{
@@ -55,9 +59,12 @@ func TestReconcile(t *testing.T) {
 			cluster:  generator.GenDefaultCluster(),
 			machines: []*clusterv1alpha1.Machine{genFakeMachine("m1", "5", "5G", "10G")},
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("5"),
-				Memory:  getQuantity("5G"),
-				Storage: getQuantity("10G"),
+				CPU:           getQuantity("5"),
+				Memory:        getQuantity("5G"),
+				Storage:       getQuantity("10G"),
+				Nodes:         getQuantity("1"),
+				GPUs:          getQuantity("0"),
+				LoadBalancers: getQuantity("0"),
 			},
 		},
 		{
@@ -69,9 +76,12 @@ func TestReconcile(t *testing.T) {
 			}(),
 			machines: []*clusterv1alpha1.Machine{genFakeMachine("m1", "5", "5G", "10G")},
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("5"),
-				Memory:  getQuantity("5G"),
-				Storage: getQuantity("10G"),
+				CPU:           getQuantity("5"),
+				Memory:        getQuantity("5G"),
+				Storage:       getQuantity("10G"),
+				Nodes:         getQuantity("1"),
+				GPUs:          getQuantity("0"),
+				LoadBalancers: getQuantity("0"),
 			},
 		},
 		{
@@ -81,9 +91,12 @@ func TestReconcile(t *testing.T) {
 				genFakeMachine("m1", "5", "5G", "10G"),
 				genFakeMachine("m2", "2", "3G", "5G")},
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("7"),
-				Memory:  getQuantity("8G"),
-				Storage: getQuantity("15G"),
+				CPU:           getQuantity("7"),
+				Memory:        getQuantity("8G"),
+				Storage:       getQuantity("15G"),
+				Nodes:         getQuantity("2"),
+				GPUs:          getQuantity("0"),
+				LoadBalancers: getQuantity("0"),
 			},
 		},
 		{
@@ -94,9 +107,40 @@ func TestReconcile(t *testing.T) {
 				return c
 			}(),
 			expectedResourceUsage: &kubermaticv1.ResourceDetails{
-				CPU:     getQuantity("0"),
-				Memory:  getQuantity("0"),
-				Storage: getQuantity("0"),
+				CPU:           getQuantity("0"),
+				Memory:        getQuantity("0"),
+				Storage:       getQuantity("0"),
+				Nodes:         getQuantity("0"),
+				GPUs:          getQuantity("0"),
+				LoadBalancers: getQuantity("0"),
+			},
+		},
+		{
+			name:    "scenario 5: count GPUs, extended resources and load balancers",
+			cluster: generator.GenDefaultCluster(),
+			machines: []*clusterv1alpha1.Machine{
+				genFakeGPUMachine("m1", "2", "node-1"),
+				genFakeMachine("m2", "2", "3G", "5G")},
+			userObjects: []ctrlruntimeclient.Object{
+				genNode("node-1", corev1.ResourceList{
+					"nvidia.com/gpu":   resource.MustParse("2"),
+					"example.com/fpga": resource.MustParse("1"),
+				}),
+				genService("lb-1", corev1.ServiceTypeLoadBalancer),
+				genService("lb-2", corev1.ServiceTypeLoadBalancer),
+				genService("internal", corev1.ServiceTypeClusterIP),
+			},
+			expectedResourceUsage: &kubermaticv1.ResourceDetails{
+				CPU:           getQuantity("7"),
+				Memory:        getQuantity("8G"),
+				Storage:       getQuantity("15G"),
+				Nodes:         getQuantity("2"),
+				GPUs:          getQuantity("2"),
+				LoadBalancers: getQuantity("2"),
+				ExtendedResources: corev1.ResourceList{
+					"nvidia.com/gpu":   resource.MustParse("2"),
+					"example.com/fpga": resource.MustParse("1"),
+				},
 			},
 		},
 	}
@@ -115,6 +159,7 @@ func TestReconcile(t *testing.T) {
 			for _, m := range tc.machines {
 				userClientBuilder.WithObjects(m)
 			}
+			userClientBuilder.WithObjects(tc.userObjects...)
 
 			seedClient := seedClientBuilder.Build()
 			userClient := userClientBuilder.Build()
@@ -156,6 +201,29 @@ func genFakeMachine(name, cpu, memory, storage string) *clusterv1alpha1.Machine
 		nil, nil)
 }
 
+func genFakeGPUMachine(name, gpus, nodeName string) *clusterv1alpha1.Machine {
+	machine := generator.GenTestMachine(name,
+		fmt.Sprintf(`{"cloudProvider":"fake", "cloudProviderSpec":{"cpu":"5","memory":"5G","storage":"10G","gpus":"%s"}}`, gpus),
+		nil, nil)
+	machine.Status.NodeRef = &corev1.ObjectReference{Name: nodeName}
+
+	return machine
+}
+
+func genNode(name string, capacity corev1.ResourceList) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Capacity: capacity},
+	}
+}
+
+func genService(name string, serviceType corev1.ServiceType) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		Spec:       corev1.ServiceSpec{Type: serviceType},
+	}
+}
+
 func getQuantity(q string) *resource.Quantity {
 	res := resource.MustParse(q)
 	return &res
@@ -0,0 +1,119 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2023 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package machine
+
+import (
+	"context"
+	"strings"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// gpuResourceNames are the extended resources under which the device plugins of common GPU vendors advertise GPUs.
+var gpuResourceNames = sets.New[corev1.ResourceName]("nvidia.com/gpu", "amd.com/gpu", "gpu.intel.com/i915")
+
+// isExtendedResourceName returns true for fully-qualified resource names outside of the kubernetes.io
+// domain, which is how Kubernetes defines extended resources.
+func isExtendedResourceName(name corev1.ResourceName) bool {
+	s := string(name)
+
+	if !strings.Contains(s, "/") || strings.Contains(s, corev1.ResourceDefaultNamespacePrefix) {
+		return false
+	}
+
+	return !strings.HasPrefix(s, corev1.DefaultResourceRequestsPrefix)
+}
+
+// GetNodeExtendedResources returns the extended resources advertised by the node of the given Machine.
+// If the Machine has no node (yet), nil is returned.
+func GetNodeExtendedResources(ctx context.Context, userClient ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine) (corev1.ResourceList, error) {
+	if machine.Status.NodeRef == nil {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	if err := userClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: machine.Status.NodeRef.Name}, node); err != nil {
+		return nil, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	var extendedResources corev1.ResourceList
+	for name, quantity := range node.Status.Capacity {
+		if !isExtendedResourceName(name) || quantity.IsZero() {
+			continue
+		}
+
+		if extendedResources == nil {
+			extendedResources = corev1.ResourceList{}
+		}
+		extendedResources[name] = quantity
+	}
+
+	return extendedResources, nil
+}
+
+// estimateExtendedResources returns the extended resources a new Machine is expected to provide. As they
+// are only advertised by the node once it joined the cluster, they are taken from the node of another
+// Machine of the same MachineSet, which shares the same provider spec.
+func estimateExtendedResources(ctx context.Context, userClient ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine) (corev1.ResourceList, error) {
+	if userClient == nil {
+		return nil, nil
+	}
+
+	owner := metav1.GetControllerOf(machine)
+	if owner == nil || owner.Kind != "MachineSet" {
+		return nil, nil
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := userClient.List(ctx, machines, ctrlruntimeclient.InNamespace(machine.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for i, sibling := range machines.Items {
+		if sibling.Name == machine.Name || sibling.Status.NodeRef == nil {
+			continue
+		}
+
+		if siblingOwner := metav1.GetControllerOf(&sibling); siblingOwner == nil || siblingOwner.UID != owner.UID {
+			continue
+		}
+
+		extendedResources, err := GetNodeExtendedResources(ctx, userClient, &machines.Items[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if extendedResources != nil {
+			return extendedResources, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -36,6 +36,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -74,6 +75,13 @@ func ValidateQuota(ctx context.Context,
 	combinedUsage.Memory().Add(*machineResourceUsage.Memory())
 	combinedUsage.Storage().Add(*machineResourceUsage.Storage())
 
+	// the expected extended resources of the Machine are only known from nodes of the same MachineSet
+	extendedResources, err := estimateExtendedResources(ctx, userClient, machine)
+	if err != nil {
+		return fmt.Errorf("error estimating extended resources of machine: %w", err)
+	}
+	machineResourceUsage.WithExtendedResources(extendedResources)
+
 	quota := resourceQuota.Spec.Quota
 	if quota.CPU != nil && quota.CPU.Cmp(*combinedUsage.Cpu()) < 0 {
 		log.Debugw("requested CPU would exceed current quota", "request",
@@ -96,13 +104,61 @@ func ValidateQuota(ctx context.Context,
 			machineResourceUsage.Storage(), quota.Storage, currentStorage.String())
 	}
 
+	globalUsage := resourceQuota.Status.GlobalUsage
+
+	if err := validateCount(log, "nodes", quota.Nodes, globalUsage.Nodes, resource.MustParse("1")); err != nil {
+		return err
+	}
+
+	if err := validateCount(log, "GPUs", quota.GPUs, globalUsage.GPUs, *machineResourceUsage.GPUs()); err != nil {
+		return err
+	}
+
+	for name, limit := range quota.ExtendedResources {
+		request, ok := machineResourceUsage.ExtendedResources()[name]
+		if !ok {
+			continue
+		}
+
+		used := globalUsage.ExtendedResources[name]
+		if err := validateCount(log, string(name), &limit, &used, request); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCount checks that adding request to the current usage does not exceed the quota, if any.
+func validateCount(log *zap.SugaredLogger, name string, quota, used *resource.Quantity, request resource.Quantity) error {
+	if quota == nil || request.IsZero() {
+		return nil
+	}
+
+	current := resource.Quantity{}
+	if used != nil {
+		current = *used
+	}
+
+	combined := current.DeepCopy()
+	combined.Add(request)
+
+	if quota.Cmp(combined) < 0 {
+		log.Debugw(fmt.Sprintf("requested %s would exceed current quota", name), "request",
+			request.String(), "quota", quota, "used", current.String())
+		return fmt.Errorf("requested %s %q would exceed current quota (quota/used %q/%q)",
+			name, request.String(), quota, current.String())
+	}
+
 	return nil
 }
 
 type ResourceDetails struct {
-	cpu     resource.Quantity
-	mem     resource.Quantity
-	storage resource.Quantity
+	cpu               resource.Quantity
+	mem               resource.Quantity
+	storage           resource.Quantity
+	gpus              resource.Quantity
+	extendedResources corev1.ResourceList
 }
 
 func NewResourceDetails(cpu resource.Quantity, mem resource.Quantity, storage resource.Quantity) *ResourceDetails {
@@ -126,11 +182,17 @@ func NewResourceDetailsFromCapacity(capacity *provider.NodeCapacity) (*ResourceD
 		return nil, errors.New("storage must not be nil")
 	}
 
-	return &ResourceDetails{
+	details := &ResourceDetails{
 		cpu:     *capacity.CPUCores,
 		mem:     *capacity.Memory,
 		storage: *capacity.Storage,
-	}, nil
+	}
+
+	if capacity.GPUs != nil {
+		details.gpus = *capacity.GPUs
+	}
+
+	return details, nil
 }
 
 func (r *ResourceDetails) Cpu() *resource.Quantity {
@@ -144,3 +206,25 @@ func (r *ResourceDetails) Memory() *resource.Quantity {
 func (r *ResourceDetails) Storage() *resource.Quantity {
 	return &r.storage
 }
+
+func (r *ResourceDetails) GPUs() *resource.Quantity {
+	return &r.gpus
+}
+
+func (r *ResourceDetails) ExtendedResources() corev1.ResourceList {
+	return r.extendedResources
+}
+
+// WithExtendedResources sets the extended resources provided by the Machine's node. If the provider
+// spec does not tell the number of GPUs, it is taken from the GPU resources advertised by the node.
+func (r *ResourceDetails) WithExtendedResources(extendedResources corev1.ResourceList) {
+	r.extendedResources = extendedResources
+
+	if r.gpus.IsZero() {
+		for name, quantity := range extendedResources {
+			if gpuResourceNames.Has(name) {
+				r.gpus.Add(quantity)
+			}
+		}
+	}
+}
@@ -42,9 +42,10 @@ func TestResourceQuotaValidation(t *testing.T) {
 	l := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
 
 	testCases := []struct {
-		name        string
-		machine     *clusterv1alpha1.Machine
-		expectedErr bool
+		name          string
+		machine       *clusterv1alpha1.Machine
+		resourceQuota *kubermaticv1.ResourceQuota
+		expectedErr   bool
 	}{
 		{
 			name:        "quota that fits should succeed",
@@ -66,11 +67,49 @@ func TestResourceQuotaValidation(t *testing.T) {
 			machine:     genFakeMachine("2", "2G", "5000G"),
 			expectedErr: true,
 		},
+		{
+			name:    "should fail with Nodes quota exceeded",
+			machine: genFakeMachine("2", "2G", "10G"),
+			resourceQuota: func() *kubermaticv1.ResourceQuota {
+				rq := genResourceQuota()
+				rq.Spec.Quota.Nodes = getQuantity("3")
+				rq.Status.GlobalUsage.Nodes = getQuantity("3")
+				return rq
+			}(),
+			expectedErr: true,
+		},
+		{
+			name:    "GPUs that fit should succeed",
+			machine: genFakeGPUMachine("2"),
+			resourceQuota: func() *kubermaticv1.ResourceQuota {
+				rq := genResourceQuota()
+				rq.Spec.Quota.GPUs = getQuantity("4")
+				rq.Status.GlobalUsage.GPUs = getQuantity("2")
+				return rq
+			}(),
+			expectedErr: false,
+		},
+		{
+			name:    "should fail with GPUs quota exceeded",
+			machine: genFakeGPUMachine("2"),
+			resourceQuota: func() *kubermaticv1.ResourceQuota {
+				rq := genResourceQuota()
+				rq.Spec.Quota.GPUs = getQuantity("3")
+				rq.Status.GlobalUsage.GPUs = getQuantity("2")
+				return rq
+			}(),
+			expectedErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := machine.ValidateQuota(context.Background(), l, nil, tc.machine, nil, genResourceQuota())
+			resourceQuota := tc.resourceQuota
+			if resourceQuota == nil {
+				resourceQuota = genResourceQuota()
+			}
+
+			err := machine.ValidateQuota(context.Background(), l, nil, tc.machine, nil, resourceQuota)
 			if err != nil {
 				if !tc.expectedErr {
 					t.Fatalf("unexpected error: %v", err)
@@ -90,6 +129,17 @@ func genFakeMachine(cpu, memory, storage string) *clusterv1alpha1.Machine {
 		nil, nil)
 }
 
+func genFakeGPUMachine(gpus string) *clusterv1alpha1.Machine {
+	return generator.GenTestMachine("fake",
+		fmt.Sprintf(`{"cloudProvider":"fake", "cloudProviderSpec":{"cpu":"2","memory":"2G","storage":"10G","gpus":"%s"}}`, gpus),
+		nil, nil)
+}
+
+func getQuantity(q string) *resource.Quantity {
+	res := resource.MustParse(q)
+	return &res
+}
+
 func genResourceQuota() *kubermaticv1.ResourceQuota {
 	rq := &kubermaticv1.ResourceQuota{}
 	rq.Spec.Quota = *kubermaticv1.NewResourceDetails(resource.MustParse("50"), resource.MustParse("50G"), resource.MustParse("1000G"))
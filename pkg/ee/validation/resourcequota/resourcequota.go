@@ -122,3 +122,20 @@ func ValidateDelete(ctx context.Context,
 	}
 	return nil
 }
+
+// GetProjectResourceQuotas returns all resource quotas that apply to the given project.
+func GetProjectResourceQuotas(ctx context.Context, client ctrlruntimeclient.Client, projectID string) ([]kubermaticv1.ResourceQuota, error) {
+	quotaList := &kubermaticv1.ResourceQuotaList{}
+	if err := client.List(ctx, quotaList); err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	var quotas []kubermaticv1.ResourceQuota
+	for _, quota := range quotaList.Items {
+		if quota.AppliesToProject(projectID) {
+			quotas = append(quotas, quota)
+		}
+	}
+
+	return quotas, nil
+}
@@ -0,0 +1,68 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2022 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ValidateLoadBalancerQuota validates if a Service that becomes a LoadBalancer fits in the load balancer
+// quota of the clusters project. oldService is nil when the Service is being created.
+func ValidateLoadBalancerQuota(log *zap.SugaredLogger, service, oldService *corev1.Service, resourceQuota *kubermaticv1.ResourceQuota) error {
+	if !isLoadBalancer(service) || isLoadBalancer(oldService) {
+		return nil
+	}
+
+	quota := resourceQuota.Spec.Quota.LoadBalancers
+	if quota == nil {
+		return nil
+	}
+
+	used := resource.Quantity{}
+	if resourceQuota.Status.GlobalUsage.LoadBalancers != nil {
+		used = *resourceQuota.Status.GlobalUsage.LoadBalancers
+	}
+
+	combined := used.DeepCopy()
+	combined.Add(resource.MustParse("1"))
+
+	if quota.Cmp(combined) < 0 {
+		log.Debugw("requested load balancer would exceed current quota", "quota", quota, "used", used.String())
+		return fmt.Errorf("requested load balancer would exceed current quota (quota/used %q/%q)", quota, used.String())
+	}
+
+	return nil
+}
+
+func isLoadBalancer(service *corev1.Service) bool {
+	return service != nil && service.Spec.Type == corev1.ServiceTypeLoadBalancer
+}
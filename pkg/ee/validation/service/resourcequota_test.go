@@ -0,0 +1,121 @@
+//go:build ee
+
+/*
+                  Kubermatic Enterprise Read-Only License
+                         Version 1.0 ("KERO-1.0”)
+                     Copyright © 2022 Kubermatic GmbH
+
+   1.	You may only view, read and display for studying purposes the source
+      code of the software licensed under this license, and, to the extent
+      explicitly provided under this license, the binary code.
+   2.	Any use of the software which exceeds the foregoing right, including,
+      without limitation, its execution, compilation, copying, modification
+      and distribution, is expressly prohibited.
+   3.	THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND,
+      EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+      MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+      IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+      CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+      TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+      SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+   END OF TERMS AND CONDITIONS
+*/
+
+package service_test
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/ee/validation/service"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateLoadBalancerQuota(t *testing.T) {
+	l := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	testCases := []struct {
+		name        string
+		service     *corev1.Service
+		oldService  *corev1.Service
+		quota       string
+		used        string
+		expectedErr bool
+	}{
+		{
+			name:    "load balancer that fits should succeed",
+			service: genService(corev1.ServiceTypeLoadBalancer),
+			quota:   "3",
+			used:    "2",
+		},
+		{
+			name:        "should fail with load balancer quota exceeded",
+			service:     genService(corev1.ServiceTypeLoadBalancer),
+			quota:       "2",
+			used:        "2",
+			expectedErr: true,
+		},
+		{
+			name:        "should fail when changing a Service into a load balancer",
+			service:     genService(corev1.ServiceTypeLoadBalancer),
+			oldService:  genService(corev1.ServiceTypeClusterIP),
+			quota:       "2",
+			used:        "2",
+			expectedErr: true,
+		},
+		{
+			name:       "updating an existing load balancer should succeed",
+			service:    genService(corev1.ServiceTypeLoadBalancer),
+			oldService: genService(corev1.ServiceTypeLoadBalancer),
+			quota:      "2",
+			used:       "2",
+		},
+		{
+			name:    "other Service types should succeed",
+			service: genService(corev1.ServiceTypeNodePort),
+			quota:   "0",
+			used:    "0",
+		},
+		{
+			name:    "no load balancer quota should succeed",
+			service: genService(corev1.ServiceTypeLoadBalancer),
+			used:    "10",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resourceQuota := &kubermaticv1.ResourceQuota{}
+			if tc.quota != "" {
+				resourceQuota.Spec.Quota.LoadBalancers = getQuantity(tc.quota)
+			}
+			resourceQuota.Status.GlobalUsage.LoadBalancers = getQuantity(tc.used)
+
+			err := service.ValidateLoadBalancerQuota(l, tc.service, tc.oldService, resourceQuota)
+			if err != nil && !tc.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err == nil && tc.expectedErr {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func genService(serviceType corev1.ServiceType) *corev1.Service {
+	return &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type: serviceType,
+		},
+	}
+}
+
+func getQuantity(q string) *resource.Quantity {
+	res := resource.MustParse(q)
+	return &res
+}
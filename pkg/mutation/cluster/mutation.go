@@ -42,6 +42,17 @@ func MutateCreate(newCluster *kubermaticv1.Cluster, config *kubermaticv1.Kuberma
 		newCluster.Spec.Features[kubermaticv1.ApiserverNetworkPolicy] = true
 	}
 
+	// Konnectivity is the default for new clusters regardless of the expose strategy; existing
+	// clusters keep OpenVPN until they are explicitly migrated.
+	network := &newCluster.Spec.ClusterNetwork
+	if network.TunnelingMechanism == "" {
+		network.TunnelingMechanism = kubermaticv1.TunnelingMechanismKonnectivity
+		if network.KonnectivityEnabled != nil && !*network.KonnectivityEnabled { //nolint:staticcheck
+			network.TunnelingMechanism = kubermaticv1.TunnelingMechanismOpenVPN
+		}
+	}
+	syncKonnectivityEnabled(network)
+
 	datacenter, fieldErr := defaulting.DatacenterForClusterSpec(&newCluster.Spec, seed)
 	if fieldErr != nil {
 		return fieldErr
@@ -102,6 +113,18 @@ func MutateUpdate(oldCluster, newCluster *kubermaticv1.Cluster, config *kubermat
 		}
 	}
 
+	// Clients that only know the deprecated KonnectivityEnabled flag still toggle the tunneling
+	// mechanism through it. Existing clusters without either field are running OpenVPN.
+	network := &newCluster.Spec.ClusterNetwork
+	oldNetwork := oldCluster.Spec.ClusterNetwork
+	if network.TunnelingMechanism == "" || (network.TunnelingMechanism == oldNetwork.TunnelingMechanism && !ptr.Equal(network.KonnectivityEnabled, oldNetwork.KonnectivityEnabled)) { //nolint:staticcheck
+		network.TunnelingMechanism = kubermaticv1.TunnelingMechanismOpenVPN
+		if network.KonnectivityEnabled != nil && *network.KonnectivityEnabled { //nolint:staticcheck
+			network.TunnelingMechanism = kubermaticv1.TunnelingMechanismKonnectivity
+		}
+	}
+	syncKonnectivityEnabled(network)
+
 	// For KubeVirt, we want to mutate and always have ClusterFeatureCCMClusterName = true
 	// It's not handled by the previous loop for the migration 2.21 to 2.22
 	// as ExternalCloudProvider feature not is set for the first time.
@@ -151,3 +174,9 @@ func addCCMCSIMigrationAnnotations(cluster *kubermaticv1.Cluster) {
 	cluster.ObjectMeta.Annotations[kubermaticv1.CCMMigrationNeededAnnotation] = ""
 	cluster.ObjectMeta.Annotations[kubermaticv1.CSIMigrationNeededAnnotation] = ""
 }
+
+// syncKonnectivityEnabled mirrors the tunneling mechanism into the deprecated KonnectivityEnabled
+// flag, so that clients reading only the flag keep seeing the right setup.
+func syncKonnectivityEnabled(network *kubermaticv1.ClusterNetworkingConfig) {
+	network.KonnectivityEnabled = ptr.To(network.TunnelingMechanism == kubermaticv1.TunnelingMechanismKonnectivity) //nolint:staticcheck
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ClusterNameLabel is set by Cluster API on all objects belonging to a cluster.
+	ClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+
+	// kubeconfigSecretKey is the key in the `<cluster>-kubeconfig` Secret holding the admin kubeconfig.
+	kubeconfigSecretKey = "value"
+)
+
+// Cluster API does not ship a Go module we depend on, so all objects are handled as unstructured.
+var (
+	ClusterGVK               = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"}
+	MachineDeploymentGVK     = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeployment"}
+	MachineDeploymentListGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeploymentList"}
+)
+
+// GetManagementClient returns a client for the management cluster referenced by the cloud spec.
+func GetManagementClient(secretKeySelector provider.SecretKeySelectorValueFunc, cloudSpec *kubermaticv1.ExternalClusterCAPICloudSpec) (ctrlruntimeclient.Client, error) {
+	kubeconfig, err := secretKeySelector(cloudSpec.CredentialsReference, resources.ExternalClusterKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("invalid management cluster kubeconfig: %w", err)
+	}
+
+	return ctrlruntimeclient.New(cfg, ctrlruntimeclient.Options{Scheme: scheme.Scheme})
+}
+
+// GetCluster returns the Cluster object referenced by the cloud spec.
+func GetCluster(ctx context.Context, client ctrlruntimeclient.Client, cloudSpec *kubermaticv1.ExternalClusterCAPICloudSpec) (*unstructured.Unstructured, error) {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(ClusterGVK)
+	if err := client.Get(ctx, types.NamespacedName{Namespace: cloudSpec.Namespace, Name: cloudSpec.Name}, cluster); err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// GetClusterStatus maps the phase of the Cluster object to an ExternalCluster condition.
+func GetClusterStatus(ctx context.Context, client ctrlruntimeclient.Client, cloudSpec *kubermaticv1.ExternalClusterCAPICloudSpec) (*kubermaticv1.ExternalClusterCondition, string, error) {
+	cluster, err := GetCluster(ctx, client, cloudSpec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	phase, _, _ := unstructured.NestedString(cluster.Object, "status", "phase")
+	message, _, _ := unstructured.NestedString(cluster.Object, "status", "failureMessage")
+
+	return &kubermaticv1.ExternalClusterCondition{
+		Phase:   ConvertStatus(phase),
+		Message: message,
+	}, phase, nil
+}
+
+// GetClusterConfig reads the admin kubeconfig Cluster API stores for the workload cluster.
+func GetClusterConfig(ctx context.Context, client ctrlruntimeclient.Client, cloudSpec *kubermaticv1.ExternalClusterCAPICloudSpec) (*api.Config, error) {
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: cloudSpec.Namespace, Name: fmt.Sprintf("%s-kubeconfig", cloudSpec.Name)}
+	if err := client.Get(ctx, name, secret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret %q: %w", name.String(), err)
+	}
+
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("Secret %q has no key %q", name.String(), kubeconfigSecretKey)
+	}
+
+	return clientcmd.Load(kubeconfig)
+}
+
+// ListMachineDeployments returns the MachineDeployments belonging to the cluster, sorted by name.
+func ListMachineDeployments(ctx context.Context, client ctrlruntimeclient.Client, cloudSpec *kubermaticv1.ExternalClusterCAPICloudSpec) ([]kubermaticv1.CAPIMachineDeployment, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(MachineDeploymentListGVK)
+	if err := client.List(ctx, list, ctrlruntimeclient.InNamespace(cloudSpec.Namespace), ctrlruntimeclient.MatchingLabels{ClusterNameLabel: cloudSpec.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	machineDeployments := make([]kubermaticv1.CAPIMachineDeployment, 0, len(list.Items))
+	for _, item := range list.Items {
+		machineDeployments = append(machineDeployments, convertMachineDeployment(item))
+	}
+	sort.Slice(machineDeployments, func(i, j int) bool {
+		return machineDeployments[i].Name < machineDeployments[j].Name
+	})
+
+	return machineDeployments, nil
+}
+
+// ScaleMachineDeployment sets the desired replicas of a MachineDeployment of the cluster.
+func ScaleMachineDeployment(ctx context.Context, client ctrlruntimeclient.Client, cloudSpec *kubermaticv1.ExternalClusterCAPICloudSpec, name string, replicas int32) error {
+	if replicas < 0 {
+		return errors.New("replicas must not be negative")
+	}
+
+	md := &unstructured.Unstructured{}
+	md.SetGroupVersionKind(MachineDeploymentGVK)
+	if err := client.Get(ctx, types.NamespacedName{Namespace: cloudSpec.Namespace, Name: name}, md); err != nil {
+		return err
+	}
+	if md.GetLabels()[ClusterNameLabel] != cloudSpec.Name {
+		return fmt.Errorf("MachineDeployment %q does not belong to cluster %q", name, cloudSpec.Name)
+	}
+
+	oldMD := md.DeepCopy()
+	if err := unstructured.SetNestedField(md.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return err
+	}
+
+	return client.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD))
+}
+
+func convertMachineDeployment(md unstructured.Unstructured) kubermaticv1.CAPIMachineDeployment {
+	version, _, _ := unstructured.NestedString(md.Object, "spec", "template", "spec", "version")
+	phase, _, _ := unstructured.NestedString(md.Object, "status", "phase")
+	replicas, _, _ := unstructured.NestedInt64(md.Object, "spec", "replicas")
+	readyReplicas, _, _ := unstructured.NestedInt64(md.Object, "status", "readyReplicas")
+
+	return kubermaticv1.CAPIMachineDeployment{
+		Name:          md.GetName(),
+		Version:       version,
+		Phase:         phase,
+		Replicas:      int32(replicas),
+		ReadyReplicas: int32(readyReplicas),
+	}
+}
+
+// ConvertStatus maps a Cluster API cluster phase to an ExternalCluster phase.
+func ConvertStatus(phase string) kubermaticv1.ExternalClusterPhase {
+	switch phase {
+	case "Pending", "Provisioning":
+		return kubermaticv1.ExternalClusterPhaseProvisioning
+	case "Provisioned":
+		return kubermaticv1.ExternalClusterPhaseRunning
+	case "Deleting":
+		return kubermaticv1.ExternalClusterPhaseDeleting
+	case "Failed":
+		return kubermaticv1.ExternalClusterPhaseError
+	default:
+		return kubermaticv1.ExternalClusterPhaseUnknown
+	}
+}
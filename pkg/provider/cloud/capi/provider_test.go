@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/diff"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testCloudSpec = &kubermaticv1.ExternalClusterCAPICloudSpec{
+	Namespace: "default",
+	Name:      "workload",
+}
+
+func newFakeClient(t *testing.T, objects ...ctrlruntimeclient.Object) ctrlruntimeclient.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	scheme.AddKnownTypeWithName(ClusterGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(MachineDeploymentGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(MachineDeploymentListGVK, &unstructured.UnstructuredList{})
+
+	return fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func newMachineDeployment(name, cluster string, replicas, readyReplicas int64) *unstructured.Unstructured {
+	md := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"version": "v1.27.3",
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"phase":         "Running",
+			"readyReplicas": readyReplicas,
+		},
+	}}
+	md.SetGroupVersionKind(MachineDeploymentGVK)
+	md.SetNamespace("default")
+	md.SetName(name)
+	md.SetLabels(map[string]string{ClusterNameLabel: cluster})
+
+	return md
+}
+
+func TestGetClusterStatus(t *testing.T) {
+	cluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":          "Failed",
+			"failureMessage": "infrastructure not ready",
+		},
+	}}
+	cluster.SetGroupVersionKind(ClusterGVK)
+	cluster.SetNamespace("default")
+	cluster.SetName("workload")
+
+	condition, phase, err := GetClusterStatus(context.Background(), newFakeClient(t, cluster), testCloudSpec)
+	if err != nil {
+		t.Fatalf("failed to get cluster status: %v", err)
+	}
+
+	if phase != "Failed" {
+		t.Errorf("expected phase Failed, got %q", phase)
+	}
+
+	expected := &kubermaticv1.ExternalClusterCondition{
+		Phase:   kubermaticv1.ExternalClusterPhaseError,
+		Message: "infrastructure not ready",
+	}
+	if !diff.SemanticallyEqual(expected, condition) {
+		t.Fatalf("Condition differs from expected:\n%v", diff.ObjectDiff(expected, condition))
+	}
+}
+
+func TestGetClusterConfig(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "workload-kubeconfig",
+		},
+		Data: map[string][]byte{
+			"value": []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: https://workload.example.com:6443
+contexts:
+- name: workload
+  context:
+    cluster: workload
+    user: admin
+current-context: workload
+users:
+- name: admin
+  user:
+    token: secret
+`),
+		},
+	}
+
+	config, err := GetClusterConfig(context.Background(), newFakeClient(t, secret), testCloudSpec)
+	if err != nil {
+		t.Fatalf("failed to get cluster config: %v", err)
+	}
+
+	if server := config.Clusters["workload"].Server; server != "https://workload.example.com:6443" {
+		t.Errorf("unexpected server %q", server)
+	}
+}
+
+func TestListMachineDeployments(t *testing.T) {
+	client := newFakeClient(t,
+		newMachineDeployment("workers-b", "workload", 3, 2),
+		newMachineDeployment("workers-a", "workload", 1, 1),
+		newMachineDeployment("other", "other-cluster", 5, 5),
+	)
+
+	mds, err := ListMachineDeployments(context.Background(), client, testCloudSpec)
+	if err != nil {
+		t.Fatalf("failed to list MachineDeployments: %v", err)
+	}
+
+	expected := []kubermaticv1.CAPIMachineDeployment{
+		{Name: "workers-a", Version: "v1.27.3", Phase: "Running", Replicas: 1, ReadyReplicas: 1},
+		{Name: "workers-b", Version: "v1.27.3", Phase: "Running", Replicas: 3, ReadyReplicas: 2},
+	}
+	if !diff.SemanticallyEqual(expected, mds) {
+		t.Fatalf("MachineDeployments differ from expected:\n%v", diff.ObjectDiff(expected, mds))
+	}
+}
+
+func TestScaleMachineDeployment(t *testing.T) {
+	client := newFakeClient(t,
+		newMachineDeployment("workers", "workload", 1, 1),
+		newMachineDeployment("other", "other-cluster", 1, 1),
+	)
+
+	if err := ScaleMachineDeployment(context.Background(), client, testCloudSpec, "workers", 4); err != nil {
+		t.Fatalf("failed to scale MachineDeployment: %v", err)
+	}
+
+	mds, err := ListMachineDeployments(context.Background(), client, testCloudSpec)
+	if err != nil {
+		t.Fatalf("failed to list MachineDeployments: %v", err)
+	}
+	if len(mds) != 1 || mds[0].Replicas != 4 {
+		t.Errorf("expected MachineDeployment to have 4 replicas, got %+v", mds)
+	}
+
+	if err := ScaleMachineDeployment(context.Background(), client, testCloudSpec, "other", 2); err == nil {
+		t.Error("expected scaling a MachineDeployment of another cluster to fail")
+	}
+}
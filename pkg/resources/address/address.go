@@ -36,6 +36,17 @@ import (
 
 type lookupFunction func(host string) ([]net.IP, error)
 
+// SeedDomain returns the DNS domain below which the user clusters of the given
+// seed are exposed, i.e. "<seed name or DNS overwrite>.<externalURL>".
+func SeedDomain(seed *kubermaticv1.Seed, externalURL string) string {
+	subdomain := seed.Name
+	if seed.Spec.SeedDNSOverwrite != "" {
+		subdomain = seed.Spec.SeedDNSOverwrite
+	}
+
+	return fmt.Sprintf("%s.%s", subdomain, externalURL)
+}
+
 type ModifiersBuilder struct {
 	log         *zap.SugaredLogger
 	client      ctrlruntimeclient.Client
@@ -90,11 +101,6 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 		return modifiers, errors.New("providing client is mandatory for building address modifiers")
 	}
 
-	subdomain := m.seed.Name
-	if m.seed.Spec.SeedDNSOverwrite != "" {
-		subdomain = m.seed.Spec.SeedDNSOverwrite
-	}
-
 	frontProxyLBServiceIP := ""
 	frontProxyLBServiceHostname := ""
 	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
@@ -115,7 +121,7 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 			externalName = frontProxyLBServiceHostname
 		}
 	} else {
-		externalName = fmt.Sprintf("%s.%s.%s", m.cluster.Name, subdomain, m.externalURL)
+		externalName = fmt.Sprintf("%s.%s", m.cluster.Name, SeedDomain(m.seed, m.externalURL))
 	}
 
 	if m.cluster.Status.Address.ExternalName != externalName {
@@ -151,9 +157,7 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 				return nil, err
 			}
 		}
-	case kubermaticv1.ExposeStrategyNodePort:
-		fallthrough
-	case kubermaticv1.ExposeStrategyTunneling:
+	case kubermaticv1.ExposeStrategyNodePort, kubermaticv1.ExposeStrategyTunneling, kubermaticv1.ExposeStrategyGateway:
 		var err error
 		// Always lookup IP address, in case it changes (IP's on AWS LB's change)
 		ip, err = m.getExternalIP(externalName)
@@ -178,14 +182,18 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	}
 
 	// Port
-	var port int32 = service.Spec.Ports[0].TargetPort.IntVal
-	if m.cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling {
+	// Use the nodeport value for KAS secure port when strategy is NodePort or
+	// LoadBalancer. This is because the same service will be accessed both
+	// locally and passing from nodeport proxy. With Tunneling and Gateway, the
+	// SNI listener in front of the apiserver uses the apiserver's target port.
+	var port int32
+	switch m.cluster.Spec.ExposeStrategy {
+	case kubermaticv1.ExposeStrategyTunneling, kubermaticv1.ExposeStrategyGateway:
+		port = service.Spec.Ports[0].TargetPort.IntVal
+	default:
 		port = service.Spec.Ports[0].NodePort
 	}
 
-	// Use the nodeport value for KAS secure port when strategy is NodePort or
-	// LoadBalancer. This is because the same service will be accessed both
-	// locally and passing from nodeport proxy.
 	if m.cluster.Status.Address.Port != port {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
 			c.Status.Address.Port = port
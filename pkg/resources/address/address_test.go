@@ -333,6 +333,43 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(6443),
 			expectedURL:          fmt.Sprintf("https://%s.%s.%s:6443", fakeClusterNameIPv6, fakeDCName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for Gateway expose strategy",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(443),
+							TargetPort: intstr.FromInt(6443),
+						}},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategyGateway,
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(6443),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s:6443", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
+		{
+			name: "Verify properties for Gateway expose strategy with seedDNSOverwrite",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(443),
+							TargetPort: intstr.FromInt(6443),
+						}},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategyGateway,
+			seedDNSOverwrite:     "alias-europe-west3-c",
+			expectedExternalName: fmt.Sprintf("%s.alias-europe-west3-c.%s", fakeClusterName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(6443),
+			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:6443", fakeClusterName, fakeExternalURL),
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
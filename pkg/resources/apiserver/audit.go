@@ -18,6 +18,8 @@ package apiserver
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"html/template"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -25,6 +27,11 @@ import (
 	"k8c.io/reconciler/pkg/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	auditpolicy "k8s.io/apiserver/pkg/audit/policy"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
 )
 
 var auditPolicies = map[kubermaticv1.AuditPolicyPreset]string{
@@ -150,6 +157,19 @@ const fluentBitConfigTemplate = `
 func AuditConfigMapReconciler(data *resources.TemplateData) reconciling.NamedConfigMapReconcilerFactory {
 	return func() (string, reconciling.ConfigMapReconciler) {
 		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			settings := data.Cluster().Spec.AuditLogging
+			if settings != nil && settings.Enabled && (settings.PolicyConfigMapRef != nil || len(settings.Rules) > 0) {
+				policy, err := customAuditPolicy(data, settings)
+				if err != nil {
+					return nil, err
+				}
+
+				cm.Data = map[string]string{
+					"policy.yaml": policy,
+				}
+				return cm, nil
+			}
+
 			// set the audit policy preset so we generate a ConfigMap in any case.
 			// It won't be used if audit logging is not enabled
 			preset := kubermaticv1.AuditPolicyPreset("")
@@ -173,6 +193,145 @@ func AuditConfigMapReconciler(data *resources.TemplateData) reconciling.NamedCon
 	}
 }
 
+type auditPolicyData interface {
+	GetKubermaticConfigMapKeyValue(ref *corev1.ConfigMapKeySelector) (string, error)
+}
+
+// customAuditPolicy returns the audit policy from the referenced ConfigMap or the inline rules
+// and makes sure that kube-apiserver is able to load it.
+func customAuditPolicy(data auditPolicyData, settings *kubermaticv1.AuditLoggingSettings) (string, error) {
+	var policy []byte
+
+	if settings.PolicyConfigMapRef != nil {
+		value, err := data.GetKubermaticConfigMapKeyValue(settings.PolicyConfigMapRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to get audit policy: %w", err)
+		}
+		policy = []byte(value)
+	} else {
+		var err error
+		policy, err = yaml.Marshal(&auditv1.Policy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: auditv1.SchemeGroupVersion.String(),
+				Kind:       "Policy",
+			},
+			Rules: settings.Rules,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to encode audit policy: %w", err)
+		}
+	}
+
+	if _, err := auditpolicy.LoadPolicyFromBytes(policy); err != nil {
+		return "", fmt.Errorf("invalid audit policy: %w", err)
+	}
+
+	return string(policy), nil
+}
+
+// AuditWebhookBackend returns the webhook backend the audit events of the cluster are sent to, or nil
+// if they are shipped by the fluent-bit sidecar. The cluster settings take precedence over the
+// defaults of the seed.
+func AuditWebhookBackend(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) *kubermaticv1.AuditWebhookBackendSettings {
+	settings := cluster.Spec.AuditLogging
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	if settings.WebhookBackend != nil {
+		return settings.WebhookBackend
+	}
+
+	if settings.SidecarSettings != nil && settings.SidecarSettings.Config != nil {
+		return nil
+	}
+
+	if seed != nil && seed.Spec.AuditLogging != nil {
+		return seed.Spec.AuditLogging.WebhookBackend
+	}
+
+	return nil
+}
+
+// auditSidecarConfig returns the fluent-bit configuration for the audit-logs sidecar. The cluster
+// settings take precedence over the defaults of the seed.
+func auditSidecarConfig(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) *kubermaticv1.AuditSidecarConfiguration {
+	settings := cluster.Spec.AuditLogging
+	if settings != nil && settings.SidecarSettings != nil && settings.SidecarSettings.Config != nil {
+		return settings.SidecarSettings.Config
+	}
+
+	if seed != nil && seed.Spec.AuditLogging != nil && seed.Spec.AuditLogging.SidecarConfig != nil {
+		return seed.Spec.AuditLogging.SidecarConfig
+	}
+
+	return &kubermaticv1.AuditSidecarConfiguration{}
+}
+
+// AuditWebhookBackendSecretReconciler returns a reconciling.NamedSecretReconcilerFactory for a secret that
+// contains the kubeconfig of the audit webhook backend, copied from the Secret referenced in the backend settings.
+func AuditWebhookBackendSecretReconciler(data *resources.TemplateData) reconciling.NamedSecretReconcilerFactory {
+	return func() (string, reconciling.SecretReconciler) {
+		return resources.AuditWebhookBackendSecretName, func(secret *corev1.Secret) (*corev1.Secret, error) {
+			backend := AuditWebhookBackend(data.Cluster(), data.Seed())
+			if backend == nil || backend.AuditWebhookConfig == nil {
+				return nil, errors.New("no audit webhook backend configured")
+			}
+
+			// only the seed's defaults may use any Secret in the KKP namespace
+			clusterSettings := data.Cluster().Spec.AuditLogging
+			requireLabel := clusterSettings != nil && clusterSettings.WebhookBackend == backend
+
+			config, err := auditWebhookConfig(data, backend, requireLabel)
+			if err != nil {
+				return nil, err
+			}
+
+			secret.Data = map[string][]byte{
+				resources.AuditWebhookConfigSecretKey: config,
+			}
+
+			return secret, nil
+		}
+	}
+}
+
+type auditWebhookConfigData interface {
+	GetKubermaticSecret(name string) (*corev1.Secret, error)
+}
+
+// auditWebhookConfig returns the kubeconfig of the webhook backend from the referenced Secret
+// and makes sure that kube-apiserver is able to load it. If requireLabel is set, the Secret
+// must be labelled as audit webhook config.
+func auditWebhookConfig(data auditWebhookConfigData, backend *kubermaticv1.AuditWebhookBackendSettings, requireLabel bool) ([]byte, error) {
+	ref := backend.AuditWebhookConfig
+
+	secret, err := data.GetKubermaticSecret(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit webhook config: %w", err)
+	}
+
+	if requireLabel && secret.Labels[kubermaticv1.AuditWebhookConfigLabelKey] != "true" {
+		return nil, fmt.Errorf("audit webhook config Secret %s is not labelled with %s=true", ref.Name, kubermaticv1.AuditWebhookConfigLabelKey)
+	}
+
+	config, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in Secret %s", ref.Key, ref.Name)
+	}
+
+	kubeconfig, err := clientcmd.Load(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit webhook config: %w", err)
+	}
+
+	if _, err := clientcmd.NewDefaultClientConfig(*kubeconfig, nil).ClientConfig(); err != nil {
+		return nil, fmt.Errorf("invalid audit webhook config: %w", err)
+	}
+
+	return config, nil
+}
+
 // FluentBitSecretReconciler returns a reconciling.NamedSecretReconcilerFactory for a secret that contains
 // fluent-bit configuration for the audit-logs sidecar.
 func FluentBitSecretReconciler(data *resources.TemplateData) reconciling.NamedSecretReconcilerFactory {
@@ -182,10 +341,7 @@ func FluentBitSecretReconciler(data *resources.TemplateData) reconciling.NamedSe
 				secret.Data = map[string][]byte{}
 			}
 
-			config := &kubermaticv1.AuditSidecarConfiguration{}
-			if data.Cluster().Spec.AuditLogging.SidecarSettings != nil && data.Cluster().Spec.AuditLogging.SidecarSettings.Config != nil {
-				config = data.Cluster().Spec.AuditLogging.SidecarSettings.Config
-			}
+			config := auditSidecarConfig(data.Cluster(), data.Seed())
 
 			t, err := template.New("fluent-bit.conf").Parse(fluentBitConfigTemplate)
 			if err != nil {
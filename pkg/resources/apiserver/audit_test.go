@@ -0,0 +1,339 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+type fakeAuditPolicyData struct {
+	configMaps map[string]string
+	secrets    map[string]*corev1.Secret
+}
+
+func (f fakeAuditPolicyData) GetKubermaticConfigMapKeyValue(ref *corev1.ConfigMapKeySelector) (string, error) {
+	value, ok := f.configMaps[ref.Name+"/"+ref.Key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+
+	return value, nil
+}
+
+func (f fakeAuditPolicyData) GetKubermaticSecret(name string) (*corev1.Secret, error) {
+	secret, ok := f.secrets[name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return secret, nil
+}
+
+func TestCustomAuditPolicy(t *testing.T) {
+	data := fakeAuditPolicyData{
+		configMaps: map[string]string{
+			"audit/policy.yaml": auditPolicies[kubermaticv1.AuditPolicyRecommended],
+			"audit/broken.yaml": "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n  - level: Everything\n",
+		},
+	}
+
+	configMapRef := func(key string) *corev1.ConfigMapKeySelector {
+		return &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "audit"},
+			Key:                  key,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		settings       *kubermaticv1.AuditLoggingSettings
+		expectedPolicy string
+		expectedErr    bool
+	}{
+		{
+			name: "policy from ConfigMap",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyConfigMapRef: configMapRef("policy.yaml"),
+			},
+			expectedPolicy: auditPolicies[kubermaticv1.AuditPolicyRecommended],
+		},
+		{
+			name: "missing ConfigMap key",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyConfigMapRef: configMapRef("missing.yaml"),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid policy in ConfigMap",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyConfigMapRef: configMapRef("broken.yaml"),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "inline rules",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Rules: []auditv1.PolicyRule{
+					{
+						Level: auditv1.LevelRequestResponse,
+						Verbs: []string{"delete"},
+					},
+					{
+						Level: auditv1.LevelMetadata,
+					},
+				},
+			},
+			expectedPolicy: `apiVersion: audit.k8s.io/v1
+kind: Policy
+metadata:
+  creationTimestamp: null
+rules:
+- level: RequestResponse
+  verbs:
+  - delete
+- level: Metadata
+`,
+		},
+		{
+			name: "invalid inline rules",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Rules: []auditv1.PolicyRule{
+					{
+						Level:      auditv1.LevelMetadata,
+						OmitStages: []auditv1.Stage{"NotAStage"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := customAuditPolicy(data, tc.settings)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if strings.TrimSpace(policy) != strings.TrimSpace(tc.expectedPolicy) {
+				t.Errorf("expected policy:\n%s\ngot:\n%s", tc.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestAuditBackend(t *testing.T) {
+	clusterWebhook := &kubermaticv1.AuditWebhookBackendSettings{AuditWebhookInitialBackoff: "5s"}
+	clusterSidecar := &kubermaticv1.AuditSidecarConfiguration{Service: map[string]string{"Flush": "1"}}
+	seedWebhook := &kubermaticv1.AuditWebhookBackendSettings{AuditWebhookInitialBackoff: "10s"}
+	seedSidecar := &kubermaticv1.AuditSidecarConfiguration{Service: map[string]string{"Flush": "5"}}
+
+	testCases := []struct {
+		name            string
+		cluster         *kubermaticv1.AuditLoggingSettings
+		seed            *kubermaticv1.SeedAuditLoggingSettings
+		expectedWebhook *kubermaticv1.AuditWebhookBackendSettings
+		expectedSidecar *kubermaticv1.AuditSidecarConfiguration
+	}{
+		{
+			name:            "no backend configured",
+			cluster:         &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			expectedSidecar: &kubermaticv1.AuditSidecarConfiguration{},
+		},
+		{
+			name:    "audit logging disabled",
+			cluster: &kubermaticv1.AuditLoggingSettings{WebhookBackend: clusterWebhook},
+			seed:    &kubermaticv1.SeedAuditLoggingSettings{WebhookBackend: seedWebhook},
+		},
+		{
+			name:            "seed webhook is used by default",
+			cluster:         &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			seed:            &kubermaticv1.SeedAuditLoggingSettings{WebhookBackend: seedWebhook},
+			expectedWebhook: seedWebhook,
+		},
+		{
+			name:            "seed sidecar config is used by default",
+			cluster:         &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			seed:            &kubermaticv1.SeedAuditLoggingSettings{SidecarConfig: seedSidecar},
+			expectedSidecar: seedSidecar,
+		},
+		{
+			name: "cluster sidecar config overrides seed webhook",
+			cluster: &kubermaticv1.AuditLoggingSettings{
+				Enabled:         true,
+				SidecarSettings: &kubermaticv1.AuditSidecarSettings{Config: clusterSidecar},
+			},
+			seed:            &kubermaticv1.SeedAuditLoggingSettings{WebhookBackend: seedWebhook},
+			expectedSidecar: clusterSidecar,
+		},
+		{
+			name:            "cluster webhook overrides seed sidecar config",
+			cluster:         &kubermaticv1.AuditLoggingSettings{Enabled: true, WebhookBackend: clusterWebhook},
+			seed:            &kubermaticv1.SeedAuditLoggingSettings{SidecarConfig: seedSidecar},
+			expectedWebhook: clusterWebhook,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{Spec: kubermaticv1.ClusterSpec{AuditLogging: tc.cluster}}
+			seed := &kubermaticv1.Seed{Spec: kubermaticv1.SeedSpec{AuditLogging: tc.seed}}
+
+			if webhook := AuditWebhookBackend(cluster, seed); webhook != tc.expectedWebhook {
+				t.Errorf("expected webhook backend %v, got %v", tc.expectedWebhook, webhook)
+			}
+
+			if tc.expectedSidecar != nil {
+				if sidecar := auditSidecarConfig(cluster, seed); !reflect.DeepEqual(sidecar, tc.expectedSidecar) {
+					t.Errorf("expected sidecar config %v, got %v", tc.expectedSidecar, sidecar)
+				}
+			}
+		})
+	}
+}
+
+func TestAuditWebhookConfig(t *testing.T) {
+	validConfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: audit
+  cluster:
+    server: https://audit.example.com/events
+contexts:
+- name: audit
+  context:
+    cluster: audit
+    user: audit
+current-context: audit
+users:
+- name: audit
+  user:
+    token: secret
+`
+
+	data := fakeAuditPolicyData{
+		secrets: map[string]*corev1.Secret{
+			"audit-webhook": {
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{kubermaticv1.AuditWebhookConfigLabelKey: "true"},
+				},
+				Data: map[string][]byte{
+					"valid":     []byte(validConfig),
+					"garbage":   []byte("{not: [a kubeconfig"),
+					"no-server": []byte("apiVersion: v1\nkind: Config\nclusters: []\n"),
+				},
+			},
+			"unlabelled": {
+				Data: map[string][]byte{
+					"valid": []byte(validConfig),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		secret       string
+		key          string
+		requireLabel bool
+		expectErr    bool
+	}{
+		{
+			name: "valid kubeconfig",
+			key:  "valid",
+		},
+		{
+			name:         "valid kubeconfig in labelled Secret",
+			key:          "valid",
+			requireLabel: true,
+		},
+		{
+			name:   "unlabelled Secret referenced by the seed",
+			secret: "unlabelled",
+			key:    "valid",
+		},
+		{
+			name:         "unlabelled Secret referenced by a cluster",
+			secret:       "unlabelled",
+			key:          "valid",
+			requireLabel: true,
+			expectErr:    true,
+		},
+		{
+			name:      "unparsable kubeconfig",
+			key:       "garbage",
+			expectErr: true,
+		},
+		{
+			name:      "kubeconfig without usable context",
+			key:       "no-server",
+			expectErr: true,
+		},
+		{
+			name:      "missing key",
+			key:       "missing",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			secretName := tc.secret
+			if secretName == "" {
+				secretName = "audit-webhook"
+			}
+
+			backend := &kubermaticv1.AuditWebhookBackendSettings{
+				AuditWebhookConfig: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  tc.key,
+				},
+			}
+
+			config, err := auditWebhookConfig(data, backend, tc.requireLabel)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(config) != validConfig {
+				t.Errorf("expected config:\n%s\ngot:\n%s", validConfig, config)
+			}
+		})
+	}
+}
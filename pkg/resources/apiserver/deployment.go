@@ -82,9 +82,17 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 			dep.Spec.Template.Spec.AutomountServiceAccountToken = ptr.To(true)
 
 			auditLogEnabled := data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled
+			auditWebhookBackend := AuditWebhookBackend(data.Cluster(), data.Seed())
+			auditSidecarEnabled := auditLogEnabled && auditWebhookBackend == nil
 
-			volumes := getVolumes(data.IsKonnectivityEnabled(), enableEncryptionConfiguration, auditLogEnabled)
-			volumeMounts := getVolumeMounts(data.IsKonnectivityEnabled(), enableEncryptionConfiguration)
+			volumes := getVolumes(data.IsKonnectivityDeployed(), enableEncryptionConfiguration, auditSidecarEnabled, auditWebhookBackend != nil)
+			volumeMounts := getVolumeMounts(data.IsKonnectivityDeployed(), enableEncryptionConfiguration, auditWebhookBackend != nil)
+
+			kmsProviders := getKMSProviders(data.Cluster())
+			if len(kmsProviders) > 0 {
+				volumes = append(volumes, kmsPluginVolume())
+				volumeMounts = append(volumeMounts, kmsPluginVolumeMount())
+			}
 
 			version := data.Cluster().Status.Versions.Apiserver.Semver()
 
@@ -122,12 +130,15 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 			var openvpnSidecar *corev1.Container
 			var dnatControllerSidecar *corev1.Container
 
-			if data.IsKonnectivityEnabled() {
+			// While a cluster is migrated from OpenVPN to Konnectivity, both sidecars are running,
+			// but the egress selector configuration is only set once the migration switches over.
+			if data.IsKonnectivityDeployed() {
 				konnectivityProxySidecar, err = konnectivity.ProxySidecar(data, *dep.Spec.Replicas)
 				if err != nil {
 					return nil, fmt.Errorf("failed to get konnectivity-proxy sidecar: %w", err)
 				}
-			} else {
+			}
+			if !data.IsKonnectivityEnabled() {
 				openvpnSidecar, err = vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
 				if err != nil {
 					return nil, fmt.Errorf("failed to get openvpn-client sidecar: %w", err)
@@ -143,7 +154,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 				}
 			}
 
-			flags, err := getApiserverFlags(data, etcdEndpoints, enableOIDCAuthentication, auditLogEnabled, enableEncryptionConfiguration, version)
+			flags, err := getApiserverFlags(data, etcdEndpoints, enableOIDCAuthentication, auditLogEnabled, auditWebhookBackend, enableEncryptionConfiguration, version)
 			if err != nil {
 				return nil, err
 			}
@@ -195,33 +206,36 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 				VolumeMounts: volumeMounts,
 			}
 
-			var defResourceRequirements map[string]*corev1.ResourceRequirements
-			if data.IsKonnectivityEnabled() {
-				dep.Spec.Template.Spec.Containers = []corev1.Container{
-					*konnectivityProxySidecar,
-					*apiserverContainer,
-				}
-				defResourceRequirements = map[string]*corev1.ResourceRequirements{
-					name:                          defaultResourceRequirements.DeepCopy(),
-					konnectivityProxySidecar.Name: konnectivityProxySidecar.Resources.DeepCopy(),
-				}
-			} else {
-				dep.Spec.Template.Spec.Containers = []corev1.Container{
-					*openvpnSidecar,
-					*dnatControllerSidecar,
-					*apiserverContainer,
-				}
-
-				defResourceRequirements = map[string]*corev1.ResourceRequirements{
-					name:                       defaultResourceRequirements.DeepCopy(),
-					openvpnSidecar.Name:        openvpnSidecar.Resources.DeepCopy(),
-					dnatControllerSidecar.Name: dnatControllerSidecar.Resources.DeepCopy(),
-				}
+			dep.Spec.Template.Spec.Containers = []corev1.Container{}
+			defResourceRequirements := map[string]*corev1.ResourceRequirements{
+				name: defaultResourceRequirements.DeepCopy(),
 			}
+			if konnectivityProxySidecar != nil {
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, *konnectivityProxySidecar)
+				defResourceRequirements[konnectivityProxySidecar.Name] = konnectivityProxySidecar.Resources.DeepCopy()
+			}
+			if openvpnSidecar != nil {
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, *openvpnSidecar, *dnatControllerSidecar)
+				defResourceRequirements[openvpnSidecar.Name] = openvpnSidecar.Resources.DeepCopy()
+				defResourceRequirements[dnatControllerSidecar.Name] = dnatControllerSidecar.Resources.DeepCopy()
+			}
+			dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, *apiserverContainer)
 
 			overrides := resources.GetOverrides(data.Cluster().Spec.ComponentsOverride)
 
-			if auditLogEnabled {
+			if len(kmsProviders) > 0 {
+				kmsSidecars, kmsDefaults, kmsOverrides := kmsPluginSidecars(kmsProviders)
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, kmsSidecars...)
+
+				for containerName, requirements := range kmsDefaults {
+					defResourceRequirements[containerName] = requirements
+				}
+				for containerName, requirements := range kmsOverrides {
+					overrides[containerName] = requirements
+				}
+			}
+
+			if auditSidecarEnabled {
 				defResourceRequirements[auditLogsSidecarName] = &corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
 						corev1.ResourceMemory: resource.MustParse("10Mi"),
@@ -271,7 +285,7 @@ func DeploymentReconciler(data *resources.TemplateData, enableOIDCAuthentication
 	}
 }
 
-func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, enableOIDCAuthentication, auditLogEnabled, enableEncryption bool, version *semverlib.Version) ([]string, error) {
+func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, enableOIDCAuthentication, auditLogEnabled bool, auditWebhookBackend *kubermaticv1.AuditWebhookBackendSettings, enableEncryption bool, version *semverlib.Version) ([]string, error) {
 	overrideFlags, err := getApiserverOverrideFlags(data)
 	if err != nil {
 		return nil, fmt.Errorf("could not get components override flags: %w", err)
@@ -370,6 +384,13 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		flags = append(flags, "--audit-policy-file", "/etc/kubernetes/audit/policy.yaml")
 	}
 
+	if auditWebhookBackend != nil {
+		flags = append(flags, "--audit-webhook-config-file", filepath.Join("/etc/kubernetes/audit-webhook", resources.AuditWebhookConfigSecretKey))
+		if auditWebhookBackend.AuditWebhookInitialBackoff != "" {
+			flags = append(flags, "--audit-webhook-initial-backoff", auditWebhookBackend.AuditWebhookInitialBackoff)
+		}
+	}
+
 	// enable service account signing key and issuer in Kubernetes 1.20 or when
 	// explicitly enabled in the cluster object
 	var audiences []string
@@ -389,7 +410,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		audiences = []string{issuer}
 	}
 
-	if data.IsKonnectivityEnabled() {
+	if data.IsKonnectivityDeployed() {
 		audiences = append(audiences, "system:konnectivity-server")
 	}
 
@@ -441,7 +462,9 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		)
 	}
 
-	if fg := data.GetCSIMigrationFeatureGates(cluster.Status.Versions.Apiserver.Semver()); len(fg) > 0 {
+	fg := data.GetCSIMigrationFeatureGates(cluster.Status.Versions.Apiserver.Semver())
+	fg = append(fg, kmsFeatureGates(cluster, version)...)
+	if len(fg) > 0 {
 		flags = append(flags, "--feature-gates")
 		flags = append(flags, strings.Join(fg, ","))
 	}
@@ -475,7 +498,7 @@ func getApiserverOverrideFlags(data *resources.TemplateData) (kubermaticv1.APISe
 	return settings, nil
 }
 
-func getVolumeMounts(isKonnectivityEnabled, isEncryptionEnabled bool) []corev1.VolumeMount {
+func getVolumeMounts(isKonnectivityEnabled, isEncryptionEnabled, isAuditWebhookEnabled bool) []corev1.VolumeMount {
 	vms := []corev1.VolumeMount{
 		{
 			MountPath: "/etc/kubernetes/tls",
@@ -566,10 +589,18 @@ func getVolumeMounts(isKonnectivityEnabled, isEncryptionEnabled bool) []corev1.V
 		})
 	}
 
+	if isAuditWebhookEnabled {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      resources.AuditWebhookBackendSecretName,
+			MountPath: "/etc/kubernetes/audit-webhook",
+			ReadOnly:  true,
+		})
+	}
+
 	return vms
 }
 
-func getVolumes(isKonnectivityEnabled, isEncryptionEnabled, isAuditEnabled bool) []corev1.Volume {
+func getVolumes(isKonnectivityEnabled, isEncryptionEnabled, isAuditSidecarEnabled, isAuditWebhookEnabled bool) []corev1.Volume {
 	vs := []corev1.Volume{
 		{
 			Name: resources.ApiserverTLSSecretName,
@@ -758,7 +789,7 @@ func getVolumes(isKonnectivityEnabled, isEncryptionEnabled, isAuditEnabled bool)
 		})
 	}
 
-	if isAuditEnabled {
+	if isAuditSidecarEnabled {
 		vs = append(vs, corev1.Volume{
 			Name: resources.FluentBitSecretName,
 			VolumeSource: corev1.VolumeSource{
@@ -769,6 +800,17 @@ func getVolumes(isKonnectivityEnabled, isEncryptionEnabled, isAuditEnabled bool)
 		})
 	}
 
+	if isAuditWebhookEnabled {
+		vs = append(vs, corev1.Volume{
+			Name: resources.AuditWebhookBackendSecretName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.AuditWebhookBackendSecretName,
+				},
+			},
+		})
+	}
+
 	return vs
 }
 
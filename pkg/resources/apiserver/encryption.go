@@ -91,9 +91,13 @@ func EncryptionConfigurationSecretReconciler(data encryptionData) reconciling.Na
 				if data.Cluster().Spec.EncryptionConfiguration.Secretbox != nil {
 					var existingKeys, secretboxKeys []apiserverconfigv1.Key
 
-					if len(existingConfig.Resources) == 1 && len(existingConfig.Resources[0].Providers) == 2 &&
-						existingConfig.Resources[0].Providers[0].Secretbox != nil {
-						existingKeys = existingConfig.Resources[0].Providers[0].Secretbox.Keys
+					if len(existingConfig.Resources) == 1 {
+						for _, provider := range existingConfig.Resources[0].Providers {
+							if provider.Secretbox != nil {
+								existingKeys = provider.Secretbox.Keys
+								break
+							}
+						}
 					}
 
 					for _, key := range data.Cluster().Spec.EncryptionConfiguration.Secretbox.Keys {
@@ -130,6 +134,16 @@ func EncryptionConfigurationSecretReconciler(data encryptionData) reconciling.Na
 					})
 				}
 
+				if data.Cluster().Spec.EncryptionConfiguration.KMS != nil {
+					// every KMS provider is a separate entry; kube-apiserver tries them in order
+					// when decrypting and always encrypts with the first one.
+					for _, provider := range data.Cluster().Spec.EncryptionConfiguration.KMS.Providers {
+						providerList = append(providerList, apiserverconfigv1.ProviderConfiguration{
+							KMS: getKMSConfiguration(provider),
+						})
+					}
+				}
+
 				// always append the "unencrypted" provider.
 				providerList = append(providerList, apiserverconfigv1.ProviderConfiguration{
 					Identity: &apiserverconfigv1.IdentityConfiguration{},
@@ -206,3 +220,17 @@ func getKeyByName(keys []apiserverconfigv1.Key, name string) *apiserverconfigv1.
 
 	return nil
 }
+
+func getKMSConfiguration(provider kubermaticv1.KMSProvider) *apiserverconfigv1.KMSConfiguration {
+	timeout := defaultKMSTimeout
+	if provider.Timeout != nil {
+		timeout = *provider.Timeout
+	}
+
+	return &apiserverconfigv1.KMSConfiguration{
+		APIVersion: "v2",
+		Name:       provider.Name,
+		Endpoint:   encryptionresources.KMSPluginEndpoint(provider.Name),
+		Timeout:    &timeout,
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	semverlib "github.com/Masterminds/semver/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	encryptionresources "k8c.io/kubermatic/v2/pkg/resources/encryption"
+
+	corev1 "k8s.io/api/core/v1"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type fakeEncryptionData struct {
+	cluster *kubermaticv1.Cluster
+}
+
+func (f fakeEncryptionData) Cluster() *kubermaticv1.Cluster {
+	return f.cluster
+}
+
+func (f fakeEncryptionData) GetSecretKeyValue(ref *corev1.SecretKeySelector) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func kmsCluster(providers ...string) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Features: map[string]bool{
+				kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+			},
+			EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+				Enabled:   true,
+				Resources: []string{"secrets"},
+				KMS:       &kubermaticv1.KMSEncryptionConfiguration{},
+			},
+		},
+	}
+
+	for _, name := range providers {
+		cluster.Spec.EncryptionConfiguration.KMS.Providers = append(cluster.Spec.EncryptionConfiguration.KMS.Providers, kubermaticv1.KMSProvider{
+			Name:  name,
+			Image: "example.com/kms-plugin:v1",
+		})
+	}
+
+	return cluster
+}
+
+func reconcileEncryptionConfiguration(t *testing.T, cluster *kubermaticv1.Cluster, secret *corev1.Secret) apiserverconfigv1.EncryptionConfiguration {
+	t.Helper()
+
+	_, reconciler := EncryptionConfigurationSecretReconciler(fakeEncryptionData{cluster: cluster})()
+
+	secret, err := reconciler(secret)
+	if err != nil {
+		t.Fatalf("Failed to reconcile secret: %v", err)
+	}
+
+	var config apiserverconfigv1.EncryptionConfiguration
+	if err := yaml.Unmarshal(secret.Data[resources.EncryptionConfigurationKeyName], &config); err != nil {
+		t.Fatalf("Failed to parse encryption configuration: %v", err)
+	}
+
+	return config
+}
+
+func TestEncryptionConfigurationWithKMS(t *testing.T) {
+	config := reconcileEncryptionConfiguration(t, kmsCluster("new", "old"), &corev1.Secret{})
+
+	if len(config.Resources) != 1 {
+		t.Fatalf("Expected exactly one resource configuration, got %d", len(config.Resources))
+	}
+
+	providers := config.Resources[0].Providers
+	if len(providers) != 3 {
+		t.Fatalf("Expected two KMS providers and identity, got %d providers", len(providers))
+	}
+
+	for i, name := range []string{"new", "old"} {
+		kms := providers[i].KMS
+		if kms == nil {
+			t.Fatalf("Expected provider %d to be a KMS provider", i)
+		}
+
+		if kms.APIVersion != "v2" || kms.Name != name || kms.Endpoint != encryptionresources.KMSPluginEndpoint(name) {
+			t.Errorf("Unexpected KMS configuration for provider %d: %+v", i, kms)
+		}
+
+		if kms.Timeout == nil || *kms.Timeout != defaultKMSTimeout {
+			t.Errorf("Expected default timeout for provider %d, got %v", i, kms.Timeout)
+		}
+	}
+
+	if providers[2].Identity == nil {
+		t.Error("Expected identity to be the last provider")
+	}
+}
+
+func TestEncryptionConfigurationDisableKMS(t *testing.T) {
+	cluster := kmsCluster("vault")
+
+	secret := &corev1.Secret{}
+	_, reconciler := EncryptionConfigurationSecretReconciler(fakeEncryptionData{cluster: cluster})()
+	secret, err := reconciler(secret)
+	if err != nil {
+		t.Fatalf("Failed to reconcile secret: %v", err)
+	}
+
+	cluster.Spec.EncryptionConfiguration.Enabled = false
+	cluster.Status.Conditions = map[kubermaticv1.ClusterConditionType]kubermaticv1.ClusterCondition{
+		kubermaticv1.ClusterConditionEncryptionInitialized: {Status: corev1.ConditionTrue},
+	}
+
+	config := reconcileEncryptionConfiguration(t, cluster, secret)
+	providers := config.Resources[0].Providers
+
+	if len(providers) != 2 || providers[0].Identity == nil || providers[1].KMS == nil {
+		t.Fatalf("Expected identity to be rotated in front of the KMS provider, got %+v", providers)
+	}
+
+	// the plugin must keep running until all data has been decrypted
+	if len(getKMSProviders(cluster)) != 1 {
+		t.Error("Expected KMS plugin to be kept while encryption is still active")
+	}
+}
+
+func TestKMSPluginSidecars(t *testing.T) {
+	cluster := kmsCluster("vault")
+	cluster.Spec.EncryptionConfiguration.KMS.Providers[0].Args = []string{"--listen=$(KMS_PLUGIN_SOCKET)"}
+
+	containers, defaults, overrides := kmsPluginSidecars(getKMSProviders(cluster))
+	if len(containers) != 1 {
+		t.Fatalf("Expected one sidecar, got %d", len(containers))
+	}
+
+	container := containers[0]
+	if container.Name != "kms-vault" {
+		t.Errorf("Expected container name kms-vault, got %q", container.Name)
+	}
+
+	if len(container.Env) == 0 || container.Env[0].Name != encryptionresources.KMSPluginSocketEnvName || container.Env[0].Value != encryptionresources.KMSPluginSocket("vault") {
+		t.Errorf("Expected socket to be passed via environment, got %+v", container.Env)
+	}
+
+	if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != encryptionresources.KMSPluginSocketDir {
+		t.Errorf("Expected socket directory to be mounted, got %+v", container.VolumeMounts)
+	}
+
+	if defaults[container.Name] == nil {
+		t.Error("Expected default resource requirements for the sidecar")
+	}
+
+	if len(overrides) != 0 {
+		t.Errorf("Expected no resource overrides, got %v", overrides)
+	}
+
+	cluster.Spec.EncryptionConfiguration.Enabled = false
+	if len(getKMSProviders(cluster)) != 0 {
+		t.Error("Expected no KMS plugins once encryption is neither enabled nor active")
+	}
+}
+
+func TestKMSFeatureGates(t *testing.T) {
+	testcases := []struct {
+		name     string
+		cluster  *kubermaticv1.Cluster
+		version  string
+		expected []string
+	}{
+		{
+			name:     "KMS v2 needs to be enabled in 1.26",
+			cluster:  kmsCluster("vault"),
+			version:  "1.26.9",
+			expected: []string{"KMSv2=true"},
+		},
+		{
+			name:    "KMS v2 is enabled by default in 1.27",
+			cluster: kmsCluster("vault"),
+			version: "1.27.6",
+		},
+		{
+			name: "no feature gates without KMS",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+						Enabled:   true,
+						Secretbox: &kubermaticv1.SecretboxEncryptionConfiguration{},
+					},
+				},
+			},
+			version: "1.26.9",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gates := kmsFeatureGates(tc.cluster, semverlib.MustParse(tc.version))
+			if !reflect.DeepEqual(gates, tc.expected) {
+				t.Errorf("Expected feature gates %v, got %v", tc.expected, gates)
+			}
+		})
+	}
+}
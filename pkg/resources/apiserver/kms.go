@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"time"
+
+	semverlib "github.com/Masterminds/semver/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	encryptionresources "k8c.io/kubermatic/v2/pkg/resources/encryption"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	kmsPluginVolumeName = "kms-plugin-socket"
+)
+
+var (
+	defaultKMSTimeout = metav1.Duration{Duration: 3 * time.Second}
+
+	// kmsV2DefaultVersion is the first Kubernetes version that enables KMS v2 by default.
+	kmsV2DefaultVersion = semverlib.MustParse("1.27.0")
+
+	defaultKMSPluginResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+	}
+)
+
+// getKMSProviders returns the KMS providers that need to run next to kube-apiserver. Plugins
+// are kept running as long as encryption is active, because kube-apiserver needs them to
+// read data even while encryption is being disabled.
+func getKMSProviders(cluster *kubermaticv1.Cluster) []kubermaticv1.KMSProvider {
+	if !(cluster.IsEncryptionEnabled() || cluster.IsEncryptionActive()) {
+		return nil
+	}
+
+	if cluster.Spec.EncryptionConfiguration == nil || cluster.Spec.EncryptionConfiguration.KMS == nil {
+		return nil
+	}
+
+	return cluster.Spec.EncryptionConfiguration.KMS.Providers
+}
+
+// kmsFeatureGates returns the feature gates kube-apiserver needs for the KMS providers. The
+// encryption configuration always uses KMS v2, which older versions only support behind a gate.
+func kmsFeatureGates(cluster *kubermaticv1.Cluster, version *semverlib.Version) []string {
+	if len(getKMSProviders(cluster)) == 0 || !version.LessThan(kmsV2DefaultVersion) {
+		return nil
+	}
+
+	return []string{"KMSv2=true"}
+}
+
+func kmsPluginContainerName(provider kubermaticv1.KMSProvider) string {
+	return fmt.Sprintf("kms-%s", provider.Name)
+}
+
+// kmsPluginSidecars returns one sidecar container per KMS provider and the resource requirements
+// (defaults and overrides) for them.
+func kmsPluginSidecars(providers []kubermaticv1.KMSProvider) ([]corev1.Container, map[string]*corev1.ResourceRequirements, map[string]*corev1.ResourceRequirements) {
+	var containers []corev1.Container
+
+	defaults := map[string]*corev1.ResourceRequirements{}
+	overrides := map[string]*corev1.ResourceRequirements{}
+
+	for _, provider := range providers {
+		containerName := kmsPluginContainerName(provider)
+
+		env := []corev1.EnvVar{
+			{
+				Name:  encryptionresources.KMSPluginSocketEnvName,
+				Value: encryptionresources.KMSPluginSocket(provider.Name),
+			},
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:    containerName,
+			Image:   provider.Image,
+			Command: provider.Command,
+			Args:    provider.Args,
+			Env:     append(env, provider.Env...),
+			VolumeMounts: []corev1.VolumeMount{
+				kmsPluginVolumeMount(),
+			},
+		})
+
+		defaults[containerName] = defaultKMSPluginResourceRequirements.DeepCopy()
+		if provider.Resources != nil {
+			overrides[containerName] = provider.Resources.DeepCopy()
+		}
+	}
+
+	return containers, defaults, overrides
+}
+
+func kmsPluginVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: kmsPluginVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func kmsPluginVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      kmsPluginVolumeName,
+		MountPath: encryptionresources.KMSPluginSocketDir,
+	}
+}
@@ -40,6 +40,10 @@ func TestServiceReconcilerRequiresExposeStrategy(t *testing.T) {
 			name:           "LoadBalancer is accepted as exposeStrategy",
 			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
 		},
+		{
+			name:           "Gateway is accepted as exposeStrategy",
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+		},
 		{
 			name:        "Empty is not accepted as exposeStrategy",
 			errExpected: true,
@@ -128,6 +132,26 @@ func TestServiceReconcilerSetsPort(t *testing.T) {
 			expectedPort:       int32(443),
 			expectedTargetPort: intstr.FromInt(6443),
 		},
+		{
+			name:           "With gateway strategy KAS uses 6443 as secure port",
+			exposeStrategy: kubermaticv1.ExposeStrategyGateway,
+			inService: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "secure",
+							Port:       int32(443),
+							TargetPort: intstr.FromInt(32000),
+							Protocol:   corev1.ProtocolTCP,
+							NodePort:   int32(32000),
+						},
+					},
+				},
+			},
+			expectedPort:       int32(443),
+			expectedTargetPort: intstr.FromInt(6443),
+		},
 	}
 
 	for _, tc := range testCases {
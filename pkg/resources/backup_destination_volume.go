@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackupDestinationVolume returns a read-only clone of the PersistentVolume that is bound to the
+// PersistentVolumeClaim of a filesystem destination in kube-system. PVCs cannot be shared across
+// namespaces, so the clone is pre-bound to the given claim instead, which makes the backups
+// available in another namespace. This requires a volume type that can be mounted multiple
+// times, like NFS.
+func BackupDestinationVolume(ctx context.Context, client ctrlruntimeclient.Reader, destination *kubermaticv1.BackupDestination, name string, claim types.NamespacedName) (*corev1.PersistentVolume, error) {
+	if destination.Filesystem == nil {
+		return nil, fmt.Errorf("no PersistentVolumeClaim configured for filesystem destination")
+	}
+
+	sourceClaim := &corev1.PersistentVolumeClaim{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: destination.Filesystem.PersistentVolumeClaim}, sourceClaim); err != nil {
+		return nil, fmt.Errorf("failed to get backup destination PersistentVolumeClaim: %w", err)
+	}
+
+	if sourceClaim.Spec.VolumeName == "" {
+		return nil, fmt.Errorf("backup destination PersistentVolumeClaim %s is not bound yet", sourceClaim.Name)
+	}
+
+	sourceVolume := &corev1.PersistentVolume{}
+	if err := client.Get(ctx, types.NamespacedName{Name: sourceClaim.Spec.VolumeName}, sourceVolume); err != nil {
+		return nil, fmt.Errorf("failed to get backup destination PersistentVolume: %w", err)
+	}
+
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: *sourceVolume.Spec.PersistentVolumeSource.DeepCopy(),
+			Capacity:               sourceVolume.Spec.Capacity,
+			AccessModes:            []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+			MountOptions:           sourceVolume.Spec.MountOptions,
+			NodeAffinity:           sourceVolume.Spec.NodeAffinity,
+			VolumeMode:             sourceVolume.Spec.VolumeMode,
+			// never touch the backups when the clone is removed
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			ClaimRef: &corev1.ObjectReference{
+				Namespace: claim.Namespace,
+				Name:      claim.Name,
+			},
+		},
+	}, nil
+}
+
+// BackupDestinationVolumeClaim returns the PersistentVolumeClaim that binds a volume created
+// by BackupDestinationVolume.
+func BackupDestinationVolumeClaim(volume *corev1.PersistentVolume) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volume.Spec.ClaimRef.Name,
+			Namespace: volume.Spec.ClaimRef.Namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+			StorageClassName: ptr.To(""),
+			VolumeName:       volume.Name,
+			VolumeMode:       volume.Spec.VolumeMode,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: volume.Spec.Capacity[corev1.ResourceStorage],
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"k8c.io/reconciler/pkg/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertificateComponentLabelKey is set on the certificate Secrets in the cluster namespace and
+// names the control plane component the certificate belongs to.
+const CertificateComponentLabelKey = "kubermatic.k8c.io/certificate-component"
+
+// certificateSecretComponents maps the certificate Secrets in the cluster namespace to
+// the control plane component they belong to.
+var certificateSecretComponents = map[string]string{
+	CASecretName:           "ca",
+	NextCASecretName:       "ca",
+	FrontProxyCASecretName: "front-proxy",
+	ApiserverFrontProxyClientCertificateSecretName:     "front-proxy",
+	EtcdTLSCertificateSecretName:                       "etcd",
+	ApiserverEtcdClientCertificateSecretName:           "etcd",
+	ApiserverTLSSecretName:                             "apiserver",
+	KubeletClientCertificatesSecretName:                "apiserver",
+	InternalUserClusterAdminKubeconfigSecretName:       "apiserver",
+	ControllerManagerKubeconfigSecretName:              "controller-manager",
+	SchedulerKubeconfigSecretName:                      "scheduler",
+	MachineControllerKubeconfigSecretName:              "machine-controller",
+	MachineControllerWebhookServingCertSecretName:      "machine-controller",
+	OperatingSystemManagerKubeconfigSecretName:         "operating-system-manager",
+	OperatingSystemManagerWebhookKubeconfigSecretName:  "operating-system-manager",
+	OperatingSystemManagerWebhookServingCertSecretName: "operating-system-manager",
+	UserClusterWebhookServingCertSecretName:            "usercluster-webhook",
+	KonnectivityProxyTLSSecretName:                     "konnectivity",
+	KonnectivityKubeconfigSecretName:                   "konnectivity",
+	OpenVPNCASecretName:                                "openvpn",
+	OpenVPNServerCertificatesSecretName:                "openvpn",
+	OpenVPNClientCertificatesSecretName:                "openvpn",
+	MetricsServerKubeconfigSecretName:                  "metrics-server",
+	KubeStateMetricsKubeconfigSecretName:               "kube-state-metrics",
+	KubeletDnatControllerKubeconfigSecretName:          "kubelet-dnat-controller",
+	ClusterAutoscalerKubeconfigSecretName:              "cluster-autoscaler",
+	CloudControllerManagerKubeconfigSecretName:         "cloud-controller-manager",
+	KubernetesDashboardKubeconfigSecretName:            "kubernetes-dashboard",
+	KubeLBCCMKubeconfigSecretName:                      "kubelb",
+}
+
+// CertificateComponentLabeler is an ObjectModifier that labels the known certificate Secrets
+// with their control plane component, so that they can be listed without reading all Secrets
+// of the seed.
+func CertificateComponentLabeler(create reconciling.ObjectReconciler) reconciling.ObjectReconciler {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		obj, err := create(existing)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return obj, nil
+		}
+
+		if component, ok := certificateSecretComponents[secret.Name]; ok {
+			if secret.Labels == nil {
+				secret.Labels = map[string]string{}
+			}
+			secret.Labels[CertificateComponentLabelKey] = component
+		}
+
+		return secret, nil
+	}
+}
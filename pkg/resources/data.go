@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -87,6 +88,8 @@ type TemplateData struct {
 
 	userClusterMLAEnabled bool
 	isKonnectivityEnabled bool
+	// isKonnectivityMigrating is set while a cluster is migrated from OpenVPN to Konnectivity.
+	isKonnectivityMigrating bool
 
 	tunnelingAgentIP string
 
@@ -164,6 +167,11 @@ func (td *TemplateDataBuilder) WithKonnectivityEnabled(enabled bool) *TemplateDa
 	return td
 }
 
+func (td *TemplateDataBuilder) WithKonnectivityMigration(migrating bool) *TemplateDataBuilder {
+	td.data.isKonnectivityMigrating = migrating
+	return td
+}
+
 func (td *TemplateDataBuilder) WithCABundle(bundle CABundle) *TemplateDataBuilder {
 	td.data.caBundle = bundle
 	return td
@@ -318,11 +326,31 @@ func (d *TemplateData) UserClusterMLAEnabled() bool {
 	return d.userClusterMLAEnabled
 }
 
-// IsKonnectivityEnabled returns isKonnectivityEnabled.
+// IsKonnectivityEnabled returns true if the control plane pods reach the user cluster nodes via
+// Konnectivity instead of an OpenVPN client sidecar. Use IsOpenVPNDeployed and IsKonnectivityDeployed
+// to decide whether the OpenVPN or Konnectivity setup itself has to be reconciled.
 func (d *TemplateData) IsKonnectivityEnabled() bool {
 	return d.isKonnectivityEnabled
 }
 
+// IsKonnectivityMigrationInProgress returns true while the cluster is migrated from OpenVPN
+// to Konnectivity. During the migration both setups are deployed side by side.
+func (d *TemplateData) IsKonnectivityMigrationInProgress() bool {
+	return d.isKonnectivityMigrating
+}
+
+// IsKonnectivityDeployed returns true if the Konnectivity server and agents have to be deployed,
+// which is also the case while the control plane still uses OpenVPN during a migration.
+func (d *TemplateData) IsKonnectivityDeployed() bool {
+	return d.isKonnectivityEnabled || d.isKonnectivityMigrating
+}
+
+// IsOpenVPNDeployed returns true if the OpenVPN setup has to be kept, which is also the case
+// while the control plane already uses Konnectivity during a migration.
+func (d *TemplateData) IsOpenVPNDeployed() bool {
+	return !d.isKonnectivityEnabled || d.isKonnectivityMigrating
+}
+
 // NodeAccessNetwork returns the node access network.
 func (d *TemplateData) NodeAccessNetwork() string {
 	return d.nodeAccessNetwork
@@ -433,6 +461,11 @@ func (d *TemplateData) GetRootCA() (*triple.KeyPair, error) {
 	return GetClusterRootCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
 }
 
+// GetRootCABundle returns the PEM-encoded bundle of CAs trusted as root CA of the cluster.
+func (d *TemplateData) GetRootCABundle() ([]byte, error) {
+	return GetClusterRootCABundle(d.ctx, d.cluster.Status.NamespaceName, d.client)
+}
+
 // GetFrontProxyCA returns the root CA for the front proxy.
 func (d *TemplateData) GetFrontProxyCA() (*triple.KeyPair, error) {
 	return GetClusterFrontProxyCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
@@ -471,8 +504,8 @@ func (d *TemplateData) GetOpenVPNServerPort() (int32, error) {
 
 // GetKonnectivityServerPort returns the nodeport of the external Konnectivity Server service.
 func (d *TemplateData) GetKonnectivityServerPort() (int32, error) {
-	// When using tunneling expose strategy the port is fixed and equal to apiserver port
-	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+	// When using tunneling or gateway expose strategy the port is fixed and equal to apiserver port
+	if es := d.Cluster().Spec.ExposeStrategy; es == kubermaticv1.ExposeStrategyTunneling || es == kubermaticv1.ExposeStrategyGateway {
 		return d.Cluster().Status.Address.Port, nil
 	}
 	service := &corev1.Service{}
@@ -503,8 +536,8 @@ func (d *TemplateData) GetTunnelingAgentIP() string {
 
 // GetMLAGatewayPort returns the NodePort of the external MLA Gateway service.
 func (d *TemplateData) GetMLAGatewayPort() (int32, error) {
-	// When using tunneling expose strategy the port is fixed and equal to apiserver port
-	if d.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+	// When using tunneling or gateway expose strategy the port is fixed and equal to apiserver port
+	if es := d.Cluster().Spec.ExposeStrategy; es == kubermaticv1.ExposeStrategyTunneling || es == kubermaticv1.ExposeStrategyGateway {
 		return d.Cluster().Status.Address.Port, nil
 	}
 	service := &corev1.Service{}
@@ -567,6 +600,40 @@ func (d *TemplateData) GetSecretKeyValue(ref *corev1.SecretKeySelector) ([]byte,
 	return val, nil
 }
 
+// GetKubermaticConfigMapKeyValue returns the value of a key in a ConfigMap in the KKP namespace,
+// which is the namespace of the Seed object.
+func (d *TemplateData) GetKubermaticConfigMapKeyValue(ref *corev1.ConfigMapKeySelector) (string, error) {
+	if d.seed == nil {
+		return "", errors.New("no seed configured")
+	}
+
+	cm := corev1.ConfigMap{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Namespace: d.seed.Namespace, Name: ref.Name}, &cm); err != nil {
+		return "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", d.seed.Namespace, ref.Name, err)
+	}
+
+	val, ok := cm.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in ConfigMap %s/%s", ref.Key, d.seed.Namespace, ref.Name)
+	}
+
+	return val, nil
+}
+
+// GetKubermaticSecret returns a Secret in the KKP namespace, which is the namespace of the Seed object.
+func (d *TemplateData) GetKubermaticSecret(name string) (*corev1.Secret, error) {
+	if d.seed == nil {
+		return nil, errors.New("no seed configured")
+	}
+
+	secret := &corev1.Secret{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Namespace: d.seed.Namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s: %w", d.seed.Namespace, name, err)
+	}
+
+	return secret, nil
+}
+
 func (d *TemplateData) GetCloudProviderName() (string, error) {
 	return kubermaticv1helper.ClusterCloudProviderName(d.Cluster().Spec.Cloud)
 }
@@ -16,10 +16,32 @@ limitations under the License.
 
 package encryption
 
+import (
+	"fmt"
+	"path"
+)
+
 const (
 	ApiserverEncryptionRevisionLabelKey = "apiserver-encryption-configuration-secret-revision"
 	ApiserverEncryptionHashLabelKey     = "kubermatic.k8c.io/encryption-spec-hash"
 
 	SecretboxPrefix = "secretbox"
+	KMSPrefix       = "kms"
 	IdentityKey     = "identity"
+
+	// KMSPluginSocketDir is the directory shared between kube-apiserver and the KMS plugin sidecars,
+	// in which the plugins create their unix sockets.
+	KMSPluginSocketDir = "/var/run/kmsplugin"
+	// KMSPluginSocketEnvName is the environment variable that tells a KMS plugin where to listen.
+	KMSPluginSocketEnvName = "KMS_PLUGIN_SOCKET"
 )
+
+// KMSPluginSocket returns the path of the unix socket the KMS plugin with the given name listens on.
+func KMSPluginSocket(name string) string {
+	return path.Join(KMSPluginSocketDir, fmt.Sprintf("%s.sock", name))
+}
+
+// KMSPluginEndpoint returns the endpoint kube-apiserver uses to reach the KMS plugin with the given name.
+func KMSPluginEndpoint(name string) string {
+	return fmt.Sprintf("unix://%s", KMSPluginSocket(name))
+}
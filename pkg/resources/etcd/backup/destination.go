@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	destinationVolumeName = "backup-destination"
+)
+
+// destinationEnvVars returns the environment variables that describe the backup destination to
+// the store and delete containers and the etcd-launcher.
+func destinationEnvVars(destination *kubermaticv1.BackupDestination) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+
+	for _, key := range backend.CredentialKeys(destination.GetType()) {
+		envVars = append(envVars, GenSecretEnvVar(key, key, destination))
+	}
+
+	return append(envVars,
+		corev1.EnvVar{
+			Name:  BucketNameEnvVarKey,
+			Value: destination.BucketName,
+		},
+		corev1.EnvVar{
+			Name:  BackupEndpointEnvVarKey,
+			Value: destination.Endpoint,
+		},
+		corev1.EnvVar{
+			Name:  backend.DestinationTypeEnvVarKey,
+			Value: string(destination.GetType()),
+		},
+	)
+}
+
+// mountDestination mounts the volume of a filesystem destination into the container, which must be
+// part of the given pod spec. All other destination types are accessed over the network.
+func mountDestination(spec *corev1.PodSpec, container *corev1.Container, destination *kubermaticv1.BackupDestination, readOnly bool) {
+	if destination == nil || destination.GetType() != kubermaticv1.BackupDestinationTypeFilesystem || destination.Filesystem == nil {
+		return
+	}
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: destinationVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: destination.Filesystem.PersistentVolumeClaim,
+				ReadOnly:  readOnly,
+			},
+		},
+	})
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      destinationVolumeName,
+		MountPath: backend.FilesystemMountPath,
+		ReadOnly:  readOnly,
+	})
+}
+
+// usesLauncherStoreContainer returns true if backups have to be stored and deleted by the
+// etcd-launcher, as the configurable store and delete containers only support S3.
+func usesLauncherStoreContainer(destination *kubermaticv1.BackupDestination) bool {
+	return destination != nil && destination.GetType() != kubermaticv1.BackupDestinationTypeS3
+}
+
+// launcherStoreContainer returns an etcd-launcher container that runs the given backup command
+// against the destination's backend.
+func launcherStoreContainer(data etcdBackupData, name string, command ...string) *corev1.Container {
+	return &corev1.Container{
+		Name:  name,
+		Image: fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+		Command: append([]string{
+			"/etcd-launcher",
+		}, append(command, fmt.Sprintf("--ca-bundle=/etc/ca-bundle/%s", resources.CABundleConfigMapKey))...),
+	}
+}
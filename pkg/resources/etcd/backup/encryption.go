@@ -0,0 +1,385 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EncryptionDataKeySecretKey is the key in the per-cluster encryption Secret that holds the
+	// plaintext data key.
+	EncryptionDataKeySecretKey = "data-key"
+	// EncryptionKeyIDAnnotation is set on the per-cluster encryption Secret and records the ID of
+	// the seed key that was last used to wrap the data key in the bucket.
+	EncryptionKeyIDAnnotation = "kubermatic.k8c.io/backup-encryption-key-id"
+
+	// EncryptionKeySize is the size of both data keys and seed keys (AES-256).
+	EncryptionKeySize = 32
+
+	// encryptedMagic is prepended to every encrypted object, so that restores can tell
+	// encrypted and plain snapshots apart.
+	encryptedMagic = "KKPENC1\n"
+
+	encryptionChunkSize   = 64 * 1024
+	encryptionNoncePrefix = 4
+
+	encryptionKeyVolumeName = "encryption-key"
+	encryptionKeyMountPath  = "/etc/etcd-backup-encryption"
+)
+
+// EncryptionKeySecretName returns the name of the Secret in kube-system that holds the data key
+// used to encrypt the backups of a cluster for the given destination.
+func EncryptionKeySecretName(cluster *kubermaticv1.Cluster, destination string) string {
+	return fmt.Sprintf("cluster-%s-etcd-backup-key-%s", cluster.Name, destination)
+}
+
+// mountEncryptionKey mounts the cluster's data key into the container and tells the etcd-launcher
+// to use it, if encryption is enabled for the backup destination. The container must be part of
+// the given pod spec.
+func mountEncryptionKey(spec *corev1.PodSpec, container *corev1.Container, data etcdBackupData, config *kubermaticv1.EtcdBackupConfig) {
+	destination := data.EtcdBackupDestination()
+	if destination == nil || destination.Encryption == nil {
+		return
+	}
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: encryptionKeyVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: EncryptionKeySecretName(data.Cluster(), config.Spec.Destination),
+			},
+		},
+	})
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      encryptionKeyVolumeName,
+		MountPath: encryptionKeyMountPath,
+		ReadOnly:  true,
+	})
+
+	container.Command = append(container.Command, fmt.Sprintf("--encryption-key-file=%s/%s", encryptionKeyMountPath, EncryptionDataKeySecretKey))
+}
+
+// WrappedDataKeyObjectName returns the name of the object that holds the wrapped data key next to
+// the backups of a cluster.
+func WrappedDataKeyObjectName(clusterName string) string {
+	return fmt.Sprintf("%s-backup-data-key.json", clusterName)
+}
+
+// GenerateDataKey returns a new random data key.
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, EncryptionKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	return key, nil
+}
+
+// WrappedDataKey is a data key, encrypted with a seed key. It is stored as JSON in the bucket.
+type WrappedDataKey struct {
+	// KeyID is the name of the seed key that was used to wrap the data key.
+	KeyID string `json:"keyID"`
+	// Ciphertext is the nonce followed by the AES-GCM sealed data key.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// WrapDataKey encrypts the data key with the given seed key.
+func WrapDataKey(keyID string, seedKey, dataKey []byte) (*WrappedDataKey, error) {
+	aead, err := newAEAD(seedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return &WrappedDataKey{
+		KeyID:      keyID,
+		Ciphertext: aead.Seal(nonce, nonce, dataKey, []byte(keyID)),
+	}, nil
+}
+
+// UnwrapDataKey decrypts the data key with the seed key it was wrapped with.
+func UnwrapDataKey(wrapped *WrappedDataKey, seedKeys map[string][]byte) ([]byte, error) {
+	seedKey, ok := seedKeys[wrapped.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("seed key %q does not exist anymore", wrapped.KeyID)
+	}
+
+	aead, err := newAEAD(seedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapped.Ciphertext) < aead.NonceSize() {
+		return nil, errors.New("wrapped data key is too short")
+	}
+
+	nonce, ciphertext := wrapped.Ciphertext[:aead.NonceSize()], wrapped.Ciphertext[aead.NonceSize():]
+
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(wrapped.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with seed key %q: %w", wrapped.KeyID, err)
+	}
+
+	return dataKey, nil
+}
+
+// EncodeWrappedDataKey serializes a wrapped data key for storing it in the bucket.
+func EncodeWrappedDataKey(wrapped *WrappedDataKey) ([]byte, error) {
+	return json.Marshal(wrapped)
+}
+
+// DecodeWrappedDataKey parses a wrapped data key created by EncodeWrappedDataKey.
+func DecodeWrappedDataKey(data []byte) (*WrappedDataKey, error) {
+	wrapped := &WrappedDataKey{}
+	if err := json.Unmarshal(data, wrapped); err != nil {
+		return nil, fmt.Errorf("invalid wrapped data key: %w", err)
+	}
+
+	return wrapped, nil
+}
+
+// GetSeedKeys returns all seed keys from the Secret configured for the destination and ensures
+// that the active key is among them.
+func GetSeedKeys(ctx context.Context, client ctrlruntimeclient.Client, encryption *kubermaticv1.BackupEncryption) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: encryption.KeySecret.Namespace, Name: encryption.KeySecret.Name}
+	if err := client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get backup encryption Secret: %w", err)
+	}
+
+	active, ok := secret.Data[encryption.ActiveKey]
+	if !ok {
+		return nil, fmt.Errorf("backup encryption Secret %s does not contain the active key %q", key, encryption.ActiveKey)
+	}
+	if len(active) != EncryptionKeySize {
+		return nil, fmt.Errorf("active backup encryption key %q must be %d bytes long", encryption.ActiveKey, EncryptionKeySize)
+	}
+
+	return secret.Data, nil
+}
+
+// DownloadWrappedDataKey fetches the wrapped data key of a cluster from the backup destination. If
+// the cluster's backups have never been encrypted, nil is returned.
+func DownloadWrappedDataKey(ctx context.Context, b backend.Backend, clusterName string) (*WrappedDataKey, error) {
+	objectName := WrappedDataKeyObjectName(clusterName)
+
+	object, err := b.Download(ctx, objectName)
+	if err != nil {
+		if errors.Is(err, backend.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to download %s: %w", objectName, err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", objectName, err)
+	}
+
+	return DecodeWrappedDataKey(data)
+}
+
+// UploadWrappedDataKey stores the wrapped data key of a cluster in the backup destination, replacing
+// the previous one. Backups are not touched, as they are encrypted with the data key itself.
+func UploadWrappedDataKey(ctx context.Context, b backend.Backend, clusterName string, wrapped *WrappedDataKey) error {
+	data, err := EncodeWrappedDataKey(wrapped)
+	if err != nil {
+		return err
+	}
+
+	objectName := WrappedDataKeyObjectName(clusterName)
+	if err := b.Upload(ctx, objectName, bytes.NewReader(data), int64(len(data))); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectName, err)
+	}
+
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("key must be %d bytes long, but is %d bytes", EncryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce for the n-th chunk. Using a counter ensures that chunks cannot be
+// reordered, while the random prefix ensures that nonces are not reused across objects.
+func chunkNonce(prefix []byte, n uint64) []byte {
+	nonce := make([]byte, encryptionNoncePrefix+8)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint64(nonce[encryptionNoncePrefix:], n)
+
+	return nonce
+}
+
+// chunkAdditionalData marks the last chunk, so that truncated objects are detected.
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+
+	return []byte{0}
+}
+
+// Encrypt reads all data from r and writes it encrypted with the data key to w. The data is
+// split into chunks, so that arbitrarily large snapshots can be encrypted in constant memory.
+func Encrypt(w io.Writer, r io.Reader, dataKey []byte) error {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	prefix := make([]byte, encryptionNoncePrefix)
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	if _, err := io.WriteString(w, encryptedMagic); err != nil {
+		return err
+	}
+	if _, err := w.Write(prefix); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReaderSize(r, encryptionChunkSize)
+	chunk := make([]byte, encryptionChunkSize)
+	length := make([]byte, 4)
+
+	for n := uint64(0); ; n++ {
+		read, err := io.ReadFull(reader, chunk)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		// peek to find out if this is the last chunk
+		_, peekErr := reader.Peek(1)
+		last := errors.Is(peekErr, io.EOF)
+
+		sealed := aead.Seal(nil, chunkNonce(prefix, n), chunk[:read], chunkAdditionalData(last))
+
+		binary.BigEndian.PutUint32(length, uint32(len(sealed)))
+		if _, err := w.Write(length); err != nil {
+			return err
+		}
+		if _, err := w.Write(sealed); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// Decrypt reads data written by Encrypt from r and writes the plaintext to w.
+func Decrypt(w io.Writer, r io.Reader, dataKey []byte) error {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, len(encryptedMagic)+encryptionNoncePrefix)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+
+	if !bytes.HasPrefix(header, []byte(encryptedMagic)) {
+		return errors.New("data is not encrypted")
+	}
+	prefix := header[len(encryptedMagic):]
+
+	length := make([]byte, 4)
+	maxLength := uint32(encryptionChunkSize + aead.Overhead())
+
+	for n := uint64(0); ; n++ {
+		if _, err := io.ReadFull(r, length); err != nil {
+			return fmt.Errorf("data is truncated: %w", err)
+		}
+
+		size := binary.BigEndian.Uint32(length)
+		if size > maxLength {
+			return fmt.Errorf("invalid chunk size %d", size)
+		}
+
+		sealed := make([]byte, size)
+		if _, err := io.ReadFull(r, sealed); err != nil {
+			return fmt.Errorf("data is truncated: %w", err)
+		}
+
+		last := false
+		plaintext, err := aead.Open(nil, chunkNonce(prefix, n), sealed, chunkAdditionalData(false))
+		if err != nil {
+			plaintext, err = aead.Open(nil, chunkNonce(prefix, n), sealed, chunkAdditionalData(true))
+			if err != nil {
+				return fmt.Errorf("failed to decrypt chunk %d: %w", n, err)
+			}
+			last = true
+		}
+
+		if _, err := w.Write(plaintext); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// IsEncrypted returns true if the data starts with the header written by Encrypt.
+// The reader is not advanced.
+func IsEncrypted(r *bufio.Reader) (bool, error) {
+	header, err := r.Peek(len(encryptedMagic))
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return string(header) == encryptedMagic, nil
+}
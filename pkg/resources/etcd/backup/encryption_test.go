@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	dataKey, err := GenerateDataKey()
+	if err != nil {
+		t.Fatalf("Failed to generate data key: %v", err)
+	}
+
+	testcases := []struct {
+		name string
+		size int
+	}{
+		{name: "empty", size: 0},
+		{name: "smaller than a chunk", size: 100},
+		{name: "exactly one chunk", size: encryptionChunkSize},
+		{name: "multiple chunks", size: 3*encryptionChunkSize + 17},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			plaintext := make([]byte, tc.size)
+			if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+				t.Fatalf("Failed to generate data: %v", err)
+			}
+
+			encrypted := &bytes.Buffer{}
+			if err := Encrypt(encrypted, bytes.NewReader(plaintext), dataKey); err != nil {
+				t.Fatalf("Failed to encrypt: %v", err)
+			}
+
+			isEncrypted, err := IsEncrypted(bufio.NewReader(bytes.NewReader(encrypted.Bytes())))
+			if err != nil {
+				t.Fatalf("Failed to check for encryption: %v", err)
+			}
+			if !isEncrypted {
+				t.Fatal("Expected data to be detected as encrypted.")
+			}
+
+			decrypted := &bytes.Buffer{}
+			if err := Decrypt(decrypted, bytes.NewReader(encrypted.Bytes()), dataKey); err != nil {
+				t.Fatalf("Failed to decrypt: %v", err)
+			}
+
+			if !bytes.Equal(plaintext, decrypted.Bytes()) {
+				t.Fatal("Decrypted data does not match the plaintext.")
+			}
+
+			// cutting off the last chunk must be detected
+			if tc.size > encryptionChunkSize {
+				truncated := encrypted.Bytes()[:encrypted.Len()-(tc.size%encryptionChunkSize)-4-16]
+				if err := Decrypt(io.Discard, bytes.NewReader(truncated), dataKey); err == nil {
+					t.Fatal("Expected truncated data to be rejected.")
+				}
+			}
+
+			otherKey, _ := GenerateDataKey()
+			if err := Decrypt(io.Discard, bytes.NewReader(encrypted.Bytes()), otherKey); err == nil {
+				t.Fatal("Expected decryption with the wrong key to fail.")
+			}
+		})
+	}
+}
+
+func TestIsEncryptedPlainSnapshot(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("abc"), bytes.Repeat([]byte{0}, 1024)} {
+		isEncrypted, err := IsEncrypted(bufio.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("Failed to check for encryption: %v", err)
+		}
+		if isEncrypted {
+			t.Fatalf("Expected %d bytes of plain data not to be detected as encrypted.", len(data))
+		}
+	}
+}
+
+func TestWrapDataKey(t *testing.T) {
+	dataKey, _ := GenerateDataKey()
+	oldSeedKey, _ := GenerateDataKey()
+	newSeedKey, _ := GenerateDataKey()
+
+	seedKeys := map[string][]byte{
+		"old": oldSeedKey,
+		"new": newSeedKey,
+	}
+
+	wrapped, err := WrapDataKey("old", oldSeedKey, dataKey)
+	if err != nil {
+		t.Fatalf("Failed to wrap data key: %v", err)
+	}
+
+	encoded, err := EncodeWrappedDataKey(wrapped)
+	if err != nil {
+		t.Fatalf("Failed to encode wrapped data key: %v", err)
+	}
+
+	decoded, err := DecodeWrappedDataKey(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode wrapped data key: %v", err)
+	}
+
+	unwrapped, err := UnwrapDataKey(decoded, seedKeys)
+	if err != nil {
+		t.Fatalf("Failed to unwrap data key: %v", err)
+	}
+
+	if !bytes.Equal(dataKey, unwrapped) {
+		t.Fatal("Unwrapped data key does not match.")
+	}
+
+	// rotating the seed key must yield the same data key
+	rewrapped, err := WrapDataKey("new", newSeedKey, unwrapped)
+	if err != nil {
+		t.Fatalf("Failed to re-wrap data key: %v", err)
+	}
+
+	unwrapped, err = UnwrapDataKey(rewrapped, seedKeys)
+	if err != nil {
+		t.Fatalf("Failed to unwrap re-wrapped data key: %v", err)
+	}
+
+	if !bytes.Equal(dataKey, unwrapped) {
+		t.Fatal("Re-wrapped data key does not match.")
+	}
+
+	// the key ID is authenticated, so it cannot be swapped
+	rewrapped.KeyID = "old"
+	if _, err := UnwrapDataKey(rewrapped, seedKeys); err == nil {
+		t.Fatal("Expected unwrapping with the wrong seed key to fail.")
+	}
+
+	delete(seedKeys, "new")
+	rewrapped.KeyID = "new"
+	if _, err := UnwrapDataKey(rewrapped, seedKeys); err == nil {
+		t.Fatal("Expected unwrapping with a removed seed key to fail.")
+	}
+}
@@ -76,23 +76,27 @@ type etcdBackupData interface {
 }
 
 func BackupJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) *batchv1.Job {
+	destination := data.EtcdBackupDestination()
+
 	storeContainer := data.EtcdBackupStoreContainer().DeepCopy()
+	if usesLauncherStoreContainer(destination) {
+		storeContainer = launcherStoreContainer(data, "store-container", "store-backup", "--file=/backup/snapshot.db", fmt.Sprintf("--object=%s", BackupObjectName(data.Cluster(), status)))
+		storeContainer.VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      SharedVolumeName,
+				MountPath: "/backup",
+			},
+		}
+	}
 
 	// If destination is set, we need to set the credentials and backup bucket details to match the destination
-	if data.EtcdBackupDestination() != nil {
-		storeContainer.Env = setEnvVar(storeContainer.Env, GenSecretEnvVar(AccessKeyIdEnvVarKey, AccessKeyIdEnvVarKey, data.EtcdBackupDestination()))
-		storeContainer.Env = setEnvVar(storeContainer.Env, GenSecretEnvVar(SecretAccessKeyEnvVarKey, SecretAccessKeyEnvVarKey, data.EtcdBackupDestination()))
-		storeContainer.Env = setEnvVar(storeContainer.Env, corev1.EnvVar{
-			Name:  BucketNameEnvVarKey,
-			Value: data.EtcdBackupDestination().BucketName,
-		})
-		storeContainer.Env = setEnvVar(storeContainer.Env, corev1.EnvVar{
-			Name:  BackupEndpointEnvVarKey,
-			Value: data.EtcdBackupDestination().Endpoint,
-		})
+	if destination != nil {
+		for _, envVar := range destinationEnvVars(destination) {
+			storeContainer.Env = setEnvVar(storeContainer.Env, envVar)
+		}
 
 		insecure := "false"
-		if isInsecureURL(data.EtcdBackupDestination().Endpoint) {
+		if isInsecureURL(destination.Endpoint) {
 			insecure = "true"
 		}
 
@@ -185,6 +189,9 @@ func BackupJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, statu
 		},
 	}
 
+	mountEncryptionKey(&job.Spec.Template.Spec, &job.Spec.Template.Spec.InitContainers[0], data, config)
+	mountDestination(&job.Spec.Template.Spec, &job.Spec.Template.Spec.Containers[0], destination, false)
+
 	return job
 }
 
@@ -212,23 +219,21 @@ func setEnvVar(envVars []corev1.EnvVar, newEnvVar corev1.EnvVar) []corev1.EnvVar
 }
 
 func BackupDeleteJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) *batchv1.Job {
+	destination := data.EtcdBackupDestination()
+
 	deleteContainer := data.EtcdBackupDeleteContainer().DeepCopy()
+	if usesLauncherStoreContainer(destination) {
+		deleteContainer = launcherStoreContainer(data, "delete-container", "delete-backup", fmt.Sprintf("--object=%s", BackupObjectName(data.Cluster(), status)))
+	}
 
 	// If destination is set, we need to set the credentials and backup bucket details to match the destination
-	if data.EtcdBackupDestination() != nil {
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, GenSecretEnvVar(AccessKeyIdEnvVarKey, AccessKeyIdEnvVarKey, data.EtcdBackupDestination()))
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, GenSecretEnvVar(SecretAccessKeyEnvVarKey, SecretAccessKeyEnvVarKey, data.EtcdBackupDestination()))
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, corev1.EnvVar{
-			Name:  BucketNameEnvVarKey,
-			Value: data.EtcdBackupDestination().BucketName,
-		})
-		deleteContainer.Env = setEnvVar(deleteContainer.Env, corev1.EnvVar{
-			Name:  BackupEndpointEnvVarKey,
-			Value: data.EtcdBackupDestination().Endpoint,
-		})
+	if destination != nil {
+		for _, envVar := range destinationEnvVars(destination) {
+			deleteContainer.Env = setEnvVar(deleteContainer.Env, envVar)
+		}
 
 		insecure := "false"
-		if isInsecureURL(data.EtcdBackupDestination().Endpoint) {
+		if isInsecureURL(destination.Endpoint) {
 			insecure = "true"
 		}
 
@@ -282,6 +287,9 @@ func BackupDeleteJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig,
 			},
 		},
 	}
+
+	mountDestination(&job.Spec.Template.Spec, &job.Spec.Template.Spec.Containers[0], destination, false)
+
 	return job
 }
 
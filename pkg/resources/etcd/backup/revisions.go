@@ -0,0 +1,210 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RevisionEventPut marks a RevisionEvent that sets a key.
+	RevisionEventPut = "PUT"
+	// RevisionEventDelete marks a RevisionEvent that removes a key.
+	RevisionEventDelete = "DELETE"
+
+	segmentSuffix = ".jsonl.gz"
+)
+
+// RevisionEvent is a single change to etcd, as observed by the revision streamer.
+type RevisionEvent struct {
+	// Revision is the etcd revision that this change created.
+	Revision int64 `json:"rev"`
+	// Time is the unix time (in nanoseconds) at which the streamer observed the revision.
+	Time  int64  `json:"ts"`
+	Type  string `json:"type"`
+	Key   []byte `json:"key"`
+	Value []byte `json:"value,omitempty"`
+}
+
+// Segment describes an uploaded object containing all events for a contiguous range of revisions.
+type Segment struct {
+	ObjectName    string
+	FirstRevision int64
+	LastRevision  int64
+}
+
+// RevisionSegmentPrefix returns the object name prefix under which the revision streamer
+// uploads segments for the given cluster.
+func RevisionSegmentPrefix(clusterName string) string {
+	return fmt.Sprintf("%s-revisions/", clusterName)
+}
+
+// RevisionSegmentObjectName returns the object name for a segment. Revisions are zero-padded so
+// that a lexical sort of the object names also sorts the segments by revision.
+func RevisionSegmentObjectName(clusterName string, first, last int64) string {
+	return fmt.Sprintf("%s%019d-%019d%s", RevisionSegmentPrefix(clusterName), first, last, segmentSuffix)
+}
+
+// ParseRevisionSegment parses an object name created by RevisionSegmentObjectName.
+func ParseRevisionSegment(clusterName, objectName string) (*Segment, error) {
+	name := strings.TrimPrefix(objectName, RevisionSegmentPrefix(clusterName))
+	if name == objectName || !strings.HasSuffix(name, segmentSuffix) {
+		return nil, fmt.Errorf("%q is not a revision segment of cluster %s", objectName, clusterName)
+	}
+
+	first, last, found := strings.Cut(strings.TrimSuffix(name, segmentSuffix), "-")
+	if !found {
+		return nil, fmt.Errorf("%q is not a revision segment of cluster %s", objectName, clusterName)
+	}
+
+	firstRev, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid first revision in %q: %w", objectName, err)
+	}
+
+	lastRev, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid last revision in %q: %w", objectName, err)
+	}
+
+	if lastRev < firstRev {
+		return nil, fmt.Errorf("invalid revision range in %q", objectName)
+	}
+
+	return &Segment{
+		ObjectName:    objectName,
+		FirstRevision: firstRev,
+		LastRevision:  lastRev,
+	}, nil
+}
+
+// SortSegments sorts segments by their first revision.
+func SortSegments(segments []Segment) {
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].FirstRevision < segments[j].FirstRevision
+	})
+}
+
+// EncodeRevisionEvents writes the events as gzipped JSON lines.
+func EncodeRevisionEvents(w io.Writer, events []RevisionEvent) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
+
+// DecodeRevisionEvents reads events written by EncodeRevisionEvents.
+func DecodeRevisionEvents(r io.Reader) ([]RevisionEvent, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var events []RevisionEvent
+
+	decoder := json.NewDecoder(bufio.NewReader(gz))
+	for {
+		event := RevisionEvent{}
+		if err := decoder.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// RevisionTarget describes up to where revisions should be replayed. Exactly one of the
+// fields must be set.
+type RevisionTarget struct {
+	Revision *int64
+	Time     *time.Time
+}
+
+// SelectRevisionEvents returns all events that need to be replayed on top of a snapshot
+// at snapshotRevision to reach the target. The events must be sorted by revision. An error
+// is returned if the events do not form a gapless history starting right after the snapshot,
+// as replaying them would result in an inconsistent state.
+func SelectRevisionEvents(events []RevisionEvent, snapshotRevision int64, target RevisionTarget) ([]RevisionEvent, error) {
+	if (target.Revision == nil) == (target.Time == nil) {
+		return nil, errors.New("exactly one of target revision and target time must be set")
+	}
+
+	if target.Revision != nil && *target.Revision < snapshotRevision {
+		return nil, fmt.Errorf("target revision %d is older than the snapshot revision %d", *target.Revision, snapshotRevision)
+	}
+
+	var selected []RevisionEvent
+
+	reachedTime := false
+	lastRevision := snapshotRevision
+	for _, event := range events {
+		if event.Revision <= snapshotRevision {
+			continue
+		}
+
+		if target.Revision != nil && event.Revision > *target.Revision {
+			break
+		}
+
+		// every etcd revision contains at least one event, so any jump means that
+		// the streamer missed some changes
+		if event.Revision != lastRevision && event.Revision != lastRevision+1 {
+			return nil, fmt.Errorf("revision history has a gap between revision %d and %d", lastRevision, event.Revision)
+		}
+
+		// all events of a revision are observed at the same time, so this never splits a revision
+		if target.Time != nil && time.Unix(0, event.Time).After(*target.Time) {
+			reachedTime = true
+			break
+		}
+
+		lastRevision = event.Revision
+		selected = append(selected, event)
+	}
+
+	if target.Revision != nil && lastRevision != *target.Revision {
+		return nil, fmt.Errorf("revision history ends at revision %d, before target revision %d", lastRevision, *target.Revision)
+	}
+
+	// without any event after the target time we cannot know whether the
+	// streamer recorded everything up to it
+	if target.Time != nil && !reachedTime {
+		return nil, fmt.Errorf("revision history ends at revision %d, before target time %s", lastRevision, target.Time.Format(time.RFC3339))
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestRevisionSegmentObjectName(t *testing.T) {
+	name := RevisionSegmentObjectName("abc", 42, 1337)
+	if expected := "abc-revisions/0000000000000000042-0000000000000001337.jsonl.gz"; name != expected {
+		t.Fatalf("Expected object name %q, got %q.", expected, name)
+	}
+
+	segment, err := ParseRevisionSegment("abc", name)
+	if err != nil {
+		t.Fatalf("Failed to parse object name: %v", err)
+	}
+
+	if segment.FirstRevision != 42 || segment.LastRevision != 1337 {
+		t.Fatalf("Expected revisions 42-1337, got %d-%d.", segment.FirstRevision, segment.LastRevision)
+	}
+
+	for _, invalid := range []string{
+		"abc-revisions/foo.jsonl.gz",
+		"abc-revisions/0000000000000000042.jsonl.gz",
+		"abc-revisions/0000000000000000042-0000000000000000001.jsonl.gz",
+		"xyz-revisions/0000000000000000042-0000000000000001337.jsonl.gz",
+		"abc-backup-2023-01-01T00:00:00",
+	} {
+		if _, err := ParseRevisionSegment("abc", invalid); err == nil {
+			t.Errorf("Expected %q to be rejected, but it was parsed successfully.", invalid)
+		}
+	}
+}
+
+func TestEncodeDecodeRevisionEvents(t *testing.T) {
+	events := []RevisionEvent{
+		{Revision: 10, Time: 1000, Type: RevisionEventPut, Key: []byte("/foo"), Value: []byte("bar")},
+		{Revision: 11, Time: 2000, Type: RevisionEventDelete, Key: []byte("/foo")},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := EncodeRevisionEvents(buf, events); err != nil {
+		t.Fatalf("Failed to encode events: %v", err)
+	}
+
+	decoded, err := DecodeRevisionEvents(buf)
+	if err != nil {
+		t.Fatalf("Failed to decode events: %v", err)
+	}
+
+	if diff := deep.Equal(events, decoded); diff != nil {
+		t.Fatalf("Decoded events do not match: %v", diff)
+	}
+}
+
+func TestSelectRevisionEvents(t *testing.T) {
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) int64 {
+		return base.Add(time.Duration(minutes) * time.Minute).UnixNano()
+	}
+	timeTarget := func(minutes int) *time.Time {
+		return ptr.To(base.Add(time.Duration(minutes) * time.Minute))
+	}
+
+	events := []RevisionEvent{
+		{Revision: 4, Time: at(0)},
+		{Revision: 5, Time: at(1)},
+		{Revision: 6, Time: at(2)},
+		{Revision: 6, Time: at(2)},
+		{Revision: 7, Time: at(3)},
+		{Revision: 8, Time: at(4)},
+	}
+
+	testcases := []struct {
+		name              string
+		events            []RevisionEvent
+		snapshotRevision  int64
+		target            RevisionTarget
+		expectedRevisions []int64
+		expectErr         bool
+	}{
+		{
+			name:              "replay up to a revision",
+			events:            events,
+			snapshotRevision:  4,
+			target:            RevisionTarget{Revision: ptr.To[int64](6)},
+			expectedRevisions: []int64{5, 6, 6},
+		},
+		{
+			name:              "target revision equals snapshot",
+			events:            events,
+			snapshotRevision:  4,
+			target:            RevisionTarget{Revision: ptr.To[int64](4)},
+			expectedRevisions: nil,
+		},
+		{
+			name:             "target revision before snapshot",
+			events:           events,
+			snapshotRevision: 5,
+			target:           RevisionTarget{Revision: ptr.To[int64](4)},
+			expectErr:        true,
+		},
+		{
+			name:             "target revision not yet recorded",
+			events:           events,
+			snapshotRevision: 4,
+			target:           RevisionTarget{Revision: ptr.To[int64](9)},
+			expectErr:        true,
+		},
+		{
+			name:              "replay up to a time",
+			events:            events,
+			snapshotRevision:  4,
+			target:            RevisionTarget{Time: timeTarget(2)},
+			expectedRevisions: []int64{5, 6, 6},
+		},
+		{
+			name:             "target time not yet recorded",
+			events:           events,
+			snapshotRevision: 4,
+			target:           RevisionTarget{Time: timeTarget(10)},
+			expectErr:        true,
+		},
+		{
+			name: "gap in history",
+			events: []RevisionEvent{
+				{Revision: 5, Time: at(1)},
+				{Revision: 7, Time: at(3)},
+			},
+			snapshotRevision: 4,
+			target:           RevisionTarget{Revision: ptr.To[int64](7)},
+			expectErr:        true,
+		},
+		{
+			name:             "history starts after snapshot",
+			events:           events,
+			snapshotRevision: 2,
+			target:           RevisionTarget{Revision: ptr.To[int64](6)},
+			expectErr:        true,
+		},
+		{
+			name:             "no target",
+			events:           events,
+			snapshotRevision: 4,
+			expectErr:        true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := SelectRevisionEvents(tc.events, tc.snapshotRevision, tc.target)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got none.")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var revisions []int64
+			for _, event := range selected {
+				revisions = append(revisions, event.Revision)
+			}
+
+			if diff := deep.Equal(tc.expectedRevisions, revisions); diff != nil {
+				t.Fatalf("Selected revisions do not match: %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// RevisionStreamerLabel defines the label we use on all revision streamer Deployments.
+	RevisionStreamerLabel = "kubermatic-etcd-revision-streamer"
+)
+
+// RevisionStreamerDeploymentName returns the name of the Deployment that streams the etcd
+// revisions for a continuous EtcdBackupConfig.
+func RevisionStreamerDeploymentName(config *kubermaticv1.EtcdBackupConfig) string {
+	return fmt.Sprintf("%s-revisions-%s", config.Spec.Cluster.Name, config.Name)
+}
+
+// RevisionStreamerDeploymentReconciler returns the Deployment that continuously watches the
+// cluster's etcd and uploads all revisions as segments into the backup destination.
+func RevisionStreamerDeploymentReconciler(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig) reconciling.NamedDeploymentReconcilerFactory {
+	return func() (string, reconciling.DeploymentReconciler) {
+		return RevisionStreamerDeploymentName(config), func(dep *appsv1.Deployment) (*appsv1.Deployment, error) {
+			cluster := data.Cluster()
+			destination := data.EtcdBackupDestination()
+			if destination == nil {
+				return nil, fmt.Errorf("no backup destination configured for %s", config.Name)
+			}
+
+			labels := map[string]string{
+				resources.AppLabelKey:     RevisionStreamerLabel,
+				resources.ClusterLabelKey: cluster.Name,
+				BackupConfigNameLabelKey:  config.Name,
+			}
+
+			dep.Labels = labels
+			dep.OwnerReferences = []metav1.OwnerReference{data.GetClusterRef()}
+
+			// only a single streamer must ever upload segments for a cluster, so never
+			// run two pods at the same time, even during updates
+			dep.Spec.Replicas = ptr.To[int32](1)
+			dep.Spec.Strategy = appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			}
+			dep.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: labels,
+			}
+			dep.Spec.Template.Labels = labels
+
+			dep.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", rbac.EtcdLauncherServiceAccountName, cluster.Name)
+			dep.Spec.Template.Spec.Containers = []corev1.Container{
+				{
+					Name:    "revision-streamer",
+					Image:   fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+					Command: streamRevisionsCommand(cluster, config),
+					Env:     destinationEnvVars(destination),
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("10m"),
+							corev1.ResourceMemory: resource.MustParse("32Mi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("200m"),
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      GetEtcdBackupSecretName(cluster),
+							MountPath: "/etc/etcd/pki/client",
+						},
+						{
+							Name:      "ca-bundle",
+							MountPath: "/etc/ca-bundle/",
+							ReadOnly:  true,
+						},
+					},
+				},
+			}
+
+			dep.Spec.Template.Spec.Volumes = []corev1.Volume{
+				{
+					Name: GetEtcdBackupSecretName(cluster),
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: GetEtcdBackupSecretName(cluster),
+						},
+					},
+				},
+				{
+					Name: "ca-bundle",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: caBundleConfigMapName(cluster),
+							},
+						},
+					},
+				},
+			}
+
+			mountEncryptionKey(&dep.Spec.Template.Spec, &dep.Spec.Template.Spec.Containers[0], data, config)
+			mountDestination(&dep.Spec.Template.Spec, &dep.Spec.Template.Spec.Containers[0], destination, false)
+
+			return dep, nil
+		}
+	}
+}
+
+func streamRevisionsCommand(cluster *kubermaticv1.Cluster, config *kubermaticv1.EtcdBackupConfig) []string {
+	return []string{
+		"/etcd-launcher",
+		"stream-revisions",
+		"--etcd-ca-file=/etc/etcd/pki/client/ca.crt",
+		"--etcd-client-cert-file=/etc/etcd/pki/client/backup-etcd-client.crt",
+		"--etcd-client-key-file=/etc/etcd/pki/client/backup-etcd-client.key",
+		fmt.Sprintf("--cluster=%s", cluster.Name),
+		fmt.Sprintf("--ca-bundle=/etc/ca-bundle/%s", resources.CABundleConfigMapKey),
+		fmt.Sprintf("--segment-interval=%s", config.Spec.Continuous.GetSegmentInterval()),
+		fmt.Sprintf("--retention=%s", config.Spec.Continuous.GetRetention()),
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// BackupVerifyContainerName is the name of the container in the verify job
+	// whose termination message contains the VerificationResult.
+	BackupVerifyContainerName = "backup-verifier"
+)
+
+// VerificationResult is what the verify job reports back as its termination message.
+type VerificationResult struct {
+	// Checksum is the hex-encoded SHA-256 checksum of the backup object.
+	Checksum string `json:"checksum"`
+	// Revision is the etcd revision stored in the snapshot.
+	Revision int64 `json:"revision"`
+	// KeyCount is the number of keys after restoring the snapshot, or nil if no
+	// restore drill was performed.
+	KeyCount *int64 `json:"keyCount,omitempty"`
+}
+
+// ParseVerificationResult parses the termination message of a verify job container.
+func ParseVerificationResult(message string) (*VerificationResult, error) {
+	result := &VerificationResult{}
+	if err := json.Unmarshal([]byte(message), result); err != nil {
+		return nil, fmt.Errorf("invalid verification result: %w", err)
+	}
+
+	if result.Checksum == "" {
+		return nil, fmt.Errorf("verification result contains no checksum")
+	}
+
+	return result, nil
+}
+
+// BackupObjectName returns the name under which the store container uploads the backup.
+func BackupObjectName(cluster *kubermaticv1.Cluster, status *kubermaticv1.BackupStatus) string {
+	return fmt.Sprintf("%s-%s", cluster.Name, status.BackupName)
+}
+
+// BackupVerifyJob returns the job that downloads a completed backup again and checks that it is
+// a valid etcd snapshot.
+func BackupVerifyJob(data etcdBackupData, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) *batchv1.Job {
+	destination := data.EtcdBackupDestination()
+
+	job := jobBase(config, data.Cluster(), status.VerifyJobName)
+	// downloading and possibly restoring a large snapshot takes longer than creating it
+	job.Spec.ActiveDeadlineSeconds = resources.Int64(10 * 60)
+
+	job.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:                     BackupVerifyContainerName,
+			Image:                    fmt.Sprintf("%s:%s", data.EtcdLauncherImage(), data.EtcdLauncherTag()),
+			Command:                  verifySnapshotCommand(data.Cluster(), config, status),
+			Env:                      destinationEnvVars(destination),
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      SharedVolumeName,
+					MountPath: "/backup",
+				},
+				{
+					Name:      "ca-bundle",
+					MountPath: "/etc/ca-bundle/",
+					ReadOnly:  true,
+				},
+			},
+		},
+	}
+
+	job.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: SharedVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: caBundleConfigMapName(data.Cluster()),
+					},
+				},
+			},
+		},
+	}
+
+	mountEncryptionKey(&job.Spec.Template.Spec, &job.Spec.Template.Spec.Containers[0], data, config)
+	mountDestination(&job.Spec.Template.Spec, &job.Spec.Template.Spec.Containers[0], destination, true)
+
+	return job
+}
+
+func verifySnapshotCommand(cluster *kubermaticv1.Cluster, config *kubermaticv1.EtcdBackupConfig, status *kubermaticv1.BackupStatus) []string {
+	command := []string{
+		"/etcd-launcher",
+		"verify-snapshot",
+		fmt.Sprintf("--cluster=%s", cluster.Name),
+		fmt.Sprintf("--ca-bundle=/etc/ca-bundle/%s", resources.CABundleConfigMapKey),
+		fmt.Sprintf("--object=%s", BackupObjectName(cluster, status)),
+		"--work-dir=/backup",
+	}
+
+	if config.Spec.Verification != nil && config.Spec.Verification.RestoreDrill {
+		command = append(command, "--restore-drill")
+	}
+
+	return command
+}
@@ -27,6 +27,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/controller/master-controller-manager/rbac"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/registry"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -41,6 +42,8 @@ const (
 	dataDir = "/var/run/etcd/pod_$(POD_NAME)/"
 
 	memberListPattern = "etcd-%d=http://etcd-%d.%s.%s.svc.cluster.local:2380"
+
+	restoreVolumeName = "restore-backup"
 )
 
 var (
@@ -105,7 +108,7 @@ func StatefulSetReconciler(data etcdStatefulSetReconcilerData, enableDataCorrupt
 				MatchLabels: baseLabels,
 			}
 
-			volumes := getVolumes()
+			volumes := getVolumes(data.Cluster())
 			podLabels, err := data.GetPodTemplateLabels(resources.EtcdStatefulSetName, volumes, baseLabels)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create pod labels: %w", err)
@@ -306,6 +309,15 @@ func StatefulSetReconciler(data etcdStatefulSetReconcilerData, enableDataCorrupt
 				},
 			}
 
+			// backups on filesystem destinations can only be restored from a volume
+			if data.Cluster().Annotations[resources.EtcdRestoreVolumeClaimAnnotation] != "" {
+				set.Spec.Template.Spec.Containers[0].VolumeMounts = append(set.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      restoreVolumeName,
+					MountPath: backend.FilesystemMountPath,
+					ReadOnly:  true,
+				})
+			}
+
 			set.Spec.Template.Spec.Tolerations = data.Cluster().Spec.ComponentsOverride.Etcd.Tolerations
 
 			err = resources.SetResourceRequirements(set.Spec.Template.Spec.Containers, defaultResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), set.Annotations)
@@ -356,8 +368,8 @@ func StatefulSetReconciler(data etcdStatefulSetReconcilerData, enableDataCorrupt
 	}
 }
 
-func getVolumes() []corev1.Volume {
-	return []corev1.Volume{
+func getVolumes(cluster *kubermaticv1.Cluster) []corev1.Volume {
+	volumes := []corev1.Volume{
 		{
 			Name: resources.EtcdTLSCertificateSecretName,
 			VolumeSource: corev1.VolumeSource{
@@ -395,6 +407,20 @@ func getVolumes() []corev1.Volume {
 			},
 		},
 	}
+
+	if claimName := cluster.Annotations[resources.EtcdRestoreVolumeClaimAnnotation]; claimName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: restoreVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+	}
+
+	return volumes
 }
 
 func GetBasePodLabels(cluster *kubermaticv1.Cluster) map[string]string {
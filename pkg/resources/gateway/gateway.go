@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/address"
+	kkpreconciling "k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// GatewayName is the name of the Gateway in the seed's Kubermatic namespace
+	// that is shared by all user clusters using the Gateway expose strategy.
+	GatewayName = "kubermatic"
+	// ListenerName is the name of the TLS passthrough listener on the Gateway.
+	ListenerName = "user-clusters"
+	// ListenerPort is the port of the TLS passthrough listener. It matches the
+	// apiserver's secure port, as the apiserver is advertised with the port of
+	// the cluster address.
+	ListenerPort = resources.APIServerSecurePort
+)
+
+// ListenerHostname returns the wildcard hostname the Gateway listener accepts
+// connections for, e.g. "*.europe-west3-c.dev.kubermatic.io".
+func ListenerHostname(seed *kubermaticv1.Seed, externalURL string) string {
+	return fmt.Sprintf("*.%s", address.SeedDomain(seed, externalURL))
+}
+
+// GatewayReconciler returns the function to reconcile the Gateway that routes
+// TLS connections to the control planes of all user clusters on the seed
+// based on the SNI.
+func GatewayReconciler(seed *kubermaticv1.Seed, externalURL string) kkpreconciling.NamedGatewayReconcilerFactory {
+	return func() (string, kkpreconciling.GatewayReconciler) {
+		return GatewayName, func(gw *gatewayapiv1beta1.Gateway) (*gatewayapiv1beta1.Gateway, error) {
+			if seed.Spec.Gateway == nil {
+				return nil, fmt.Errorf("Seed %q has no Gateway configured", seed.Name)
+			}
+
+			if gw.Annotations == nil {
+				gw.Annotations = map[string]string{}
+			}
+			for k, v := range seed.Spec.Gateway.Annotations {
+				gw.Annotations[k] = v
+			}
+
+			gw.Spec.GatewayClassName = gatewayapiv1beta1.ObjectName(seed.Spec.Gateway.GatewayClassName)
+			gw.Spec.Listeners = []gatewayapiv1beta1.Listener{
+				{
+					Name:     ListenerName,
+					Hostname: ptr.To(gatewayapiv1beta1.Hostname(ListenerHostname(seed, externalURL))),
+					Port:     ListenerPort,
+					Protocol: gatewayapiv1beta1.TLSProtocolType,
+					TLS: &gatewayapiv1beta1.GatewayTLSConfig{
+						Mode: ptr.To(gatewayapiv1beta1.TLSModePassthrough),
+					},
+					AllowedRoutes: &gatewayapiv1beta1.AllowedRoutes{
+						// TLSRoutes are created in the cluster namespaces.
+						Namespaces: &gatewayapiv1beta1.RouteNamespaces{
+							From: ptr.To(gatewayapiv1beta1.NamespacesFromAll),
+						},
+						Kinds: []gatewayapiv1beta1.RouteGroupKind{
+							{
+								Group: ptr.To(gatewayapiv1beta1.Group(gatewayapiv1alpha2.GroupName)),
+								Kind:  "TLSRoute",
+							},
+						},
+					},
+				},
+			}
+
+			return gw, nil
+		}
+	}
+}
+
+// TLSRouteReconciler returns the function to reconcile a TLSRoute that attaches
+// to the seed's Gateway and routes TLS connections for the given hostname to
+// the port of the given Service. The route is named after the Service.
+func TLSRouteReconciler(gatewayNamespace string, serviceName string, servicePort int32, hostname string) kkpreconciling.NamedTLSRouteReconcilerFactory {
+	return func() (string, kkpreconciling.TLSRouteReconciler) {
+		return serviceName, func(route *gatewayapiv1alpha2.TLSRoute) (*gatewayapiv1alpha2.TLSRoute, error) {
+			route.Spec.ParentRefs = []gatewayapiv1alpha2.ParentReference{
+				{
+					Group:       ptr.To(gatewayapiv1beta1.Group(gatewayapiv1beta1.GroupName)),
+					Kind:        ptr.To(gatewayapiv1beta1.Kind("Gateway")),
+					Namespace:   ptr.To(gatewayapiv1beta1.Namespace(gatewayNamespace)),
+					Name:        GatewayName,
+					SectionName: ptr.To(gatewayapiv1beta1.SectionName(ListenerName)),
+				},
+			}
+			route.Spec.Hostnames = []gatewayapiv1alpha2.Hostname{gatewayapiv1alpha2.Hostname(hostname)}
+			route.Spec.Rules = []gatewayapiv1alpha2.TLSRouteRule{
+				{
+					BackendRefs: []gatewayapiv1alpha2.BackendRef{
+						{
+							BackendObjectReference: gatewayapiv1alpha2.BackendObjectReference{
+								Group: ptr.To(gatewayapiv1beta1.Group("")),
+								Kind:  ptr.To(gatewayapiv1beta1.Kind("Service")),
+								Name:  gatewayapiv1beta1.ObjectName(serviceName),
+								Port:  ptr.To(gatewayapiv1beta1.PortNumber(servicePort)),
+							},
+							Weight: ptr.To[int32](1),
+						},
+					},
+				},
+			}
+
+			return route, nil
+		}
+	}
+}
+
+// ResourcesForDeletion returns all TLSRoutes that can be created in a cluster
+// namespace.
+func ResourcesForDeletion(namespace string) []ctrlruntimeclient.Object {
+	var routes []ctrlruntimeclient.Object
+	for _, name := range []string{resources.ApiserverServiceName, resources.KonnectivityProxyServiceName, resources.MLAGatewayExternalServiceName} {
+		routes = append(routes, &gatewayapiv1alpha2.TLSRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		})
+	}
+
+	return routes
+}
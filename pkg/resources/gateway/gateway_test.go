@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestGatewayReconciler(t *testing.T) {
+	testCases := []struct {
+		name             string
+		seed             *kubermaticv1.Seed
+		expectedHostname string
+		errExpected      bool
+	}{
+		{
+			name: "listener hostname uses the seed name",
+			seed: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{Name: "europe-west3-c"},
+				Spec: kubermaticv1.SeedSpec{
+					Gateway: &kubermaticv1.SeedGatewaySettings{GatewayClassName: "envoy"},
+				},
+			},
+			expectedHostname: "*.europe-west3-c.dev.kubermatic.io",
+		},
+		{
+			name: "listener hostname uses the seed DNS overwrite",
+			seed: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{Name: "europe-west3-c"},
+				Spec: kubermaticv1.SeedSpec{
+					SeedDNSOverwrite: "alias",
+					Gateway:          &kubermaticv1.SeedGatewaySettings{GatewayClassName: "envoy"},
+				},
+			},
+			expectedHostname: "*.alias.dev.kubermatic.io",
+		},
+		{
+			name: "seed without Gateway settings",
+			seed: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{Name: "europe-west3-c"},
+			},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, reconciler := GatewayReconciler(tc.seed, "dev.kubermatic.io")()
+			gw, err := reconciler(&gatewayapiv1beta1.Gateway{})
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err: %t, but got err %v", tc.errExpected, err)
+			}
+			if tc.errExpected {
+				return
+			}
+
+			if string(gw.Spec.GatewayClassName) != tc.seed.Spec.Gateway.GatewayClassName {
+				t.Errorf("Expected GatewayClass %q, but got %q", tc.seed.Spec.Gateway.GatewayClassName, gw.Spec.GatewayClassName)
+			}
+			if len(gw.Spec.Listeners) != 1 {
+				t.Fatalf("Expected exactly one listener, got %d", len(gw.Spec.Listeners))
+			}
+
+			listener := gw.Spec.Listeners[0]
+			if listener.Hostname == nil || string(*listener.Hostname) != tc.expectedHostname {
+				t.Errorf("Expected listener hostname %q, but got %v", tc.expectedHostname, listener.Hostname)
+			}
+			if listener.TLS == nil || listener.TLS.Mode == nil || *listener.TLS.Mode != gatewayapiv1beta1.TLSModePassthrough {
+				t.Errorf("Expected listener to use TLS passthrough, but got %v", listener.TLS)
+			}
+		})
+	}
+}
+
+func TestTLSRouteReconciler(t *testing.T) {
+	name, reconciler := TLSRouteReconciler("kubermatic", "apiserver-external", 443, "cluster.europe-west3-c.dev.kubermatic.io")()
+	if name != "apiserver-external" {
+		t.Errorf("Expected TLSRoute to be named after the Service, but got %q", name)
+	}
+
+	route, err := reconciler(&gatewayapiv1alpha2.TLSRoute{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(route.Spec.ParentRefs) != 1 {
+		t.Fatalf("Expected exactly one parent, got %d", len(route.Spec.ParentRefs))
+	}
+	parent := route.Spec.ParentRefs[0]
+	if parent.Name != GatewayName || parent.Namespace == nil || *parent.Namespace != "kubermatic" {
+		t.Errorf("Expected parent to be Gateway kubermatic/%s, but got %v/%s", GatewayName, parent.Namespace, parent.Name)
+	}
+
+	if len(route.Spec.Hostnames) != 1 || route.Spec.Hostnames[0] != "cluster.europe-west3-c.dev.kubermatic.io" {
+		t.Errorf("Expected the cluster's external name as only hostname, but got %v", route.Spec.Hostnames)
+	}
+
+	if len(route.Spec.Rules) != 1 || len(route.Spec.Rules[0].BackendRefs) != 1 {
+		t.Fatalf("Expected exactly one rule with one backend, got %v", route.Spec.Rules)
+	}
+	backend := route.Spec.Rules[0].BackendRefs[0]
+	if backend.Name != "apiserver-external" || backend.Port == nil || *backend.Port != 443 {
+		t.Errorf("Expected backend apiserver-external:443, but got %s:%v", backend.Name, backend.Port)
+	}
+}
@@ -18,15 +18,22 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// secretRevisionLabelSuffix is appended to the Secret name in the pod labels created by
+// VolumeRevisionLabels.
+const secretRevisionLabelSuffix = "-secret-revision"
+
 // HealthyDeployment tells if the deployment has a minimum of minReady replicas in Ready status.
 // minReady smaller than 0 means that spec.replicas of the Deployment is used.
 func HealthyDeployment(ctx context.Context, client ctrlruntimeclient.Client, nn types.NamespacedName, minReady int32) (kubermaticv1.HealthStatus, error) {
@@ -94,3 +101,106 @@ func HealthyDaemonSet(ctx context.Context, client ctrlruntimeclient.Client, nn t
 	}
 	return kubermaticv1.HealthStatusUp, nil
 }
+
+// DeploymentRolledOut tells if the latest spec of the Deployment has been observed and all of its
+// replicas are updated and ready.
+func DeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	return workloadRolledOut(deployment.Generation, deployment.Status.ObservedGeneration, deployment.Spec.Replicas, deployment.Status.UpdatedReplicas, deployment.Status.ReadyReplicas)
+}
+
+// StatefulSetRolledOut tells if the latest spec of the StatefulSet has been observed and all of
+// its replicas are updated and ready.
+func StatefulSetRolledOut(statefulSet *appsv1.StatefulSet) bool {
+	return workloadRolledOut(statefulSet.Generation, statefulSet.Status.ObservedGeneration, statefulSet.Spec.Replicas, statefulSet.Status.UpdatedReplicas, statefulSet.Status.ReadyReplicas)
+}
+
+func workloadRolledOut(generation, observedGeneration int64, replicas *int32, updatedReplicas, readyReplicas int32) bool {
+	desired := int32(1)
+	if replicas != nil {
+		desired = *replicas
+	}
+
+	return observedGeneration >= generation && updatedReplicas == desired && readyReplicas == desired
+}
+
+// ControlPlaneRolledOut tells if all Deployments and StatefulSets in the cluster namespace are
+// rolled out and all running pods that mount Secrets are ready and use the current revision of
+// these Secrets, as recorded in the labels created by VolumeRevisionLabels.
+func ControlPlaneRolledOut(ctx context.Context, client ctrlruntimeclient.Client, namespace string) (bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := client.List(ctx, deployments, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list Deployments: %w", err)
+	}
+
+	for i := range deployments.Items {
+		if !DeploymentRolledOut(&deployments.Items[i]) {
+			return false, nil
+		}
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := client.List(ctx, statefulSets, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list StatefulSets: %w", err)
+	}
+
+	for i := range statefulSets.Items {
+		if !StatefulSetRolledOut(&statefulSets.Items[i]) {
+			return false, nil
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list Pods: %w", err)
+	}
+
+	revisions := map[string]string{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		mountsSecrets := false
+		for label, revision := range pod.Labels {
+			if !strings.HasSuffix(label, secretRevisionLabelSuffix) {
+				continue
+			}
+			mountsSecrets = true
+
+			name := strings.TrimSuffix(label, secretRevisionLabelSuffix)
+			current, ok := revisions[name]
+			if !ok {
+				secret := &corev1.Secret{}
+				if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+					if apierrors.IsNotFound(err) {
+						continue
+					}
+					return false, fmt.Errorf("failed to get Secret %s: %w", name, err)
+				}
+				current = secret.ResourceVersion
+				revisions[name] = current
+			}
+
+			if revision != current {
+				return false, nil
+			}
+		}
+
+		if mountsSecrets && !podReady(pod) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestControlPlaneRolledOut(t *testing.T) {
+	const namespace = "cluster-test"
+
+	genPod := func(revision string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "apiserver",
+				Labels:    map[string]string{CASecretName + "-secret-revision": revision},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	genDeployment := func(replicas, updated, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      ApiserverDeploymentName,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+			},
+			Status: appsv1.DeploymentStatus{
+				UpdatedReplicas: updated,
+				ReadyReplicas:   ready,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		objects  []ctrlruntimeclient.Object
+		expected bool
+	}{
+		{
+			name:     "pod mounts the current secret",
+			objects:  []ctrlruntimeclient.Object{genPod("1", corev1.ConditionTrue), genDeployment(1, 1, 1)},
+			expected: true,
+		},
+		{
+			name:     "pod mounts an outdated secret",
+			objects:  []ctrlruntimeclient.Object{genPod("0", corev1.ConditionTrue), genDeployment(1, 1, 1)},
+			expected: false,
+		},
+		{
+			name:     "pod is not ready",
+			objects:  []ctrlruntimeclient.Object{genPod("1", corev1.ConditionFalse), genDeployment(1, 1, 1)},
+			expected: false,
+		},
+		{
+			name:     "deployment is still rolling out",
+			objects:  []ctrlruntimeclient.Object{genPod("1", corev1.ConditionTrue), genDeployment(2, 1, 2)},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       namespace,
+					Name:            CASecretName,
+					ResourceVersion: "1",
+				},
+			}
+
+			client := fake.NewClientBuilder().WithObjects(append(tc.objects, secret)...).Build()
+
+			rolledOut, err := ControlPlaneRolledOut(context.Background(), client, namespace)
+			if err != nil {
+				t.Fatalf("failed to check control plane: %v", err)
+			}
+			if rolledOut != tc.expected {
+				t.Errorf("expected rolled out to be %v, got %v", tc.expected, rolledOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/reconciler/pkg/reconciling"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HibernationModifiers returns the modifiers to apply to Deployments and StatefulSets in the
+// cluster namespace. While the cluster is hibernated, they are scaled to zero.
+func HibernationModifiers(cluster *kubermaticv1.Cluster) []reconciling.ObjectModifier {
+	if !cluster.Status.IsHibernated() {
+		return nil
+	}
+
+	return []reconciling.ObjectModifier{scaleToZero}
+}
+
+func scaleToZero(create reconciling.ObjectReconciler) reconciling.ObjectReconciler {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		obj, err := create(existing)
+		if err != nil {
+			return nil, err
+		}
+
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			o.Spec.Replicas = ptr.To[int32](0)
+		case *appsv1.StatefulSet:
+			o.Spec.Replicas = ptr.To[int32](0)
+		}
+
+		return obj, nil
+	}
+}
@@ -55,6 +55,11 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL s
 				se.Annotations[nodeportproxy.DefaultExposeAnnotationKey] = strings.Join([]string{nodeportproxy.SNIType.String(), nodeportproxy.TunnelingType.String()}, ",")
 				se.Annotations[nodeportproxy.PortHostMappingAnnotationKey] = fmt.Sprintf(`{"secure": %q}`, "konnectivity-server."+externalURL)
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			case kubermaticv1.ExposeStrategyGateway:
+				se.Spec.Type = corev1.ServiceTypeClusterIP
+				delete(se.Annotations, nodeportproxy.DefaultExposeAnnotationKey)
+				delete(se.Annotations, nodeportproxy.PortHostMappingAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			default:
 				return nil, fmt.Errorf("unsupported expose strategy: %q", exposeStrategy)
 			}
@@ -70,7 +75,7 @@ func ServiceReconciler(exposeStrategy kubermaticv1.ExposeStrategy, externalURL s
 			se.Spec.Ports[0].Protocol = corev1.ProtocolTCP
 			se.Spec.Ports[0].TargetPort = intstr.FromInt(port)
 
-			if exposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+			if exposeStrategy == kubermaticv1.ExposeStrategyTunneling || exposeStrategy == kubermaticv1.ExposeStrategyGateway {
 				se.Spec.Ports[0].NodePort = 0
 			}
 
@@ -60,7 +60,7 @@ func TLSServingCertificateReconciler(data tlsServingCertReconcilerData) reconcil
 				DNSNames: []string{
 					// external address - nodeport / LB expose strategy
 					address.ExternalName,
-					// external address - tunneling expose strategy
+					// external address - tunneling / gateway expose strategy
 					fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, address.ExternalName),
 				},
 				IPs: []net.IP{
@@ -40,7 +40,7 @@ const (
 
 type adminKubeconfigReconcilerData interface {
 	Cluster() *kubermaticv1.Cluster
-	GetRootCA() (*triple.KeyPair, error)
+	GetRootCABundle() ([]byte, error)
 }
 
 // AdminKubeconfigReconciler returns a function to create/update the secret with the admin kubeconfig.
@@ -51,13 +51,13 @@ func AdminKubeconfigReconciler(data adminKubeconfigReconcilerData) reconciling.N
 				se.Data = map[string][]byte{}
 			}
 
-			ca, err := data.GetRootCA()
+			caBundle, err := data.GetRootCABundle()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
+				return nil, fmt.Errorf("failed to get cluster ca bundle: %w", err)
 			}
 
 			address := data.Cluster().Status.Address
-			config := GetBaseKubeconfig(ca.Cert, address.URL, data.Cluster().Name)
+			config := GetBaseKubeconfigForCABundle(caBundle, address.URL, data.Cluster().Name)
 			config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
 				kubeconfigDefaultAuthInfoKey: {
 					Token: address.AdminToken,
@@ -84,12 +84,12 @@ func ViewerKubeconfigReconciler(data *TemplateData) reconciling.NamedSecretRecon
 				se.Data = map[string][]byte{}
 			}
 
-			ca, err := data.GetRootCA()
+			caBundle, err := data.GetRootCABundle()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
+				return nil, fmt.Errorf("failed to get cluster ca bundle: %w", err)
 			}
 
-			config := GetBaseKubeconfig(ca.Cert, data.Cluster().Status.Address.URL, data.Cluster().Name)
+			config := GetBaseKubeconfigForCABundle(caBundle, data.Cluster().Status.Address.URL, data.Cluster().Name)
 			token, err := data.GetViewerToken()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get token: %w", err)
@@ -114,6 +114,7 @@ func ViewerKubeconfigReconciler(data *TemplateData) reconciling.NamedSecretRecon
 
 type internalKubeconfigReconcilerData interface {
 	GetRootCA() (*triple.KeyPair, error)
+	GetRootCABundle() ([]byte, error)
 	Cluster() *kubermaticv1.Cluster
 }
 
@@ -130,9 +131,14 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 				return nil, fmt.Errorf("failed to get cluster ca: %w", err)
 			}
 
+			caBundle, err := data.GetRootCABundle()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster ca bundle: %w", err)
+			}
+
 			b := se.Data[KubeconfigSecretKey]
 			apiserverURL := fmt.Sprintf("https://%s", data.Cluster().Status.Address.InternalName)
-			valid, err := IsValidKubeconfig(b, ca.Cert, apiserverURL, commonName, organizations, data.Cluster().Name)
+			valid, err := IsValidKubeconfig(b, caBundle, ca.Cert, apiserverURL, commonName, organizations, data.Cluster().Name)
 			if err != nil || !valid {
 				objLogger := log.With("namespace", namespace, "name", name)
 				if err != nil {
@@ -141,7 +147,7 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 					objLogger.Info("invalid/outdated kubeconfig found, regenerating")
 				}
 
-				se.Data[KubeconfigSecretKey], err = BuildNewKubeconfigAsByte(ca, apiserverURL, commonName, organizations, data.Cluster().Name)
+				se.Data[KubeconfigSecretKey], err = BuildNewKubeconfigAsByte(ca, caBundle, apiserverURL, commonName, organizations, data.Cluster().Name)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create new kubeconfig: %w", err)
 				}
@@ -153,8 +159,8 @@ func GetInternalKubeconfigReconciler(namespace, name, commonName string, organiz
 	}
 }
 
-func BuildNewKubeconfigAsByte(ca *triple.KeyPair, server, commonName string, organizations []string, clusterName string) ([]byte, error) {
-	kubeconfig, err := buildNewKubeconfig(ca, server, commonName, organizations, clusterName)
+func BuildNewKubeconfigAsByte(ca *triple.KeyPair, caBundle []byte, server, commonName string, organizations []string, clusterName string) ([]byte, error) {
+	kubeconfig, err := buildNewKubeconfig(ca, caBundle, server, commonName, organizations, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +168,8 @@ func BuildNewKubeconfigAsByte(ca *triple.KeyPair, server, commonName string, org
 	return clientcmd.Write(*kubeconfig)
 }
 
-func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizations []string, clusterName string) (*clientcmdapi.Config, error) {
-	baseKubconfig := GetBaseKubeconfig(ca.Cert, server, clusterName)
+func buildNewKubeconfig(ca *triple.KeyPair, caBundle []byte, server, commonName string, organizations []string, clusterName string) (*clientcmdapi.Config, error) {
+	baseKubconfig := GetBaseKubeconfigForCABundle(caBundle, server, clusterName)
 
 	kp, err := triple.NewClientKeyPair(ca, commonName, organizations)
 	if err != nil {
@@ -181,12 +187,18 @@ func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizat
 }
 
 func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *clientcmdapi.Config {
+	return GetBaseKubeconfigForCABundle(triple.EncodeCertPEM(caCert), server, clusterName)
+}
+
+// GetBaseKubeconfigForCABundle returns a kubeconfig without credentials that trusts all
+// PEM-encoded certificates in caBundle, e.g. the old and new root CA during a CA rotation.
+func GetBaseKubeconfigForCABundle(caBundle []byte, server, clusterName string) *clientcmdapi.Config {
 	return &clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
 			// We use the actual cluster name here. It is later used in encodeKubeconfig()
 			// to set the filename of the kubeconfig downloaded from API to `kubeconfig-clusterName`.
 			clusterName: {
-				CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+				CertificateAuthorityData: caBundle,
 				Server:                   server,
 			},
 		},
@@ -200,7 +212,7 @@ func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *cl
 	}
 }
 
-func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server, commonName string, organizations []string, clusterName string) (bool, error) {
+func IsValidKubeconfig(kubeconfigBytes []byte, caBundle []byte, caCert *x509.Certificate, server, commonName string, organizations []string, clusterName string) (bool, error) {
 	if len(kubeconfigBytes) == 0 {
 		return false, nil
 	}
@@ -210,7 +222,7 @@ func IsValidKubeconfig(kubeconfigBytes []byte, caCert *x509.Certificate, server,
 		return false, err
 	}
 
-	baseKubeconfig := GetBaseKubeconfig(caCert, server, clusterName)
+	baseKubeconfig := GetBaseKubeconfigForCABundle(caBundle, server, clusterName)
 
 	authInfo := existingKubeconfig.AuthInfos[kubeconfigDefaultAuthInfoKey]
 	if authInfo == nil {
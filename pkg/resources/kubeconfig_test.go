@@ -93,7 +93,8 @@ func TestGetInternalKubeconfigReconcilerWithOrgs(t *testing.T) {
 
 // fakeDataProvider provides just enough for testing kubeconfig creation.
 type fakeDataProvider struct {
-	caPair *triple.KeyPair
+	caPair   *triple.KeyPair
+	caBundle []byte
 }
 
 func (fake *fakeDataProvider) Cluster() *kubermaticv1.Cluster { return &kubermaticv1.Cluster{} }
@@ -106,6 +107,13 @@ func (fake *fakeDataProvider) GetFrontProxyCA() (*triple.KeyPair, error) { retur
 
 func (fake *fakeDataProvider) GetRootCA() (*triple.KeyPair, error) { return fake.caPair, nil }
 
+func (fake *fakeDataProvider) GetRootCABundle() ([]byte, error) {
+	if fake.caBundle != nil {
+		return fake.caBundle, nil
+	}
+	return triple.EncodeCertPEM(fake.caPair.Cert), nil
+}
+
 func (fake *fakeDataProvider) GetOpenVPNCA() (*ECDSAKeyPair, error) { return &ECDSAKeyPair{}, nil }
 
 func (fake *fakeDataProvider) InClusterApiserverAddress() (string, error) { return "", nil }
@@ -134,3 +142,51 @@ func checkKubeConfigRegeneration(t *testing.T, orgs []string) {
 	// kubeconfig should be unmodified
 	assert.Equal(t, string(secret.Data[KubeconfigSecretKey]), string(secret2.Data[KubeconfigSecretKey]))
 }
+
+func TestGetInternalKubeconfigReconcilerCABundle(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("Failed to generate test root ca: %v", err)
+	}
+	nextCA, err := triple.NewCA("test-ca-next")
+	if err != nil {
+		t.Fatalf("Failed to generate next test root ca: %v", err)
+	}
+
+	data := &fakeDataProvider{caPair: ca}
+	_, create := GetInternalKubeconfigReconciler("some-namespace", "some-name", "test-creator-cn", nil, data, zap.NewNop().Sugar())()
+	secret, err := create(&corev1.Secret{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// trusting an additional CA must regenerate the kubeconfig with the whole bundle
+	bundle := append(triple.EncodeCertPEM(ca.Cert), triple.EncodeCertPEM(nextCA.Cert)...)
+	data.caBundle = bundle
+
+	secret2, err := create(secret.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := IsValidKubeconfig(secret2.Data[KubeconfigSecretKey], bundle, ca.Cert, "https://", "test-creator-cn", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, valid, "kubeconfig should embed the CA bundle")
+
+	// switching the signing CA must re-issue the client certificate
+	data.caPair = nextCA
+	data.caBundle = append(triple.EncodeCertPEM(nextCA.Cert), triple.EncodeCertPEM(ca.Cert)...)
+
+	secret3, err := create(secret2.DeepCopy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err = IsValidKubeconfig(secret3.Data[KubeconfigSecretKey], data.caBundle, nextCA.Cert, "https://", "test-creator-cn", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, valid, "kubeconfig should use a client certificate signed by the new CA")
+}
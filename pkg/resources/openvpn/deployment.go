@@ -76,6 +76,8 @@ type openVPNDeploymentReconcilerData interface {
 }
 
 // DeploymentReconciler returns the function to create and update the openvpn server deployment.
+// OpenVPN is deprecated in favour of Konnectivity and only deployed for clusters that have not
+// been migrated yet.
 func DeploymentReconciler(data openVPNDeploymentReconcilerData) reconciling.NamedDeploymentReconcilerFactory {
 	return func() (string, reconciling.DeploymentReconciler) {
 		return resources.OpenVPNServerDeploymentName, func(dep *appsv1.Deployment) (*appsv1.Deployment, error) {
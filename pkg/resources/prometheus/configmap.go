@@ -142,9 +142,9 @@ func ConfigMapReconciler(data *resources.TemplateData) reconciling.NamedConfigMa
 			} else {
 				cm.Data["rules.yaml"] = prometheusRules
 
-				// deploy DNSResolverDownAlert rule only if Konnectivity is disabled
+				// deploy DNSResolverDownAlert rule only if the OpenVPN setup is deployed
 				// (custom DNS resolver in not deployed in Konnectivity setup)
-				if !data.IsKonnectivityEnabled() {
+				if data.IsOpenVPNDeployed() {
 					cm.Data["rules.yaml"] += prometheusRuleDNSResolverDownAlert
 				}
 
@@ -33,6 +33,8 @@ import (
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	instancetypev1alpha1 "kubevirt.io/api/instancetype/v1alpha1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayapiv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // VerticalPodAutoscalerReconciler defines an interface to create/update VerticalPodAutoscalers.
@@ -997,6 +999,80 @@ func ReconcileOperatingSystemConfigs(ctx context.Context, namedFactories []Named
 	return nil
 }
 
+// GatewayReconciler defines an interface to create/update Gateways.
+type GatewayReconciler = func(existing *gatewayapiv1beta1.Gateway) (*gatewayapiv1beta1.Gateway, error)
+
+// NamedGatewayReconcilerFactory returns the name of the resource and the corresponding Reconciler function.
+type NamedGatewayReconcilerFactory = func() (name string, reconciler GatewayReconciler)
+
+// GatewayObjectWrapper adds a wrapper so the GatewayReconciler matches ObjectReconciler.
+// This is needed as Go does not support function interface matching.
+func GatewayObjectWrapper(reconciler GatewayReconciler) reconciling.ObjectReconciler {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return reconciler(existing.(*gatewayapiv1beta1.Gateway))
+		}
+		return reconciler(&gatewayapiv1beta1.Gateway{})
+	}
+}
+
+// ReconcileGateways will create and update the Gateways coming from the passed GatewayReconciler slice.
+func ReconcileGateways(ctx context.Context, namedFactories []NamedGatewayReconcilerFactory, namespace string, client ctrlruntimeclient.Client, objectModifiers ...reconciling.ObjectModifier) error {
+	for _, factory := range namedFactories {
+		name, reconciler := factory()
+		reconcileObject := GatewayObjectWrapper(reconciler)
+		reconcileObject = reconciling.CreateWithNamespace(reconcileObject, namespace)
+		reconcileObject = reconciling.CreateWithName(reconcileObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			reconcileObject = objectModifier(reconcileObject)
+		}
+
+		if err := reconciling.EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, reconcileObject, client, &gatewayapiv1beta1.Gateway{}, false); err != nil {
+			return fmt.Errorf("failed to ensure Gateway %s/%s: %w", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
+// TLSRouteReconciler defines an interface to create/update TLSRoutes.
+type TLSRouteReconciler = func(existing *gatewayapiv1alpha2.TLSRoute) (*gatewayapiv1alpha2.TLSRoute, error)
+
+// NamedTLSRouteReconcilerFactory returns the name of the resource and the corresponding Reconciler function.
+type NamedTLSRouteReconcilerFactory = func() (name string, reconciler TLSRouteReconciler)
+
+// TLSRouteObjectWrapper adds a wrapper so the TLSRouteReconciler matches ObjectReconciler.
+// This is needed as Go does not support function interface matching.
+func TLSRouteObjectWrapper(reconciler TLSRouteReconciler) reconciling.ObjectReconciler {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return reconciler(existing.(*gatewayapiv1alpha2.TLSRoute))
+		}
+		return reconciler(&gatewayapiv1alpha2.TLSRoute{})
+	}
+}
+
+// ReconcileTLSRoutes will create and update the TLSRoutes coming from the passed TLSRouteReconciler slice.
+func ReconcileTLSRoutes(ctx context.Context, namedFactories []NamedTLSRouteReconcilerFactory, namespace string, client ctrlruntimeclient.Client, objectModifiers ...reconciling.ObjectModifier) error {
+	for _, factory := range namedFactories {
+		name, reconciler := factory()
+		reconcileObject := TLSRouteObjectWrapper(reconciler)
+		reconcileObject = reconciling.CreateWithNamespace(reconcileObject, namespace)
+		reconcileObject = reconciling.CreateWithName(reconcileObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			reconcileObject = objectModifier(reconcileObject)
+		}
+
+		if err := reconciling.EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, reconcileObject, client, &gatewayapiv1alpha2.TLSRoute{}, false); err != nil {
+			return fmt.Errorf("failed to ensure TLSRoute %s/%s: %w", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
 // VirtualMachineInstancetypeReconciler defines an interface to create/update VirtualMachineInstancetypes.
 type VirtualMachineInstancetypeReconciler = func(existing *instancetypev1alpha1.VirtualMachineInstancetype) (*instancetypev1alpha1.VirtualMachineInstancetype, error)
 
@@ -28,13 +28,12 @@ import (
 	"os"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
-	"k8c.io/kubermatic/v2/pkg/util/s3"
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/reconciler/pkg/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -193,6 +192,9 @@ const (
 	FrontProxyCASecretName = "front-proxy-ca"
 	// CASecretName is the name for the secret containing the root ca.
 	CASecretName = "ca"
+	// NextCASecretName is the name for the secret containing the root ca that replaces the current one
+	// during a CA rotation.
+	NextCASecretName = "ca-next"
 	// ApiserverTLSSecretName is the name for the secrets required for the apiserver tls.
 	ApiserverTLSSecretName = "apiserver-tls"
 	// KubeletClientCertificatesSecretName is the name for the secret containing the kubelet client certificates.
@@ -257,6 +259,11 @@ const (
 	// FluentBitSecretName is the name of the secret that contains the fluent-bit configuration mounted
 	// into kube-apisever and used by the "audit-logs" sidecar to ship audit logs.
 	FluentBitSecretName = "audit-logs-fluentbit"
+	// AuditWebhookBackendSecretName is the name of the secret that contains the kubeconfig of the
+	// audit webhook backend mounted into kube-apiserver.
+	AuditWebhookBackendSecretName = "audit-webhook-backend"
+	// AuditWebhookConfigSecretKey is the key in the AuditWebhookBackendSecretName secret holding the kubeconfig.
+	AuditWebhookConfigSecretKey = "webhook.kubeconfig"
 	// AdmissionControlConfigMapName is the name for the configmap that contains the Admission Controller config file.
 	AdmissionControlConfigMapName = "adm-control"
 
@@ -556,9 +563,18 @@ const (
 	EtcdBackupAndRestoreS3AccessKeyIDKey        = "ACCESS_KEY_ID"
 	EtcdBackupAndRestoreS3SecretKeyAccessKeyKey = "SECRET_ACCESS_KEY"
 
+	// EtcdRestoreDestinationTypeKey holds the type of the backup destination in the backup download Secret.
+	EtcdRestoreDestinationTypeKey = "BACKUP_DESTINATION_TYPE"
 	EtcdRestoreS3BucketNameKey    = "BUCKET_NAME"
 	EtcdRestoreS3EndpointKey      = "ENDPOINT"
 	EtcdRestoreDefaultS3SEndpoint = "s3.amazonaws.com"
+	// EtcdRestoreEncryptionDataKeyKey holds the unwrapped data key in the backup download Secret,
+	// if the backups of the cluster are encrypted.
+	EtcdRestoreEncryptionDataKeyKey = "ENCRYPTION_DATA_KEY"
+	// EtcdRestoreVolumeClaimAnnotation is set on clusters that are being restored from a filesystem
+	// backup destination and names the PVC in the cluster namespace that the etcd pods mount the
+	// backups from.
+	EtcdRestoreVolumeClaimAnnotation = "kubermatic.k8c.io/etcd-restore-volume-claim"
 
 	// ApiserverEtcdClientCertificateCertSecretKey apiserver-etcd-client.crt.
 	ApiserverEtcdClientCertificateCertSecretKey = "apiserver-etcd-client.crt"
@@ -1349,8 +1365,10 @@ func getClusterCAFromLister(ctx context.Context, namespace, name string, client
 		return nil, nil, fmt.Errorf("got an invalid cert from the CA secret %s: %w", caSecretKey, err)
 	}
 
-	if len(certs) != 1 {
-		return nil, nil, fmt.Errorf("did not find exactly one but %v certificates in the CA secret", len(certs))
+	// during a CA rotation, the secret contains a bundle of CAs, of which only the first one
+	// belongs to the private key and is used for signing
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("did not find any certificate in the CA secret %s", caSecretKey)
 	}
 
 	key, err := triple.ParsePrivateKeyPEM(caSecret.Data[CAKeySecretKey])
@@ -1381,6 +1399,22 @@ func GetClusterRootCA(ctx context.Context, namespace string, client ctrlruntimec
 	return getRSAClusterCAFromLister(ctx, namespace, CASecretName, client)
 }
 
+// GetClusterRootCABundle returns the PEM-encoded certificates that are trusted as root CA of the
+// cluster. Outside of a CA rotation, this is only the root CA itself.
+func GetClusterRootCABundle(ctx context.Context, namespace string, client ctrlruntimeclient.Client) ([]byte, error) {
+	caSecret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: CASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get CA secret: %w", err)
+	}
+
+	bundle := caSecret.Data[CACertSecretKey]
+	if len(bundle) == 0 {
+		return nil, fmt.Errorf("CA secret contains no data for key %s", CACertSecretKey)
+	}
+
+	return bundle, nil
+}
+
 // GetClusterFrontProxyCA returns the frontproxy CA of the cluster from the lister.
 func GetClusterFrontProxyCA(ctx context.Context, namespace string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
 	return getRSAClusterCAFromLister(ctx, namespace, FrontProxyCASecretName, client)
@@ -1457,7 +1491,7 @@ func VolumeRevisionLabels(
 			if err != nil {
 				return nil, err
 			}
-			labels[fmt.Sprintf("%s-secret-revision", v.VolumeSource.Secret.SecretName)] = revision
+			labels[v.VolumeSource.Secret.SecretName+secretRevisionLabelSuffix] = revision
 		}
 		if v.VolumeSource.ConfigMap != nil {
 			key := types.NamespacedName{Namespace: namespace, Name: v.VolumeSource.ConfigMap.Name}
@@ -1637,43 +1671,47 @@ func BackupCABundleConfigMapName(cluster *kubermaticv1.Cluster) string {
 	return fmt.Sprintf("cluster-%s-ca-bundle", cluster.Name)
 }
 
-// GetEtcdRestoreS3Client returns an S3 client for downloading the backup for a given EtcdRestore.
+// GetEtcdRestoreBackend returns the storage backend for downloading the backup for a given EtcdRestore.
 // If the EtcdRestore doesn't reference a secret containing the credentials and endpoint and bucket name data,
 // one can optionally be created from a well-known secret and configmap in kube-system, or from a specified backup destination.
-func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdRestore, createSecretIfMissing bool, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster,
-	destination *kubermaticv1.BackupDestination) (*minio.Client, string, error) {
-	secretData := make(map[string]string)
+func GetEtcdRestoreBackend(ctx context.Context, restore *kubermaticv1.EtcdRestore, createSecretIfMissing bool, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster,
+	destination *kubermaticv1.BackupDestination) (backend.Backend, error) {
+	secretData := make(map[string][]byte)
 
 	if restore.Spec.BackupDownloadCredentialsSecret != "" {
 		secret := &corev1.Secret{}
 		if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: restore.Spec.BackupDownloadCredentialsSecret}, secret); err != nil {
-			return nil, "", fmt.Errorf("failed to get BackupDownloadCredentialsSecret credentials secret %v: %w", restore.Spec.BackupDownloadCredentialsSecret, err)
+			return nil, fmt.Errorf("failed to get BackupDownloadCredentialsSecret credentials secret %v: %w", restore.Spec.BackupDownloadCredentialsSecret, err)
 		}
 
 		for k, v := range secret.Data {
-			secretData[k] = string(v)
+			secretData[k] = v
 		}
 	} else {
 		if !createSecretIfMissing {
-			return nil, "", fmt.Errorf("BackupDownloadCredentialsSecret not set")
+			return nil, fmt.Errorf("BackupDownloadCredentialsSecret not set")
 		}
 
-		credsSecret := &corev1.Secret{}
-		if err := client.Get(ctx, types.NamespacedName{Namespace: destination.Credentials.Namespace, Name: destination.Credentials.Name}, credsSecret); err != nil {
-			return nil, "", fmt.Errorf("failed to get s3 credentials secret %v/%v: %w", destination.Credentials.Namespace, destination.Credentials.Name, err)
-		}
-		for k, v := range credsSecret.Data {
-			secretData[k] = string(v)
+		// filesystem destinations do not need any credentials
+		if destination.Credentials != nil {
+			credsSecret := &corev1.Secret{}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: destination.Credentials.Namespace, Name: destination.Credentials.Name}, credsSecret); err != nil {
+				return nil, fmt.Errorf("failed to get backup credentials secret %v/%v: %w", destination.Credentials.Namespace, destination.Credentials.Name, err)
+			}
+			for k, v := range credsSecret.Data {
+				secretData[k] = v
+			}
 		}
-		secretData[EtcdRestoreS3BucketNameKey] = destination.BucketName
-		secretData[EtcdRestoreS3EndpointKey] = destination.Endpoint
+		secretData[EtcdRestoreDestinationTypeKey] = []byte(destination.GetType())
+		secretData[EtcdRestoreS3BucketNameKey] = []byte(destination.BucketName)
+		secretData[EtcdRestoreS3EndpointKey] = []byte(destination.Endpoint)
 
 		creator := func(se *corev1.Secret) (*corev1.Secret, error) {
 			if se.Data == nil {
 				se.Data = map[string][]byte{}
 			}
 			for k, v := range secretData {
-				se.Data[k] = []byte(v)
+				se.Data[k] = v
 			}
 			return se, nil
 		}
@@ -1687,50 +1725,107 @@ func GetEtcdRestoreS3Client(ctx context.Context, restore *kubermaticv1.EtcdResto
 			ctx,
 			types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: secretName},
 			wrappedCreator, client, &corev1.Secret{}, false); err != nil {
-			return nil, "", fmt.Errorf("failed to ensure Secret %s: %w", secretName, err)
+			return nil, fmt.Errorf("failed to ensure Secret %s: %w", secretName, err)
 		}
 
 		oldRestore := restore.DeepCopy()
 		restore.Spec.BackupDownloadCredentialsSecret = secretName
 		if err := client.Patch(ctx, restore, ctrlruntimeclient.MergeFrom(oldRestore)); err != nil {
-			return nil, "", fmt.Errorf("failed to write etcdrestore.backupDownloadCredentialsSecret: %w", err)
+			return nil, fmt.Errorf("failed to write etcdrestore.backupDownloadCredentialsSecret: %w", err)
 		}
 	}
 
-	accessKeyID := secretData[EtcdBackupAndRestoreS3AccessKeyIDKey]
-	secretAccessKey := secretData[EtcdBackupAndRestoreS3SecretKeyAccessKeyKey]
-	bucketName := secretData[EtcdRestoreS3BucketNameKey]
-	endpoint := secretData[EtcdRestoreS3EndpointKey]
+	// secrets created before other destination types were supported always refer to S3
+	destinationType := kubermaticv1.BackupDestinationType(secretData[EtcdRestoreDestinationTypeKey])
+	if destinationType == "" {
+		destinationType = kubermaticv1.BackupDestinationTypeS3
+	}
 
-	if bucketName == "" {
-		return nil, "", fmt.Errorf("s3 bucket name not set")
+	config := backend.Config{
+		Type:        destinationType,
+		Endpoint:    string(secretData[EtcdRestoreS3EndpointKey]),
+		BucketName:  string(secretData[EtcdRestoreS3BucketNameKey]),
+		Path:        backend.FilesystemMountPath,
+		Credentials: secretData,
+		AppName:     "kubermatic",
 	}
-	if endpoint == "" {
-		endpoint = EtcdRestoreDefaultS3SEndpoint
+
+	return newEtcdBackupBackend(ctx, client, cluster, config)
+}
+
+// GetEtcdRestoreDataKey returns the data key to decrypt the backup for a given EtcdRestore, or nil
+// if the backups are not encrypted.
+func GetEtcdRestoreDataKey(ctx context.Context, restore *kubermaticv1.EtcdRestore, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) ([]byte, error) {
+	if restore.Spec.BackupDownloadCredentialsSecret == "" {
+		return nil, fmt.Errorf("BackupDownloadCredentialsSecret not set")
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: restore.Spec.BackupDownloadCredentialsSecret}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get BackupDownloadCredentialsSecret credentials secret %v: %w", restore.Spec.BackupDownloadCredentialsSecret, err)
+	}
+
+	return secret.Data[EtcdRestoreEncryptionDataKeyKey], nil
+}
+
+// GetEtcdBackupBackend returns the storage backend for the given backup destination, using the
+// credentials referenced by the destination.
+func GetEtcdBackupBackend(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, destination *kubermaticv1.BackupDestination) (backend.Backend, error) {
+	var credentials map[string][]byte
+
+	if destination.Credentials != nil {
+		credsSecret := &corev1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: destination.Credentials.Namespace, Name: destination.Credentials.Name}, credsSecret); err != nil {
+			return nil, fmt.Errorf("failed to get backup credentials secret %v/%v: %w", destination.Credentials.Namespace, destination.Credentials.Name, err)
+		}
+		credentials = credsSecret.Data
+	} else if destination.GetType() != kubermaticv1.BackupDestinationTypeFilesystem {
+		return nil, errors.New("credentials not set for backup destination")
+	}
+
+	config := backend.NewConfig(destination, credentials, nil)
+	config.AppName = "kubermatic"
+
+	return newEtcdBackupBackend(ctx, client, cluster, config)
+}
+
+func newEtcdBackupBackend(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, config backend.Config) (backend.Backend, error) {
+	switch config.Type {
+	case kubermaticv1.BackupDestinationTypeFilesystem:
+		// no network access involved
+		return backend.New(ctx, config)
+	case kubermaticv1.BackupDestinationTypeS3:
+		if config.Endpoint == "" {
+			config.Endpoint = EtcdRestoreDefaultS3SEndpoint
+		}
+	}
+
+	if config.BucketName == "" {
+		return nil, fmt.Errorf("bucket name not set")
 	}
 
 	caBundleConfigMap := &corev1.ConfigMap{}
 	caBundleKey := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: BackupCABundleConfigMapName(cluster)}
 	if err := client.Get(ctx, caBundleKey, caBundleConfigMap); err != nil {
-		return nil, "", fmt.Errorf("failed to get CA bundle ConfigMap: %w", err)
+		return nil, fmt.Errorf("failed to get CA bundle ConfigMap: %w", err)
 	}
 	bundle, ok := caBundleConfigMap.Data[CABundleConfigMapKey]
 	if !ok {
-		return nil, "", fmt.Errorf("ConfigMap does not contain key %q", CABundleConfigMapKey)
+		return nil, fmt.Errorf("ConfigMap does not contain key %q", CABundleConfigMapKey)
 	}
 
 	pool := x509.NewCertPool()
 	if !pool.AppendCertsFromPEM([]byte(bundle)) {
-		return nil, "", errors.New("CA bundle does not contain any valid certificates")
+		return nil, errors.New("CA bundle does not contain any valid certificates")
 	}
+	config.RootCAs = pool
 
-	s3Client, err := s3.NewClient(endpoint, accessKeyID, secretAccessKey, pool)
+	b, err := backend.New(ctx, config)
 	if err != nil {
-		return nil, "", fmt.Errorf("error creating S3 client: %w", err)
+		return nil, fmt.Errorf("error creating %s backend: %w", config.Type, err)
 	}
-	s3Client.SetAppInfo("kubermatic", "v0.2")
 
-	return s3Client, bucketName, nil
+	return b, nil
 }
 
 // GetClusterNodeCIDRMaskSizeIPv4 returns effective mask size used to address the nodes within provided IPv4 Pods CIDR.
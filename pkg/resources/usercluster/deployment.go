@@ -73,6 +73,9 @@ type userclusterControllerData interface {
 	GetCloudProviderName() (string, error)
 	UserClusterMLAEnabled() bool
 	IsKonnectivityEnabled() bool
+	IsKonnectivityMigrationInProgress() bool
+	IsKonnectivityDeployed() bool
+	IsOpenVPNDeployed() bool
 	DC() *kubermaticv1.Datacenter
 	GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
 	GetEnvVars() ([]corev1.EnvVar, error)
@@ -167,9 +170,14 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 
 			if data.IsKonnectivityEnabled() {
 				args = append(args, "-konnectivity-enabled=true")
+			}
+			if data.IsKonnectivityMigrationInProgress() {
+				args = append(args, "-konnectivity-migration=true")
+			}
 
+			if data.IsKonnectivityDeployed() {
 				kHost := address.ExternalName
-				if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+				if es := data.Cluster().Spec.ExposeStrategy; es == kubermaticv1.ExposeStrategyTunneling || es == kubermaticv1.ExposeStrategyGateway {
 					kHost = fmt.Sprintf("%s.%s", resources.KonnectivityProxyServiceName, kHost)
 				}
 				kPort, err := data.GetKonnectivityServerPort()
@@ -179,7 +187,8 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 				args = append(args, "-konnectivity-server-host", kHost)
 				args = append(args, "-konnectivity-server-port", fmt.Sprint(kPort))
 				args = append(args, "-konnectivity-keepalive-time", data.GetKonnectivityKeepAliveTime())
-			} else {
+			}
+			if data.IsOpenVPNDeployed() {
 				openvpnServerPort, err := data.GetOpenVPNServerPort()
 				if err != nil {
 					return nil, err
@@ -233,7 +242,7 @@ func DeploymentReconciler(data userclusterControllerData) reconciling.NamedDeplo
 						return nil, err
 					}
 					mlaEndpoint := net.JoinHostPort(address.ExternalName, fmt.Sprintf("%d", mlaGatewayPort))
-					if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+					if es := data.Cluster().Spec.ExposeStrategy; es == kubermaticv1.ExposeStrategyTunneling || es == kubermaticv1.ExposeStrategyGateway {
 						mlaEndpoint = resources.MLAGatewaySNIPrefix + mlaEndpoint
 					}
 					args = append(args, "-mla-gateway-url", "https://"+mlaEndpoint)
@@ -0,0 +1,173 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+)
+
+// azureBlockSize is the size of the blocks objects are uploaded in; Azure
+// allows up to 50,000 blocks per blob.
+const azureBlockSize = 8 * 1024 * 1024
+
+type azureBackend struct {
+	client    *azblob.Client
+	container string
+}
+
+// NewAzure returns a backend for an Azure Blob Storage container.
+func NewAzure(config Config) (Backend, error) {
+	account := string(config.Credentials[AzureAccountNameKey])
+
+	credential, err := azblob.NewSharedKeyCredential(account, string(config.Credentials[AzureAccountKeyKey]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid account key: %w", err)
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+
+	client, err := azblob.NewClientWithSharedKeyCredential(endpoint, credential, &azblob.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Transport: &http.Client{
+				Transport: &http.Transport{
+					Proxy:           http.ProxyFromEnvironment,
+					TLSClientConfig: &tls.Config{RootCAs: config.RootCAs},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure client: %w", err)
+	}
+
+	return &azureBackend{
+		client:    client,
+		container: config.BucketName,
+	}, nil
+}
+
+func convertAzureError(err error, name string) error {
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	return err
+}
+
+func (b *azureBackend) EnsureBucket(ctx context.Context) error {
+	_, err := b.client.CreateContainer(ctx, b.container, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return err
+	}
+
+	return nil
+}
+
+func (b *azureBackend) Upload(ctx context.Context, name string, r io.Reader, size int64) error {
+	_, err := b.client.UploadStream(ctx, b.container, name, r, &blockblob.UploadStreamOptions{
+		BlockSize: azureBlockSize,
+	})
+
+	return err
+}
+
+func (b *azureBackend) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	resp, err := b.client.DownloadStream(ctx, b.container, name, nil)
+	if err != nil {
+		return nil, convertAzureError(err, name)
+	}
+
+	return resp.Body, nil
+}
+
+func (b *azureBackend) blobClient(name string) *blob.Client {
+	return b.client.ServiceClient().NewContainerClient(b.container).NewBlobClient(name)
+}
+
+func (b *azureBackend) Stat(ctx context.Context, name string) (*Object, error) {
+	props, err := b.blobClient(name).GetProperties(ctx, nil)
+	if err != nil {
+		return nil, convertAzureError(err, name)
+	}
+
+	object := &Object{
+		Name: name,
+	}
+	if props.ContentLength != nil {
+		object.Size = *props.ContentLength
+	}
+	if props.LastModified != nil {
+		object.LastModified = *props.LastModified
+	}
+
+	return object, nil
+}
+
+func (b *azureBackend) Delete(ctx context.Context, name string) error {
+	_, err := b.client.DeleteBlob(ctx, b.container, name, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (b *azureBackend) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	pager := b.client.NewListBlobsFlatPager(b.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Segment.BlobItems {
+			object := Object{
+				Name: *item.Name,
+			}
+			if item.Properties != nil {
+				if item.Properties.ContentLength != nil {
+					object.Size = *item.Properties.ContentLength
+				}
+				if item.Properties.LastModified != nil {
+					object.LastModified = *item.Properties.LastModified
+				}
+			}
+
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, nil
+}
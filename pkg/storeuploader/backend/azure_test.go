@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// azureBlockList is the body of a Put Block List request.
+type azureBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+// fakeBlobService implements the parts of the Azure Blob Storage API used by the backend.
+type fakeBlobService struct {
+	lock      sync.Mutex
+	container string
+	created   bool
+	blobs     map[string][]byte
+	blocks    map[string][]byte
+	committed map[string]int
+}
+
+func (s *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey testaccount:") || r.Header.Get("x-ms-date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	container, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if container != s.container {
+		w.Header().Set("x-ms-error-code", "ContainerNotFound")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case name == "" && r.Method == http.MethodPut:
+		if s.created {
+			w.Header().Set("x-ms-error-code", "ContainerAlreadyExists")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.created = true
+		w.WriteHeader(http.StatusCreated)
+
+	case name == "" && r.Method == http.MethodGet:
+		var names []string
+		for blob := range s.blobs {
+			if strings.HasPrefix(blob, query.Get("prefix")) {
+				names = append(names, blob)
+			}
+		}
+		sort.Strings(names)
+
+		fmt.Fprint(w, "<EnumerationResults><Blobs>")
+		for _, blob := range names {
+			fmt.Fprintf(w, "<Blob><Name>%s</Name><Properties><Last-Modified>%s</Last-Modified><Content-Length>%d</Content-Length></Properties></Blob>",
+				blob, time.Now().UTC().Format(http.TimeFormat), len(s.blobs[blob]))
+		}
+		fmt.Fprint(w, "</Blobs><NextMarker /></EnumerationResults>")
+
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		s.blocks[query.Get("blockid")] = body
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		blockList := azureBlockList{}
+		if err := xml.Unmarshal(body, &blockList); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		blob := []byte{}
+		for _, id := range blockList.Latest {
+			blob = append(blob, s.blocks[id]...)
+		}
+		s.blobs[name] = blob
+		s.committed[name] = len(blockList.Latest)
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut:
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.blobs[name] = body
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		blob, exists := s.blobs[name]
+		if !exists {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodDelete:
+			delete(s.blobs, name)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodHead, http.MethodGet:
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", len(blob)))
+			if r.Method == http.MethodGet {
+				_, _ = w.Write(blob)
+			}
+		}
+	}
+}
+
+func TestAzureBackend(t *testing.T) {
+	ctx := context.Background()
+
+	service := &fakeBlobService{
+		container: "backups",
+		blobs:     map[string][]byte{},
+		blocks:    map[string][]byte{},
+		committed: map[string]int{},
+	}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	b, err := NewAzure(Config{
+		Type:       "azure",
+		Endpoint:   server.URL,
+		BucketName: "backups",
+		Credentials: map[string][]byte{
+			AzureAccountNameKey: []byte("testaccount"),
+			AzureAccountKeyKey:  []byte(base64.StdEncoding.EncodeToString([]byte("secret"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+
+	// creating the container twice must not fail
+	for i := 0; i < 2; i++ {
+		if err := b.EnsureBucket(ctx); err != nil {
+			t.Fatalf("Failed to ensure container: %v", err)
+		}
+	}
+
+	small := []byte("small backup")
+	if err := b.Upload(ctx, "cluster-a-small", bytes.NewReader(small), int64(len(small))); err != nil {
+		t.Fatalf("Failed to upload small object: %v", err)
+	}
+
+	// large objects are split into blocks
+	large := bytes.Repeat([]byte("0123456789abcdef"), (2*azureBlockSize+1024)/16)
+	if err := b.Upload(ctx, "cluster-a-large", bytes.NewReader(large), -1); err != nil {
+		t.Fatalf("Failed to upload large object: %v", err)
+	}
+	if service.committed["cluster-a-large"] != 3 {
+		t.Errorf("Expected large object to be uploaded in 3 blocks, got %d.", service.committed["cluster-a-large"])
+	}
+
+	r, err := b.Download(ctx, "cluster-a-large")
+	if err != nil {
+		t.Fatalf("Failed to download object: %v", err)
+	}
+	downloaded, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("Failed to read object: %v", err)
+	}
+	if !bytes.Equal(downloaded, large) {
+		t.Error("Downloaded object does not match uploaded data.")
+	}
+
+	object, err := b.Stat(ctx, "cluster-a-small")
+	if err != nil {
+		t.Fatalf("Failed to stat object: %v", err)
+	}
+	if object.Size != int64(len(small)) {
+		t.Errorf("Expected size %d, got %d.", len(small), object.Size)
+	}
+
+	objects, err := b.List(ctx, "cluster-a-")
+	if err != nil {
+		t.Fatalf("Failed to list objects: %v", err)
+	}
+	if len(objects) != 2 {
+		t.Errorf("Expected 2 objects, got %v.", objects)
+	}
+
+	if err := b.Delete(ctx, "cluster-a-small"); err != nil {
+		t.Fatalf("Failed to delete object: %v", err)
+	}
+	if err := b.Delete(ctx, "cluster-a-small"); err != nil {
+		t.Errorf("Failed to delete missing object: %v", err)
+	}
+	if _, err := b.Stat(ctx, "cluster-a-small"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+	if _, err := b.Download(ctx, "cluster-a-small"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backend provides a common interface for all storage systems that etcd backups
+// can be stored in.
+package backend
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+)
+
+const (
+	// S3AccessKeyIDKey is the credentials key for the S3 access key ID.
+	S3AccessKeyIDKey = "ACCESS_KEY_ID"
+	// S3SecretAccessKeyKey is the credentials key for the S3 secret access key.
+	S3SecretAccessKeyKey = "SECRET_ACCESS_KEY"
+	// AzureAccountNameKey is the credentials key for the Azure storage account name.
+	AzureAccountNameKey = "ACCOUNT_NAME"
+	// AzureAccountKeyKey is the credentials key for the Azure storage account key.
+	AzureAccountKeyKey = "ACCOUNT_KEY"
+	// GCSServiceAccountKey is the credentials key for the JSON-encoded GCP service account key.
+	GCSServiceAccountKey = "SERVICE_ACCOUNT"
+
+	// DestinationTypeEnvVarKey is the environment variable that holds the destination type.
+	DestinationTypeEnvVarKey = "BACKUP_DESTINATION_TYPE"
+	// BucketNameEnvVarKey is the environment variable that holds the bucket name.
+	BucketNameEnvVarKey = "BUCKET_NAME"
+	// EndpointEnvVarKey is the environment variable that holds the endpoint.
+	EndpointEnvVarKey = "ENDPOINT"
+
+	// FilesystemMountPath is where filesystem destinations are mounted into pods.
+	FilesystemMountPath = "/backup-destination"
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Object describes a stored object.
+type Object struct {
+	Name         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Backend is a storage system that holds etcd backups. Object names can contain slashes.
+type Backend interface {
+	// EnsureBucket creates the bucket (or container, or directory) if it does not exist yet.
+	EnsureBucket(ctx context.Context) error
+	// Upload stores the data under the given name, replacing any existing object. If the size
+	// is not known in advance, -1 can be passed.
+	Upload(ctx context.Context, name string, r io.Reader, size int64) error
+	// Download returns the content of an object, or ErrNotFound.
+	Download(ctx context.Context, name string) (io.ReadCloser, error)
+	// Stat returns information about an object, or ErrNotFound.
+	Stat(ctx context.Context, name string) (*Object, error)
+	// Delete removes an object. Deleting an object that does not exist is not an error.
+	Delete(ctx context.Context, name string) error
+	// List returns all objects whose name starts with the prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Config holds everything required to connect to a backend.
+type Config struct {
+	Type       kubermaticv1.BackupDestinationType
+	Endpoint   string
+	BucketName string
+	// Path is the directory in which a filesystem destination is mounted.
+	Path string
+	// Credentials contains the keys of the destination's credentials Secret.
+	Credentials map[string][]byte
+	// RootCAs is used to verify the endpoint's TLS certificate. If nil, the system
+	// certificates are used.
+	RootCAs *x509.CertPool
+	// AppName is used as the user agent, if supported by the backend.
+	AppName string
+}
+
+// CredentialKeys returns the keys that the credentials Secret of a destination
+// of the given type must contain.
+func CredentialKeys(destinationType kubermaticv1.BackupDestinationType) []string {
+	switch destinationType {
+	case "", kubermaticv1.BackupDestinationTypeS3:
+		return []string{S3AccessKeyIDKey, S3SecretAccessKeyKey}
+	case kubermaticv1.BackupDestinationTypeAzure:
+		return []string{AzureAccountNameKey, AzureAccountKeyKey}
+	case kubermaticv1.BackupDestinationTypeGCS:
+		return []string{GCSServiceAccountKey}
+	default:
+		return nil
+	}
+}
+
+// NewConfig returns the config for a destination, with the credentials taken from the given Secret data.
+func NewConfig(destination *kubermaticv1.BackupDestination, credentials map[string][]byte, rootCAs *x509.CertPool) Config {
+	return Config{
+		Type:        destination.GetType(),
+		Endpoint:    destination.Endpoint,
+		BucketName:  destination.BucketName,
+		Path:        FilesystemMountPath,
+		Credentials: credentials,
+		RootCAs:     rootCAs,
+	}
+}
+
+// ConfigFromEnv returns the config that was passed to a job via environment variables.
+func ConfigFromEnv(rootCAs *x509.CertPool) Config {
+	destinationType := kubermaticv1.BackupDestinationType(os.Getenv(DestinationTypeEnvVarKey))
+	if destinationType == "" {
+		destinationType = kubermaticv1.BackupDestinationTypeS3
+	}
+
+	credentials := map[string][]byte{}
+	for _, key := range CredentialKeys(destinationType) {
+		credentials[key] = []byte(os.Getenv(key))
+	}
+
+	return Config{
+		Type:        destinationType,
+		Endpoint:    os.Getenv(EndpointEnvVarKey),
+		BucketName:  os.Getenv(BucketNameEnvVarKey),
+		Path:        FilesystemMountPath,
+		Credentials: credentials,
+		RootCAs:     rootCAs,
+	}
+}
+
+// DestinationMountPath returns where a filesystem destination is mounted into the
+// seed-controller-manager, which has access to all destinations of a seed at once.
+func DestinationMountPath(destination string) string {
+	return path.Join(FilesystemMountPath, destination)
+}
+
+// New returns the backend for the given config.
+func New(ctx context.Context, config Config) (Backend, error) {
+	for _, key := range CredentialKeys(config.Type) {
+		if len(config.Credentials[key]) == 0 {
+			return nil, fmt.Errorf("credentials do not contain %q", key)
+		}
+	}
+
+	switch config.Type {
+	case "", kubermaticv1.BackupDestinationTypeS3:
+		return NewS3(config)
+	case kubermaticv1.BackupDestinationTypeFilesystem:
+		return NewFilesystem(config.Path), nil
+	case kubermaticv1.BackupDestinationTypeAzure:
+		return NewAzure(config)
+	case kubermaticv1.BackupDestinationTypeGCS:
+		return NewGCS(ctx, config)
+	default:
+		return nil, fmt.Errorf("unknown backup destination type %q", config.Type)
+	}
+}
+
+// DownloadFile stores the content of an object in the given file.
+func DownloadFile(ctx context.Context, b Backend, name, filename string) error {
+	object, err := b.Download(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, object); err != nil {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	return f.Sync()
+}
+
+// UploadFile stores the content of the given file as an object.
+func UploadFile(ctx context.Context, b Backend, name, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	return b.Upload(ctx, name, f, info.Size())
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type filesystemBackend struct {
+	root string
+}
+
+// NewFilesystem returns a backend that stores objects as files below root, which is usually
+// a mounted network volume.
+func NewFilesystem(root string) Backend {
+	return &filesystemBackend{
+		root: root,
+	}
+}
+
+func (b *filesystemBackend) filename(name string) (string, error) {
+	cleaned := path.Clean("/" + name)
+	if cleaned == "/" || cleaned != "/"+name {
+		return "", fmt.Errorf("invalid object name %q", name)
+	}
+
+	return filepath.Join(b.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (b *filesystemBackend) EnsureBucket(ctx context.Context) error {
+	return os.MkdirAll(b.root, 0755)
+}
+
+func (b *filesystemBackend) Upload(ctx context.Context, name string, r io.Reader, size int64) error {
+	filename, err := b.filename(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that readers never see partial objects
+	f, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	if err := f.Sync(); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filename)
+}
+
+func (b *filesystemBackend) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	filename, err := b.filename(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, convertFilesystemError(err)
+	}
+
+	return f, nil
+}
+
+func (b *filesystemBackend) Stat(ctx context.Context, name string) (*Object, error) {
+	filename, err := b.filename(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, convertFilesystemError(err)
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is a directory", ErrNotFound, name)
+	}
+
+	return &Object{
+		Name:         name,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (b *filesystemBackend) Delete(ctx context.Context, name string) error {
+	filename, err := b.filename(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (b *filesystemBackend) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	err := filepath.WalkDir(b.root, func(filename string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(b.root, filename)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(relative)
+		if !strings.HasPrefix(name, prefix) || strings.HasPrefix(path.Base(name), ".upload-") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, Object{
+			Name:         name,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Name < objects[j].Name
+	})
+
+	return objects, nil
+}
+
+func convertFilesystemError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+
+	return err
+}
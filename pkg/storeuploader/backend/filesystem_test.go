@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFilesystemBackend(t *testing.T) {
+	ctx := context.Background()
+	b := NewFilesystem(t.TempDir())
+
+	if err := b.EnsureBucket(ctx); err != nil {
+		t.Fatalf("Failed to ensure bucket: %v", err)
+	}
+
+	objects := map[string]string{
+		"cluster-a-backup-1":       "first",
+		"cluster-a-backup-2":       "second",
+		"revisions/cluster-a/0001": "segment",
+	}
+	for name, content := range objects {
+		if err := b.Upload(ctx, name, strings.NewReader(content), int64(len(content))); err != nil {
+			t.Fatalf("Failed to upload %s: %v", name, err)
+		}
+	}
+
+	r, err := b.Download(ctx, "revisions/cluster-a/0001")
+	if err != nil {
+		t.Fatalf("Failed to download object: %v", err)
+	}
+	content, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("Failed to read object: %v", err)
+	}
+	if string(content) != "segment" {
+		t.Errorf("Expected content %q, got %q.", "segment", string(content))
+	}
+
+	object, err := b.Stat(ctx, "cluster-a-backup-2")
+	if err != nil {
+		t.Fatalf("Failed to stat object: %v", err)
+	}
+	if object.Size != int64(len("second")) {
+		t.Errorf("Expected size %d, got %d.", len("second"), object.Size)
+	}
+
+	listed, err := b.List(ctx, "cluster-a-")
+	if err != nil {
+		t.Fatalf("Failed to list objects: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Name != "cluster-a-backup-1" || listed[1].Name != "cluster-a-backup-2" {
+		t.Errorf("Expected the two backups to be listed, got %v.", listed)
+	}
+
+	if err := b.Delete(ctx, "cluster-a-backup-1"); err != nil {
+		t.Fatalf("Failed to delete object: %v", err)
+	}
+	if _, err := b.Stat(ctx, "cluster-a-backup-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+	if _, err := b.Download(ctx, "cluster-a-backup-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+
+	// deleting missing objects is not an error
+	if err := b.Delete(ctx, "cluster-a-backup-1"); err != nil {
+		t.Errorf("Failed to delete missing object: %v", err)
+	}
+}
+
+func TestFilesystemBackendRejectsInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	b := NewFilesystem(t.TempDir())
+
+	for _, name := range []string{"", "../escape", "a/../../escape", "/absolute", "a//b"} {
+		if err := b.Upload(ctx, name, strings.NewReader("data"), 4); err == nil {
+			t.Errorf("Expected object name %q to be rejected.", name)
+		}
+	}
+}
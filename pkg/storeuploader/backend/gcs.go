@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+	htransport "google.golang.org/api/transport/http"
+)
+
+type gcsBackend struct {
+	service   *storage.Service
+	bucket    string
+	projectID string
+}
+
+// NewGCS returns a backend for a Google Cloud Storage bucket.
+func NewGCS(ctx context.Context, config Config) (Backend, error) {
+	serviceAccount := config.Credentials[GCSServiceAccountKey]
+
+	// the project is only required to create the bucket
+	key := struct {
+		ProjectID string `json:"project_id"`
+	}{}
+	if err := json.Unmarshal(serviceAccount, &key); err != nil {
+		return nil, fmt.Errorf("invalid service account: %w", err)
+	}
+
+	// the transport is built manually so that both the API and the token
+	// requests trust the configured CA bundle
+	baseClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: config.RootCAs},
+		},
+	}
+
+	transport, err := htransport.NewTransport(
+		context.WithValue(ctx, oauth2.HTTPClient, baseClient),
+		baseClient.Transport,
+		option.WithCredentialsJSON(serviceAccount),
+		option.WithScopes(storage.DevstorageFullControlScope),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS transport: %w", err)
+	}
+
+	options := []option.ClientOption{
+		option.WithHTTPClient(&http.Client{Transport: transport}),
+	}
+	if config.Endpoint != "" {
+		options = append(options, option.WithEndpoint(config.Endpoint))
+	}
+
+	service, err := storage.NewService(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return &gcsBackend{
+		service:   service,
+		bucket:    config.BucketName,
+		projectID: key.ProjectID,
+	}, nil
+}
+
+func (b *gcsBackend) EnsureBucket(ctx context.Context) error {
+	_, err := b.service.Buckets.Get(b.bucket).Context(ctx).Do()
+	if err == nil {
+		return nil
+	}
+
+	if !isGCSNotFound(err) {
+		return err
+	}
+
+	_, err = b.service.Buckets.Insert(b.projectID, &storage.Bucket{Name: b.bucket}).Context(ctx).Do()
+	if isGCSStatus(err, http.StatusConflict) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *gcsBackend) Upload(ctx context.Context, name string, r io.Reader, size int64) error {
+	_, err := b.service.Objects.Insert(b.bucket, &storage.Object{Name: name}).Media(r).Context(ctx).Do()
+	return err
+}
+
+func (b *gcsBackend) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	resp, err := b.service.Objects.Get(b.bucket, name).Context(ctx).Download()
+	if err != nil {
+		return nil, convertGCSError(err)
+	}
+
+	return resp.Body, nil
+}
+
+func (b *gcsBackend) Stat(ctx context.Context, name string) (*Object, error) {
+	object, err := b.service.Objects.Get(b.bucket, name).Context(ctx).Do()
+	if err != nil {
+		return nil, convertGCSError(err)
+	}
+
+	return convertGCSObject(object)
+}
+
+func (b *gcsBackend) Delete(ctx context.Context, name string) error {
+	err := b.service.Objects.Delete(b.bucket, name).Context(ctx).Do()
+	if isGCSNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *gcsBackend) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	err := b.service.Objects.List(b.bucket).Prefix(prefix).Pages(ctx, func(page *storage.Objects) error {
+		for _, item := range page.Items {
+			object, err := convertGCSObject(item)
+			if err != nil {
+				return err
+			}
+
+			objects = append(objects, *object)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func convertGCSObject(object *storage.Object) (*Object, error) {
+	lastModified, err := time.Parse(time.RFC3339, object.Updated)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modification time of %s: %w", object.Name, err)
+	}
+
+	return &Object{
+		Name:         object.Name,
+		Size:         int64(object.Size),
+		LastModified: lastModified,
+	}, nil
+}
+
+func isGCSStatus(err error, code int) bool {
+	var apiErr *googleapi.Error
+
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+func isGCSNotFound(err error) bool {
+	return isGCSStatus(err, http.StatusNotFound)
+}
+
+func convertGCSError(err error) error {
+	if isGCSNotFound(err) {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+
+	return err
+}
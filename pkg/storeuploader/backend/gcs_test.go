@@ -0,0 +1,314 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeGCSObject struct {
+	Name    string `json:"name"`
+	Size    uint64 `json:"size,string"`
+	Updated string `json:"updated"`
+}
+
+// fakeGCSService implements the parts of the Google Cloud Storage JSON API used by the backend.
+type fakeGCSService struct {
+	lock    sync.Mutex
+	buckets map[string]map[string][]byte
+	tokens  int
+}
+
+func (s *fakeGCSService) writeJSON(w http.ResponseWriter, code int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func (s *fakeGCSService) writeError(w http.ResponseWriter, code int) {
+	s.writeJSON(w, code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": http.StatusText(code),
+		},
+	})
+}
+
+func (s *fakeGCSService) object(name string, data []byte) fakeGCSObject {
+	return fakeGCSObject{
+		Name:    name,
+		Size:    uint64(len(data)),
+		Updated: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func (s *fakeGCSService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if r.URL.Path == "/token" {
+		s.tokens++
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		s.writeError(w, http.StatusUnauthorized)
+		return
+	}
+
+	if r.URL.Path == "/storage/v1/b" && r.Method == http.MethodPost {
+		bucket := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&bucket); err != nil {
+			s.writeError(w, http.StatusBadRequest)
+			return
+		}
+		if _, exists := s.buckets[bucket.Name]; exists {
+			s.writeError(w, http.StatusConflict)
+			return
+		}
+		s.buckets[bucket.Name] = map[string][]byte{}
+		s.writeJSON(w, http.StatusOK, bucket)
+		return
+	}
+
+	upload := strings.HasPrefix(r.URL.Path, "/upload/")
+	path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/upload"), "/storage/v1/b/")
+
+	bucket, rest, _ := strings.Cut(path, "/")
+	objects, exists := s.buckets[bucket]
+	if !exists {
+		s.writeError(w, http.StatusNotFound)
+		return
+	}
+
+	if rest == "" {
+		s.writeJSON(w, http.StatusOK, map[string]string{"name": bucket})
+		return
+	}
+
+	name := strings.TrimPrefix(rest, "o/")
+
+	switch {
+	case upload && r.Method == http.MethodPost:
+		name, data, err := readMultipartUpload(r)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest)
+			return
+		}
+		objects[name] = data
+		s.writeJSON(w, http.StatusOK, s.object(name, data))
+
+	case rest == "o" && r.Method == http.MethodGet:
+		var names []string
+		for object := range objects {
+			if strings.HasPrefix(object, r.URL.Query().Get("prefix")) {
+				names = append(names, object)
+			}
+		}
+		sort.Strings(names)
+
+		items := []fakeGCSObject{}
+		for _, object := range names {
+			items = append(items, s.object(object, objects[object]))
+		}
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+
+	default:
+		data, exists := objects[name]
+		if !exists {
+			s.writeError(w, http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(objects, name)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("alt") == "media":
+			_, _ = w.Write(data)
+		default:
+			s.writeJSON(w, http.StatusOK, s.object(name, data))
+		}
+	}
+}
+
+// readMultipartUpload returns the object name from the metadata part and the
+// object data from the media part of a multipart upload.
+func readMultipartUpload(r *http.Request) (string, []byte, error) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	reader := multipart.NewReader(r.Body, params["boundary"])
+
+	part, err := reader.NextPart()
+	if err != nil {
+		return "", nil, err
+	}
+
+	metadata := fakeGCSObject{}
+	if err := json.NewDecoder(part).Decode(&metadata); err != nil {
+		return "", nil, err
+	}
+
+	part, err = reader.NextPart()
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := io.ReadAll(part)
+
+	return metadata.Name, data, err
+}
+
+func fakeServiceAccount(t *testing.T, tokenURL string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to encode key: %v", err)
+	}
+
+	serviceAccount, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "test-project",
+		"private_key_id": "test",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "backup@test-project.iam.gserviceaccount.com",
+		"token_uri":      tokenURL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode service account: %v", err)
+	}
+
+	return serviceAccount
+}
+
+func TestGCSBackend(t *testing.T) {
+	ctx := context.Background()
+
+	service := &fakeGCSService{
+		buckets: map[string]map[string][]byte{},
+	}
+	// use TLS to ensure both the API and the token requests honor the configured CAs
+	server := httptest.NewTLSServer(service)
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	b, err := NewGCS(ctx, Config{
+		Type:       "gcs",
+		Endpoint:   server.URL + "/storage/v1/",
+		BucketName: "backups",
+		RootCAs:    rootCAs,
+		Credentials: map[string][]byte{
+			GCSServiceAccountKey: fakeServiceAccount(t, server.URL+"/token"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+
+	// creating the bucket twice must not fail
+	for i := 0; i < 2; i++ {
+		if err := b.EnsureBucket(ctx); err != nil {
+			t.Fatalf("Failed to ensure bucket: %v", err)
+		}
+	}
+
+	data := []byte("small backup")
+	for _, name := range []string{"cluster-a-1", "cluster-a-2", "cluster-b-1"} {
+		if err := b.Upload(ctx, name, bytes.NewReader(data), int64(len(data))); err != nil {
+			t.Fatalf("Failed to upload object: %v", err)
+		}
+	}
+
+	if service.tokens == 0 {
+		t.Error("Expected the backend to request an access token.")
+	}
+
+	r, err := b.Download(ctx, "cluster-a-1")
+	if err != nil {
+		t.Fatalf("Failed to download object: %v", err)
+	}
+	downloaded, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("Failed to read object: %v", err)
+	}
+	if !bytes.Equal(downloaded, data) {
+		t.Error("Downloaded object does not match uploaded data.")
+	}
+
+	object, err := b.Stat(ctx, "cluster-a-1")
+	if err != nil {
+		t.Fatalf("Failed to stat object: %v", err)
+	}
+	if object.Size != int64(len(data)) {
+		t.Errorf("Expected size %d, got %d.", len(data), object.Size)
+	}
+
+	objects, err := b.List(ctx, "cluster-a-")
+	if err != nil {
+		t.Fatalf("Failed to list objects: %v", err)
+	}
+	if len(objects) != 2 {
+		t.Errorf("Expected 2 objects, got %v.", objects)
+	}
+
+	if err := b.Delete(ctx, "cluster-a-1"); err != nil {
+		t.Fatalf("Failed to delete object: %v", err)
+	}
+	if err := b.Delete(ctx, "cluster-a-1"); err != nil {
+		t.Errorf("Failed to delete missing object: %v", err)
+	}
+	if _, err := b.Stat(ctx, "cluster-a-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+	if _, err := b.Download(ctx, "cluster-a-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+}
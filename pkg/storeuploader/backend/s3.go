@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+
+	"k8c.io/kubermatic/v2/pkg/util/s3"
+)
+
+type s3Backend struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3 returns a backend for an S3-compatible bucket.
+func NewS3(config Config) (Backend, error) {
+	client, err := s3.NewClient(config.Endpoint, string(config.Credentials[S3AccessKeyIDKey]), string(config.Credentials[S3SecretAccessKeyKey]), config.RootCAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	if config.AppName != "" {
+		client.SetAppInfo(config.AppName, "v0.2")
+	}
+
+	return NewS3FromClient(client, config.BucketName), nil
+}
+
+// NewS3FromClient returns a backend for a bucket, using an existing client.
+func NewS3FromClient(client *minio.Client, bucket string) Backend {
+	return &s3Backend{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+func (b *s3Backend) EnsureBucket(ctx context.Context) error {
+	exists, err := b.client.BucketExists(ctx, b.bucket)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	return b.client.MakeBucket(ctx, b.bucket, minio.MakeBucketOptions{})
+}
+
+func (b *s3Backend) Upload(ctx context.Context, name string, r io.Reader, size int64) error {
+	_, err := b.client.PutObject(ctx, b.bucket, name, r, size, minio.PutObjectOptions{})
+	return err
+}
+
+func (b *s3Backend) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	object, err := b.client.GetObject(ctx, b.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, convertS3Error(err)
+	}
+
+	// GetObject does not perform any request yet, so check that the object exists
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, convertS3Error(err)
+	}
+
+	return object, nil
+}
+
+func (b *s3Backend) Stat(ctx context.Context, name string) (*Object, error) {
+	info, err := b.client.StatObject(ctx, b.bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, convertS3Error(err)
+	}
+
+	return &Object{
+		Name:         info.Key,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (b *s3Backend) Delete(ctx context.Context, name string) error {
+	err := b.client.RemoveObject(ctx, b.bucket, name, minio.RemoveObjectOptions{})
+	if errors.Is(convertS3Error(err), ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *s3Backend) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+
+	for object := range b.client.ListObjects(ctx, b.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+
+		objects = append(objects, Object{
+			Name:         object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		})
+	}
+
+	return objects, nil
+}
+
+func convertS3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// a missing bucket must not be reported as a missing object
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+
+	return err
+}
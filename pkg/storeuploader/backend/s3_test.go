@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3Service implements the parts of the S3 API used by the backend, using path-style requests.
+type fakeS3Service struct {
+	lock    sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+func (s *fakeS3Service) writeError(w http.ResponseWriter, code int, errorCode string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", errorCode, http.StatusText(code))
+}
+
+func (s *fakeS3Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=testkey/") {
+		s.writeError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	bucket, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	if name == "" && r.Method == http.MethodPut {
+		if _, exists := s.buckets[bucket]; exists {
+			s.writeError(w, http.StatusConflict, "BucketAlreadyOwnedByYou")
+			return
+		}
+		s.buckets[bucket] = map[string][]byte{}
+		return
+	}
+
+	objects, exists := s.buckets[bucket]
+	if !exists {
+		s.writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case name == "" && query.Has("location"):
+		fmt.Fprint(w, "<LocationConstraint>us-east-1</LocationConstraint>")
+
+	case name == "" && r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+
+	case name == "" && r.Method == http.MethodGet:
+		var keys []string
+		for key := range objects {
+			if strings.HasPrefix(key, query.Get("prefix")) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(w, "<ListBucketResult><Name>%s</Name><KeyCount>%d</KeyCount><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated>", bucket, len(keys))
+		for _, key := range keys {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key><LastModified>%s</LastModified><ETag>\"etag\"</ETag><Size>%d</Size></Contents>",
+				key, time.Now().UTC().Format("2006-01-02T15:04:05.000Z"), len(objects[key]))
+		}
+		fmt.Fprint(w, "</ListBucketResult>")
+
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		objects[name] = data
+		w.Header().Set("ETag", "\"etag\"")
+
+	case r.Method == http.MethodDelete:
+		delete(objects, name)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		data, exists := objects[name]
+		if !exists {
+			s.writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+
+		w.Header().Set("ETag", "\"etag\"")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	}
+}
+
+func TestS3Backend(t *testing.T) {
+	ctx := context.Background()
+
+	service := &fakeS3Service{
+		buckets: map[string]map[string][]byte{},
+	}
+	server := httptest.NewTLSServer(service)
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	b, err := NewS3(Config{
+		Type:       "s3",
+		Endpoint:   server.URL,
+		BucketName: "backups",
+		RootCAs:    rootCAs,
+		Credentials: map[string][]byte{
+			S3AccessKeyIDKey:     []byte("testkey"),
+			S3SecretAccessKeyKey: []byte("testsecret"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create backend: %v", err)
+	}
+
+	// creating the bucket twice must not fail
+	for i := 0; i < 2; i++ {
+		if err := b.EnsureBucket(ctx); err != nil {
+			t.Fatalf("Failed to ensure bucket: %v", err)
+		}
+	}
+
+	data := []byte("small backup")
+	for _, name := range []string{"cluster-a-1", "cluster-a-2", "cluster-b-1"} {
+		if err := b.Upload(ctx, name, bytes.NewReader(data), int64(len(data))); err != nil {
+			t.Fatalf("Failed to upload object: %v", err)
+		}
+	}
+
+	r, err := b.Download(ctx, "cluster-a-1")
+	if err != nil {
+		t.Fatalf("Failed to download object: %v", err)
+	}
+	downloaded, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("Failed to read object: %v", err)
+	}
+	if !bytes.Equal(downloaded, data) {
+		t.Error("Downloaded object does not match uploaded data.")
+	}
+
+	object, err := b.Stat(ctx, "cluster-a-1")
+	if err != nil {
+		t.Fatalf("Failed to stat object: %v", err)
+	}
+	if object.Size != int64(len(data)) {
+		t.Errorf("Expected size %d, got %d.", len(data), object.Size)
+	}
+
+	objects, err := b.List(ctx, "cluster-a-")
+	if err != nil {
+		t.Fatalf("Failed to list objects: %v", err)
+	}
+	if len(objects) != 2 {
+		t.Errorf("Expected 2 objects, got %v.", objects)
+	}
+
+	if err := b.Delete(ctx, "cluster-a-1"); err != nil {
+		t.Fatalf("Failed to delete object: %v", err)
+	}
+	if err := b.Delete(ctx, "cluster-a-1"); err != nil {
+		t.Errorf("Failed to delete missing object: %v", err)
+	}
+	if _, err := b.Stat(ctx, "cluster-a-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+	if _, err := b.Download(ctx, "cluster-a-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted object to not be found, got %v.", err)
+	}
+}
@@ -27,9 +27,9 @@ import (
 	"sort"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"go.uber.org/zap"
 
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/kubermatic/v2/pkg/util/s3"
 )
 
@@ -42,12 +42,12 @@ const prefixSeparator = "storeuploader"
 // StoreUploader is the configuration
 // for the StoreUploader.
 type StoreUploader struct {
-	// client is a pointer to an initialized client
-	client *minio.Client
-	logger *zap.SugaredLogger
+	// backendFor returns the storage backend for a bucket
+	backendFor func(bucket string) backend.Backend
+	logger     *zap.SugaredLogger
 }
 
-// New returns a new instance of the StoreUploader.
+// New returns a new instance of the StoreUploader for an S3-compatible endpoint.
 func New(endpoint string, accessKeyID, secretAccessKey string, logger *zap.SugaredLogger, rootCAs *x509.CertPool) (*StoreUploader, error) {
 	client, err := s3.NewClient(endpoint, accessKeyID, secretAccessKey, rootCAs)
 	if err != nil {
@@ -56,12 +56,26 @@ func New(endpoint string, accessKeyID, secretAccessKey string, logger *zap.Sugar
 	client.SetAppInfo("kubermatic-store-uploader", "v0.2")
 
 	return &StoreUploader{
-		client: client,
+		backendFor: func(bucket string) backend.Backend {
+			return backend.NewS3FromClient(client, bucket)
+		},
 		logger: logger,
 	}, nil
 }
 
-// Store uploads the given file to S3.
+// NewWithBackend returns a new instance of the StoreUploader that stores all files in the given
+// backend. As the backend already points to a bucket, the bucket passed to the methods is only
+// used for logging.
+func NewWithBackend(b backend.Backend, logger *zap.SugaredLogger) *StoreUploader {
+	return &StoreUploader{
+		backendFor: func(string) backend.Backend {
+			return b
+		},
+		logger: logger,
+	}
+}
+
+// Store uploads the given file to the bucket.
 func (u *StoreUploader) Store(ctx context.Context, file, bucket, prefix string, createBucket bool) error {
 	if len(prefix) == 0 {
 		return errors.New("prefix cannot be empty")
@@ -72,26 +86,19 @@ func (u *StoreUploader) Store(ctx context.Context, file, bucket, prefix string,
 	}
 
 	logger := u.logger.With("bucket", bucket)
+	b := u.backendFor(bucket)
 
 	if createBucket {
-		logger.Debug("Check if bucket exists")
-		exists, err := u.client.BucketExists(ctx, bucket)
-		if err != nil {
+		logger.Debug("Ensuring bucket exists")
+		if err := b.EnsureBucket(ctx); err != nil {
 			return err
 		}
-		if !exists {
-			logger.Info("Creating bucket")
-			if err := u.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
-				return err
-			}
-		}
 	}
 
 	objectName := fmt.Sprintf("%s-%s-%s-%s", prefix, prefixSeparator, time.Now().Format("2006-01-02T150405"), path.Base(file))
 	logger.Infow("Uploading file", "src", file, "dst", objectName)
 
-	_, err := u.client.FPutObject(ctx, bucket, objectName, file, minio.PutObjectOptions{})
-	return err
+	return backend.UploadFile(ctx, b, objectName, file)
 }
 
 // DeleteOldBackups deletes revisions of all files of the given prefix which are older than max-revisions.
@@ -100,31 +107,17 @@ func (u *StoreUploader) DeleteOldBackups(ctx context.Context, bucket, prefix str
 		return errors.New("prefix cannot be empty")
 	}
 
-	doneCh := make(chan struct{})
-	defer close(doneCh)
-
 	logger := u.logger.With("bucket", bucket, "prefix", prefix, "keep", revisionsToKeep)
+	b := u.backendFor(bucket)
 
-	logger.Debugw("Listing existing objects")
-
-	listOpts := minio.ListObjectsOptions{
-		Recursive: true,
-		Prefix:    fmt.Sprintf("%s-%s", prefix, prefixSeparator),
-	}
-
-	var existingObjects []minio.ObjectInfo
-	for object := range u.client.ListObjects(ctx, bucket, listOpts) {
-		if object.Err != nil {
-			return object.Err
-		}
-		existingObjects = append(existingObjects, object)
+	existingObjects, err := u.listObjects(ctx, logger, b, prefix)
+	if err != nil {
+		return err
 	}
 
-	logger.Debugw("Done listing bucket", "objects", len(existingObjects))
-
 	for _, object := range u.getObjectsToDelete(existingObjects, revisionsToKeep) {
-		logger.Infow("Removing object", "object", object.Key)
-		if err := u.client.RemoveObject(ctx, bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+		logger.Infow("Removing object", "object", object.Name)
+		if err := b.Delete(ctx, object.Name); err != nil {
 			return err
 		}
 	}
@@ -138,31 +131,17 @@ func (u *StoreUploader) DeleteAll(ctx context.Context, bucket, prefix string) er
 		return errors.New("prefix cannot be empty")
 	}
 
-	doneCh := make(chan struct{})
-	defer close(doneCh)
-
 	logger := u.logger.With("bucket", bucket, "prefix", prefix)
+	b := u.backendFor(bucket)
 
-	logger.Debugw("Listing existing objects")
-
-	listOpts := minio.ListObjectsOptions{
-		Recursive: true,
-		Prefix:    fmt.Sprintf("%s-%s", prefix, prefixSeparator),
-	}
-
-	var existingObjects []minio.ObjectInfo
-	for object := range u.client.ListObjects(ctx, bucket, listOpts) {
-		if object.Err != nil {
-			return object.Err
-		}
-		existingObjects = append(existingObjects, object)
+	existingObjects, err := u.listObjects(ctx, logger, b, prefix)
+	if err != nil {
+		return err
 	}
 
-	logger.Debugw("Done listing bucket", "objects", len(existingObjects))
-
 	for _, object := range existingObjects {
-		logger.Infow("Removing object", "object", object.Key)
-		if err := u.client.RemoveObject(ctx, bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+		logger.Infow("Removing object", "object", object.Name)
+		if err := b.Delete(ctx, object.Name); err != nil {
 			return err
 		}
 	}
@@ -170,7 +149,20 @@ func (u *StoreUploader) DeleteAll(ctx context.Context, bucket, prefix string) er
 	return nil
 }
 
-func (u *StoreUploader) getObjectsToDelete(objects []minio.ObjectInfo, revisionsToKeep int) []minio.ObjectInfo {
+func (u *StoreUploader) listObjects(ctx context.Context, logger *zap.SugaredLogger, b backend.Backend, prefix string) ([]backend.Object, error) {
+	logger.Debugw("Listing existing objects")
+
+	objects, err := b.List(ctx, fmt.Sprintf("%s-%s", prefix, prefixSeparator))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugw("Done listing bucket", "objects", len(objects))
+
+	return objects, nil
+}
+
+func (u *StoreUploader) getObjectsToDelete(objects []backend.Object, revisionsToKeep int) []backend.Object {
 	if len(objects) <= revisionsToKeep {
 		return nil
 	}
@@ -181,7 +173,7 @@ func (u *StoreUploader) getObjectsToDelete(objects []minio.ObjectInfo, revisions
 
 	numRevisionsToDelete := len(objects) - revisionsToKeep
 
-	var objectsToDelete []minio.ObjectInfo
+	var objectsToDelete []backend.Object
 	for idx, object := range objects {
 		if idx >= numRevisionsToDelete {
 			return objectsToDelete
@@ -20,24 +20,23 @@ import (
 	"testing"
 	"time"
 
-	"github.com/minio/minio-go/v7"
-
+	"k8c.io/kubermatic/v2/pkg/storeuploader/backend"
 	"k8c.io/kubermatic/v2/pkg/test/diff"
 )
 
 func TestGetObjectsToDelete(t *testing.T) {
 	tests := []struct {
 		name             string
-		existingObjects  []minio.ObjectInfo
-		expectedToDelete []minio.ObjectInfo
+		existingObjects  []backend.Object
+		expectedToDelete []backend.Object
 		revisions        int
 	}{
 		{
 			name:      "nothing gets deleted as revisions==existing-backups",
 			revisions: 1,
-			existingObjects: []minio.ObjectInfo{
+			existingObjects: []backend.Object{
 				{
-					Key:          "foo",
+					Name:         "foo",
 					LastModified: time.Unix(1, 0),
 				},
 			},
@@ -46,19 +45,19 @@ func TestGetObjectsToDelete(t *testing.T) {
 		{
 			name:      "oldest should be deleted as revisions < existing-backups",
 			revisions: 1,
-			existingObjects: []minio.ObjectInfo{
+			existingObjects: []backend.Object{
 				{
-					Key:          "foo",
+					Name:         "foo",
 					LastModified: time.Unix(1, 0),
 				},
 				{
-					Key:          "bar",
+					Name:         "bar",
 					LastModified: time.Unix(10, 0),
 				},
 			},
-			expectedToDelete: []minio.ObjectInfo{
+			expectedToDelete: []backend.Object{
 				{
-					Key:          "foo",
+					Name:         "foo",
 					LastModified: time.Unix(1, 0),
 				},
 			},
@@ -70,13 +69,13 @@ func TestGetObjectsToDelete(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Log("existing objects:")
 			for _, object := range test.existingObjects {
-				t.Logf("existing object: %s - %s", object.LastModified.Format("2006-01-02T15:04:05"), object.Key)
+				t.Logf("existing object: %s - %s", object.LastModified.Format("2006-01-02T15:04:05"), object.Name)
 			}
 
 			gotToDelete := uploader.getObjectsToDelete(test.existingObjects, test.revisions)
 			t.Log("objects to delete:")
 			for _, object := range gotToDelete {
-				t.Logf("existing object: %s - %s", object.LastModified.Format("2006-01-02T15:04:05"), object.Key)
+				t.Logf("existing object: %s - %s", object.LastModified.Format("2006-01-02T15:04:05"), object.Name)
 			}
 
 			if !diff.DeepEqual(test.expectedToDelete, gotToDelete) {
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// The types in this file mirror the KMS v2 gRPC API as defined by Kubernetes
+// (k8s.io/kms/apis/v2/api.proto). They carry the protobuf struct tags, so the
+// default gRPC codec can (un)marshal them without generated descriptors.
+
+const (
+	// apiVersion is the version of the KMS gRPC plugin API implemented here.
+	apiVersion = "v2"
+
+	serviceName = "v2.KeyManagementService"
+)
+
+func messageString(m protoiface.MessageV1) string {
+	return protoimpl.X.MessageStringOf(protoimpl.X.ProtoMessageV2Of(m))
+}
+
+type StatusRequest struct{}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return messageString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+
+type StatusResponse struct {
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Healthz string `protobuf:"bytes,2,opt,name=healthz,proto3" json:"healthz,omitempty"`
+	KeyID   string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return messageString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+
+type DecryptRequest struct {
+	Ciphertext  []byte            `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	UID         string            `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	KeyID       string            `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Annotations map[string][]byte `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *DecryptRequest) Reset()         { *m = DecryptRequest{} }
+func (m *DecryptRequest) String() string { return messageString(m) }
+func (*DecryptRequest) ProtoMessage()    {}
+
+type DecryptResponse struct {
+	Plaintext []byte `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+}
+
+func (m *DecryptResponse) Reset()         { *m = DecryptResponse{} }
+func (m *DecryptResponse) String() string { return messageString(m) }
+func (*DecryptResponse) ProtoMessage()    {}
+
+type EncryptRequest struct {
+	Plaintext []byte `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	UID       string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (m *EncryptRequest) Reset()         { *m = EncryptRequest{} }
+func (m *EncryptRequest) String() string { return messageString(m) }
+func (*EncryptRequest) ProtoMessage()    {}
+
+type EncryptResponse struct {
+	Ciphertext  []byte            `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	KeyID       string            `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Annotations map[string][]byte `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *EncryptResponse) Reset()         { *m = EncryptResponse{} }
+func (m *EncryptResponse) String() string { return messageString(m) }
+func (*EncryptResponse) ProtoMessage()    {}
+
+// KeyManagementServiceServer is the server API of a KMS v2 plugin.
+type KeyManagementServiceServer interface {
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	Decrypt(context.Context, *DecryptRequest) (*DecryptResponse, error)
+	Encrypt(context.Context, *EncryptRequest) (*EncryptResponse, error)
+}
+
+func unaryHandler[Req any, Resp any](method string, call func(KeyManagementServiceServer, context.Context, *Req) (*Resp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(Req)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			if interceptor == nil {
+				return call(srv.(KeyManagementServiceServer), ctx, in)
+			}
+
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + serviceName + "/" + method,
+			}
+
+			return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(srv.(KeyManagementServiceServer), ctx, req.(*Req))
+			})
+		},
+	}
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*KeyManagementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryHandler("Status", KeyManagementServiceServer.Status),
+		unaryHandler("Decrypt", KeyManagementServiceServer.Decrypt),
+		unaryHandler("Encrypt", KeyManagementServiceServer.Encrypt),
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api.proto",
+}
+
+// RegisterKeyManagementServiceServer registers the KMS v2 service on the given gRPC server.
+func RegisterKeyManagementServiceServer(s *grpc.Server, srv KeyManagementServiceServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8c.io/kubermatic/v2/pkg/test/kms"
+)
+
+func main() {
+	socket := flag.String("listen", os.Getenv("KMS_PLUGIN_SOCKET"), "unix socket to serve the KMS v2 API on")
+	flag.Parse()
+
+	if *socket == "" {
+		log.Fatal("-listen or $KMS_PLUGIN_SOCKET must be set")
+	}
+
+	plugin, err := kms.NewPlugin()
+	if err != nil {
+		log.Fatalf("Failed to create plugin: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	log.Printf("Serving KMS v2 API on %s with key %s", *socket, plugin.KeyID())
+
+	if err := plugin.Serve(ctx, *socket); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kms implements a local software KMS v2 plugin that can stand in for an external
+// key management service in tests. Keys are generated on startup and only kept in memory,
+// so data encrypted with it cannot be decrypted anymore once the plugin has been stopped.
+package kms
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Plugin is a KMS v2 plugin encrypting data with AES-GCM. It keeps all keys it ever
+// created, so that data encrypted with a rotated key can still be decrypted.
+type Plugin struct {
+	lock         sync.RWMutex
+	keys         map[string]cipher.AEAD
+	currentKeyID string
+}
+
+var _ KeyManagementServiceServer = &Plugin{}
+
+// NewPlugin returns a plugin with a freshly generated key.
+func NewPlugin() (*Plugin, error) {
+	p := &Plugin{
+		keys: map[string]cipher.AEAD{},
+	}
+
+	if _, err := p.Rotate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Rotate generates a new key, which will be used for all future encryptions, and returns its ID.
+func (p *Plugin) Rotate() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	checksum := sha256.Sum256(key)
+	keyID := hex.EncodeToString(checksum[:8])
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.keys[keyID] = aead
+	p.currentKeyID = keyID
+
+	return keyID, nil
+}
+
+// KeyID returns the ID of the key currently used for encryption.
+func (p *Plugin) KeyID() string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.currentKeyID
+}
+
+func (p *Plugin) Status(_ context.Context, _ *StatusRequest) (*StatusResponse, error) {
+	return &StatusResponse{
+		Version: apiVersion,
+		Healthz: "ok",
+		KeyID:   p.KeyID(),
+	}, nil
+}
+
+func (p *Plugin) Encrypt(_ context.Context, req *EncryptRequest) (*EncryptResponse, error) {
+	p.lock.RLock()
+	keyID := p.currentKeyID
+	aead := p.keys[keyID]
+	p.lock.RUnlock()
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate nonce: %v", err)
+	}
+
+	return &EncryptResponse{
+		Ciphertext: aead.Seal(nonce, nonce, req.Plaintext, []byte(keyID)),
+		KeyID:      keyID,
+	}, nil
+}
+
+func (p *Plugin) Decrypt(_ context.Context, req *DecryptRequest) (*DecryptResponse, error) {
+	p.lock.RLock()
+	aead, ok := p.keys[req.KeyID]
+	p.lock.RUnlock()
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown key ID %q", req.KeyID)
+	}
+
+	if len(req.Ciphertext) < aead.NonceSize() {
+		return nil, status.Error(codes.InvalidArgument, "ciphertext is too short")
+	}
+
+	nonce, ciphertext := req.Ciphertext[:aead.NonceSize()], req.Ciphertext[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(req.KeyID))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decrypt: %v", err)
+	}
+
+	return &DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// Serve listens on the given unix socket and serves the KMS v2 API until the context is cancelled.
+func (p *Plugin) Serve(ctx context.Context, socket string) error {
+	if err := os.Remove(socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+
+	server := grpc.NewServer()
+	RegisterKeyManagementServiceServer(server, p)
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	return server.Serve(listener)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func invoke[Resp any](ctx context.Context, t *testing.T, conn *grpc.ClientConn, method string, req interface{}) *Resp {
+	t.Helper()
+
+	resp := new(Resp)
+	if err := conn.Invoke(ctx, "/"+serviceName+"/"+method, req, resp); err != nil {
+		t.Fatalf("%s failed: %v", method, err)
+	}
+
+	return resp
+}
+
+func TestPlugin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	plugin, err := NewPlugin()
+	if err != nil {
+		t.Fatalf("Failed to create plugin: %v", err)
+	}
+
+	socket := filepath.Join(t.TempDir(), "kms.sock")
+
+	served := make(chan error, 1)
+	go func() {
+		served <- plugin.Serve(ctx, socket)
+	}()
+
+	conn, err := grpc.DialContext(ctx, "unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Failed to connect to plugin: %v", err)
+	}
+	defer conn.Close()
+
+	status := invoke[StatusResponse](ctx, t, conn, "Status", &StatusRequest{})
+	if status.Version != apiVersion || status.Healthz != "ok" || status.KeyID == "" {
+		t.Fatalf("Unexpected status response: %+v", status)
+	}
+
+	plaintext := []byte("super secret data")
+
+	encrypted := invoke[EncryptResponse](ctx, t, conn, "Encrypt", &EncryptRequest{Plaintext: plaintext, UID: "1"})
+	if encrypted.KeyID != status.KeyID {
+		t.Fatalf("Expected data to be encrypted with key %q, got %q", status.KeyID, encrypted.KeyID)
+	}
+	if bytes.Contains(encrypted.Ciphertext, plaintext) {
+		t.Fatal("Ciphertext contains the plaintext")
+	}
+
+	newKeyID, err := plugin.Rotate()
+	if err != nil {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+
+	status = invoke[StatusResponse](ctx, t, conn, "Status", &StatusRequest{})
+	if status.KeyID != newKeyID {
+		t.Fatalf("Expected status to report rotated key %q, got %q", newKeyID, status.KeyID)
+	}
+
+	// data encrypted with the previous key must still be readable
+	decrypted := invoke[DecryptResponse](ctx, t, conn, "Decrypt", &DecryptRequest{Ciphertext: encrypted.Ciphertext, UID: "2", KeyID: encrypted.KeyID})
+	if !bytes.Equal(decrypted.Plaintext, plaintext) {
+		t.Fatalf("Expected %q after decryption, got %q", plaintext, decrypted.Plaintext)
+	}
+
+	if err := conn.Invoke(ctx, "/"+serviceName+"/Decrypt", &DecryptRequest{Ciphertext: encrypted.Ciphertext, UID: "3", KeyID: "unknown"}, &DecryptResponse{}); err == nil {
+		t.Fatal("Expected decryption with an unknown key ID to fail")
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Fatalf("Serving failed: %v", err)
+	}
+}
@@ -33,6 +33,7 @@ func ValidateApplicationDefinitionSpec(ad appskubermaticv1.ApplicationDefinition
 
 	allErrs = append(allErrs, ValidateApplicationDefinitionWithOpenAPI(ad, parentFieldPath)...)
 	allErrs = append(allErrs, ValidateApplicationVersions(ad.Spec.Versions, parentFieldPath.Child("spec"))...)
+	allErrs = append(allErrs, validateSourcesForMethod(ad.Spec.Method, ad.Spec.Versions, parentFieldPath.Child("spec"))...)
 	allErrs = append(allErrs, ValidateDeployOpts(ad.Spec.DefaultDeployOptions, parentFieldPath.Child("spec.defaultDeployOptions"))...)
 	return allErrs
 }
@@ -72,6 +73,25 @@ func ValidateApplicationVersions(vs []appskubermaticv1.ApplicationVersion, paren
 	return allErrs
 }
 
+// validateSourcesForMethod ensures that the sources can be rendered by the method. Only Helm can
+// install a chart from a Helm repository, the other methods require a git source.
+func validateSourcesForMethod(method appskubermaticv1.TemplateMethod, vs []appskubermaticv1.ApplicationVersion, parentFieldPath *field.Path) []*field.Error {
+	allErrs := field.ErrorList{}
+
+	// unknown methods are already rejected by the OpenAPI validation
+	if method != appskubermaticv1.KustomizeTemplateMethod && method != appskubermaticv1.ManifestsTemplateMethod {
+		return allErrs
+	}
+
+	for i, v := range vs {
+		if v.Template.Source.Helm != nil {
+			allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child(fmt.Sprintf("versions[%d].template.source.helm", i)), fmt.Sprintf("helm source can not be used with method %q", method)))
+		}
+	}
+
+	return allErrs
+}
+
 func validateSource(source appskubermaticv1.ApplicationSource, f *field.Path) []*field.Error {
 	allErrs := field.ErrorList{}
 
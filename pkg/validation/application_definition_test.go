@@ -178,6 +178,27 @@ func TestValidateApplicationDefinitionSpec(t *testing.T) {
 			},
 			0,
 		},
+		"valid method kustomize with git source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.KustomizeTemplateMethod
+					s.Versions = []appskubermaticv1.ApplicationVersion{gitv}
+					return *s
+				}(),
+			},
+			0,
+		},
+		"invalid method manifests with helm source": {
+			appskubermaticv1.ApplicationDefinition{
+				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
+					s := spec.DeepCopy()
+					s.Method = appskubermaticv1.ManifestsTemplateMethod
+					return *s
+				}(),
+			},
+			1,
+		},
 		"invalid missing source": {
 			appskubermaticv1.ApplicationDefinition{
 				Spec: func() appskubermaticv1.ApplicationDefinitionSpec {
@@ -905,7 +926,7 @@ func TestValidateApplicationDefinitionUpdate(t *testing.T) {
 					return *spec
 				}(),
 			},
-			// Current we only support one Method which is helm. So we got 2 errors:
+			// "foo" is not a supported Method. So we got 2 errors:
 			//  1) spec.method: Unsupported value "foo"
 			//  2) spec.method: Invalid value: "foo": field is immutable
 			expErrLen: 2,
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	appskubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/apps.kubermatic/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
@@ -28,6 +29,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -67,11 +69,146 @@ func ValidateApplicationInstallationSpec(ctx context.Context, client ctrlruntime
 		if !exists {
 			allErrs = append(allErrs, field.NotFound(specPath.Child("applicationRef", "version"), spec.ApplicationRef.Version))
 		}
+
+		if spec.DriftDetection != nil && ad.Spec.Method != appskubermaticv1.HelmTemplateMethod {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("driftDetection"), fmt.Sprintf("drift detection is not supported by template method %q", ad.Spec.Method)))
+		}
+
+		if len(spec.ValuesFrom) > 0 && ad.Spec.Method != appskubermaticv1.HelmTemplateMethod {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("valuesFrom"), fmt.Sprintf("values are not supported by template method %q", ad.Spec.Method)))
+		}
 	}
+
+	if spec.DriftDetection != nil && spec.DriftDetection.Interval.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("driftDetection", "interval"), spec.DriftDetection.Interval.Duration.String(), "should be a positive value, or zero to use the default"))
+	}
+	allErrs = append(allErrs, validateValuesFrom(spec.ValuesFrom, specPath.Child("valuesFrom"))...)
 	allErrs = append(allErrs, ValidateDeployOpts(spec.DeployOptions, specPath.Child("deployOptions"))...)
 	return allErrs
 }
 
+func validateValuesFrom(valuesFrom []appskubermaticv1.ValuesReference, f *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	supportedKinds := sets.New(appskubermaticv1.ValuesReferenceKindSecret, appskubermaticv1.ValuesReferenceKindConfigMap)
+	supportedSources := sets.New(appskubermaticv1.ValuesReferenceSource(""), appskubermaticv1.ValuesReferenceSourceUserCluster, appskubermaticv1.ValuesReferenceSourceSeed)
+
+	for i, ref := range valuesFrom {
+		refPath := f.Index(i)
+
+		if !supportedKinds.Has(ref.Kind) {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("kind"), ref.Kind, []string{string(appskubermaticv1.ValuesReferenceKindSecret), string(appskubermaticv1.ValuesReferenceKindConfigMap)}))
+		}
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "name must be set"))
+		}
+		if !supportedSources.Has(ref.Source) {
+			allErrs = append(allErrs, field.NotSupported(refPath.Child("source"), ref.Source, []string{string(appskubermaticv1.ValuesReferenceSourceUserCluster), string(appskubermaticv1.ValuesReferenceSourceSeed)}))
+		}
+		if ref.Source == appskubermaticv1.ValuesReferenceSourceSeed && ref.Namespace != "" {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("namespace"), "namespace can not be set for objects on the seed"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateApplicationInstallationDependencies validates the dependencies of the ApplicationInstallation and ensures that
+// they do not form a cycle with the other ApplicationInstallations of the cluster.
+func ValidateApplicationInstallationDependencies(ctx context.Context, userClient ctrlruntimeclient.Client, ai appskubermaticv1.ApplicationInstallation) field.ErrorList {
+	dependsOnPath := field.NewPath("spec", "dependsOn")
+	allErrs := field.ErrorList{}
+
+	if len(ai.Spec.DependsOn) == 0 || !ai.DeletionTimestamp.IsZero() {
+		return allErrs
+	}
+
+	for i, dep := range ai.Spec.DependsOn {
+		depPath := dependsOnPath.Index(i)
+
+		switch {
+		case dep.Name == "" && dep.ApplicationRef == nil:
+			allErrs = append(allErrs, field.Required(depPath, "either name or applicationRef must be set"))
+		case dep.Name != "" && dep.ApplicationRef != nil:
+			allErrs = append(allErrs, field.Forbidden(depPath, "name and applicationRef are mutually exclusive"))
+		case dep.Namespace != "" && dep.Name == "":
+			allErrs = append(allErrs, field.Forbidden(depPath.Child("namespace"), "namespace can only be used together with name"))
+		case dep.Matches(&ai, &ai):
+			allErrs = append(allErrs, field.Invalid(depPath, dep, "an application can not depend on itself"))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	installations := &appskubermaticv1.ApplicationInstallationList{}
+	if err := userClient.List(ctx, installations); err != nil {
+		return append(allErrs, field.InternalError(dependsOnPath, err))
+	}
+
+	// validate against the new version of the ApplicationInstallation
+	all := []appskubermaticv1.ApplicationInstallation{ai}
+	for _, installation := range installations.Items {
+		if installation.Namespace != ai.Namespace || installation.Name != ai.Name {
+			all = append(all, installation)
+		}
+	}
+
+	if cycle := findDependencyCycle(&all[0], all); cycle != nil {
+		allErrs = append(allErrs, field.Forbidden(dependsOnPath, fmt.Sprintf("dependency cycle detected: %s", strings.Join(cycle, " -> "))))
+	}
+
+	return allErrs
+}
+
+// findDependencyCycle returns the path of ApplicationInstallations leading from start back to itself, or nil if start is
+// not part of a dependency cycle.
+func findDependencyCycle(start *appskubermaticv1.ApplicationInstallation, all []appskubermaticv1.ApplicationInstallation) []string {
+	key := func(ai *appskubermaticv1.ApplicationInstallation) string {
+		return types.NamespacedName{Namespace: ai.Namespace, Name: ai.Name}.String()
+	}
+
+	var path []string
+	visited := sets.New[string]()
+
+	var visit func(current *appskubermaticv1.ApplicationInstallation) bool
+	visit = func(current *appskubermaticv1.ApplicationInstallation) bool {
+		path = append(path, key(current))
+
+		for _, dep := range current.Spec.DependsOn {
+			for i := range all {
+				candidate := &all[i]
+				if !dep.Matches(current, candidate) {
+					continue
+				}
+
+				if key(candidate) == key(start) {
+					path = append(path, key(start))
+					return true
+				}
+
+				if visited.Has(key(candidate)) {
+					continue
+				}
+				visited.Insert(key(candidate))
+
+				if visit(candidate) {
+					return true
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if visit(start) {
+		return path
+	}
+
+	return nil
+}
+
 func ValidateDeployOpts(deployOpts *appskubermaticv1.DeployOptions, f *field.Path) []*field.Error {
 	allErrs := field.ErrorList{}
 	if deployOpts != nil && deployOpts.Helm != nil {
@@ -39,9 +39,11 @@ const (
 // TestValidateApplicationInstallationSpec tests the validation for ApplicationInstallation creation.
 func TestValidateApplicationInstallationSpec(t *testing.T) {
 	ad := getApplicationDefinition(defaultAppName)
+	kustomizeAD := getApplicationDefinition("kustomize-app")
+	kustomizeAD.Spec.Method = appskubermaticv1.KustomizeTemplateMethod
 	fakeClient := fake.
 		NewClientBuilder().
-		WithObjects(ad).
+		WithObjects(ad, kustomizeAD).
 		Build()
 
 	ai := getApplicationInstallation(defaultAppName, defaultAppName, defaultAppVersion, nil)
@@ -186,6 +188,77 @@ func TestValidateApplicationInstallationSpec(t *testing.T) {
 				}(),
 			}, expectedError: `[spec.reconciliationInterval: Invalid value: "-10ns": should be a positive value, or zero to disable]`,
 		},
+		{
+			name: "Create ApplicationInstallation Success - DriftDetection with helm",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.DriftDetection = &appskubermaticv1.DriftDetection{
+						Interval:          metav1.Duration{Duration: 5 * time.Minute},
+						RemediationPolicy: appskubermaticv1.DriftRemediationPolicyUpgrade,
+					}
+					return *spec
+				}(),
+			}, expectedError: `[]`,
+		},
+		{
+			name: "Create ApplicationInstallation Failure - DriftDetection with kustomize",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.ApplicationRef.Name = "kustomize-app"
+					spec.DriftDetection = &appskubermaticv1.DriftDetection{}
+					return *spec
+				}(),
+			}, expectedError: `[spec.driftDetection: Forbidden: drift detection is not supported by template method "kustomize"]`,
+		},
+		{
+			name: "Create ApplicationInstallation Failure - DriftDetection interval less than 0",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.DriftDetection = &appskubermaticv1.DriftDetection{Interval: metav1.Duration{Duration: -10}}
+					return *spec
+				}(),
+			}, expectedError: `[spec.driftDetection.interval: Invalid value: "-10ns": should be a positive value, or zero to use the default]`,
+		},
+		{
+			name: "Create ApplicationInstallation Success - ValuesFrom",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.ValuesFrom = []appskubermaticv1.ValuesReference{
+						{Kind: appskubermaticv1.ValuesReferenceKindConfigMap, Name: "defaults", Namespace: "default"},
+						{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "credentials", Source: appskubermaticv1.ValuesReferenceSourceSeed, Key: "credentials.yaml"},
+					}
+					return *spec
+				}(),
+			}, expectedError: `[]`,
+		},
+		{
+			name: "Create ApplicationInstallation Failure - ValuesFrom with invalid references",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.ValuesFrom = []appskubermaticv1.ValuesReference{
+						{Kind: "Pod", Name: "defaults"},
+						{Kind: appskubermaticv1.ValuesReferenceKindSecret, Source: appskubermaticv1.ValuesReferenceSourceSeed, Namespace: "kube-system"},
+					}
+					return *spec
+				}(),
+			}, expectedError: `[spec.valuesFrom[0].kind: Unsupported value: "Pod": supported values: "Secret", "ConfigMap" spec.valuesFrom[1].name: Required value: name must be set spec.valuesFrom[1].namespace: Forbidden: namespace can not be set for objects on the seed]`,
+		},
+		{
+			name: "Create ApplicationInstallation Failure - ValuesFrom with kustomize",
+			ai: &appskubermaticv1.ApplicationInstallation{
+				Spec: func() appskubermaticv1.ApplicationInstallationSpec {
+					spec := ai.Spec.DeepCopy()
+					spec.ApplicationRef.Name = "kustomize-app"
+					spec.ValuesFrom = []appskubermaticv1.ValuesReference{{Kind: appskubermaticv1.ValuesReferenceKindSecret, Name: "credentials"}}
+					return *spec
+				}(),
+			}, expectedError: `[spec.valuesFrom: Forbidden: values are not supported by template method "kustomize"]`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -383,6 +456,88 @@ func TestValidateApplicationInstallationUpdate(t *testing.T) {
 	}
 }
 
+// TestValidateApplicationInstallationDependencies tests the validation of the dependencies of an ApplicationInstallation.
+func TestValidateApplicationInstallationDependencies(t *testing.T) {
+	certManager := getApplicationInstallation("cert-manager", "cert-manager", defaultAppVersion, nil)
+
+	ingress := getApplicationInstallation("ingress", "ingress", defaultAppVersion, nil)
+	ingress.Spec.DependsOn = []appskubermaticv1.ApplicationDependency{{Name: "cert-manager"}}
+
+	monitoring := getApplicationInstallation("monitoring", "monitoring", defaultAppVersion, nil)
+	monitoring.Spec.DependsOn = []appskubermaticv1.ApplicationDependency{{ApplicationRef: &appskubermaticv1.ApplicationRef{Name: "ingress", Version: defaultAppVersion}}}
+
+	fakeClient := fake.
+		NewClientBuilder().
+		WithObjects(certManager, ingress, monitoring).
+		Build()
+
+	withDependencies := func(ai *appskubermaticv1.ApplicationInstallation, deps ...appskubermaticv1.ApplicationDependency) *appskubermaticv1.ApplicationInstallation {
+		ai = ai.DeepCopy()
+		ai.Spec.DependsOn = deps
+		return ai
+	}
+
+	testCases := []struct {
+		name          string
+		ai            *appskubermaticv1.ApplicationInstallation
+		expectedError string
+	}{
+		{
+			name:          "No dependencies",
+			ai:            certManager,
+			expectedError: "[]",
+		},
+		{
+			name:          "Dependency chain without cycle",
+			ai:            withDependencies(getApplicationInstallation("dashboard", "dashboard", defaultAppVersion, nil), appskubermaticv1.ApplicationDependency{Name: "monitoring"}),
+			expectedError: "[]",
+		},
+		{
+			name:          "Neither name nor applicationRef",
+			ai:            withDependencies(certManager, appskubermaticv1.ApplicationDependency{}),
+			expectedError: "[spec.dependsOn[0]: Required value: either name or applicationRef must be set]",
+		},
+		{
+			name:          "Both name and applicationRef",
+			ai:            withDependencies(certManager, appskubermaticv1.ApplicationDependency{Name: "ingress", ApplicationRef: &appskubermaticv1.ApplicationRef{Name: "ingress", Version: defaultAppVersion}}),
+			expectedError: "[spec.dependsOn[0]: Forbidden: name and applicationRef are mutually exclusive]",
+		},
+		{
+			name:          "Namespace without name",
+			ai:            withDependencies(certManager, appskubermaticv1.ApplicationDependency{Namespace: "kube-system", ApplicationRef: &appskubermaticv1.ApplicationRef{Name: "ingress", Version: defaultAppVersion}}),
+			expectedError: "[spec.dependsOn[0].namespace: Forbidden: namespace can only be used together with name]",
+		},
+		{
+			name:          "Depends on itself",
+			ai:            withDependencies(certManager, appskubermaticv1.ApplicationDependency{Name: "cert-manager", Namespace: "kube-system"}),
+			expectedError: `[spec.dependsOn[0]: Invalid value: v1.ApplicationDependency{Name:"cert-manager", Namespace:"kube-system", ApplicationRef:(*v1.ApplicationRef)(nil)}: an application can not depend on itself]`,
+		},
+		{
+			name:          "Dependency cycle through name and applicationRef",
+			ai:            withDependencies(certManager, appskubermaticv1.ApplicationDependency{Name: "monitoring"}),
+			expectedError: "[spec.dependsOn: Forbidden: dependency cycle detected: kube-system/cert-manager -> kube-system/monitoring -> kube-system/ingress -> kube-system/cert-manager]",
+		},
+		{
+			name: "Deleting ApplicationInstallation is not validated",
+			ai: func() *appskubermaticv1.ApplicationInstallation {
+				ai := withDependencies(certManager, appskubermaticv1.ApplicationDependency{Name: "monitoring"})
+				ai.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return ai
+			}(),
+			expectedError: "[]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateApplicationInstallationDependencies(context.Background(), fakeClient, *testCase.ai)
+			if fmt.Sprint(err) != testCase.expectedError {
+				t.Fatalf("expected error to be %s but got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
 func getApplicationDefinition(name string) *appskubermaticv1.ApplicationDefinition {
 	return &appskubermaticv1.ApplicationDefinition{
 		ObjectMeta: metav1.ObjectMeta{
@@ -390,6 +545,7 @@ func getApplicationDefinition(name string) *appskubermaticv1.ApplicationDefiniti
 		},
 		Spec: appskubermaticv1.ApplicationDefinitionSpec{
 			Description: "Description",
+			Method:      appskubermaticv1.HelmTemplateMethod,
 			Versions: []appskubermaticv1.ApplicationVersion{
 				{
 					Version: defaultAppVersion,
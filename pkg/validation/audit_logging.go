@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func validateAuditLogging(settings *kubermaticv1.AuditLoggingSettings, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if settings == nil {
+		return allErrs
+	}
+
+	policySources := 0
+	if settings.PolicyPreset != "" {
+		policySources++
+	}
+	if len(settings.Rules) > 0 {
+		policySources++
+	}
+	if settings.PolicyConfigMapRef != nil {
+		policySources++
+
+		ref := settings.PolicyConfigMapRef
+		refPath := fldPath.Child("policyConfigMapRef")
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "ConfigMap name is required"))
+		}
+		if ref.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), "ConfigMap key is required"))
+		}
+	}
+	if policySources > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of 'policyPreset', 'rules' and 'policyConfigMapRef' can be set"))
+	}
+
+	if settings.WebhookBackend != nil {
+		if settings.SidecarSettings != nil && settings.SidecarSettings.Config != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("webhookBackend"), "cannot be combined with a sidecar config"))
+		}
+
+		allErrs = append(allErrs, validateAuditWebhookBackend(settings.WebhookBackend, fldPath.Child("webhookBackend"))...)
+	}
+
+	return allErrs
+}
+
+func validateAuditWebhookBackend(backend *kubermaticv1.AuditWebhookBackendSettings, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	configPath := fldPath.Child("auditWebhookConfig")
+	if config := backend.AuditWebhookConfig; config == nil {
+		allErrs = append(allErrs, field.Required(configPath, "webhook config Secret is required"))
+	} else {
+		if config.Name == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("name"), "Secret name is required"))
+		}
+		if config.Key == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("key"), "Secret key is required"))
+		}
+	}
+
+	if backoff := backend.AuditWebhookInitialBackoff; backoff != "" {
+		if _, err := time.ParseDuration(backoff); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("auditWebhookInitialBackoff"), backoff, "must be a valid duration"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateSeedAuditLogging validates the default audit backend configured on a seed.
+func ValidateSeedAuditLogging(settings *kubermaticv1.SeedAuditLoggingSettings) error {
+	if settings == nil {
+		return nil
+	}
+
+	fldPath := field.NewPath("spec", "auditLogging")
+	allErrs := field.ErrorList{}
+
+	if settings.WebhookBackend != nil {
+		if settings.SidecarConfig != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("webhookBackend"), "cannot be combined with 'sidecarConfig'"))
+		}
+
+		allErrs = append(allErrs, validateAuditWebhookBackend(settings.WebhookBackend, fldPath.Child("webhookBackend"))...)
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// ValidateAuditWebhookConfigSecret ensures that the audit webhook config Secret referenced
+// by a cluster exists in the given namespace and has been explicitly allowed to be used by
+// clusters. Without this check, cluster owners could mount arbitrary Secrets from the KKP
+// namespace into their control plane. The reference is only checked when it is new or
+// has changed, so that relabelling a Secret does not block unrelated cluster updates.
+func ValidateAuditWebhookConfigSecret(ctx context.Context, client ctrlruntimeclient.Reader, namespace string, newCluster, oldCluster *kubermaticv1.Cluster) *field.Error {
+	ref := auditWebhookConfigRef(newCluster)
+	if ref == nil || ref.Name == "" {
+		return nil
+	}
+
+	if oldCluster != nil && reflect.DeepEqual(ref, auditWebhookConfigRef(oldCluster)) {
+		return nil
+	}
+
+	fldPath := field.NewPath("spec", "auditLogging", "webhookBackend", "auditWebhookConfig")
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.Invalid(fldPath.Child("name"), ref.Name, "no such Secret exists")
+		}
+
+		return field.InternalError(fldPath, fmt.Errorf("failed to get Secret: %w", err))
+	}
+
+	if secret.Labels[kubermaticv1.AuditWebhookConfigLabelKey] != "true" {
+		return field.Forbidden(fldPath.Child("name"), fmt.Sprintf("Secret %q is not labelled with %s=true", ref.Name, kubermaticv1.AuditWebhookConfigLabelKey))
+	}
+
+	return nil
+}
+
+func auditWebhookConfigRef(cluster *kubermaticv1.Cluster) *corev1.SecretKeySelector {
+	if cluster == nil || cluster.Spec.AuditLogging == nil || cluster.Spec.AuditLogging.WebhookBackend == nil {
+		return nil
+	}
+
+	return cluster.Spec.AuditLogging.WebhookBackend.AuditWebhookConfig
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/test/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+func TestValidateAuditLogging(t *testing.T) {
+	webhookConfig := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "audit-webhook"},
+		Key:                  "kubeconfig",
+	}
+
+	testCases := []struct {
+		name     string
+		settings *kubermaticv1.AuditLoggingSettings
+		valid    bool
+	}{
+		{
+			name:     "preset",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true, PolicyPreset: kubermaticv1.AuditPolicyMinimal},
+			valid:    true,
+		},
+		{
+			name: "preset and inline rules",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyMinimal,
+				Rules:        []auditv1.PolicyRule{{Level: auditv1.LevelMetadata}},
+			},
+			valid: false,
+		},
+		{
+			name: "ConfigMap reference without key",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled: true,
+				PolicyConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "audit"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "webhook backend",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:        true,
+				WebhookBackend: &kubermaticv1.AuditWebhookBackendSettings{AuditWebhookConfig: webhookConfig, AuditWebhookInitialBackoff: "10s"},
+			},
+			valid: true,
+		},
+		{
+			name: "webhook backend with invalid backoff",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:        true,
+				WebhookBackend: &kubermaticv1.AuditWebhookBackendSettings{AuditWebhookConfig: webhookConfig, AuditWebhookInitialBackoff: "soon"},
+			},
+			valid: false,
+		},
+		{
+			name: "webhook backend and sidecar config",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:         true,
+				SidecarSettings: &kubermaticv1.AuditSidecarSettings{Config: &kubermaticv1.AuditSidecarConfiguration{}},
+				WebhookBackend:  &kubermaticv1.AuditWebhookBackendSettings{AuditWebhookConfig: webhookConfig},
+			},
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateAuditLogging(tc.settings, field.NewPath("spec", "auditLogging"))
+			if tc.valid && len(errs) > 0 {
+				t.Errorf("expected settings to be valid, got: %v", errs)
+			}
+			if !tc.valid && len(errs) == 0 {
+				t.Error("expected settings to be invalid")
+			}
+		})
+	}
+}
+
+func TestValidateSeedAuditLogging(t *testing.T) {
+	if err := ValidateSeedAuditLogging(&kubermaticv1.SeedAuditLoggingSettings{
+		SidecarConfig: &kubermaticv1.AuditSidecarConfiguration{},
+	}); err != nil {
+		t.Errorf("expected sidecar config to be valid, got: %v", err)
+	}
+
+	if err := ValidateSeedAuditLogging(&kubermaticv1.SeedAuditLoggingSettings{
+		WebhookBackend: &kubermaticv1.AuditWebhookBackendSettings{},
+	}); err == nil {
+		t.Error("expected webhook backend without config to be invalid")
+	}
+}
+
+func TestValidateAuditWebhookConfigSecret(t *testing.T) {
+	const namespace = "kubermatic"
+
+	client := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "allowed",
+				Namespace: namespace,
+				Labels:    map[string]string{kubermaticv1.AuditWebhookConfigLabelKey: "true"},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "internal",
+				Namespace: namespace,
+			},
+		},
+	).Build()
+
+	clusterWithSecret := func(name string) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				AuditLogging: &kubermaticv1.AuditLoggingSettings{
+					Enabled: true,
+					WebhookBackend: &kubermaticv1.AuditWebhookBackendSettings{
+						AuditWebhookConfig: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: name},
+							Key:                  "kubeconfig",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		newCluster *kubermaticv1.Cluster
+		oldCluster *kubermaticv1.Cluster
+		valid      bool
+	}{
+		{
+			name:       "no webhook backend",
+			newCluster: &kubermaticv1.Cluster{},
+			valid:      true,
+		},
+		{
+			name:       "labelled Secret",
+			newCluster: clusterWithSecret("allowed"),
+			valid:      true,
+		},
+		{
+			name:       "unlabelled Secret",
+			newCluster: clusterWithSecret("internal"),
+			valid:      false,
+		},
+		{
+			name:       "missing Secret",
+			newCluster: clusterWithSecret("missing"),
+			valid:      false,
+		},
+		{
+			name:       "switching to an unlabelled Secret",
+			newCluster: clusterWithSecret("internal"),
+			oldCluster: clusterWithSecret("allowed"),
+			valid:      false,
+		},
+		{
+			name:       "unchanged reference",
+			newCluster: clusterWithSecret("internal"),
+			oldCluster: clusterWithSecret("internal"),
+			valid:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAuditWebhookConfigSecret(context.Background(), client, namespace, tc.newCluster, tc.oldCluster)
+			if tc.valid && err != nil {
+				t.Errorf("expected reference to be valid, got: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected reference to be invalid")
+			}
+		})
+	}
+}
@@ -43,6 +43,7 @@ import (
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	kubenetutil "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -96,6 +97,10 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, field.Invalid(parentFieldPath.Child("containerRuntime"), spec.ContainerRuntime, fmt.Sprintf("failed to validate container runtime: %s", err)))
 	}
 
+	if err := ValidateUpdateWindow(spec.HibernationSchedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(parentFieldPath.Child("hibernationSchedule"), spec.HibernationSchedule, err.Error()))
+	}
+
 	if !kubermaticv1.AllExposeStrategies.Has(spec.ExposeStrategy) {
 		allErrs = append(allErrs, field.NotSupported(parentFieldPath.Child("exposeStrategy"), spec.ExposeStrategy, kubermaticv1.AllExposeStrategies.Items()))
 	}
@@ -110,6 +115,11 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("TunnelingAgentIP"), "Tunneling agent IP can be configured only for Tunneling Expose strategy"))
 	}
 
+	// OpenVPN traffic cannot be routed by SNI, so the Gateway expose strategy requires Konnectivity
+	if spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway && spec.ClusterNetwork.GetTunnelingMechanism() != kubermaticv1.TunnelingMechanismKonnectivity {
+		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("exposeStrategy"), "Gateway expose strategy can be used only when Konnectivity is enabled"))
+	}
+
 	// External CCM is not supported for all providers and all Kubernetes versions.
 	if spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider] {
 		if !resources.ExternalCloudControllerFeatureSupported(dc, &spec.Cloud, spec.Version, versionManager.GetIncompatibilities()...) {
@@ -164,6 +174,10 @@ func ValidateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.Datace
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := validateAuditLogging(spec.AuditLogging, parentFieldPath.Child("auditLogging")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	// KubeLB can only be enabled on the cluster if it's either enforced or enabled at the datacenter level.
 	if spec.IsKubeLBEnabled() && (dc.Spec.KubeLB == nil || !(dc.Spec.KubeLB.Enabled || dc.Spec.KubeLB.Enforced)) {
 		allErrs = append(allErrs, field.Forbidden(parentFieldPath.Child("kubeLB"), "KubeLB is not enabled on this datacenter"))
@@ -427,7 +441,7 @@ func ValidateClusterNetworkConfig(n *kubermaticv1.ClusterNetworkingConfig, dc *k
 			fmt.Sprintf("%s proxy mode is valid only for %s CNI", resources.EBPFProxyMode, kubermaticv1.CNIPluginTypeCilium)))
 	}
 
-	if n.ProxyMode == resources.EBPFProxyMode && (n.KonnectivityEnabled == nil || !*n.KonnectivityEnabled) { //nolint:staticcheck
+	if n.ProxyMode == resources.EBPFProxyMode && n.GetTunnelingMechanism() != kubermaticv1.TunnelingMechanismKonnectivity {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("proxyMode"), n.ProxyMode,
 			fmt.Sprintf("%s proxy mode can be used only when Konnectivity is enabled", resources.EBPFProxyMode)))
 	}
@@ -460,12 +474,17 @@ func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *
 				fmt.Sprintf("cannot enable encryption configuration if feature gate '%s' is not set", kubermaticv1.ClusterFeatureEncryptionAtRest)))
 		}
 
-		// TODO: Update with implementations of other encryption providers (KMS)
+		secretbox := spec.EncryptionConfiguration.Secretbox
+		kms := spec.EncryptionConfiguration.KMS
 
-		if spec.EncryptionConfiguration.Secretbox == nil {
+		switch {
+		case secretbox == nil && kms == nil:
 			allErrs = append(allErrs, field.Required(fieldPath.Child("secretbox"),
 				"exactly one encryption provider (secretbox, kms) needs to be configured"))
-		} else {
+		case secretbox != nil && kms != nil:
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("kms"),
+				"exactly one encryption provider (secretbox, kms) needs to be configured"))
+		case secretbox != nil:
 			for i, key := range spec.EncryptionConfiguration.Secretbox.Keys {
 				childPath := fieldPath.Child("secretbox", "keys").Index(i)
 				if key.Name == "" {
@@ -489,9 +508,53 @@ func validateEncryptionConfiguration(spec *kubermaticv1.ClusterSpec, fieldPath *
 					}
 				}
 			}
+
+			if rotation := secretbox.Rotation; rotation != nil && rotation.Interval != nil && rotation.Interval.Duration < minEncryptionKeyRotationInterval {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("secretbox", "rotation", "interval"), rotation.Interval.Duration.String(),
+					fmt.Sprintf("key rotation interval must be at least %v", minEncryptionKeyRotationInterval)))
+			}
+		case kms != nil:
+			allErrs = append(allErrs, validateKMSEncryptionConfiguration(kms, fieldPath.Child("kms"))...)
 		}
+	}
+
+	return allErrs
+}
+
+// minEncryptionKeyRotationInterval prevents rotating keys more often than all data can be re-encrypted.
+const minEncryptionKeyRotationInterval = time.Hour
 
-		// END TODO
+func validateKMSEncryptionConfiguration(kms *kubermaticv1.KMSEncryptionConfiguration, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(kms.Providers) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("providers"), "at least one KMS provider is required"))
+	}
+
+	names := sets.New[string]()
+	for i, provider := range kms.Providers {
+		childPath := fieldPath.Child("providers").Index(i)
+
+		if provider.Name == "" {
+			allErrs = append(allErrs, field.Required(childPath.Child("name"), "KMS provider name is required"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(provider.Name) {
+				allErrs = append(allErrs, field.Invalid(childPath.Child("name"), provider.Name, msg))
+			}
+
+			if names.Has(provider.Name) {
+				allErrs = append(allErrs, field.Duplicate(childPath.Child("name"), provider.Name))
+			}
+			names.Insert(provider.Name)
+		}
+
+		if provider.Image == "" {
+			allErrs = append(allErrs, field.Required(childPath.Child("image"), "KMS plugin image is required"))
+		}
+
+		if provider.Timeout != nil && provider.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(childPath.Child("timeout"), provider.Timeout.Duration.String(), "timeout must be positive"))
+		}
 	}
 
 	return allErrs
@@ -524,6 +587,15 @@ func validateEncryptionUpdate(oldCluster *kubermaticv1.Cluster, newCluster *kube
 				}
 			}
 
+			// KMS plugins run only as long as they are configured, but kube-apiserver needs them to read
+			// data until it has been fully decrypted again.
+			if oldCluster.Spec.EncryptionConfiguration != nil && oldCluster.Spec.EncryptionConfiguration.KMS != nil &&
+				(newCluster.Spec.EncryptionConfiguration == nil || newCluster.Spec.EncryptionConfiguration.KMS == nil && !newCluster.Spec.EncryptionConfiguration.Enabled) {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "encryptionConfiguration", "kms"),
+					"kms configuration cannot be removed while encryption is active. Please set 'enabled' to false and wait for the data to be decrypted",
+				))
+			}
+
 			encryptionConfigExists :=
 				oldCluster.Spec.EncryptionConfiguration != nil &&
 					newCluster.Spec.EncryptionConfiguration != nil
@@ -533,6 +605,16 @@ func validateEncryptionUpdate(oldCluster *kubermaticv1.Cluster, newCluster *kube
 					oldCluster.Spec.EncryptionConfiguration.Enabled &&
 						newCluster.Spec.EncryptionConfiguration.Enabled
 
+				// kube-apiserver cannot read data written by a previous encryption scheme once it is gone from
+				// the configuration, so switching between secretbox and KMS requires disabling encryption first.
+				if encryptionConfigEnabled && newCluster.Spec.EncryptionConfiguration.Secretbox != nil && oldCluster.Spec.EncryptionConfiguration.KMS != nil ||
+					encryptionConfigEnabled && newCluster.Spec.EncryptionConfiguration.KMS != nil && oldCluster.Spec.EncryptionConfiguration.Secretbox != nil {
+					allErrs = append(allErrs, field.Forbidden(
+						field.NewPath("spec", "encryptionConfiguration"),
+						"encryption provider cannot be changed between secretbox and kms. Please disable encryption and re-configure",
+					))
+				}
+
 				if encryptionConfigEnabled && !equality.Semantic.DeepEqual(oldCluster.Spec.EncryptionConfiguration.Resources, newCluster.Spec.EncryptionConfiguration.Resources) {
 					allErrs = append(
 						allErrs,
@@ -1114,21 +1196,8 @@ func validateNutanixCloudSpec(spec *kubermaticv1.NutanixCloudSpec) error {
 
 func ValidateUpdateWindow(updateWindow *kubermaticv1.UpdateWindow) error {
 	if updateWindow != nil && updateWindow.Start != "" && updateWindow.Length != "" {
-		_, err := time.ParseDuration(updateWindow.Length)
-		if err != nil {
-			return fmt.Errorf("error parsing update Length: %w", err)
-		}
-
-		var layout string
-		if strings.Contains(updateWindow.Start, " ") {
-			layout = "Mon 15:04"
-		} else {
-			layout = "15:04"
-		}
-
-		_, err = time.Parse(layout, updateWindow.Start)
-		if err != nil {
-			return fmt.Errorf("error parsing start day: %w", err)
+		if _, err := kubermaticv1helper.ParseUpdateWindow(updateWindow); err != nil {
+			return err
 		}
 	}
 	return nil
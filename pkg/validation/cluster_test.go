@@ -23,6 +23,7 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	semverlib "github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +34,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/semver"
 	"k8c.io/kubermatic/v2/pkg/version"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 )
@@ -1106,6 +1108,107 @@ func TestValidateEncryptionConfiguration(t *testing.T) {
 			},
 			expectErr: field.ErrorList{},
 		},
+		{
+			name: "too short key rotation interval",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					Secretbox: &kubermaticv1.SecretboxEncryptionConfiguration{
+						Keys: []kubermaticv1.SecretboxKey{
+							{
+								Name:  "good-key",
+								Value: "RGolflgAc+eBbm1lys87pTNQZVf0i67rlpPZGtTkVjQ=",
+							},
+						},
+						Rotation: &kubermaticv1.SecretboxKeyRotationPolicy{
+							Interval: &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+			expectErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "encryptionConfiguration", "secretbox", "rotation", "interval"), "1m0s", "key rotation interval must be at least 1h0m0s"),
+			},
+		},
+		{
+			name: "valid kms",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Providers: []kubermaticv1.KMSProvider{
+							{
+								Name:  "vault",
+								Image: "example.com/vault-kms-plugin:v1",
+							},
+						},
+					},
+				},
+			},
+			expectErr: field.ErrorList{},
+		},
+		{
+			name: "invalid kms providers",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Providers: []kubermaticv1.KMSProvider{
+							{
+								Name:  "vault",
+								Image: "example.com/vault-kms-plugin:v1",
+							},
+							{
+								Name: "vault",
+							},
+						},
+					},
+				},
+			},
+			expectErr: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "encryptionConfiguration", "kms", "providers").Index(1).Child("name"), "vault"),
+				field.Required(field.NewPath("spec", "encryptionConfiguration", "kms", "providers").Index(1).Child("image"), "KMS plugin image is required"),
+			},
+		},
+		{
+			name: "secretbox and kms",
+			clusterSpec: &kubermaticv1.ClusterSpec{
+				Features: map[string]bool{
+					kubermaticv1.ClusterFeatureEncryptionAtRest: true,
+				},
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{
+					Enabled: true,
+					Secretbox: &kubermaticv1.SecretboxEncryptionConfiguration{
+						Keys: []kubermaticv1.SecretboxKey{
+							{
+								Name:  "good-key",
+								Value: "RGolflgAc+eBbm1lys87pTNQZVf0i67rlpPZGtTkVjQ=",
+							},
+						},
+					},
+					KMS: &kubermaticv1.KMSEncryptionConfiguration{
+						Providers: []kubermaticv1.KMSProvider{
+							{
+								Name:  "vault",
+								Image: "example.com/vault-kms-plugin:v1",
+							},
+						},
+					},
+				},
+			},
+			expectErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "encryptionConfiguration", "kms"), "exactly one encryption provider (secretbox, kms) needs to be configured"),
+			},
+		},
 	}
 
 	for _, test := range tests {
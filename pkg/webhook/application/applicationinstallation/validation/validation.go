@@ -37,17 +37,19 @@ import (
 
 // AdmissionHandler for validating ApplicationInstallation CRD.
 type AdmissionHandler struct {
-	log     *zap.SugaredLogger
-	decoder *admission.Decoder
-	client  ctrlruntimeclient.Client
+	log        *zap.SugaredLogger
+	decoder    *admission.Decoder
+	client     ctrlruntimeclient.Client
+	userClient ctrlruntimeclient.Client
 }
 
 // NewAdmissionHandler returns a new validation AdmissionHandler.
-func NewAdmissionHandler(log *zap.SugaredLogger, scheme *runtime.Scheme, client ctrlruntimeclient.Client) *AdmissionHandler {
+func NewAdmissionHandler(log *zap.SugaredLogger, scheme *runtime.Scheme, client ctrlruntimeclient.Client, userClient ctrlruntimeclient.Client) *AdmissionHandler {
 	return &AdmissionHandler{
-		log:     log,
-		decoder: admission.NewDecoder(scheme),
-		client:  client,
+		log:        log,
+		decoder:    admission.NewDecoder(scheme),
+		client:     client,
+		userClient: userClient,
 	}
 }
 
@@ -66,6 +68,7 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 			return webhook.Errored(http.StatusBadRequest, err)
 		}
 		allErrs = append(allErrs, validation.ValidateApplicationInstallationSpec(ctx, h.client, *ad)...)
+		allErrs = append(allErrs, validation.ValidateApplicationInstallationDependencies(ctx, h.userClient, *ad)...)
 
 	case admissionv1.Update:
 		if err := h.decoder.Decode(req, ad); err != nil {
@@ -75,6 +78,7 @@ func (h *AdmissionHandler) Handle(ctx context.Context, req webhook.AdmissionRequ
 			return webhook.Errored(http.StatusBadRequest, err)
 		}
 		allErrs = append(allErrs, validation.ValidateApplicationInstallationUpdate(ctx, h.client, *ad, *oldAD)...)
+		allErrs = append(allErrs, validation.ValidateApplicationInstallationDependencies(ctx, h.userClient, *ad)...)
 
 	case admissionv1.Delete:
 		// NOP we always allow delete operations
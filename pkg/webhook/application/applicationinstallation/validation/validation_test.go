@@ -45,15 +45,21 @@ var (
 
 func TestValidateApplicationInstallation(t *testing.T) {
 	ad := getApplicationDefinition(defaultAppName)
+	dependent := getApplicationInstallation("dependent", defaultAppName, defaultAppVersion)
+	dependent.Spec.DependsOn = []appskubermaticv1.ApplicationDependency{{Name: defaultAppName}}
 	fakeClient := fake.
 		NewClientBuilder().
 		WithScheme(testScheme).
-		WithObjects(ad).
+		WithObjects(ad, dependent).
 		Build()
 
 	ai := getApplicationInstallation(defaultAppName, defaultAppName, defaultAppVersion)
 	validRaw := applicationInstallationToRawExt(*ai)
 
+	cyclicAI := ai.DeepCopy()
+	cyclicAI.Spec.DependsOn = []appskubermaticv1.ApplicationDependency{{Name: "dependent"}}
+	cyclicRaw := applicationInstallationToRawExt(*cyclicAI)
+
 	ai.Spec.Namespace.Create = false
 	invalidUpdateRaw := applicationInstallationToRawExt(*ai)
 
@@ -97,6 +103,22 @@ func TestValidateApplicationInstallation(t *testing.T) {
 			},
 			wantAllowed: false,
 		},
+		{
+			name: "Create ApplicationInstallation Failure - dependency cycle",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   appskubermaticv1.GroupName,
+						Version: appskubermaticv1.GroupVersion,
+						Kind:    "ApplicationInstallation",
+					},
+					Name:   "default",
+					Object: cyclicRaw,
+				},
+			},
+			wantAllowed: false,
+		},
 		{
 			name: "Delete ApplicationInstallation Success",
 			req: webhook.AdmissionRequest{
@@ -135,9 +157,10 @@ func TestValidateApplicationInstallation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			handler := AdmissionHandler{
-				log:     zap.NewNop().Sugar(),
-				decoder: admission.NewDecoder(testScheme),
-				client:  fakeClient,
+				log:        zap.NewNop().Sugar(),
+				decoder:    admission.NewDecoder(testScheme),
+				client:     fakeClient,
+				userClient: fakeClient,
 			}
 
 			if res := handler.Handle(context.Background(), tt.req); res.Allowed != tt.wantAllowed {
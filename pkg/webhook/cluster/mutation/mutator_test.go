@@ -100,6 +100,13 @@ var (
 		defaultNetworkingPatchesWithoutProxyMode,
 		jsonpatch.NewOperation("replace", "/spec/clusterNetwork/proxyMode", "iptables"),
 	)
+
+	// openVPNPatches are the patches that occur when an existing cluster without
+	// a tunneling mechanism is updated.
+	openVPNPatches = []jsonpatch.JsonPatchOperation{
+		jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", false),
+		jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismOpenVPN)),
+	}
 )
 
 func TestMutator(t *testing.T) {
@@ -227,6 +234,8 @@ func TestMutator(t *testing.T) {
 
 			wantAllowed: true,
 			wantPatches: []jsonpatch.JsonPatchOperation{
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("add", "/spec/componentsOverride/apiserver/nodePortRange", "30000-32768"),
 				jsonpatch.NewOperation("add", "/spec/componentsOverride/apiserver/replicas", float64(2)),
 				jsonpatch.NewOperation("add", "/spec/componentsOverride/apiserver/resources", map[string]interface{}{"requests": map[string]interface{}{"memory": "500M"}}),
@@ -287,6 +296,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				defaultPatches,
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("add", "/spec/cniPlugin", map[string]interface{}{
 					"type":    "cilium",
 					"version": cni.GetDefaultCNIPluginVersion(kubermaticv1.CNIPluginTypeCilium),
@@ -335,6 +346,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				defaultPatches,
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("add", "/spec/features/ccmClusterName", true),
 			),
 		},
@@ -365,6 +378,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				defaultPatches,
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("add", "/spec/features/apiserverNetworkPolicy", true),
 				jsonpatch.NewOperation("add", "/spec/features/ccmClusterName", true),
 			),
@@ -395,7 +410,7 @@ func TestMutator(t *testing.T) {
 			}.Do(),
 			wantAllowed: true,
 			wantPatches: append(
-				append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...),
+				append(append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...), openVPNPatches...),
 				jsonpatch.NewOperation("replace", "/spec/cloud/providerName", string(kubermaticv1.HetznerCloudProvider)),
 			),
 		},
@@ -427,7 +442,7 @@ func TestMutator(t *testing.T) {
 			}.Do(),
 			wantAllowed: true,
 			wantPatches: append(
-				append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...),
+				append(append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...), openVPNPatches...),
 				jsonpatch.NewOperation("replace", "/spec/cloud/providerName", string(kubermaticv1.HetznerCloudProvider)),
 			),
 		},
@@ -459,6 +474,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				defaultPatches,
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("add", "/spec/cniPlugin", map[string]interface{}{
 					"type":    string(kubermaticv1.CNIPluginTypeCanal),
 					"version": cni.GetDefaultCNIPluginVersion(kubermaticv1.CNIPluginTypeCanal),
@@ -512,7 +529,7 @@ func TestMutator(t *testing.T) {
 			}.Do(),
 			wantAllowed: true,
 			wantPatches: append(
-				defaultPatches,
+				append(defaultPatches, openVPNPatches...),
 				jsonpatch.NewOperation("add", "/spec/cniPlugin", map[string]interface{}{
 					"type":    string(kubermaticv1.CNIPluginTypeCanal),
 					"version": cni.GetDefaultCNIPluginVersion(kubermaticv1.CNIPluginTypeCanal),
@@ -575,7 +592,7 @@ func TestMutator(t *testing.T) {
 			}.Do(),
 			wantAllowed: true,
 			wantPatches: append(
-				defaultPatches,
+				append(defaultPatches, openVPNPatches...),
 				jsonpatch.NewOperation("replace", "/spec/cniPlugin/version", "v3.23"),
 			),
 		},
@@ -599,6 +616,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				append(defaultPatches, defaultNetworkingPatches...),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("replace", "/spec/cloud/providerName", string(kubermaticv1.OpenstackCloudProvider)),
 				jsonpatch.NewOperation("add", "/spec/features/externalCloudProvider", true),
 				jsonpatch.NewOperation("add", "/spec/features/ccmClusterName", true),
@@ -624,6 +643,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				defaultPatches,
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("add", "/spec/clusterNetwork/ipFamily", string(kubermaticv1.IPFamilyIPv4)),
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/services/cidrBlocks", []interface{}{"10.241.0.0/20"}),
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/pods/cidrBlocks", []interface{}{"172.26.0.0/16"}),
@@ -663,6 +684,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				append(defaultPatches, defaultNetworkingPatchesWithoutProxyMode...),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("replace", "/spec/cloud/providerName", string(kubermaticv1.OpenstackCloudProvider)),
 				jsonpatch.NewOperation("add", "/spec/features/externalCloudProvider", true),
 				jsonpatch.NewOperation("add", "/spec/features/ccmClusterName", true),
@@ -691,6 +714,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				append(defaultPatches, defaultNetworkingPatchesWithoutProxyMode...),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("replace", "/spec/cloud/providerName", string(kubermaticv1.OpenstackCloudProvider)),
 				jsonpatch.NewOperation("add", "/spec/features/externalCloudProvider", true),
 				jsonpatch.NewOperation("add", "/spec/features/ccmClusterName", true),
@@ -719,6 +744,8 @@ func TestMutator(t *testing.T) {
 			wantAllowed: true,
 			wantPatches: append(
 				defaultPatches,
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/konnectivityEnabled", true),
+				jsonpatch.NewOperation("add", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/services/cidrBlocks", []interface{}{resources.DefaultClusterServicesCIDRIPv4, resources.DefaultClusterServicesCIDRIPv6}),
 				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/pods/cidrBlocks", []interface{}{resources.DefaultClusterPodsCIDRIPv4, resources.DefaultClusterPodsCIDRIPv6}),
 				jsonpatch.NewOperation("add", "/spec/clusterNetwork/nodeCidrMaskSizeIPv4", float64(resources.DefaultNodeCIDRMaskSizeIPv4)),
@@ -762,7 +789,7 @@ func TestMutator(t *testing.T) {
 			}.Do(),
 			wantAllowed: true,
 			wantPatches: append(
-				append(defaultPatches, defaultNetworkingPatches...),
+				append(append(defaultPatches, defaultNetworkingPatches...), openVPNPatches...),
 				jsonpatch.NewOperation("add", "/metadata/annotations", map[string]interface{}{"ccm-migration.k8c.io/migration-needed": "", "csi-migration.k8c.io/migration-needed": ""}),
 				jsonpatch.NewOperation("add", "/spec/cloud/openstack/useOctavia", true),
 				jsonpatch.NewOperation("add", "/spec/features/ccmClusterName", true),
@@ -797,7 +824,7 @@ func TestMutator(t *testing.T) {
 				},
 			}.Do(),
 			wantAllowed: true,
-			wantPatches: append(defaultPatches, defaultNetworkingPatches...),
+			wantPatches: append(append(defaultPatches, defaultNetworkingPatches...), openVPNPatches...),
 		},
 		{
 			name: "Update non-OpenStack cluster to enable CCM/CSI migration",
@@ -828,7 +855,7 @@ func TestMutator(t *testing.T) {
 				},
 			}.Do(),
 			wantAllowed: true,
-			wantPatches: append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...),
+			wantPatches: append(append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...), openVPNPatches...),
 		},
 		{
 			name: "Update cluster with CNI none",
@@ -859,7 +886,47 @@ func TestMutator(t *testing.T) {
 				},
 			}.Do(),
 			wantAllowed: true,
-			wantPatches: append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...),
+			wantPatches: append(append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...), openVPNPatches...),
+		},
+		{
+			name: "Enabling the deprecated Konnectivity flag switches the tunneling mechanism",
+			oldCluster: rawClusterGen{
+				Name: "foo",
+				CloudSpec: kubermaticv1.CloudSpec{
+					ProviderName:   string(kubermaticv1.HetznerCloudProvider),
+					DatacenterName: "hetzner-dc",
+					Hetzner:        &kubermaticv1.HetznerCloudSpec{},
+				},
+				CNIPluginSpec: &kubermaticv1.CNIPluginSettings{
+					Type:    kubermaticv1.CNIPluginTypeCanal,
+					Version: "v3.20",
+				},
+				NetworkConfig: kubermaticv1.ClusterNetworkingConfig{
+					KonnectivityEnabled: ptr.To(false),
+					TunnelingMechanism:  kubermaticv1.TunnelingMechanismOpenVPN,
+				},
+			}.Do(),
+			newCluster: rawClusterGen{
+				Name: "foo",
+				CloudSpec: kubermaticv1.CloudSpec{
+					ProviderName:   string(kubermaticv1.HetznerCloudProvider),
+					DatacenterName: "hetzner-dc",
+					Hetzner:        &kubermaticv1.HetznerCloudSpec{},
+				},
+				CNIPluginSpec: &kubermaticv1.CNIPluginSettings{
+					Type:    kubermaticv1.CNIPluginTypeCanal,
+					Version: "v3.20",
+				},
+				NetworkConfig: kubermaticv1.ClusterNetworkingConfig{
+					KonnectivityEnabled: ptr.To(true),
+					TunnelingMechanism:  kubermaticv1.TunnelingMechanismOpenVPN,
+				},
+			}.Do(),
+			wantAllowed: true,
+			wantPatches: append(
+				append(defaultPatches, defaultNetworkingPatchesIptablesProxyMode...),
+				jsonpatch.NewOperation("replace", "/spec/clusterNetwork/tunnelingMechanism", string(kubermaticv1.TunnelingMechanismKonnectivity)),
+			),
 		},
 	}
 	for _, tt := range tests {
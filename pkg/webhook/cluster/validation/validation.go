@@ -99,6 +99,10 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		errs = append(errs, err)
 	}
 
+	if err := v.validateAuditWebhookConfig(ctx, cluster, nil); err != nil {
+		errs = append(errs, err)
+	}
+
 	return nil, errs.ToAggregate()
 }
 
@@ -131,6 +135,10 @@ func (v *validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.O
 		errs = append(errs, err)
 	}
 
+	if err := v.validateAuditWebhookConfig(ctx, newCluster, oldCluster); err != nil {
+		errs = append(errs, err)
+	}
+
 	return nil, errs.ToAggregate()
 }
 
@@ -152,6 +160,10 @@ func (v *validator) buildValidationDependencies(ctx context.Context, c *kubermat
 		return nil, nil, fieldErr
 	}
 
+	if c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway && seed.Spec.Gateway == nil {
+		return nil, nil, field.Forbidden(field.NewPath("spec", "exposeStrategy"), fmt.Sprintf("Seed %q has no Gateway configured", seed.Name))
+	}
+
 	if v.disableProviderValidation {
 		return datacenter, nil, nil
 	}
@@ -165,6 +177,15 @@ func (v *validator) buildValidationDependencies(ctx context.Context, c *kubermat
 	return datacenter, cloudProvider, nil
 }
 
+func (v *validator) validateAuditWebhookConfig(ctx context.Context, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) *field.Error {
+	seed, err := v.seedGetter()
+	if err != nil {
+		return field.InternalError(nil, err)
+	}
+
+	return validation.ValidateAuditWebhookConfigSecret(ctx, v.client, seed.Namespace, cluster, oldCluster)
+}
+
 func (v *validator) validateProjectRelation(ctx context.Context, cluster *kubermaticv1.Cluster, oldCluster *kubermaticv1.Cluster) *field.Error {
 	label := kubermaticv1.ProjectIDLabelKey
 	fieldPath := field.NewPath("metadata", "labels")
@@ -19,49 +19,35 @@ package validation
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"go.uber.org/zap"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
-	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/selection"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // validator for validating Kubermatic Machine CRD.
 type validator struct {
-	log             *zap.SugaredLogger
-	seedClient      ctrlruntimeclient.Client
-	userClient      ctrlruntimeclient.Client
-	caBundle        *certificates.CABundle
-	subjectSelector labels.Selector
+	log        *zap.SugaredLogger
+	seedClient ctrlruntimeclient.Client
+	userClient ctrlruntimeclient.Client
+	caBundle   *certificates.CABundle
+	projectID  string
 }
 
 // NewValidator returns a new Machine validator.
 func NewValidator(seedClient, userClient ctrlruntimeclient.Client, log *zap.SugaredLogger, caBundle *certificates.CABundle,
 	projectID string) (*validator, error) {
-	subjectNameReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectNameLabelKey, selection.Equals, []string{projectID})
-	if err != nil {
-		return nil, fmt.Errorf("error creating resource quota subject name requirement: %w", err)
-	}
-	subjectKindReq, err := labels.NewRequirement(kubermaticv1.ResourceQuotaSubjectKindLabelKey, selection.Equals, []string{kubermaticv1.ProjectSubjectKind})
-	if err != nil {
-		return nil, fmt.Errorf("error creating resource quota subject kind requirement: %w", err)
-	}
-	subjectSelector := labels.NewSelector().Add(*subjectNameReq, *subjectKindReq)
-
 	return &validator{
-		log:             log,
-		seedClient:      seedClient,
-		userClient:      userClient,
-		caBundle:        caBundle,
-		subjectSelector: subjectSelector,
+		log:        log,
+		seedClient: seedClient,
+		userClient: userClient,
+		caBundle:   caBundle,
+		projectID:  projectID,
 	}, nil
 }
 
@@ -76,12 +62,16 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 	log := v.log.With("machine", machine.Name)
 	log.Debug("validating create")
 
-	quota, err := getResourceQuota(ctx, v.seedClient, v.subjectSelector)
+	// the project quota as well as the quotas of all users and groups bound to the project apply,
+	// so the Machine must fit into the tightest of them
+	quotas, err := getResourceQuotas(ctx, v.seedClient, v.projectID)
 	if err != nil {
 		return nil, err
 	}
-	if quota != nil {
-		return nil, validateQuota(ctx, log, v.userClient, machine, v.caBundle, quota)
+	for i := range quotas {
+		if err := validateQuota(ctx, log.With("resourcequota", quotas[i].Name), v.userClient, machine, v.caBundle, &quotas[i]); err != nil {
+			return nil, err
+		}
 	}
 	return nil, nil
 }
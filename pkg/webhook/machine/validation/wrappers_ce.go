@@ -27,7 +27,6 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 
-	"k8s.io/apimachinery/pkg/labels"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -37,6 +36,6 @@ func validateQuota(_ context.Context, _ *zap.SugaredLogger, _ ctrlruntimeclient.
 }
 
 // Resource Quotas are an EE feature
-func getResourceQuota(_ context.Context, _ ctrlruntimeclient.Client, _ labels.Selector) (*kubermaticv1.ResourceQuota, error) {
+func getResourceQuotas(_ context.Context, _ ctrlruntimeclient.Client, _ string) ([]kubermaticv1.ResourceQuota, error) {
 	return nil, nil
 }
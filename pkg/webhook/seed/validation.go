@@ -27,6 +27,7 @@ import (
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	etcdbackup "k8c.io/kubermatic/v2/pkg/resources/etcd/backup"
 	"k8c.io/kubermatic/v2/pkg/validation"
 
 	corev1 "k8s.io/api/core/v1"
@@ -182,10 +183,18 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object, isDelete b
 		return err
 	}
 
+	if !isDelete && subject.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyGateway && subject.Spec.Gateway == nil {
+		return fmt.Errorf("expose strategy %q requires a Gateway to be configured", subject.Spec.ExposeStrategy)
+	}
+
 	if err := validation.ValidateMeteringConfiguration(subject.Spec.Metering); err != nil {
 		return err
 	}
 
+	if err := validation.ValidateSeedAuditLogging(subject.Spec.AuditLogging); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -241,6 +250,10 @@ func validateEtcdBackupConfiguration(ctx context.Context, seedClient ctrlruntime
 				return fmt.Errorf("destination name is invalid, must match %s", resourceNameValidator.String())
 			}
 
+			if err := validateBackupDestinationType(name, dest); err != nil {
+				return err
+			}
+
 			if dest.Credentials != nil {
 				etcdBackupSecret := corev1.Secret{}
 				if err := seedClient.Get(ctx, types.NamespacedName{Name: dest.Credentials.Name,
@@ -248,6 +261,47 @@ func validateEtcdBackupConfiguration(ctx context.Context, seedClient ctrlruntime
 					return fmt.Errorf("invalid etcd backup configuration: invalid destination %q credentials %s: %w", name, dest.Credentials.Name, err)
 				}
 			}
+
+			if dest.Encryption != nil {
+				if dest.Encryption.ActiveKey == "" {
+					return fmt.Errorf("invalid etcd backup configuration: destination %q has no active encryption key", name)
+				}
+
+				if _, err := etcdbackup.GetSeedKeys(ctx, seedClient, dest.Encryption); err != nil {
+					return fmt.Errorf("invalid etcd backup configuration: invalid destination %q encryption: %w", name, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateBackupDestinationType(name string, dest *kubermaticv1.BackupDestination) error {
+	destType := dest.GetType()
+
+	if destType != kubermaticv1.BackupDestinationTypeFilesystem && dest.Filesystem != nil {
+		return fmt.Errorf("invalid etcd backup configuration: destination %q of type %q must not configure a filesystem", name, destType)
+	}
+
+	switch destType {
+	case kubermaticv1.BackupDestinationTypeFilesystem:
+		if dest.Filesystem == nil || dest.Filesystem.PersistentVolumeClaim == "" {
+			return fmt.Errorf("invalid etcd backup configuration: filesystem destination %q must configure a PersistentVolumeClaim", name)
+		}
+
+		// the controllers cannot access the volume to store the wrapped data keys
+		if dest.Encryption != nil {
+			return fmt.Errorf("invalid etcd backup configuration: filesystem destination %q does not support encryption", name)
+		}
+
+	case kubermaticv1.BackupDestinationTypeAzure, kubermaticv1.BackupDestinationTypeGCS:
+		if dest.BucketName == "" {
+			return fmt.Errorf("invalid etcd backup configuration: destination %q must configure a bucket name", name)
+		}
+
+		if dest.Credentials == nil {
+			return fmt.Errorf("invalid etcd backup configuration: destination %q must configure credentials", name)
 		}
 	}
 
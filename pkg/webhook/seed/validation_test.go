@@ -442,6 +442,32 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Adding a seed with Gateway ExposeStrategy and a Gateway should succeed",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+					Gateway: &kubermaticv1.SeedGatewaySettings{
+						GatewayClassName: "envoy",
+					},
+				},
+			},
+		},
+		{
+			name: "Adding a seed with Gateway ExposeStrategy but without a Gateway should fail",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					ExposeStrategy: kubermaticv1.ExposeStrategyGateway,
+				},
+			},
+			errExpected: true,
+		},
 		{
 			name: "Adding a seed with invalid cron expression",
 			seedToValidate: &kubermaticv1.Seed{
@@ -461,6 +487,63 @@ func TestValidate(t *testing.T) {
 			features:    features.FeatureGate{},
 			errExpected: true,
 		},
+		{
+			name: "Adding a seed with a filesystem backup destination should succeed",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+						Destinations: map[string]*kubermaticv1.BackupDestination{
+							"nfs": {
+								Type: kubermaticv1.BackupDestinationTypeFilesystem,
+								Filesystem: &kubermaticv1.BackupDestinationFilesystem{
+									PersistentVolumeClaim: "etcd-backups",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Adding a seed with a filesystem backup destination without a PersistentVolumeClaim should fail",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+						Destinations: map[string]*kubermaticv1.BackupDestination{
+							"nfs": {
+								Type: kubermaticv1.BackupDestinationTypeFilesystem,
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Adding a seed with a GCS backup destination without credentials should fail",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					EtcdBackupRestore: &kubermaticv1.EtcdBackupRestore{
+						Destinations: map[string]*kubermaticv1.BackupDestination{
+							"gcs": {
+								Type:       kubermaticv1.BackupDestinationTypeGCS,
+								BucketName: "etcd-backups",
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
 		{
 			name: "Adding a seed with kubevirt datacenter should fail with not supported operating-system",
 			seedToValidate: &kubermaticv1.Seed{
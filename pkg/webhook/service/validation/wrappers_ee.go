@@ -0,0 +1,41 @@
+//go:build ee
+
+/*
+Copyright 2023 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/apis/kubermatic/v1"
+	eeresourcequotavalidation "k8c.io/kubermatic/v2/pkg/ee/validation/resourcequota"
+	eeservicevalidation "k8c.io/kubermatic/v2/pkg/ee/validation/service"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func validateLoadBalancerQuota(log *zap.SugaredLogger, service, oldService *corev1.Service, resourceQuota *kubermaticv1.ResourceQuota) error {
+	return eeservicevalidation.ValidateLoadBalancerQuota(log, service, oldService, resourceQuota)
+}
+
+// getResourceQuotas returns all resource quotas that apply to the given project.
+func getResourceQuotas(ctx context.Context, seedClient ctrlruntimeclient.Client, projectID string) ([]kubermaticv1.ResourceQuota, error) {
+	return eeresourcequotavalidation.GetProjectResourceQuotas(ctx, seedClient, projectID)
+}